@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	sesEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/spf13/cobra"
+)
+
+// # HTTP insert endpoint (signed with HMAC client credentials)
+// ./insight-collector bench ingest --entity user_activities --concurrency 50 --duration 30s --client-id <id> --secret <secret>
+
+// # Directly at job queue (skips HTTP layer)
+// ./insight-collector bench ingest --entity security_events --direct
+
+// benchUserAgents are rotated in synthetic payloads
+var benchUserAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+	"okhttp/4.12.0",
+}
+
+// benchEntity describes insert endpoint and synthetic request builder for entity
+type benchEntity struct {
+	path  string
+	build func(seq int64) interface{}
+}
+
+// benchEntities lists benchmarkable entities (same request structs as insert endpoints)
+var benchEntities = map[string]benchEntity{
+	"user_activities": {"/v1/user-activities/insert", func(seq int64) interface{} {
+		return &uaEntities.UserActivitiesRequest{
+			UserID:       fmt.Sprintf("bench-user-%d", seq%1000),
+			SessionID:    fmt.Sprintf("bench-session-%d", seq%5000),
+			ActivityType: "page_view",
+			Category:     "navigation",
+			Status:       "success",
+			Channel:      "web",
+			Method:       "GET",
+			TraceID:      fmt.Sprintf("bench-trace-%d", seq),
+			RequestID:    fmt.Sprintf("bench-req-%d", seq),
+			DurationMs:   rand.Intn(500),
+			ResponseCode: 200,
+			IPAddress:    benchIP(),
+			UserAgent:    benchUserAgents[seq%int64(len(benchUserAgents))],
+			Endpoint:     "/api/v1/dashboard",
+			Timestamp:    time.Now(),
+		}
+	}},
+	"security_events": {"/v1/security-events/insert", func(seq int64) interface{} {
+		return &seEntities.SecurityEventsRequest{
+			UserID:       fmt.Sprintf("bench-user-%d", seq%1000),
+			EventType:    "login_failed",
+			Severity:     "medium",
+			AuthStage:    "password",
+			ActionTaken:  "logged",
+			Channel:      "web",
+			Method:       "POST",
+			RequestID:    fmt.Sprintf("bench-req-%d", seq),
+			AttemptCount: 1 + rand.Intn(5),
+			ResponseCode: 401,
+			IPAddress:    benchIP(),
+			UserAgent:    benchUserAgents[seq%int64(len(benchUserAgents))],
+			Endpoint:     "/api/v1/auth/login",
+			Timestamp:    time.Now(),
+		}
+	}},
+	"session_events": {"/v1/session-events/insert", func(seq int64) interface{} {
+		start := time.Now().Add(-time.Duration(1+rand.Intn(3600)) * time.Second)
+		return &sesEntities.SessionEventsRequest{
+			SessionID:         fmt.Sprintf("bench-session-%d", seq),
+			UserID:            fmt.Sprintf("bench-user-%d", seq%1000),
+			EventType:         "ended",
+			TerminationReason: "logout",
+			Channel:           "web",
+			StartTime:         start,
+			EndTime:           time.Now(),
+			DeviceFingerprint: fmt.Sprintf("bench-device-%d", seq%2000),
+			IPStart:           benchIP(),
+			UserAgent:         benchUserAgents[seq%int64(len(benchUserAgents))],
+			Timestamp:         time.Now(),
+		}
+	}},
+	"error_events": {"/v1/error-events/insert", func(seq int64) interface{} {
+		return &eeEntities.ErrorEventsRequest{
+			Service:      "bench-service",
+			Environment:  "bench",
+			ErrorType:    "timeout",
+			Severity:     "error",
+			Message:      "upstream request timed out",
+			Method:       "GET",
+			Endpoint:     "/api/v1/orders",
+			ResponseCode: 504,
+			RequestID:    fmt.Sprintf("bench-req-%d", seq),
+			Timestamp:    time.Now(),
+		}
+	}},
+	"callback_logs": {"/v1/callback-logs/insert", func(seq int64) interface{} {
+		return &clEntities.CallbackLogsRequest{
+			TransactionID:  fmt.Sprintf("bench-trx-%d", seq),
+			CallbackType:   "payment",
+			Status:         "success",
+			HTTPStatusCode: 200,
+			DurationMs:     rand.Intn(1000),
+			DestinationURL: "https://merchant.example.com/callback",
+			Timestamp:      time.Now(),
+		}
+	}},
+	"transaction_events": {"/v1/transaction-events/insert", func(seq int64) interface{} {
+		amount := float64(10000 + rand.Intn(990000))
+		return &teEntities.TransactionEventsRequest{
+			UserID:          fmt.Sprintf("bench-user-%d", seq%1000),
+			SessionID:       fmt.Sprintf("bench-session-%d", seq%5000),
+			TransactionType: "payment",
+			Currency:        "IDR",
+			PaymentMethod:   "ewallet",
+			Status:          "completed",
+			Channel:         "mobile",
+			TransactionID:   fmt.Sprintf("bench-trx-%d", seq),
+			Amount:          amount,
+			NetAmount:       amount,
+			ResponseCode:    200,
+			IPAddress:       benchIP(),
+			UserAgent:       benchUserAgents[seq%int64(len(benchUserAgents))],
+			Endpoint:        "/api/v1/payments",
+			Method:          "POST",
+			Timestamp:       time.Now(),
+		}
+	}},
+}
+
+// Bench command flags
+var (
+	benchEntityName  string
+	benchConcurrency int
+	benchDuration    time.Duration
+	benchURL         string
+	benchDirect      bool
+	benchClientID    string
+	benchSecret      string
+	benchAPIKey      string
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark tools",
+	Long:  `Benchmark tools for measuring ingestion throughput`,
+}
+
+var benchIngestCmd = &cobra.Command{
+	Use:   "ingest",
+	Short: "Benchmark ingestion throughput",
+	Long: `Fire synthetic valid requests at insert endpoint (or directly at job queue with --direct)
+and report achieved RPS, latency percentiles and error rate`,
+	RunE:          runBenchIngest,
+	SilenceErrors: true,
+}
+
+func init() {
+	benchIngestCmd.Flags().StringVarP(&benchEntityName, "entity", "e", "user_activities", "Entity name: "+strings.Join(benchEntityNames(), ", "))
+	benchIngestCmd.Flags().IntVarP(&benchConcurrency, "concurrency", "c", 50, "Number of concurrent workers")
+	benchIngestCmd.Flags().DurationVarP(&benchDuration, "duration", "d", 30*time.Second, "Benchmark duration")
+	benchIngestCmd.Flags().StringVar(&benchURL, "url", "", "Server base URL (default: http://localhost:<app.port>)")
+	benchIngestCmd.Flags().BoolVar(&benchDirect, "direct", false, "Dispatch jobs directly to queue instead of HTTP")
+	benchIngestCmd.Flags().StringVar(&benchClientID, "client-id", "", "Client ID for signature auth")
+	benchIngestCmd.Flags().StringVar(&benchSecret, "secret", "", "HMAC secret key (default: looked up from config by client ID)")
+	benchIngestCmd.Flags().StringVar(&benchAPIKey, "api-key", "", "API key for X-API-Key auth")
+
+	benchCmd.AddCommand(benchIngestCmd)
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchResult holds per-worker measurements (merged after run, no locking on hot path)
+type benchResult struct {
+	latencies []time.Duration
+	errors    int
+	statuses  map[string]int
+}
+
+// runBenchIngest runs workers until duration elapses and prints summary
+func runBenchIngest(cmd *cobra.Command, args []string) error {
+	spec, exists := benchEntities[benchEntityName]
+	if !exists {
+		return fmt.Errorf("invalid entity: %s (must be one of %s)", benchEntityName, strings.Join(benchEntityNames(), ", "))
+	}
+	if benchConcurrency <= 0 {
+		return fmt.Errorf("invalid concurrency: %d (must be > 0)", benchConcurrency)
+	}
+	if benchDuration <= 0 {
+		return fmt.Errorf("invalid duration: %s (must be > 0)", benchDuration)
+	}
+
+	// Resolve target
+	var send func(seq int64) (string, error)
+	target := "job queue (direct)"
+	if benchDirect {
+		replay := replayEntities[benchEntityName]
+		send = func(seq int64) (string, error) {
+			return benchDispatch(replay, spec.build(seq))
+		}
+	} else {
+		if benchURL == "" {
+			benchURL = fmt.Sprintf("http://localhost:%d", config.Get().App.Port)
+		}
+		if benchClientID != "" && benchSecret == "" {
+			secret, err := benchLookupSecret(benchClientID)
+			if err != nil {
+				return err
+			}
+			benchSecret = secret
+		}
+
+		target = strings.TrimRight(benchURL, "/") + spec.path
+		client := &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConns:        benchConcurrency,
+				MaxIdleConnsPerHost: benchConcurrency,
+			},
+		}
+		send = func(seq int64) (string, error) {
+			return benchPost(client, target, spec.path, spec.build(seq))
+		}
+	}
+
+	fmt.Printf("Benchmarking %s -> %s (%d workers, %s)...\n\n", benchEntityName, target, benchConcurrency, benchDuration)
+
+	ctx, cancel := context.WithTimeout(context.Background(), benchDuration)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		seq     int64
+		results = make([]*benchResult, benchConcurrency)
+		start   = time.Now()
+	)
+	for i := 0; i < benchConcurrency; i++ {
+		result := &benchResult{statuses: make(map[string]int)}
+		results[i] = result
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				reqStart := time.Now()
+				status, err := send(atomic.AddInt64(&seq, 1))
+				result.latencies = append(result.latencies, time.Since(reqStart))
+				result.statuses[status]++
+				if err != nil {
+					result.errors++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// Merge worker results
+	total := &benchResult{statuses: make(map[string]int)}
+	for _, result := range results {
+		total.latencies = append(total.latencies, result.latencies...)
+		total.errors += result.errors
+		for status, count := range result.statuses {
+			total.statuses[status] += count
+		}
+	}
+	sort.Slice(total.latencies, func(i, j int) bool { return total.latencies[i] < total.latencies[j] })
+
+	printBenchSummary(total, elapsed)
+
+	if len(total.latencies) > 0 && total.errors == len(total.latencies) {
+		return fmt.Errorf("all %d requests failed", total.errors)
+	}
+	return nil
+}
+
+// benchPost sends signed JSON request to insert endpoint, returns HTTP status as result key
+func benchPost(client *http.Client, url, path string, request interface{}) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "encode_error", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "request_error", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Auth headers (same scheme as signature/API key middleware)
+	switch {
+	case benchClientID != "":
+		bodySHA256, err := auth.BodySHA256(bytes.NewReader(body), nil)
+		if err != nil {
+			return "sign_error", err
+		}
+		payload := auth.SignaturePayload{
+			ClientID:   benchClientID,
+			Timestamp:  time.Now().Unix(),
+			Nonce:      fmt.Sprintf("%x", rand.Int63()),
+			Method:     http.MethodPost,
+			Path:       path,
+			BodySHA256: bodySHA256,
+		}
+		signature, err := auth.GenerateSignature(payload, benchSecret)
+		if err != nil {
+			return "sign_error", err
+		}
+		req.Header.Set("X-Client-ID", payload.ClientID)
+		req.Header.Set("X-Timestamp", strconv.FormatInt(payload.Timestamp, 10))
+		req.Header.Set("X-Nonce", payload.Nonce)
+		req.Header.Set("X-Signature", signature)
+		req.Header.Set("X-Signature-Version", auth.SignatureVersionBodyHash)
+	case benchAPIKey != "":
+		req.Header.Set("X-API-Key", benchAPIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "network_error", err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) // Drain body to reuse connection
+
+	status := strconv.Itoa(resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		return status, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return status, nil
+}
+
+// benchDispatch enqueues request synchronously with same payload as insert endpoints
+func benchDispatch(spec replayEntity, request interface{}) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "encode_error", err
+	}
+
+	hash := md5.Sum(data)
+	payload := asynq.Payload{
+		TaskId:   fmt.Sprintf("%s_%x", spec.jobPrefix, hash[:8]),
+		TaskType: spec.taskType,
+		Data:     request,
+	}
+	if err := asynq.DispatchJobSync(&payload); err != nil {
+		return "dispatch_error", err
+	}
+	return "dispatched", nil
+}
+
+// benchLookupSecret finds HMAC secret for client ID in config
+func benchLookupSecret(clientID string) (string, error) {
+	for _, client := range config.Get().Auth.Clients {
+		if client.ClientID != clientID {
+			continue
+		}
+		if client.AuthType != "hmac" || client.SecretKey == "" {
+			return "", fmt.Errorf("client '%s' is not HMAC client, provide --secret", clientID)
+		}
+		return client.SecretKey, nil
+	}
+	return "", fmt.Errorf("client '%s' not found in config, provide --secret", clientID)
+}
+
+// printBenchSummary prints throughput, latency percentiles and result breakdown
+func printBenchSummary(result *benchResult, elapsed time.Duration) {
+	count := len(result.latencies)
+	errorRate := 0.0
+	if count > 0 {
+		errorRate = float64(result.errors) / float64(count) * 100
+	}
+
+	fmt.Printf("Benchmark summary (%s):\n\n", benchEntityName)
+	fmt.Printf("  Requests:    %d\n", count)
+	fmt.Printf("  Succeeded:   %d\n", count-result.errors)
+	fmt.Printf("  Errors:      %d (%.2f%%)\n", result.errors, errorRate)
+	fmt.Printf("  Elapsed:     %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("  RPS:         %.1f\n", float64(count)/elapsed.Seconds())
+
+	if count > 0 {
+		fmt.Printf("\nLatency:\n\n")
+		fmt.Printf("  p50:         %s\n", benchPercentile(result.latencies, 50))
+		fmt.Printf("  p95:         %s\n", benchPercentile(result.latencies, 95))
+		fmt.Printf("  p99:         %s\n", benchPercentile(result.latencies, 99))
+		fmt.Printf("  max:         %s\n", result.latencies[count-1].Round(time.Microsecond))
+	}
+
+	if len(result.statuses) > 0 {
+		statuses := make([]string, 0, len(result.statuses))
+		for status := range result.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+
+		fmt.Printf("\nResults:\n\n")
+		for _, status := range statuses {
+			fmt.Printf("  %-14s %d\n", status+":", result.statuses[status])
+		}
+	}
+
+	if result.errors > 0 {
+		fmt.Printf("\n⚠️  %d request(s) failed\n", result.errors)
+	} else {
+		fmt.Printf("\n✅ All requests succeeded\n")
+	}
+}
+
+// benchPercentile returns p-th percentile of sorted latencies (nearest rank)
+func benchPercentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank].Round(time.Microsecond)
+}
+
+// benchIP returns random documentation-range IPv4 address (RFC 5737)
+func benchIP() string {
+	prefixes := []string{"192.0.2", "198.51.100", "203.0.113"}
+	return fmt.Sprintf("%s.%d", prefixes[rand.Intn(len(prefixes))], 1+rand.Intn(254))
+}
+
+// benchEntityNames returns sorted benchmarkable entity names
+func benchEntityNames() []string {
+	names := make([]string, 0, len(benchEntities))
+	for name := range benchEntities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
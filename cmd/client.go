@@ -95,16 +95,31 @@ var clientGenerateSignCmd = &cobra.Command{
 	SilenceErrors: true,
 }
 
+var clientGenerateJWTCmd = &cobra.Command{
+	Use:           "generatejwt [client_id]",
+	Short:         "Generate JWT for testing",
+	Long:          `Generate signed JWT with client_id, permissions and exp claims for testing API endpoints (RSA clients only, configured algorithm must be RS256 or RS512)`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runClientGenerateJWT,
+	SilenceErrors: true,
+}
+
 // Command flags
 var (
 	clientName        string
 	clientType        string
 	clientPermissions string
 	clientKeyPath     string
+	clientWindow      int
+	clientAllowedIPs  string
 	forceDelete       bool
 	signMethod        string
 	signPath          string
 	withNonce         bool
+	signBody          string
+	signVersion       string
+	jwtTTL            time.Duration
+	jwtPrivateKey     string
 )
 
 func init() {
@@ -118,12 +133,15 @@ func init() {
 	clientCmd.AddCommand(clientDeleteCmd)
 	clientCmd.AddCommand(clientReloadCmd)
 	clientCmd.AddCommand(clientGenerateSignCmd)
+	clientCmd.AddCommand(clientGenerateJWTCmd)
 
 	// Create command flags
 	clientCreateCmd.Flags().StringVarP(&clientName, "name", "n", "", "Client name (required)")
-	clientCreateCmd.Flags().StringVarP(&clientType, "type", "t", "hmac", "Auth type: rsa or hmac (default: hmac)")
+	clientCreateCmd.Flags().StringVarP(&clientType, "type", "t", "hmac", "Auth type: rsa, ed25519, hmac or apikey (default: hmac)")
 	clientCreateCmd.Flags().StringVarP(&clientPermissions, "permissions", "p", "read:health,read:ping", "Comma-separated permissions")
-	clientCreateCmd.Flags().StringVarP(&clientKeyPath, "key-path", "k", "", "Public key path (required for RSA type)")
+	clientCreateCmd.Flags().StringVarP(&clientKeyPath, "key-path", "k", "", "Public key path (required for RSA and Ed25519 types)")
+	clientCreateCmd.Flags().IntVarP(&clientWindow, "window", "w", 0, "Signature timestamp window in seconds, max 150 (default: global 30s)")
+	clientCreateCmd.Flags().StringVar(&clientAllowedIPs, "allowed-ips", "", "Comma-separated source IPs/CIDRs allowlist (default: allow all)")
 	clientCreateCmd.MarkFlagRequired("name")
 
 	// Delete command flags
@@ -133,6 +151,14 @@ func init() {
 	clientGenerateSignCmd.Flags().StringVarP(&signMethod, "method", "m", "GET", "HTTP method (default: GET)")
 	clientGenerateSignCmd.Flags().StringVarP(&signPath, "path", "p", "/v1/health", "API path (default: /v1/health)")
 	clientGenerateSignCmd.Flags().BoolVarP(&withNonce, "with-nonce", "n", false, "Include nonce for replay attack prevention")
+	clientGenerateSignCmd.Flags().StringVarP(&signBody, "body", "b", "", "Request body to sign (e.g. JSON for POST)")
+	clientGenerateSignCmd.Flags().StringVar(&signVersion, "signature-version", auth.SignatureVersionBodyHash, "Signature scheme: 2 signs body SHA256, 1 embeds raw body (legacy)")
+
+	// Generate JWT command flags
+	clientGenerateJWTCmd.Flags().DurationVar(&jwtTTL, "ttl", time.Hour, "Token lifetime (default: 1h)")
+	clientGenerateJWTCmd.Flags().StringVarP(&jwtPrivateKey, "private-key", "k", "", "RSA private key path matching client public key (required)")
+	clientGenerateJWTCmd.Flags().StringVarP(&signPath, "path", "p", "/v1/health", "API path for curl example (default: /v1/health)")
+	clientGenerateJWTCmd.MarkFlagRequired("private-key")
 
 	// Add to root command
 	rootCmd.AddCommand(clientCmd)
@@ -160,7 +186,7 @@ func getKeySize() int {
 	switch algorithm {
 	case "HS256", "RS256":
 		return 32 // 256 bits
-	case "HS512", "RS512":
+	case "HS512", "RS512", "EdDSA":
 		return 64 // 512 bits
 	default:
 		return 32 // Default to 256 bits
@@ -184,52 +210,102 @@ func saveConfig(cfg *config.Config) error {
 	return nil
 }
 
-// runClientCreate creates a new authentication client
-func runClientCreate(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
+// parsePermissions splits comma-separated permissions
+func parsePermissions(value string) []string {
+	permissions := strings.Split(value, ",")
+	for i, perm := range permissions {
+		permissions[i] = strings.TrimSpace(perm)
+	}
+	return permissions
+}
 
+// newClientConfig validates input and builds new client config with generated ID and credentials.
+// Returns plaintext API key for apikey clients (shown once, only hash is stored).
+func newClientConfig(name, authType string, permissions []string, keyPath string, window int, allowedIPs []string) (config.ClientConfig, string, error) {
 	// Validate auth type
-	if clientType != "rsa" && clientType != "hmac" {
-		return fmt.Errorf("invalid auth type: %s (must be 'rsa' or 'hmac')", clientType)
+	if authType != "rsa" && authType != "ed25519" && authType != "hmac" && authType != "apikey" {
+		return config.ClientConfig{}, "", fmt.Errorf("invalid auth type: %s (must be 'rsa', 'ed25519', 'hmac' or 'apikey')", authType)
 	}
 
-	// Validate RSA key path
-	if clientType == "rsa" && clientKeyPath == "" {
-		return fmt.Errorf("key-path is required for RSA auth type")
+	// Validate signature window (0 = global default)
+	if window < 0 || window > auth.MaxSignatureWindowSeconds {
+		return config.ClientConfig{}, "", fmt.Errorf("invalid window: %d (must be positive seconds, at most %d)", window, auth.MaxSignatureWindowSeconds)
 	}
 
-	// Check if key file exists for RSA
-	if clientType == "rsa" {
-		if _, err := os.Stat(clientKeyPath); os.IsNotExist(err) {
-			return fmt.Errorf("public key file not found: %s", clientKeyPath)
-		}
+	// Validate source IP allowlist
+	if _, err := auth.ParseAllowedIPs(allowedIPs); err != nil {
+		return config.ClientConfig{}, "", fmt.Errorf("invalid allowed-ips: %v", err)
 	}
 
-	// Parse permissions
-	permissions := strings.Split(clientPermissions, ",")
-	for i, perm := range permissions {
-		permissions[i] = strings.TrimSpace(perm)
+	// Public key based auth types (RSA, Ed25519) require key path
+	usesKeyPath := authType == "rsa" || authType == "ed25519"
+
+	// Validate key path
+	if usesKeyPath && keyPath == "" {
+		return config.ClientConfig{}, "", fmt.Errorf("key-path is required for %s auth type", strings.ToUpper(authType))
 	}
 
-	// Generate client ID
-	clientID := generateClientID()
+	// Check if key file exists
+	if usesKeyPath {
+		if _, err := os.Stat(keyPath); os.IsNotExist(err) {
+			return config.ClientConfig{}, "", fmt.Errorf("public key file not found: %s", keyPath)
+		}
+	}
 
 	// Create client config
 	newClient := config.ClientConfig{
-		ClientID:    clientID,
-		ClientName:  clientName,
-		AuthType:    clientType,
+		ClientID:    generateClientID(),
+		ClientName:  name,
+		AuthType:    authType,
 		Permissions: permissions,
 		Active:      true,
+
+		SignatureWindowSeconds: window,
+		AllowedIPs:             allowedIPs,
 	}
 
 	// Set auth-specific fields
-	if clientType == "rsa" {
-		newClient.KeyPath = clientKeyPath
-	} else {
+	var apiKey string
+	switch {
+	case usesKeyPath:
+		newClient.KeyPath = keyPath
+	case authType == "apikey":
+		// Only hash of API key is stored in config
+		key, keyHash, err := auth.GenerateAPIKey()
+		if err != nil {
+			return config.ClientConfig{}, "", err
+		}
+		apiKey = key
+		newClient.APIKeyHash = keyHash
+	default:
 		newClient.SecretKey = generateSecretKey()
 	}
 
+	return newClient, apiKey, nil
+}
+
+// runClientCreate creates a new authentication client
+func runClientCreate(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	// Parse source IP allowlist
+	var allowedIPs []string
+	if clientAllowedIPs != "" {
+		for _, entry := range strings.Split(clientAllowedIPs, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				allowedIPs = append(allowedIPs, entry)
+			}
+		}
+	}
+
+	// Validate input and build client config
+	newClient, apiKey, err := newClientConfig(clientName, clientType, parsePermissions(clientPermissions), clientKeyPath, clientWindow, allowedIPs)
+	if err != nil {
+		return err
+	}
+	clientID := newClient.ClientID
+	permissions := newClient.Permissions
+
 	// DUAL UPDATE: 1. Add to memory cache first
 	if err := auth.AddClient(newClient); err != nil {
 		return fmt.Errorf("failed to add client to memory cache: %v", err)
@@ -250,10 +326,20 @@ func runClientCreate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Auth Type:    %s\n", clientType)
 	fmt.Printf("Permissions:  %s\n", strings.Join(permissions, ", "))
 	fmt.Printf("Status:       active\n")
+	if clientWindow > 0 {
+		fmt.Printf("Sig. Window:  %ds\n", clientWindow)
+	}
+	if len(allowedIPs) > 0 {
+		fmt.Printf("Allowed IPs:  %s\n", strings.Join(allowedIPs, ", "))
+	}
 
 	if clientType == "hmac" {
 		fmt.Printf("\n🔑 Secret Key: %s\n", newClient.SecretKey)
 		fmt.Printf("\n⚠️  Save this secret key securely - it won't be shown again!\n")
+	} else if clientType == "apikey" {
+		fmt.Printf("\n🔑 API Key: %s\n", apiKey)
+		fmt.Printf("\n⚠️  Save this API key securely - only its hash is stored, it can't be shown again!\n")
+		fmt.Printf("💡 Send it in the X-API-Key header\n")
 	} else {
 		fmt.Printf("Key Path:     %s\n", clientKeyPath)
 	}
@@ -330,9 +416,21 @@ func runClientShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Auth Type:    %s\n", client.AuthType)
 	fmt.Printf("Status:       %s\n", map[bool]string{true: "active", false: "revoked"}[client.Active])
 	fmt.Printf("Permissions:  %s\n", strings.Join(client.Permissions, ", "))
+	if client.SignatureWindowSeconds > 0 {
+		fmt.Printf("Sig. Window:  %ds\n", client.SignatureWindowSeconds)
+	} else {
+		fmt.Printf("Sig. Window:  default\n")
+	}
+	if len(client.AllowedIPs) > 0 {
+		fmt.Printf("Allowed IPs:  %s\n", strings.Join(client.AllowedIPs, ", "))
+	} else {
+		fmt.Printf("Allowed IPs:  any\n")
+	}
 
-	if client.AuthType == "rsa" {
+	if client.AuthType == "rsa" || client.AuthType == "ed25519" {
 		fmt.Printf("Key Path:     %s\n", client.KeyPath)
+	} else if client.AuthType == "apikey" {
+		fmt.Printf("API Key Hash: %s****** (sha256)\n", client.APIKeyHash[:8])
 	} else {
 		fmt.Printf("Secret Key:   %s****** (hidden)\n", client.SecretKey[:8])
 	}
@@ -575,14 +673,21 @@ func runClientGenerateSign(cmd *cobra.Command, args []string) error {
 		nonce = hex.EncodeToString(nonceBytes)
 	}
 
-	// Create signature payload
+	// Create signature payload (body digest for version 2, raw body for legacy version 1)
+	if !auth.IsSignatureVersion(signVersion) {
+		return fmt.Errorf("unsupported signature version: %s (expected %s or %s)", signVersion, auth.SignatureVersionRawBody, auth.SignatureVersionBodyHash)
+	}
 	payload := auth.SignaturePayload{
 		ClientID:  clientID,
 		Timestamp: timestamp,
 		Nonce:     nonce,
 		Method:    signMethod,
 		Path:      signPath,
-		Body:      "", // No body for GET requests
+	}
+	if signVersion == auth.SignatureVersionBodyHash {
+		payload.BodySHA256, _ = auth.BodySHA256(strings.NewReader(signBody), nil)
+	} else {
+		payload.Body = signBody
 	}
 
 	// Generate signature using the client's secret key
@@ -604,6 +709,9 @@ func runClientGenerateSign(cmd *cobra.Command, args []string) error {
 	if withNonce {
 		fmt.Printf("  Nonce:       %s\n", nonce)
 	}
+	if payload.BodySHA256 != "" {
+		fmt.Printf("  Body SHA256: %s\n", payload.BodySHA256)
+	}
 	fmt.Printf("\nGenerated Headers:\n")
 	fmt.Printf("  X-Client-ID: %s\n", clientID)
 	fmt.Printf("  X-Timestamp: %d\n", timestamp)
@@ -611,6 +719,9 @@ func runClientGenerateSign(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  X-Nonce:     %s\n", nonce)
 	}
 	fmt.Printf("  X-Signature: %s\n", signature)
+	if signVersion == auth.SignatureVersionBodyHash {
+		fmt.Printf("  X-Signature-Version: %s\n", signVersion)
+	}
 
 	// Generate ready-to-use curl command
 	fmt.Printf("\n📋 Ready-to-use curl command:\n")
@@ -621,6 +732,78 @@ func runClientGenerateSign(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  -H \"X-Nonce: %s\" \\\n", nonce)
 	}
 	fmt.Printf("  -H \"X-Signature: %s\" \\\n", signature)
+	if signVersion == auth.SignatureVersionBodyHash {
+		fmt.Printf("  -H \"X-Signature-Version: %s\" \\\n", signVersion)
+	}
+	if signBody != "" {
+		fmt.Printf("  -H \"Content-Type: application/json\" \\\n")
+		fmt.Printf("  --data-raw '%s' \\\n", signBody)
+	}
+	fmt.Printf("  \"http://localhost:8080%s\"\n", signPath)
+
+	return nil
+}
+
+// runClientGenerateJWT mints JWT for testing API endpoints with RSA clients
+func runClientGenerateJWT(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+	clientID := args[0]
+
+	// Find client
+	var client *config.ClientConfig
+	for _, c := range cfg.Auth.Clients {
+		if c.ClientID == clientID {
+			client = &c
+			break
+		}
+	}
+
+	if client == nil {
+		fmt.Printf("❌ Client not found: %s\n", clientID)
+		fmt.Printf("\nUse 'client list' to see all available clients.\n")
+		return fmt.Errorf("client not found")
+	}
+
+	// Check if client is RSA type
+	if client.AuthType != "rsa" {
+		fmt.Printf("❌ JWT generation is only supported for RSA clients.\n")
+		fmt.Printf("Client %s (%s) is using %s authentication.\n", clientID, client.ClientName, client.AuthType)
+		return fmt.Errorf("unsupported auth type for JWT generation")
+	}
+
+	// Check if client is active
+	if !client.Active {
+		fmt.Printf("⚠️  Warning: Client %s (%s) is currently inactive.\n", clientID, client.ClientName)
+	}
+
+	privateKey, err := auth.LoadRSAPrivateKey(jwtPrivateKey)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return err
+	}
+
+	token, claims, err := auth.GenerateJWT(*client, privateKey, jwtTTL)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return err
+	}
+
+	// Display results
+	fmt.Printf("🔐 JWT Generated Successfully!\n\n")
+	fmt.Printf("Client Details:\n")
+	fmt.Printf("  Client ID:   %s\n", client.ClientID)
+	fmt.Printf("  Client Name: %s\n", client.ClientName)
+	fmt.Printf("  Status:      %s\n", map[bool]string{true: "active", false: "inactive"}[client.Active])
+	fmt.Printf("\nToken Details:\n")
+	fmt.Printf("  Algorithm:   %s\n", cfg.Auth.Algorithm)
+	fmt.Printf("  Permissions: %s\n", strings.Join(claims.Permissions, ", "))
+	fmt.Printf("  Expires At:  %s (ttl %s)\n", claims.ExpiresAt.Time.Format(time.RFC3339), jwtTTL)
+	fmt.Printf("\nToken:\n%s\n", token)
+
+	// Generate ready-to-use curl command
+	fmt.Printf("\n📋 Ready-to-use curl command:\n")
+	fmt.Printf("curl -s \\\n")
+	fmt.Printf("  -H \"Authorization: Bearer %s\" \\\n", token)
 	fmt.Printf("  \"http://localhost:8080%s\"\n", signPath)
 
 	return nil
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/spf13/cobra"
+)
+
+// # clients.csv (header row optional, permissions separated by ";" or quoted ",")
+// name,type,permissions,key-path
+// Payment Service,hmac,write:transaction_events;read:health,
+// Mobile Gateway,rsa,"read:health,read:ping",storage/keys/mobile.pub
+
+// # Create all valid rows, report failed rows
+// ./insight-collector client create-bulk clients.csv
+
+// # All-or-nothing
+// ./insight-collector client create-bulk clients.csv --atomic
+
+var clientCreateBulkCmd = &cobra.Command{
+	Use:           "create-bulk [csv]",
+	Short:         "Create multiple clients from CSV file",
+	Long:          `Create clients from CSV with columns name, type, permissions, key-path (generated secrets are shown once)`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runClientCreateBulk,
+	SilenceErrors: true,
+}
+
+// Bulk command flags
+var bulkAtomic bool
+
+// bulkRow holds parsed CSV row and its result
+type bulkRow struct {
+	line   int
+	name   string
+	client config.ClientConfig
+	apiKey string
+	err    error
+}
+
+func init() {
+	clientCmd.AddCommand(clientCreateBulkCmd)
+
+	// Bulk command flags
+	clientCreateBulkCmd.Flags().BoolVar(&bulkAtomic, "atomic", false, "Abort and roll back all clients if any row fails (default: continue and report per row)")
+}
+
+// runClientCreateBulk creates clients from CSV rows
+func runClientCreateBulk(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	rows, err := readBulkCSV(args[0])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No client rows found in CSV.")
+		return nil
+	}
+
+	// Validation pass (no changes yet, atomic mode aborts here)
+	failed := 0
+	for _, row := range rows {
+		if row.err != nil {
+			failed++
+		}
+	}
+	if bulkAtomic && failed > 0 {
+		printBulkResults(rows, false)
+		fmt.Printf("\n❌ %d row(s) failed validation, no clients created (--atomic).\n", failed)
+		return fmt.Errorf("%d row(s) failed validation", failed)
+	}
+
+	// DUAL UPDATE: 1. Add to memory cache
+	var added []string
+	for _, row := range rows {
+		if row.err != nil {
+			continue
+		}
+
+		if err := auth.AddClient(row.client); err != nil {
+			row.err = fmt.Errorf("failed to add client to memory cache: %v", err)
+			failed++
+
+			if bulkAtomic {
+				rollbackBulk(added)
+				printBulkResults(rows, false)
+				fmt.Printf("\n❌ Line %d failed, all clients rolled back (--atomic).\n", row.line)
+				return row.err
+			}
+			continue
+		}
+		added = append(added, row.client.ClientID)
+	}
+
+	// DUAL UPDATE: 2. Add to config file (once for all rows)
+	if len(added) > 0 {
+		for _, row := range rows {
+			if row.err == nil {
+				cfg.Auth.Clients = append(cfg.Auth.Clients, row.client)
+			}
+		}
+		if err := saveConfig(cfg); err != nil {
+			rollbackBulk(added)
+			return fmt.Errorf("failed to save config (rolled back memory cache): %v", err)
+		}
+	}
+
+	printBulkResults(rows, true)
+	fmt.Printf("\nCreated: %d, Failed: %d\n", len(added), failed)
+	if len(added) > 0 {
+		fmt.Printf("\n⚠️  Save generated secret/API keys securely - they won't be shown again!\n")
+		fmt.Printf("✨ Clients are immediately active - no server restart required!\n")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d row(s) failed", failed)
+	}
+	return nil
+}
+
+// readBulkCSV parses CSV rows into client configs (row validation errors are kept per row)
+func readBulkCSV(path string) ([]*bulkRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Trailing key-path column is optional
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var rows []*bulkRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		// Skip header row
+		if len(rows) == 0 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "name") {
+			continue
+		}
+
+		rows = append(rows, parseBulkRecord(line, record))
+	}
+	return rows, nil
+}
+
+// parseBulkRecord builds client config from CSV record: name, type, permissions, key-path
+func parseBulkRecord(line int, record []string) *bulkRow {
+	field := func(i int) string {
+		if i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := &bulkRow{line: line, name: field(0)}
+	if row.name == "" {
+		row.err = fmt.Errorf("name is required")
+		return row
+	}
+
+	authType := strings.ToLower(field(1))
+	if authType == "" {
+		authType = "hmac"
+	}
+
+	// Permissions separated by ";" (or "," when column is quoted)
+	var permissions []string
+	for _, perm := range strings.FieldsFunc(field(2), func(r rune) bool { return r == ';' || r == ',' }) {
+		if perm = strings.TrimSpace(perm); perm != "" {
+			permissions = append(permissions, perm)
+		}
+	}
+	if len(permissions) == 0 {
+		row.err = fmt.Errorf("permissions are required")
+		return row
+	}
+
+	row.client, row.apiKey, row.err = newClientConfig(row.name, authType, permissions, field(3), 0, nil)
+	return row
+}
+
+// rollbackBulk removes added clients from memory cache
+func rollbackBulk(clientIDs []string) {
+	for _, clientID := range clientIDs {
+		auth.RemoveClient(clientID)
+	}
+}
+
+// printBulkResults prints per-row results table, credentials are shown only when clients were persisted
+func printBulkResults(rows []*bulkRow, persisted bool) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"Line", "Name", "Type", "Client ID", "Secret / API Key", "Status"})
+
+	for _, row := range rows {
+		status := "✅ created"
+		clientID := row.client.ClientID
+		credential := ""
+
+		switch {
+		case row.err != nil:
+			status = "❌ " + row.err.Error()
+			clientID = ""
+		case !persisted:
+			status = "⏭️  not created"
+			clientID = ""
+		case row.client.AuthType == "hmac":
+			credential = row.client.SecretKey
+		case row.client.AuthType == "apikey":
+			credential = row.apiKey
+		default:
+			credential = "key: " + row.client.KeyPath
+		}
+
+		table.Append([]string{
+			strconv.Itoa(row.line),
+			row.name,
+			row.client.AuthType,
+			clientID,
+			credential,
+			status,
+		})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/spf13/cobra"
+)
+
+// # Export all clients (HMAC secrets included)
+// ./insight-collector client export --output clients.json
+
+// # Export without secrets (review/audit only, redacted HMAC clients cannot be imported)
+// ./insight-collector client export --redact
+
+// # Import, skipping clients that already exist
+// ./insight-collector client import clients.json
+
+// # Import, overwriting existing clients
+// ./insight-collector client import clients.json --merge
+
+var clientExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all client configs as JSON",
+	Long:  `Export all authentication client configs as JSON (stdout or file) for migration between environments`,
+	RunE:  runClientExport,
+}
+
+var clientImportCmd = &cobra.Command{
+	Use:           "import [file]",
+	Short:         "Import client configs from JSON",
+	Long:          `Import authentication client configs exported with 'client export' ("-" reads stdin)`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runClientImport,
+	SilenceErrors: true,
+}
+
+// Transfer command flags
+var (
+	exportOutput string
+	exportRedact bool
+	importMerge  bool
+)
+
+// clientExport is export file format
+type clientExport struct {
+	ExportedAt time.Time             `json:"exported_at"`
+	Clients    []config.ClientConfig `json:"clients"`
+}
+
+func init() {
+	clientCmd.AddCommand(clientExportCmd)
+	clientCmd.AddCommand(clientImportCmd)
+
+	// Export command flags
+	clientExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
+	clientExportCmd.Flags().BoolVar(&exportRedact, "redact", false, "Redact HMAC secret keys")
+
+	// Import command flags
+	clientImportCmd.Flags().BoolVar(&importMerge, "merge", false, "Overwrite existing clients with same client_id (default: skip)")
+}
+
+// runClientExport writes all client configs as JSON
+func runClientExport(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	export := clientExport{
+		ExportedAt: time.Now().UTC(),
+		Clients:    make([]config.ClientConfig, len(cfg.Auth.Clients)),
+	}
+	copy(export.Clients, cfg.Auth.Clients)
+
+	if exportRedact {
+		for i := range export.Clients {
+			export.Clients[i].SecretKey = ""
+		}
+	}
+
+	data, err := json.MarshalIndent(export, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode clients: %v", err)
+	}
+	data = append(data, '\n')
+
+	if exportOutput == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	// Export contains secrets unless redacted, keep file private
+	if err := os.WriteFile(exportOutput, data, 0600); err != nil {
+		return fmt.Errorf("failed to write export file: %v", err)
+	}
+
+	fmt.Printf("✅ Exported %d client(s) to %s\n", len(export.Clients), exportOutput)
+	if !exportRedact {
+		fmt.Printf("\n⚠️  Export contains HMAC secret keys - store it securely!\n")
+	}
+
+	return nil
+}
+
+// runClientImport loads client configs, adds them to memory cache and persists config file
+func runClientImport(cmd *cobra.Command, args []string) error {
+	cfg := config.Get()
+
+	clients, err := readClientImport(args[0])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return err
+	}
+
+	// Index existing clients
+	existing := make(map[string]int, len(cfg.Auth.Clients))
+	for i, client := range cfg.Auth.Clients {
+		existing[client.ClientID] = i
+	}
+
+	var (
+		added    []string
+		updated  []config.ClientConfig // Previous configs, used for rollback
+		skipped  []string
+		failures []string
+	)
+
+	for _, client := range clients {
+		if err := validateImportedClient(client); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", client.ClientID, err))
+			continue
+		}
+
+		index, exists := existing[client.ClientID]
+		switch {
+		case exists && !importMerge:
+			skipped = append(skipped, client.ClientID)
+
+		case exists:
+			// DUAL UPDATE: 1. Update memory cache
+			if err := auth.UpdateClient(client); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", client.ClientID, err))
+				continue
+			}
+			updated = append(updated, cfg.Auth.Clients[index])
+			cfg.Auth.Clients[index] = client
+
+		default:
+			// DUAL UPDATE: 1. Add to memory cache
+			if err := auth.AddClient(client); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", client.ClientID, err))
+				continue
+			}
+			added = append(added, client.ClientID)
+			cfg.Auth.Clients = append(cfg.Auth.Clients, client)
+			existing[client.ClientID] = len(cfg.Auth.Clients) - 1
+		}
+	}
+
+	// DUAL UPDATE: 2. Save config file (once for all changes)
+	if len(added) > 0 || len(updated) > 0 {
+		if err := saveConfig(cfg); err != nil {
+			// Rollback memory cache
+			for _, clientID := range added {
+				auth.RemoveClient(clientID)
+			}
+			for _, previous := range updated {
+				auth.UpdateClient(previous)
+			}
+			return fmt.Errorf("failed to save config (rolled back memory cache): %v", err)
+		}
+	}
+
+	// Summary
+	fmt.Printf("Import summary (%d in file):\n\n", len(clients))
+	fmt.Printf("  Added:    %d\n", len(added))
+	fmt.Printf("  Updated:  %d\n", len(updated))
+	fmt.Printf("  Skipped:  %d\n", len(skipped))
+	fmt.Printf("  Failed:   %d\n", len(failures))
+
+	if len(skipped) > 0 {
+		fmt.Printf("\nSkipped (already exist, use --merge to overwrite):\n")
+		for _, clientID := range skipped {
+			fmt.Printf("  - %s\n", clientID)
+		}
+	}
+	if len(failures) > 0 {
+		fmt.Printf("\n❌ Failed:\n")
+		for _, failure := range failures {
+			fmt.Printf("  - %s\n", failure)
+		}
+		return fmt.Errorf("%d client(s) failed to import", len(failures))
+	}
+
+	fmt.Printf("\n✅ Import completed (immediate effect).\n")
+	return nil
+}
+
+// readClientImport reads export file (object with "clients" or plain array of client configs)
+func readClientImport(path string) ([]config.ClientConfig, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %v", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var clients []config.ClientConfig
+		if err := json.Unmarshal(data, &clients); err != nil {
+			return nil, fmt.Errorf("invalid import file: %v", err)
+		}
+		return clients, nil
+	}
+
+	var export clientExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid import file: %v", err)
+	}
+	return export.Clients, nil
+}
+
+// validateImportedClient checks required fields per auth type before touching memory cache
+func validateImportedClient(client config.ClientConfig) error {
+	if client.ClientID == "" {
+		return fmt.Errorf("missing client_id")
+	}
+
+	switch client.AuthType {
+	case "rsa", "ed25519":
+		if client.KeyPath == "" {
+			return fmt.Errorf("missing key_path for %s client", client.AuthType)
+		}
+		if _, err := os.Stat(client.KeyPath); os.IsNotExist(err) {
+			return fmt.Errorf("public key file not found: %s", client.KeyPath)
+		}
+	case "hmac":
+		if client.SecretKey == "" {
+			return fmt.Errorf("missing secret_key (redacted export?)")
+		}
+	case "apikey":
+		if client.APIKeyHash == "" {
+			return fmt.Errorf("missing api_key_hash")
+		}
+	default:
+		return fmt.Errorf("invalid auth_type '%s' (must be 'rsa', 'ed25519', 'hmac' or 'apikey')", client.AuthType)
+	}
+
+	if _, err := auth.ParseAllowedIPs(client.AllowedIPs); err != nil {
+		return fmt.Errorf("invalid allowed_ips: %v", err)
+	}
+	return nil
+}
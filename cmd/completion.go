@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	asynqPkg "github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion script",
+	Long: `Generate shell completion script and write it to stdout.
+
+  bash:       source <(insight-collector completion bash)
+  zsh:        insight-collector completion zsh > "${fpath[1]}/_insight-collector"
+  fish:       insight-collector completion fish > ~/.config/fish/completions/insight-collector.fish
+  powershell: insight-collector completion powershell | Out-String | Invoke-Expression`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+// isCompletionRequest reports whether CLI was invoked to generate or query shell completion
+func isCompletionRequest() bool {
+	if len(os.Args) < 2 {
+		return false
+	}
+	switch os.Args[1] {
+	case "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return false
+}
+
+// completeClientIDs completes first argument with configured client IDs (client name as description)
+func completeClientIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 || config.Get() == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, client := range config.Get().Auth.Clients {
+		if strings.HasPrefix(client.ClientID, toComplete) {
+			ids = append(ids, client.ClientID+"\t"+client.ClientName)
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkerNames completes first argument with worker names from current worker config
+func completeWorkerNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, worker := range asynqPkg.GetWorkers() {
+		if strings.HasPrefix(worker.Name, toComplete) {
+			names = append(names, worker.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	// Client commands taking client_id
+	for _, c := range []*cobra.Command{
+		clientShowCmd, clientRevokeCmd, clientActivateCmd, clientRegenerateCmd,
+		clientDeleteCmd, clientGenerateSignCmd, clientGenerateJWTCmd,
+	} {
+		c.ValidArgsFunction = completeClientIDs
+	}
+
+	// Worker commands taking worker name
+	for _, c := range []*cobra.Command{workerShowCmd, workerSetCmd} {
+		c.ValidArgsFunction = completeWorkerNames
+	}
+
+	rootCmd.AddCommand(completionCmd)
+}
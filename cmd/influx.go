@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	sesEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/spf13/cobra"
+)
+
+// # Export one month of transaction events
+// ./insight-collector influx export --measurement transaction_events --start 2025-01-01 --end 2025-01-31 --output te_2025-01.jsonl
+
+// # Restore export (original timestamps & tags)
+// ./insight-collector influx import te_2025-01.jsonl
+
+// influxMaxLineSize bounds single backup line (large details payloads)
+const influxMaxLineSize = 4 * 1024 * 1024
+
+// influxMeasurement describes how to query and map measurement for backup
+type influxMeasurement struct {
+	queryConfig func() v2oss.QueryBuilderConfig
+	newEntity   func() entity.Entity // Zero entity, its point gives tag keys
+}
+
+// influxMeasurements lists measurements supported by export (same as entity list/export endpoints)
+var influxMeasurements = map[string]influxMeasurement{
+	"transaction_events": {teEntities.GetQueryConfig, func() entity.Entity { return &teEntities.TransactionEvents{} }},
+	"error_events":       {eeEntities.GetQueryConfig, func() entity.Entity { return &eeEntities.ErrorEvents{} }},
+	"security_events":    {seEntities.GetQueryConfig, func() entity.Entity { return &seEntities.SecurityEvents{} }},
+	"session_events":     {sesEntities.GetQueryConfig, func() entity.Entity { return &sesEntities.SessionEvents{} }},
+	"callback_logs":      {clEntities.GetQueryConfig, func() entity.Entity { return &clEntities.CallbackLogs{} }},
+	"user_activities":    {uaEntities.GetQueryConfig, func() entity.Entity { return &uaEntities.UserActivities{} }},
+}
+
+// Influx command flags
+var (
+	influxMeasurementName string
+	influxStart           string
+	influxEnd             string
+	influxOutput          string
+	influxBatchSize       int
+)
+
+var influxCmd = &cobra.Command{
+	Use:   "influx",
+	Short: "InfluxDB backup and restore",
+	Long:  `Export measurements to JSON lines files and import them back (InfluxDB v2-oss only)`,
+}
+
+var influxExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export measurement to JSON lines file",
+	Long: `Stream all points of measurement within date range (YYYY-MM-DD, inclusive) to JSON lines file.
+Each line holds measurement, time, tags and fields of one point.`,
+	RunE:          runInfluxExport,
+	SilenceErrors: true,
+}
+
+var influxImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import JSON lines file created by influx export",
+	Long: `Write points from influx export file back to InfluxDB in batches, keeping original timestamps and tags.
+Points go to the measurement bucket (influxdb.buckets) or the configured bucket. Re-importing overwrites identical points.`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runInfluxImport,
+	SilenceErrors: true,
+}
+
+func init() {
+	influxExportCmd.Flags().StringVarP(&influxMeasurementName, "measurement", "m", "", "Measurement: "+strings.Join(influxMeasurementNames(), ", ")+" (required)")
+	influxExportCmd.Flags().StringVar(&influxStart, "start", "", "Start date YYYY-MM-DD (required)")
+	influxExportCmd.Flags().StringVar(&influxEnd, "end", "", "End date YYYY-MM-DD, inclusive (default: start date)")
+	influxExportCmd.Flags().StringVarP(&influxOutput, "output", "o", "", "Output file (required)")
+	influxExportCmd.MarkFlagRequired("measurement")
+	influxExportCmd.MarkFlagRequired("start")
+	influxExportCmd.MarkFlagRequired("output")
+
+	influxImportCmd.Flags().IntVarP(&influxBatchSize, "batch-size", "b", 5000, "Points per write request")
+
+	influxCmd.AddCommand(influxExportCmd)
+	influxCmd.AddCommand(influxImportCmd)
+	rootCmd.AddCommand(influxCmd)
+}
+
+// runInfluxExport streams measurement records to JSON lines file
+func runInfluxExport(cmd *cobra.Command, args []string) error {
+	spec, exists := influxMeasurements[influxMeasurementName]
+	if !exists {
+		return fmt.Errorf("invalid measurement: %s (must be one of %s)", influxMeasurementName, strings.Join(influxMeasurementNames(), ", "))
+	}
+
+	client, ok := influxdb.GetCurrentClient().(*v2oss.Client)
+	if !ok || client == nil {
+		return fmt.Errorf("influx export requires InfluxDB v2-oss client")
+	}
+
+	tagKeys, err := measurementTagKeys(spec)
+	if err != nil {
+		return err
+	}
+
+	// Backup keeps every column, not only those listed by the entity
+	queryConfig := spec.queryConfig()
+	queryConfig.Columns = nil
+	qb := v2oss.NewQueryBuilder(queryConfig)
+
+	req := &v2oss.PaginationRequest{
+		Direction: "next",
+		Range:     &v2oss.DateRangeFilter{Start: influxStart, End: influxEnd},
+	}
+
+	file, err := os.Create(influxOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	start := time.Now()
+	exported := 0
+
+	err = qb.StreamQuery(req, client, func(record map[string]interface{}) error {
+		backup, err := v2oss.NewBackupRecord(record, tagKeys)
+		if err != nil {
+			return fmt.Errorf("record %d: %v", exported+1, err)
+		}
+		if err := encoder.Encode(backup); err != nil {
+			return err
+		}
+		exported++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("export failed after %d records: %v", exported, err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write output file: %v", err)
+	}
+
+	fmt.Printf("✅ Exported %d %s records to %s (%s)\n", exported, influxMeasurementName, influxOutput, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// runInfluxImport writes points from JSON lines file in batches
+func runInfluxImport(cmd *cobra.Command, args []string) error {
+	if influxBatchSize < 1 {
+		return fmt.Errorf("invalid batch size: %d (must be >= 1)", influxBatchSize)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), influxMaxLineSize)
+
+	var (
+		lineNo, imported, invalid int
+		batch                     []interface{}
+		batchBucket               string
+		counts                    = make(map[string]int)
+		start                     = time.Now()
+	)
+
+	// Batch holds points of single bucket
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := influxdb.WritePointsToBucket(batchBucket, batch); err != nil {
+			return fmt.Errorf("write failed after %d points: %v", imported, err)
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		backup, err := v2oss.DecodeBackupRecord(line)
+		if err != nil {
+			invalid++
+			fmt.Printf("❌ line %d: %v\n", lineNo, err)
+			continue
+		}
+
+		bucket := influxdb.MeasurementBucket(backup.Measurement)
+		if bucket != batchBucket || len(batch) >= influxBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			batchBucket = bucket
+		}
+
+		batch = append(batch, influxdb.NewPoint(backup.Measurement, backup.Tags, backup.Fields, backup.Time))
+		counts[backup.Measurement]++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read import file at line %d: %v", lineNo+1, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	// Summary
+	elapsed := time.Since(start)
+	fmt.Printf("Import summary (%s):\n\n", args[0])
+	fmt.Printf("  Lines:       %d\n", lineNo)
+	fmt.Printf("  Imported:    %d\n", imported)
+	fmt.Printf("  Invalid:     %d\n", invalid)
+	for _, name := range sortedKeys(counts) {
+		fmt.Printf("    - %s: %d\n", name, counts[name])
+	}
+	if elapsed > 0 {
+		fmt.Printf("  Rate:        %.1f points/s\n", float64(imported)/elapsed.Seconds())
+	}
+	fmt.Printf("  Elapsed:     %s\n", elapsed.Round(time.Millisecond))
+
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid line(s)", invalid)
+	}
+	return nil
+}
+
+// measurementTagKeys returns tag keys written by entity ToPoint
+func measurementTagKeys(spec influxMeasurement) (map[string]bool, error) {
+	point, ok := spec.newEntity().ToPoint().(*v2oss.Point)
+	if !ok || point == nil {
+		return nil, fmt.Errorf("influx export requires InfluxDB v2-oss points")
+	}
+
+	tagKeys := make(map[string]bool)
+	for _, tag := range point.TagList() {
+		tagKeys[tag.Key] = true
+	}
+	return tagKeys, nil
+}
+
+// influxMeasurementNames returns sorted exportable measurement names
+func influxMeasurementNames() []string {
+	names := make([]string, 0, len(influxMeasurements))
+	for name := range influxMeasurements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedKeys returns map keys in sorted order
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,11 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/benedict-erwin/insight-collector/config"
 	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 	"github.com/spf13/cobra"
@@ -20,7 +22,7 @@ import (
 // // Manual database update check
 // func CheckForUpdates() error
 
-// // Force download specific database ("city" atau "asn")
+// // Force download specific database ("city", "asn" atau "anon")
 // func ForceDownload(dbType string) error
 
 // // Get current download status
@@ -44,6 +46,9 @@ import (
 // # Show status with auth info (masked)
 // ./insight-collector maxmind status
 
+// # Fail (exit 1) when any database is older than maxmind.max_age
+// ./insight-collector maxmind verify
+
 // Check for updates
 var maxmindCheckCmd = &cobra.Command{
 	Use:   "check-updates",
@@ -71,14 +76,14 @@ var maxmindCheckCmd = &cobra.Command{
 
 // Download databases
 var maxmindDownloadCmd = &cobra.Command{
-	Use:   "download [city|asn|all]",
+	Use:   "download [city|asn|anon|all]",
 	Short: "Force download MaxMind database",
 	Long:  "Force download specific MaxMind database or all databases",
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
-			return fmt.Errorf("requires exactly 1 arg city|asn|all")
+			return fmt.Errorf("requires exactly 1 arg city|asn|anon|all")
 		}
-		validArgs := []string{"city", "asn", "all"}
+		validArgs := []string{"city", "asn", "anon", "all"}
 		for _, valid := range validArgs {
 			if args[0] == valid {
 				return nil
@@ -115,6 +120,16 @@ var maxmindDownloadCmd = &cobra.Command{
 				fmt.Println("ASN database downloaded successfully")
 			}
 
+			// Download Anonymous IP database (optional)
+			if cfg := config.Get(); cfg != nil && cfg.MaxMind.Databases.Anon != "" {
+				fmt.Println("Downloading Anonymous IP database...")
+				if err := maxmind.ForceDownload("anon"); err != nil {
+					fmt.Printf("Error downloading Anonymous IP database: %v\n", err)
+				} else {
+					fmt.Println("Anonymous IP database downloaded successfully")
+				}
+			}
+
 			fmt.Println("All database downloads completed")
 			return nil
 		} else {
@@ -260,11 +275,21 @@ var maxmindInfoCmd = &cobra.Command{
 			fmt.Printf("  Path: %s\n", dbInfo.CityDBPath)
 			fmt.Printf("  Size: %.2f MB\n", float64(dbInfo.CityDBSize)/(1024*1024))
 			fmt.Printf("  Modified: %s\n", dbInfo.CityDBModTime.Format("2006-01-02 15:04:05"))
+			fmt.Printf("  Built: %s\n", dbInfo.CityDBBuildTime.Format("2006-01-02 15:04:05"))
 
 			fmt.Printf("\nASN Database:\n")
 			fmt.Printf("  Path: %s\n", dbInfo.ASNDBPath)
 			fmt.Printf("  Size: %.2f MB\n", float64(dbInfo.ASNDBSize)/(1024*1024))
 			fmt.Printf("  Modified: %s\n", dbInfo.ASNDBModTime.Format("2006-01-02 15:04:05"))
+			fmt.Printf("  Built: %s\n", dbInfo.ASNDBBuildTime.Format("2006-01-02 15:04:05"))
+
+			if dbInfo.AnonDBPath != "" {
+				fmt.Printf("\nAnonymous IP Database:\n")
+				fmt.Printf("  Path: %s\n", dbInfo.AnonDBPath)
+				fmt.Printf("  Size: %.2f MB\n", float64(dbInfo.AnonDBSize)/(1024*1024))
+				fmt.Printf("  Modified: %s\n", dbInfo.AnonDBModTime.Format("2006-01-02 15:04:05"))
+				fmt.Printf("  Built: %s\n", dbInfo.AnonDBBuildTime.Format("2006-01-02 15:04:05"))
+			}
 			fmt.Printf("\n")
 		}
 
@@ -272,6 +297,64 @@ var maxmindInfoCmd = &cobra.Command{
 	},
 }
 
+var maxmindVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify databases are not outdated",
+	Long:  "Check build time of each loaded database (mmdb metadata) against maxmind.max_age, exits non-zero when any database is stale",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize MaxMind (will init config internally)
+		if err := maxmind.InitMinimalForCLI(); err != nil {
+			return fmt.Errorf("failed to initialize MaxMind: %w", err)
+		}
+		defer maxmind.Close()
+
+		dbInfo, err := maxmind.GetDatabaseInfoCLI()
+		if err != nil {
+			return err
+		}
+		if !dbInfo.Enabled {
+			return fmt.Errorf("MaxMind service is disabled")
+		}
+
+		ages := maxmind.CheckDatabaseAges(dbInfo, maxmind.MaxAge, time.Now())
+		var stale []string
+		for _, age := range ages {
+			if age.Stale {
+				stale = append(stale, age.Database)
+			}
+		}
+
+		// If using JSON Output
+		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+			output, _ := json.MarshalIndent(ages, "", "  ")
+			fmt.Println(string(output))
+		} else {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.Header([]string{"Database", "Built", "Age", "Max Age", "Status"})
+			for _, age := range ages {
+				built, ageStr, status := "-", "-", "✅ OK"
+				if age.Loaded {
+					built = age.BuildTime.Format("2006-01-02 15:04")
+					ageStr = age.Age
+				}
+				switch {
+				case !age.Loaded:
+					status = "❌ Not loaded"
+				case age.Stale:
+					status = "⚠️ Outdated"
+				}
+				table.Append([]string{age.Database, built, ageStr, age.MaxAge, status})
+			}
+			table.Render()
+		}
+
+		if len(stale) > 0 {
+			return fmt.Errorf("outdated or missing GeoIP database: %s", strings.Join(stale, ", "))
+		}
+		return nil
+	},
+}
+
 var maxmindLookupCmd = &cobra.Command{
 	Use:   "lookup [ip]",
 	Short: "Test IP address lookup",
@@ -289,13 +372,15 @@ var maxmindLookupCmd = &cobra.Command{
 		// Perform lookups
 		geoLocation := maxmind.LookupCityFromString(ipAddr)
 		asnInfo := maxmind.LookupASNFromString(ipAddr)
+		anonInfo := maxmind.LookupAnonymousFromString(ipAddr)
 
 		// If using JSON Output
 		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
 			result := map[string]interface{}{
 				"ip":  ipAddr,
 				"geo": geoLocation,
-				"asn": asnInfo,
+				"asn":       asnInfo,
+				"anonymous": anonInfo,
 			}
 			utils.ClearScreen()
 			output, _ := json.MarshalIndent(result, "", "  ")
@@ -319,6 +404,66 @@ var maxmindLookupCmd = &cobra.Command{
 		fmt.Printf("  ASN: %d\n", asnInfo.ASN)
 		fmt.Printf("  Organization: %s\n", asnInfo.Organization)
 
+		fmt.Printf("\nAnonymous IP Information:\n")
+		fmt.Printf("  Anonymous: %v\n", anonInfo.IsAnonymous)
+		fmt.Printf("  VPN: %v\n", anonInfo.IsAnonymousVPN)
+		fmt.Printf("  Hosting Provider: %v\n", anonInfo.IsHostingProvider)
+		fmt.Printf("  Public Proxy: %v\n", anonInfo.IsPublicProxy)
+		fmt.Printf("  Residential Proxy: %v\n", anonInfo.IsResidentialProxy)
+		fmt.Printf("  Tor Exit Node: %v\n", anonInfo.IsTorExitNode)
+
+		return nil
+	},
+}
+
+var maxmindDistanceCmd = &cobra.Command{
+	Use:   "distance [ip1] [ip2]",
+	Short: "Calculate distance between two IP locations",
+	Long:  "Lookup both IP addresses and calculate great-circle (haversine) distance between their locations in kilometers",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ip1, ip2 := net.ParseIP(args[0]), net.ParseIP(args[1])
+		if ip1 == nil {
+			return fmt.Errorf("invalid IP address: %s", args[0])
+		}
+		if ip2 == nil {
+			return fmt.Errorf("invalid IP address: %s", args[1])
+		}
+
+		// Initialize MaxMind (will init config internally)
+		if err := maxmind.InitMinimalForCLI(); err != nil {
+			return fmt.Errorf("failed to initialize MaxMind: %w", err)
+		}
+		defer maxmind.Close()
+
+		// Calculate distance
+		distance, err := maxmind.LookupDistanceBetweenIPs(ip1, ip2)
+		if err != nil {
+			return fmt.Errorf("distance calculation failed: %w", err)
+		}
+		loc1, loc2 := maxmind.LookupCity(ip1), maxmind.LookupCity(ip2)
+
+		// If using JSON Output
+		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+			result := map[string]interface{}{
+				"from":        loc1,
+				"to":          loc2,
+				"distance_km": distance,
+			}
+			utils.ClearScreen()
+			output, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(output))
+			return nil
+		}
+
+		// Pretty print results
+		utils.ClearScreen()
+		fmt.Printf("Distance between %s and %s\n", args[0], args[1])
+		fmt.Printf("=============================\n")
+		fmt.Printf("  From: %s, %s (%.4f, %.4f)\n", loc1.City, loc1.Country, loc1.Latitude, loc1.Longitude)
+		fmt.Printf("  To: %s, %s (%.4f, %.4f)\n", loc2.City, loc2.Country, loc2.Latitude, loc2.Longitude)
+		fmt.Printf("  Distance: %.2f km\n", distance)
+
 		return nil
 	},
 }
@@ -335,12 +480,16 @@ func init() {
 	maxmindCmd.AddCommand(maxmindDownloadCmd)
 	maxmindCmd.AddCommand(maxmindStatusCmd)
 	maxmindCmd.AddCommand(maxmindInfoCmd)
+	maxmindCmd.AddCommand(maxmindVerifyCmd)
 	maxmindCmd.AddCommand(maxmindLookupCmd)
+	maxmindCmd.AddCommand(maxmindDistanceCmd)
 
 	// Command flag
 	maxmindStatusCmd.Flags().BoolP("json", "j", false, "Output info in JSON format")
 	maxmindInfoCmd.Flags().BoolP("json", "j", false, "Output info in JSON format")
+	maxmindVerifyCmd.Flags().BoolP("json", "j", false, "Output info in JSON format")
 	maxmindLookupCmd.Flags().BoolP("json", "j", false, "Output info in JSON format")
+	maxmindDistanceCmd.Flags().BoolP("json", "j", false, "Output info in JSON format")
 
 	// Add root command
 	rootCmd.AddCommand(maxmindCmd)
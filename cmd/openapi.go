@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+	"github.com/benedict-erwin/insight-collector/pkg/openapi"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// # Print spec as YAML
+// ./insight-collector openapi generate
+
+// # Write JSON spec to file
+// ./insight-collector openapi generate --format json --output openapi.json
+
+var (
+	openapiFormat string
+	openapiOutput string
+)
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "OpenAPI specification tools",
+	Long:  "Generate OpenAPI 3 specification from registered HTTP routes",
+}
+
+// Generate spec
+var openapiGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate OpenAPI 3 specification",
+	Long:  "Introspect registered routes and request/response structs (json & validate tags) to build OpenAPI 3 document",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(openapiFormat)
+		if format != "json" && format != "yaml" {
+			return fmt.Errorf("invalid format %q (json or yaml)", openapiFormat)
+		}
+
+		// Build routes without starting server
+		e := echo.New()
+		registry.SetupAllRoutes(e)
+
+		routes := make([]openapi.Route, 0, len(e.Routes()))
+		for _, route := range e.Routes() {
+			routes = append(routes, openapi.Route{
+				Method:  route.Method,
+				Path:    route.Path,
+				Handler: route.Name,
+			})
+		}
+
+		generator := openapi.Generator{
+			Title:       config.Get().App.Name,
+			Version:     config.Get().App.Version,
+			Description: "InsightCollector HTTP API",
+			Envelope:    response.Response{},
+			EnumValues: func(name string) []string {
+				if set, exists := entity.GetEnum(name); exists {
+					return entity.EnumValues(set)
+				}
+				return nil
+			},
+		}
+		doc := generator.Generate(routes)
+
+		output, err := encodeSpec(doc, format)
+		if err != nil {
+			return fmt.Errorf("failed to encode spec: %w", err)
+		}
+
+		// Write to stdout or file
+		if openapiOutput == "" {
+			fmt.Print(string(output))
+			return nil
+		}
+		if err := os.WriteFile(openapiOutput, output, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", openapiOutput, err)
+		}
+		fmt.Printf("OpenAPI spec written to %s (%d paths)\n", openapiOutput, len(doc.Paths))
+		return nil
+	},
+}
+
+// encodeSpec marshals document as indented JSON or YAML (YAML keeps JSON field names & order)
+func encodeSpec(doc *openapi.Document, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format == "json" {
+		return append(data, '\n'), nil
+	}
+
+	// JSON is valid YAML, decode into node tree and re-emit in block style
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// resetYAMLStyle clears flow/quoted styles inherited from JSON input
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func init() {
+	openapiCmd.AddCommand(openapiGenerateCmd)
+
+	// Command flag
+	openapiGenerateCmd.Flags().StringVarP(&openapiFormat, "format", "f", "yaml", "Output format: yaml or json")
+	openapiGenerateCmd.Flags().StringVarP(&openapiOutput, "output", "o", "", "Output file (default: stdout)")
+
+	// Add root command
+	rootCmd.AddCommand(openapiCmd)
+}
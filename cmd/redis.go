@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/spf13/cobra"
+)
+
+// # Show connection pool statistics of Redis clients
+// ./insight-collector redis stats
+// ./insight-collector redis stats --json
+
+// redisStatsClients lists logical Redis clients reported by `redis stats` (main client is initialized by root)
+var redisStatsClients = []struct {
+	name      string
+	newClient func() (redis.Client, error)
+}{
+	{"worker_config", redis.NewClientForWorkerConfig},
+	{"sessions", redis.NewClientForSessions},
+	{"cache", redis.NewClientForCache},
+	{"nonce", redis.NewClientForNonceStore},
+}
+
+// redisPoolStatsRow holds pool statistics of single logical client
+type redisPoolStatsRow struct {
+	Client string          `json:"client"`
+	Error  string          `json:"error,omitempty"`
+	Stats  redis.PoolStats `json:"stats"`
+}
+
+var redisCmd = &cobra.Command{
+	Use:   "redis",
+	Short: "Redis connection diagnostics",
+	Long:  "Commands for inspecting Redis clients used by the application",
+}
+
+var redisStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show Redis connection pool statistics",
+	Long: `Show connection pool statistics (hits, misses, timeouts, total/idle connections) for each logical Redis client.
+Statistics cover pools of this CLI process; use GET /v1/health for pool statistics of a running server.
+Cluster mode statistics are aggregated over all nodes.`,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rows := []redisPoolStatsRow{}
+
+		// Main client (initialized on startup)
+		if stats, err := redis.GetPoolStats(); err != nil {
+			rows = append(rows, redisPoolStatsRow{Client: "main", Error: err.Error()})
+		} else {
+			rows = append(rows, redisPoolStatsRow{Client: "main", Stats: stats})
+		}
+
+		for _, item := range redisStatsClients {
+			client, err := item.newClient()
+			if err != nil {
+				rows = append(rows, redisPoolStatsRow{Client: item.name, Error: err.Error()})
+				continue
+			}
+			rows = append(rows, redisPoolStatsRow{Client: item.name, Stats: client.PoolStats()})
+			_ = client.Close()
+		}
+
+		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+			output, _ := json.MarshalIndent(rows, "", "  ")
+			fmt.Println(string(output))
+			return nil
+		}
+
+		mode := config.Get().Redis.Mode
+		if mode == "" {
+			mode = string(redis.ModeSingle)
+		}
+		fmt.Printf("📊 Redis Pool Statistics (mode: %s)\n\n", mode)
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"Client", "Pool Size", "Total", "Idle", "Stale", "Hits", "Misses", "Timeouts", "Status"})
+		for _, row := range rows {
+			if row.Error != "" {
+				table.Append([]string{row.Client, "-", "-", "-", "-", "-", "-", "-", "❌ " + row.Error})
+				continue
+			}
+
+			status := "✅"
+			if row.Stats.Timeouts > 0 {
+				status = "⚠️ pool wait timeouts"
+			}
+			table.Append([]string{
+				row.Client,
+				strconv.Itoa(row.Stats.PoolSize),
+				strconv.FormatUint(uint64(row.Stats.TotalConns), 10),
+				strconv.FormatUint(uint64(row.Stats.IdleConns), 10),
+				strconv.FormatUint(uint64(row.Stats.StaleConns), 10),
+				strconv.FormatUint(uint64(row.Stats.Hits), 10),
+				strconv.FormatUint(uint64(row.Stats.Misses), 10),
+				strconv.FormatUint(uint64(row.Stats.Timeouts), 10),
+				status,
+			})
+		}
+		table.Render()
+		return nil
+	},
+}
+
+func init() {
+	redisCmd.AddCommand(redisStatsCmd)
+
+	// Command flag
+	redisStatsCmd.Flags().BoolP("json", "j", false, "Output stats in JSON format")
+
+	// Add root command
+	rootCmd.AddCommand(redisCmd)
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	sesEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	clJobs "github.com/benedict-erwin/insight-collector/internal/jobs/callback_logs"
+	eeJobs "github.com/benedict-erwin/insight-collector/internal/jobs/error_events"
+	seJobs "github.com/benedict-erwin/insight-collector/internal/jobs/security_events"
+	sesJobs "github.com/benedict-erwin/insight-collector/internal/jobs/session_events"
+	teJobs "github.com/benedict-erwin/insight-collector/internal/jobs/transaction_events"
+	uaJobs "github.com/benedict-erwin/insight-collector/internal/jobs/user_activities"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/spf13/cobra"
+)
+
+// # Validate capture without dispatching
+// ./insight-collector replay events.jsonl --entity security_events --dry-run
+
+// # Re-ingest at 100 events/second with time rewritten to now
+// ./insight-collector replay events.jsonl --entity security_events --rate 100 --rewrite-time
+
+// replayMaxLineSize bounds single JSON line (large details payloads)
+const replayMaxLineSize = 1024 * 1024
+
+// replayMaxErrors limits invalid line details printed in summary
+const replayMaxErrors = 20
+
+// replayEntity describes how to decode and dispatch request for entity
+type replayEntity struct {
+	taskType   string
+	jobPrefix  string
+	newRequest func() interface{} // Pointer to entity request struct
+}
+
+// replayEntities lists replayable entities (same request structs & task types as insert endpoints)
+var replayEntities = map[string]replayEntity{
+	"transaction_events": {teJobs.TypeTransactionEventsLogging, "te", func() interface{} { return &teEntities.TransactionEventsRequest{} }},
+	"error_events":       {eeJobs.TypeErrorEventsLogging, "ee", func() interface{} { return &eeEntities.ErrorEventsRequest{} }},
+	"security_events":    {seJobs.TypeSecurityEventsLogging, "se", func() interface{} { return &seEntities.SecurityEventsRequest{} }},
+	"session_events":     {sesJobs.TypeSessionEventsLogging, "ss", func() interface{} { return &sesEntities.SessionEventsRequest{} }},
+	"callback_logs":      {clJobs.TypeCallbackLogsLogging, "cl", func() interface{} { return &clEntities.CallbackLogsRequest{} }},
+	"user_activities":    {uaJobs.TypeUserActivitiesLogging, "ua", func() interface{} { return &uaEntities.UserActivitiesRequest{} }},
+}
+
+// Replay command flags
+var (
+	replayEntityName  string
+	replayRate        float64
+	replayRewriteTime bool
+	replayDryRun      bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay [file]",
+	Short: "Replay events from JSON lines file",
+	Long: `Re-ingest captured events (one request object per line) through the job queue at throttled rate.
+Lines are validated with the same rules as the insert endpoints ("-" reads stdin).`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runReplay,
+	SilenceErrors: true,
+}
+
+func init() {
+	replayCmd.Flags().StringVarP(&replayEntityName, "entity", "e", "", "Entity name: "+strings.Join(replayEntityNames(), ", ")+" (required)")
+	replayCmd.Flags().Float64VarP(&replayRate, "rate", "r", 100, "Max events dispatched per second (0 = unlimited)")
+	replayCmd.Flags().BoolVar(&replayRewriteTime, "rewrite-time", false, "Rewrite event time to now")
+	replayCmd.Flags().BoolVar(&replayDryRun, "dry-run", false, "Validate lines without dispatching")
+	replayCmd.MarkFlagRequired("entity")
+
+	rootCmd.AddCommand(replayCmd)
+}
+
+// runReplay reads JSON lines, validates and dispatches each request
+func runReplay(cmd *cobra.Command, args []string) error {
+	spec, exists := replayEntities[replayEntityName]
+	if !exists {
+		return fmt.Errorf("invalid entity: %s (must be one of %s)", replayEntityName, strings.Join(replayEntityNames(), ", "))
+	}
+	if replayRate < 0 {
+		return fmt.Errorf("invalid rate: %v (must be >= 0)", replayRate)
+	}
+
+	var input io.Reader = os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open replay file: %v", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	// Throttle dispatching (dry run validates at full speed)
+	var ticker *time.Ticker
+	if replayRate > 0 && !replayDryRun {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / replayRate))
+		defer ticker.Stop()
+	}
+
+	validator := registry.NewValidator()
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), replayMaxLineSize)
+
+	var (
+		lineNo, valid, invalid, dispatched, failed int
+		errorsShown                                []string
+		start                                      = time.Now()
+	)
+	recordError := func(line int, err error) {
+		if len(errorsShown) < replayMaxErrors {
+			errorsShown = append(errorsShown, fmt.Sprintf("line %d: %v", line, err))
+		}
+	}
+
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		req, data, err := decodeReplayLine(spec, line, validator)
+		if err != nil {
+			invalid++
+			recordError(lineNo, err)
+			continue
+		}
+		valid++
+
+		if replayDryRun {
+			continue
+		}
+		if ticker != nil {
+			<-ticker.C
+		}
+
+		// Same payload as insert endpoints, job ID derived from request content
+		hash := md5.Sum(data)
+		payload := asynq.Payload{
+			TaskId:   fmt.Sprintf("%s_%x", spec.jobPrefix, hash[:8]),
+			TaskType: spec.taskType,
+			Data:     req,
+		}
+		if err := asynq.DispatchJobSync(&payload); err != nil {
+			failed++
+			recordError(lineNo, err)
+			continue
+		}
+		dispatched++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read replay file at line %d: %v", lineNo+1, err)
+	}
+
+	// Summary
+	elapsed := time.Since(start)
+	fmt.Printf("Replay summary (%s):\n\n", replayEntityName)
+	fmt.Printf("  Lines:       %d\n", lineNo)
+	fmt.Printf("  Valid:       %d\n", valid)
+	fmt.Printf("  Invalid:     %d\n", invalid)
+	if replayDryRun {
+		fmt.Printf("  Dispatched:  0 (dry run)\n")
+	} else {
+		fmt.Printf("  Dispatched:  %d\n", dispatched)
+		fmt.Printf("  Failed:      %d\n", failed)
+		if elapsed > 0 {
+			fmt.Printf("  Rate:        %.1f events/s\n", float64(dispatched)/elapsed.Seconds())
+		}
+	}
+	fmt.Printf("  Elapsed:     %s\n", elapsed.Round(time.Millisecond))
+
+	if len(errorsShown) > 0 {
+		fmt.Printf("\n❌ Errors (first %d):\n", len(errorsShown))
+		for _, e := range errorsShown {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+
+	if invalid > 0 || failed > 0 {
+		return fmt.Errorf("%d invalid line(s), %d failed dispatch(es)", invalid, failed)
+	}
+	return nil
+}
+
+// decodeReplayLine decodes and validates single JSON line into entity request.
+// Returns request and its canonical JSON (used for job ID).
+func decodeReplayLine(spec replayEntity, line []byte, validator *registry.CustomValidator) (interface{}, []byte, error) {
+	if replayRewriteTime {
+		var raw map[string]interface{}
+		if err := json.Unmarshal(line, &raw); err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		raw["time"] = time.Now().Format(time.RFC3339Nano)
+
+		rewritten, err := json.Marshal(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to rewrite time: %v", err)
+		}
+		line = rewritten
+	}
+
+	req := spec.newRequest()
+	if err := json.Unmarshal(line, req); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	// Normalize enum fields like insert endpoints
+	if normalizer, ok := req.(interface{ Normalize() }); ok {
+		normalizer.Normalize()
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %v", err)
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode request: %v", err)
+	}
+	return req, data, nil
+}
+
+// replayEntityNames returns sorted replayable entity names
+func replayEntityNames() []string {
+	names := make([]string, 0, len(replayEntities))
+	for name := range replayEntities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
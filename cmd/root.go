@@ -30,6 +30,11 @@ func Execute() {
 
 // init initializes all application dependencies and registers commands
 func init() {
+	// Completion scripts are written to stdout, keep logs out of them
+	if isCompletionRequest() {
+		logger.Silence()
+	}
+
 	// Initialize config
 	if err := config.Init(); err != nil {
 		panic(err)
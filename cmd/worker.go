@@ -13,9 +13,13 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/spf13/cobra"
 
+	"github.com/benedict-erwin/insight-collector/internal/constants"
 	"github.com/benedict-erwin/insight-collector/internal/jobs"
 	asynqPkg "github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
 
@@ -25,6 +29,19 @@ var workerCmd = &cobra.Command{
 	Long:  `Manage Asynq background job workers and configuration`,
 }
 
+// Command flags
+var (
+	applyConcurrencyFlag bool
+	normalizeWorkersFlag bool
+	deadLetterQueue      string
+	deadLetterLimit      int
+	deadLetterForce      bool
+	tasksState           string
+	tasksLimit           int
+	tasksQueue           string
+	drainTimeout         time.Duration
+)
+
 // Subcommands
 var (
 	workerStartCmd = &cobra.Command{
@@ -56,7 +73,7 @@ var (
 	workerSetCmd = &cobra.Command{
 		Use:   "set [worker-name] [percentage] [task-types]",
 		Short: "Set worker configuration",
-		Long:  `Set worker percentage and task types. Task types should be comma-separated.`,
+		Long:  `Set worker percentage and task types. Task types should be comma-separated. Other workers are rescaled so percentages sum to 100 (disable with --normalize=false).`,
 		Args:  cobra.ExactArgs(3),
 		Run: func(cmd *cobra.Command, args []string) {
 			setWorker(args[0], args[1], args[2])
@@ -81,6 +98,14 @@ var (
 		},
 	}
 
+	workerMetricsCmd = &cobra.Command{
+		Use:   "metrics",
+		Short: "Show live queue metrics (pending, active, processed, failed, latency)",
+		Run: func(cmd *cobra.Command, args []string) {
+			showMetrics()
+		},
+	}
+
 	workerValidateCmd = &cobra.Command{
 		Use:   "validate",
 		Short: "Validate worker configuration",
@@ -97,12 +122,169 @@ var (
 		},
 	}
 
+	workerDeadLetterCmd = &cobra.Command{
+		Use:   "deadletter",
+		Short: "Inspect tasks that exhausted their retries",
+		Long:  `Inspect, retry or purge tasks moved to dead queue after exhausting their retries`,
+	}
+
+	workerDeadLetterListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List dead-letter tasks",
+		Run: func(cmd *cobra.Command, args []string) {
+			listDeadLetter()
+		},
+	}
+
+	workerDeadLetterRetryCmd = &cobra.Command{
+		Use:   "retry [task-id]",
+		Short: "Retry dead-letter tasks (all tasks when task-id is omitted)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			taskID := ""
+			if len(args) > 0 {
+				taskID = args[0]
+			}
+			retryDeadLetter(taskID)
+		},
+	}
+
+	workerDeadLetterPurgeCmd = &cobra.Command{
+		Use:   "purge",
+		Short: "Permanently delete dead-letter tasks",
+		Run: func(cmd *cobra.Command, args []string) {
+			purgeDeadLetter()
+		},
+	}
+
+	workerTasksCmd = &cobra.Command{
+		Use:   "tasks",
+		Short: "Inspect and manage queued tasks",
+		Long:  `Inspect, cancel, archive or retry tasks directly in Redis (works without running worker)`,
+	}
+
+	workerTasksListCmd = &cobra.Command{
+		Use:   "list [queue]",
+		Short: "List tasks by state (all queues when queue is omitted)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			queue := ""
+			if len(args) > 0 {
+				queue = args[0]
+			}
+			listTasks(queue)
+		},
+	}
+
+	workerTasksCancelCmd = &cobra.Command{
+		Use:   "cancel [task-id]",
+		Short: "Cancel active task (requires running worker)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cancelTask(args[0])
+		},
+	}
+
+	workerTasksArchiveCmd = &cobra.Command{
+		Use:   "archive [task-id]",
+		Short: "Archive pending, scheduled or retry task",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			archiveTask(args[0])
+		},
+	}
+
+	workerTasksRetryCmd = &cobra.Command{
+		Use:   "retry [task-id]",
+		Short: "Run scheduled, retry or archived task immediately",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			retryTask(args[0])
+		},
+	}
+
+	workerSchedulerCmd = &cobra.Command{
+		Use:   "scheduler",
+		Short: "Manage recurring (cron) tasks",
+		Long:  `Manage recurring tasks enqueued by Asynq scheduler. Schedules are persisted in Redis.`,
+	}
+
+	workerSchedulerStartCmd = &cobra.Command{
+		Use:   "start",
+		Short: "Start scheduler enqueuing registered cron tasks",
+		Run: func(cmd *cobra.Command, args []string) {
+			startScheduler()
+		},
+	}
+
+	workerSchedulerListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List registered schedules with next run time",
+		Run: func(cmd *cobra.Command, args []string) {
+			listSchedules()
+		},
+	}
+
+	workerSchedulerAddCmd = &cobra.Command{
+		Use:   "add [cronspec] [task-type] [payload-json]",
+		Short: "Register recurring task",
+		Long:  `Register recurring task. Cronspec uses standard 5 fields (e.g. "0 2 * * *") or descriptors (@daily, @every 1h). Payload is optional JSON.`,
+		Args:  cobra.RangeArgs(2, 3),
+		Run: func(cmd *cobra.Command, args []string) {
+			payload := ""
+			if len(args) > 2 {
+				payload = args[2]
+			}
+			addSchedule(args[0], args[1], payload)
+		},
+	}
+
+	workerSchedulerRemoveCmd = &cobra.Command{
+		Use:   "remove [cronspec] [task-type]",
+		Short: "Remove recurring task",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			removeSchedule(args[0], args[1])
+		},
+	}
+
 	workerConcurrencyCmd = &cobra.Command{
 		Use:   "concurrency [number]",
 		Short: "Set worker concurrency",
+		Long:  `Set worker concurrency. Use --apply to reconfigure running worker without restart.`,
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			setConcurrency(args[0])
+			setConcurrency(args[0], applyConcurrencyFlag)
+		},
+	}
+
+	workerPauseCmd = &cobra.Command{
+		Use:       "pause [queue]",
+		Short:     "Pause processing of queue",
+		Long:      `Pause processing of queue on all workers while other queues keep running. Pending tasks stay in queue and paused state survives worker restarts until resumed.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: constants.GetAllQueues(),
+		Run: func(cmd *cobra.Command, args []string) {
+			pauseQueue(args[0])
+		},
+	}
+
+	workerResumeCmd = &cobra.Command{
+		Use:       "resume [queue]",
+		Short:     "Resume processing of paused queue",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: constants.GetAllQueues(),
+		Run: func(cmd *cobra.Command, args []string) {
+			resumeQueue(args[0])
+		},
+	}
+
+	workerDrainCmd = &cobra.Command{
+		Use:   "drain",
+		Short: "Gracefully drain running worker",
+		Long:  `Signal running worker to stop pulling new tasks while in-flight tasks complete, then wait until no task is active. Pending tasks stay in queues for next worker start.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			drainWorker(drainTimeout)
 		},
 	}
 )
@@ -120,6 +302,7 @@ func startWorker() {
 	// Initialize server
 	server := asynqPkg.InitServer()
 	mux := asynq.NewServeMux()
+	mux.Use(asynqPkg.DeadLetterMiddleware) // Exhausted tasks go to dead queue
 
 	// Register handlers (ignore returned job metadata in worker context)
 	_, err := jobs.RegisterHandlers(mux)
@@ -131,36 +314,98 @@ func startWorker() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start heartbeat goroutine
-	go func() {
-		ticker := time.NewTicker(15 * time.Second) // Reduced to 15 seconds for less overhead
-		defer ticker.Stop()
+	// Drain flag of previous deployment must not stop this worker
+	asynqPkg.ClearDrain()
 
-		for {
-			select {
-			case <-ticker.C:
-				asynqPkg.SetWorkerHeartbeat()
-			case <-sigChan:
-				return
+	// Queues paused by `worker pause` stay paused across restarts
+	asynqPkg.RestorePausedQueues()
+
+	// Batch InfluxDB writes of job handlers (influxdb.write_buffer)
+	if err := influxdb.StartWriteBuffer(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start InfluxDB write buffer")
+	}
+
+	// Start server (non-blocking, allows live concurrency reconfiguration)
+	log.Info().Msg("Starting Asynq worker server...")
+	if err := server.Start(mux); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start worker server")
+	}
+	asynqPkg.SetServerRunning(true) // Mark server as running
+	asynqPkg.SetWorkerHeartbeat()   // Send initial heartbeat
+
+	// Control client reused by drain & concurrency apply checks
+	controlClient, err := redis.NewClientForAsynq()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create Redis client for worker control")
+	}
+	defer controlClient.Close()
+
+	// Heartbeat & concurrency apply tickers
+	heartbeatTicker := time.NewTicker(15 * time.Second) // Reduced to 15 seconds for less overhead
+	defer heartbeatTicker.Stop()
+	applyTicker := time.NewTicker(5 * time.Second)
+	defer applyTicker.Stop()
+
+	// Concurrency apply runs in background so heartbeat keeps ticking during server restart
+	applyDone := make(chan concurrencyApplyResult, 1)
+	applying := false
+
+	// Wait for shutdown signal
+	var sig os.Signal
+	for sig == nil {
+		select {
+		case <-heartbeatTicker.C:
+			asynqPkg.SetWorkerHeartbeat()
+			if err := latency.Flush(controlClient); err != nil {
+				log.Warn().Err(err).Msg("Failed to flush ingest latency histogram")
+			}
+			if stats, ok := influxdb.WriteBufferStats(); ok {
+				log.Debug().
+					Int("buffered", stats.Buffered).
+					Int64("flushed_points", stats.FlushedPoints).
+					Int64("failed_flushes", stats.FailedFlushes).
+					Int64("dropped_points", stats.DroppedPoints).
+					Int64("rejected", stats.Rejected).
+					Msg("InfluxDB write buffer stats")
+			}
+		case <-applyTicker.C:
+			// Drained server keeps heartbeat only (restart required to consume again)
+			if asynqPkg.IsDraining() || applying {
+				continue
 			}
-		}
-	}()
 
-	// Start server
-	go func() {
-		log.Info().Msg("Starting Asynq worker server...")
-		asynqPkg.SetServerRunning(true) // Mark server as running
-		asynqPkg.SetWorkerHeartbeat()   // Send initial heartbeat
+			// Stop pulling new tasks requested by `worker drain`
+			if asynqPkg.IsDrainRequested(controlClient) {
+				log.Info().Msg("Drain requested, stopping to pull new tasks while running tasks complete...")
+				server.Stop()
+				asynqPkg.SetDraining()
+				continue
+			}
 
-		if err := server.Run(mux); err != nil {
-			asynqPkg.SetServerRunning(false) // Mark server as stopped on error
-			log.Fatal().Err(err).Msg("Failed to start worker server")
+			// Apply concurrency requested by `worker concurrency --apply`
+			concurrency, ok := asynqPkg.PopConcurrencyApply(controlClient)
+			if !ok {
+				continue
+			}
+			log.Info().Int("concurrency", concurrency).Msg("Applying new concurrency, waiting for running tasks to complete (max 30s)...")
+			applying = true
+			go func() {
+				newServer, err := asynqPkg.ApplyConcurrency(mux)
+				applyDone <- concurrencyApplyResult{server: newServer, concurrency: concurrency, err: err}
+			}()
+		case result := <-applyDone:
+			applying = false
+			server = applyConcurrencyResult(result, controlClient)
+		case sig = <-sigChan:
 		}
-		asynqPkg.SetServerRunning(false) // Mark server as stopped when Run() exits
-	}()
+	}
+
+	// Wait for in-progress concurrency apply so new server is the one shut down
+	if applying {
+		log.Info().Msg("Waiting for concurrency apply to finish before shutdown...")
+		server = applyConcurrencyResult(<-applyDone, controlClient)
+	}
 
-	// Wait for shutdown signal
-	sig := <-sigChan
 	log.Info().Str("signal", sig.String()).Msg("Received shutdown signal, initiating graceful shutdown...")
 
 	log.Info().Msg("Stopping server, waiting for running tasks to complete (max 30s)...")
@@ -168,13 +413,108 @@ func startWorker() {
 	// Shutdown waits for tasks to finish
 	// Timeout: 30 seconds
 	server.Shutdown()
+	asynqPkg.SetServerRunning(false) // Mark server as stopped
 
 	// Clear server reference and status
 	asynqPkg.ClearServerReference()
 
+	// Flush points buffered by completed tasks
+	if err := influxdb.StopWriteBuffer(); err != nil {
+		log.Error().Err(err).Msg("Failed to flush InfluxDB write buffer")
+	}
+
+	// Latency observed since last heartbeat
+	if err := latency.Flush(controlClient); err != nil {
+		log.Warn().Err(err).Msg("Failed to flush ingest latency histogram")
+	}
+
 	log.Info().Msg("Worker server stopped gracefully - all tasks completed or timed out")
 }
 
+// concurrencyApplyResult holds outcome of background concurrency apply
+type concurrencyApplyResult struct {
+	server      *asynq.Server
+	concurrency int
+	err         error
+}
+
+// applyConcurrencyResult marks restarted server as running and acknowledges apply to CLI
+func applyConcurrencyResult(result concurrencyApplyResult, client redis.Client) *asynq.Server {
+	if result.err != nil {
+		logger.WithScope("startWorker").Fatal().Err(result.err).Msg("Failed to apply new concurrency")
+	}
+	asynqPkg.SetServerRunning(true)
+	asynqPkg.SetWorkerHeartbeat()
+	asynqPkg.AckConcurrencyApply(client, result.concurrency)
+	return result.server
+}
+
+// drainWorker requests running worker to stop pulling new tasks and waits until active tasks complete
+func drainWorker(timeout time.Duration) {
+	if !asynqPkg.IsServerRunning() {
+		fmt.Println("ℹ️  No running worker detected, nothing to drain")
+		return
+	}
+
+	if err := asynqPkg.RequestDrain(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("🚰 Drain requested, waiting for running worker (timeout %s)...\n", timeout)
+
+	deadline := time.Now().Add(timeout)
+	lastActive := -1
+	for {
+		status, err := asynqPkg.GetDrainStatus()
+		if err != nil {
+			fmt.Printf("❌ Failed to get drain status: %v\n", err)
+			os.Exit(1)
+		}
+
+		if status.Acknowledged {
+			if status.Active == 0 {
+				fmt.Println("✅ Worker drained: no active tasks")
+				if status.Pending > 0 {
+					fmt.Printf("📦 %d pending task(s) left in queues for next worker start\n", status.Pending)
+				}
+				return
+			}
+			if status.Active != lastActive {
+				fmt.Printf("⏳ Worker stopped pulling new tasks, %d task(s) still active...\n", status.Active)
+				lastActive = status.Active
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if !status.Acknowledged {
+				fmt.Println("⚠️  Timeout: running worker did not acknowledge drain request (drain flag stays set)")
+			} else {
+				fmt.Printf("⚠️  Timeout: %d task(s) still active\n", status.Active)
+			}
+			os.Exit(1)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// pauseQueue stops processing of queue until resumed
+func pauseQueue(queue string) {
+	if err := asynqPkg.PauseQueue(queue); err != nil {
+		fmt.Printf("❌ Failed to pause queue: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("⏸️  Queue '%s' paused, pending tasks are kept until: ./app worker resume %s\n", queue, queue)
+}
+
+// resumeQueue resumes processing of paused queue
+func resumeQueue(queue string) {
+	if err := asynqPkg.ResumeQueue(queue); err != nil {
+		fmt.Printf("❌ Failed to resume queue: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("▶️  Queue '%s' resumed\n", queue)
+}
+
 // listWorkers displays all worker configurations in a table format
 func listWorkers() {
 	// Initialize concurrency and load config from Redis
@@ -277,8 +617,20 @@ func setWorker(name, percentageStr, taskTypesStr string) {
 		}
 	}
 
-	asynqPkg.SetWorker(name, percentage, taskTypes)
+	asynqPkg.SetWorker(name, percentage, taskTypes, normalizeWorkersFlag)
 	fmt.Printf("Worker '%s' updated: %d%% with %d task types\n", name, percentage, len(taskTypes))
+
+	// Print resulting distribution
+	fmt.Println("Worker distribution:")
+	total := 0
+	for _, worker := range asynqPkg.GetWorkers() {
+		fmt.Printf("  %-12s %3d%%\n", worker.Name, worker.Percentage)
+		total += worker.Percentage
+	}
+	fmt.Printf("  %-12s %3d%%\n", "total", total)
+	if total != 100 {
+		fmt.Println("⚠️  Percentages do not sum to 100%, adjust other workers before starting worker")
+	}
 }
 
 // addWorkerTaskTypes adds new task types to existing worker without changing existing ones
@@ -324,7 +676,7 @@ func addWorkerTaskTypes(name, taskTypesStr string) {
 	}
 
 	// Update worker with merged task types (keep same percentage)
-	asynqPkg.SetWorker(name, currentWorker.Percentage, currentWorker.TaskTypes)
+	asynqPkg.SetWorker(name, currentWorker.Percentage, currentWorker.TaskTypes, false)
 	fmt.Printf("Worker '%s': added %d new task types (total: %d task types)\n", name, addedCount, len(currentWorker.TaskTypes))
 }
 
@@ -336,17 +688,65 @@ func showStatus() {
 	queues := asynqPkg.GenerateQueues()
 	concurrency := asynqPkg.GetConcurrency()
 
+	// Paused state is informational, weights are still shown when Redis is unreachable
+	states, err := asynqPkg.GetQueuePauseStates()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to get queue pause states")
+	}
+
 	utils.ClearScreen()
 	fmt.Printf("Active Queue Configuration:\n")
 	total := 0
 	for queueName, weight := range queues {
-		fmt.Printf("  %s: %d weight\n", queueName, weight)
+		state := "active"
+		if states == nil {
+			state = "unknown"
+		} else if states[queueName] {
+			state = "paused"
+		}
+		fmt.Printf("  %s: %d weight (%s)\n", queueName, weight, state)
 		total += weight
 	}
 	fmt.Printf("\nConcurrency: %d workers\n", concurrency)
 	fmt.Printf("Total Weight: %d\n", total)
 }
 
+// showMetrics displays live queue metrics in JSON format
+func showMetrics() {
+	metrics, err := asynqPkg.GetQueueMetrics()
+	if err != nil {
+		fmt.Printf("Failed to get queue metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	running := asynqPkg.IsServerRunning()
+	output := map[string]interface{}{
+		"worker_running": running,
+		"queues":         metrics,
+	}
+
+	// Enqueue → write latency flushed by workers
+	if client, err := redis.NewClientForAsynq(); err == nil {
+		if histograms, err := latency.Load(client); err == nil {
+			output["ingest_latency"] = histograms
+		}
+		client.Close()
+	}
+
+	// Output as pretty JSON
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	utils.ClearScreen()
+	fmt.Println(string(jsonData))
+	if !running {
+		fmt.Println("⚠️  Worker is not running, pending tasks are not being processed. Start with: ./app worker start")
+	}
+}
+
 // validateConfig checks if current worker configuration is valid
 func validateConfig() {
 	// Initialize concurrency and load config from Redis
@@ -354,7 +754,7 @@ func validateConfig() {
 
 	if err := asynqPkg.ValidateWorkerConfig(); err != nil {
 		fmt.Printf("Validation failed: %v\n", err)
-		return
+		os.Exit(1)
 	}
 	fmt.Println("Worker configuration is valid")
 }
@@ -369,7 +769,7 @@ func resetConfig() {
 }
 
 // setConcurrency updates worker concurrency setting with restart instructions
-func setConcurrency(concurrencyStr string) {
+func setConcurrency(concurrencyStr string, apply bool) {
 	concurrency, err := strconv.Atoi(concurrencyStr)
 	if err != nil {
 		fmt.Printf("Invalid concurrency: %s\n", concurrencyStr)
@@ -388,6 +788,12 @@ func setConcurrency(concurrencyStr string) {
 
 	fmt.Printf("✅ Concurrency updated to %d\n", concurrency)
 
+	// Live reconfiguration of running worker
+	if apply {
+		applyConcurrency(concurrency)
+		return
+	}
+
 	if asynqPkg.IsServerRunning() {
 		fmt.Println("⚠️  Worker is currently running. Please restart worker to apply new concurrency:")
 		fmt.Println("   1. Stop current worker gracefully (Ctrl+C)")
@@ -400,6 +806,220 @@ func setConcurrency(concurrencyStr string) {
 	}
 }
 
+// applyConcurrency asks running worker to restart its server with new concurrency and waits for confirmation
+func applyConcurrency(concurrency int) {
+	if !asynqPkg.IsServerRunning() {
+		fmt.Println("💡 Worker is not running, new concurrency will be used on next start: ./app worker start")
+		return
+	}
+
+	if err := asynqPkg.RequestConcurrencyApply(concurrency); err != nil {
+		fmt.Printf("Failed to request concurrency apply: %v\n", err)
+		return
+	}
+
+	fmt.Println("⏳ Applying to running worker (waits for running tasks to complete, max 30s)...")
+
+	// Worker checks every 5s, shutdown of old server takes up to 30s
+	if !asynqPkg.WaitConcurrencyApplied(concurrency, 45*time.Second) {
+		fmt.Println("⚠️  Worker did not confirm within 45s, check worker logs (request expires in 5 minutes)")
+		return
+	}
+
+	fmt.Printf("✅ Running worker now uses concurrency %d\n", concurrency)
+}
+
+// listDeadLetter displays dead queue tasks in JSON format
+func listDeadLetter() {
+	tasks, err := asynqPkg.ListDeadTasks(deadLetterQueue, deadLetterLimit)
+	if err != nil {
+		fmt.Printf("Failed to list dead-letter tasks: %v\n", err)
+		os.Exit(1)
+	}
+
+	output := map[string]interface{}{
+		"count": len(tasks),
+		"tasks": tasks,
+	}
+
+	// Output as pretty JSON
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(jsonData))
+}
+
+// retryDeadLetter re-enqueues dead queue tasks to their original queue
+func retryDeadLetter(taskID string) {
+	count, err := asynqPkg.RetryDeadTasks(deadLetterQueue, taskID)
+	if err != nil {
+		fmt.Printf("Failed to retry dead-letter tasks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %d task(s) re-enqueued to original queue\n", count)
+}
+
+// purgeDeadLetter permanently deletes dead queue tasks after confirmation
+func purgeDeadLetter() {
+	scope := "all queues"
+	if deadLetterQueue != "" && deadLetterQueue != constants.QueueDead {
+		scope = fmt.Sprintf("queue '%s'", deadLetterQueue)
+	}
+
+	if !deadLetterForce {
+		fmt.Printf("⚠️  Are you sure you want to permanently delete dead-letter tasks of %s? [y/N]: ", scope)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Println("❌ Purge cancelled.")
+			return
+		}
+	}
+
+	count, err := asynqPkg.PurgeDeadTasks(deadLetterQueue)
+	if err != nil {
+		fmt.Printf("Failed to purge dead-letter tasks: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %d dead-letter task(s) deleted from %s\n", count, scope)
+}
+
+// listTasks displays tasks of given state in JSON format
+func listTasks(queue string) {
+	tasks, err := asynqPkg.ListTasks(queue, tasksState, tasksLimit)
+	if err != nil {
+		fmt.Printf("Failed to list tasks: %v\n", err)
+		os.Exit(1)
+	}
+
+	output := map[string]interface{}{
+		"state":          tasksState,
+		"count":          len(tasks),
+		"worker_running": asynqPkg.IsWorkerRunning(),
+		"tasks":          tasks,
+	}
+
+	// Output as pretty JSON
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(jsonData))
+}
+
+// cancelTask sends cancel signal for active task
+func cancelTask(taskID string) {
+	if err := asynqPkg.CancelTask(tasksQueue, taskID); err != nil {
+		fmt.Printf("Failed to cancel task: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Cancel signal sent for task %s\n", taskID)
+}
+
+// archiveTask moves task to archived state
+func archiveTask(taskID string) {
+	if err := asynqPkg.ArchiveTask(tasksQueue, taskID); err != nil {
+		fmt.Printf("Failed to archive task: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Task %s archived\n", taskID)
+}
+
+// retryTask moves task to pending state for immediate processing
+func retryTask(taskID string) {
+	if err := asynqPkg.RetryTask(tasksQueue, taskID); err != nil {
+		fmt.Printf("Failed to retry task: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Task %s moved to pending\n", taskID)
+}
+
+// startScheduler runs Asynq scheduler until shutdown signal
+func startScheduler() {
+	// Setup logger scope
+	log := logger.WithScope("startScheduler")
+
+	// Initialize worker config (queue routing for scheduled tasks)
+	asynqPkg.InitConcurrency()
+
+	scheduler, err := asynqPkg.NewScheduler()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize scheduler")
+	}
+
+	if err := scheduler.Start(); err != nil {
+		log.Fatal().Err(err).Msg("Failed to start scheduler")
+	}
+
+	// Wait for shutdown signal
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigChan
+	log.Info().Str("signal", sig.String()).Msg("Received shutdown signal, stopping scheduler...")
+
+	scheduler.Shutdown()
+}
+
+// listSchedules displays registered schedules in JSON format
+func listSchedules() {
+	// Initialize worker config (queue routing)
+	asynqPkg.InitConcurrency()
+
+	schedules, err := asynqPkg.ListSchedules()
+	if err != nil {
+		fmt.Printf("Failed to list schedules: %v\n", err)
+		os.Exit(1)
+	}
+
+	output := map[string]interface{}{
+		"count":     len(schedules),
+		"schedules": schedules,
+	}
+
+	// Output as pretty JSON
+	jsonData, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling JSON: %v\n", err)
+		return
+	}
+
+	utils.ClearScreen()
+	fmt.Println(string(jsonData))
+}
+
+// addSchedule validates and persists recurring task
+func addSchedule(cronspec, taskType, payloadStr string) {
+	var payload interface{}
+	if payloadStr != "" {
+		if err := json.Unmarshal([]byte(payloadStr), &payload); err != nil {
+			fmt.Printf("Invalid payload JSON: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := asynqPkg.AddSchedule(cronspec, taskType, payload); err != nil {
+		fmt.Printf("Failed to add schedule: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Schedule '%s' registered for '%s'\n", cronspec, taskType)
+	fmt.Println("💡 Restart scheduler to apply: ./app worker scheduler start")
+}
+
+// removeSchedule deletes persisted recurring task
+func removeSchedule(cronspec, taskType string) {
+	if err := asynqPkg.RemoveSchedule(cronspec, taskType); err != nil {
+		fmt.Printf("Failed to remove schedule: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Schedule '%s' for '%s' removed\n", cronspec, taskType)
+	fmt.Println("💡 Restart scheduler to apply: ./app worker scheduler start")
+}
+
 // init registers all worker subcommands with the root command
 func init() {
 	// Register subcommands
@@ -409,9 +1029,52 @@ func init() {
 	workerCmd.AddCommand(workerSetCmd)
 	workerCmd.AddCommand(workerAddCmd)
 	workerCmd.AddCommand(workerStatusCmd)
+	workerCmd.AddCommand(workerMetricsCmd)
 	workerCmd.AddCommand(workerValidateCmd)
 	workerCmd.AddCommand(workerResetCmd)
 	workerCmd.AddCommand(workerConcurrencyCmd)
+	workerCmd.AddCommand(workerDeadLetterCmd)
+	workerCmd.AddCommand(workerTasksCmd)
+	workerCmd.AddCommand(workerSchedulerCmd)
+	workerCmd.AddCommand(workerDrainCmd)
+	workerCmd.AddCommand(workerPauseCmd)
+	workerCmd.AddCommand(workerResumeCmd)
+
+	// Scheduler subcommands
+	workerSchedulerCmd.AddCommand(workerSchedulerStartCmd)
+	workerSchedulerCmd.AddCommand(workerSchedulerListCmd)
+	workerSchedulerCmd.AddCommand(workerSchedulerAddCmd)
+	workerSchedulerCmd.AddCommand(workerSchedulerRemoveCmd)
+
+	// Dead-letter subcommands
+	workerDeadLetterCmd.AddCommand(workerDeadLetterListCmd)
+	workerDeadLetterCmd.AddCommand(workerDeadLetterRetryCmd)
+	workerDeadLetterCmd.AddCommand(workerDeadLetterPurgeCmd)
+
+	// Tasks subcommands
+	workerTasksCmd.AddCommand(workerTasksListCmd)
+	workerTasksCmd.AddCommand(workerTasksCancelCmd)
+	workerTasksCmd.AddCommand(workerTasksArchiveCmd)
+	workerTasksCmd.AddCommand(workerTasksRetryCmd)
+
+	// Set command flags
+	workerSetCmd.Flags().BoolVar(&normalizeWorkersFlag, "normalize", true, "Rescale other workers so percentages sum to 100")
+
+	// Dead-letter command flags
+	workerDeadLetterCmd.PersistentFlags().StringVarP(&deadLetterQueue, "queue", "q", "", "Original queue name (default or \"dead\": all queues)")
+	workerDeadLetterListCmd.Flags().IntVarP(&deadLetterLimit, "limit", "l", 50, "Maximum tasks listed per queue")
+	workerDeadLetterPurgeCmd.Flags().BoolVarP(&deadLetterForce, "force", "f", false, "Purge without confirmation")
+
+	// Tasks command flags
+	workerTasksListCmd.Flags().StringVarP(&tasksState, "state", "s", "pending", "Task state (pending|active|scheduled|retry|archived)")
+	workerTasksListCmd.Flags().IntVarP(&tasksLimit, "limit", "l", 50, "Maximum tasks listed per queue")
+	workerTasksCancelCmd.Flags().StringVarP(&tasksQueue, "queue", "q", "", "Queue name (default: search all queues)")
+	workerTasksArchiveCmd.Flags().StringVarP(&tasksQueue, "queue", "q", "", "Queue name (default: search all queues)")
+	workerTasksRetryCmd.Flags().StringVarP(&tasksQueue, "queue", "q", "", "Queue name (default: search all queues)")
+
+	// Concurrency command flags
+	workerDrainCmd.Flags().DurationVarP(&drainTimeout, "timeout", "t", 60*time.Second, "Maximum time to wait for active tasks to complete")
+	workerConcurrencyCmd.Flags().BoolVar(&applyConcurrencyFlag, "apply", false, "Apply new concurrency to running worker without restart")
 
 	// Register worker command
 	rootCmd.AddCommand(workerCmd)
@@ -13,6 +13,9 @@ type (
 		Port     int    `json:"port" mapstructure:"port"`
 		Timezone string `json:"timezone" mapstructure:"timezone"`
 		Version  string `json:"version" mapstructure:"version"`
+
+		TrustedProxies  []string `json:"trusted_proxies" mapstructure:"trusted_proxies"`   // Load balancer IPs/CIDRs whose X-Forwarded-For / X-Real-IP are trusted, empty trusts TCP peer only
+		ShutdownTimeout string   `json:"shutdown_timeout" mapstructure:"shutdown_timeout"` // Max time in-flight requests are drained on SIGTERM/SIGINT (default 15s, capped at 25s)
 	}
 
 	influxDb struct {
@@ -24,8 +27,23 @@ type (
 		Org string `json:"org,omitempty" mapstructure:"org"` // Organization name
 
 		// Common fields (used by both versions)
-		Token  string `json:"token" mapstructure:"token"`
-		Bucket string `json:"bucket" mapstructure:"bucket"`
+		Token   string            `json:"token" mapstructure:"token"`
+		Bucket  string            `json:"bucket" mapstructure:"bucket"`
+		Buckets map[string]string `json:"buckets,omitempty" mapstructure:"buckets"` // Per-measurement bucket override (retention tiers)
+
+		// Worker write buffering (v2-oss only): points are written in batches instead of one request per event
+		WriteBuffer struct {
+			Enabled       bool   `json:"enabled" mapstructure:"enabled"`
+			BatchSize     int    `json:"batch_size" mapstructure:"batch_size"`         // Flush when this many points are buffered (default 500)
+			FlushInterval string `json:"flush_interval" mapstructure:"flush_interval"` // Max time points wait for flush (default 1s)
+			MaxBuffered   int    `json:"max_buffered" mapstructure:"max_buffered"`     // New points rejected (job retried) above this (default 10x batch_size)
+		} `json:"write_buffer" mapstructure:"write_buffer"`
+
+		// Transient write failure retry (v2-oss only): 5xx, 429, timeouts and connection errors are retried with backoff
+		WriteRetry struct {
+			MaxAttempts int    `json:"max_attempts" mapstructure:"max_attempts"` // Total attempts including first (default 3, 1 disables retry)
+			BaseDelay   string `json:"base_delay" mapstructure:"base_delay"`     // Delay before first retry, doubled per attempt up to 5s (default 200ms)
+		} `json:"write_retry" mapstructure:"write_retry"`
 
 		// v3-core fields (legacy InfluxDB v3 Core) - kept for backward compatibility
 		Host       string `json:"host,omitempty" mapstructure:"host"`
@@ -44,6 +62,11 @@ type (
 			Nodes    []string `json:"nodes" mapstructure:"nodes"`
 			Password string   `json:"password" mapstructure:"password"`
 		} `json:"cluster" mapstructure:"cluster"`
+		Sentinel struct {
+			MasterName string   `json:"master_name" mapstructure:"master_name"`
+			Nodes      []string `json:"nodes" mapstructure:"nodes"`
+			Password   string   `json:"password" mapstructure:"password"` // Sentinel auth, data password uses redis.password
+		} `json:"sentinel" mapstructure:"sentinel"`
 		Pools map[string]PoolConfig `json:"pools" mapstructure:"pools"`
 	}
 
@@ -59,15 +82,17 @@ type (
 	}
 
 	asynq struct {
-		Concurrency int `json:"concurrency" mapstructure:"concurrency"`
-		DB          int `json:"db" mapstructure:"db"`
-		PoolSize    int `json:"pool_size" mapstructure:"pool_size"`
+		Concurrency int    `json:"concurrency" mapstructure:"concurrency"`
+		DB          int    `json:"db" mapstructure:"db"`
+		PoolSize    int    `json:"pool_size" mapstructure:"pool_size"`
+		DedupWindow string `json:"dedup_window" mapstructure:"dedup_window"` // Max duration same job is rejected as duplicate while still queued (default 10m)
 	}
 
 	auth struct {
-		Enabled   bool           `json:"enabled" mapstructure:"enabled"`
-		Algorithm string         `json:"algorithm" mapstructure:"algorithm"`
-		Clients   []ClientConfig `json:"clients" mapstructure:"clients"`
+		Enabled       bool           `json:"enabled" mapstructure:"enabled"`
+		Algorithm     string         `json:"algorithm" mapstructure:"algorithm"`
+		Clients       []ClientConfig `json:"clients" mapstructure:"clients"`
+		AuditFailures bool           `json:"audit_failures" mapstructure:"audit_failures"` // Record signature/JWT failures as auth_failure security events
 	}
 
 	maxmind struct {
@@ -77,7 +102,9 @@ type (
 		Databases     struct {
 			City string `json:"city" mapstructure:"city"`
 			ASN  string `json:"asn" mapstructure:"asn"`
+			Anon string `json:"anon" mapstructure:"anon"` // Optional GeoIP2-Anonymous-IP (commercial), empty = disabled
 		} `json:"databases" mapstructure:"databases"`
+		MaxAge     map[string]string `json:"max_age" mapstructure:"max_age"` // Staleness threshold per database (city, asn, anon), Go duration, default 336h
 		Downloader struct {
 			Enabled       bool   `json:"enabled" mapstructure:"enabled"`
 			AccountID     string `json:"account_id" mapstructure:"account_id"`
@@ -91,26 +118,98 @@ type (
 			Enabled    bool   `json:"enabled" mapstructure:"enabled"`
 			MaxEntries int    `json:"max_entries" mapstructure:"max_entries"`
 			TTL        string `json:"ttl" mapstructure:"ttl"`
+			Shared     bool   `json:"shared" mapstructure:"shared"` // Two-tier cache (LRU + Redis) for job IP lookups
 		} `json:"cache" mapstructure:"cache"`
+		Fallback struct {
+			CityDB      string `json:"city_db" mapstructure:"city_db"`           // Secondary City-compatible mmdb consulted when MaxMind has no data
+			CountryCIDR string `json:"country_cidr" mapstructure:"country_cidr"` // Static "cidr,country_code[,country]" CSV consulted last
+		} `json:"fallback" mapstructure:"fallback"`
+	}
+
+	privacy struct {
+		MaskIP        bool `json:"mask_ip" mapstructure:"mask_ip"`                 // Zero last IPv4 octet / last 80 bits of IPv6
+		HashUserAgent bool `json:"hash_user_agent" mapstructure:"hash_user_agent"` // Store SHA256 of user agent instead of raw string
+	}
+
+	rateLimit struct {
+		Enabled           bool    `json:"enabled" mapstructure:"enabled"`
+		RequestsPerSecond float64 `json:"requests_per_second" mapstructure:"requests_per_second"` // Token refill rate per client
+		Burst             int     `json:"burst" mapstructure:"burst"`                             // Bucket size, defaults to ceil(requests_per_second)
+	}
+
+	cors struct {
+		AllowOrigins     []string `json:"allow_origins" mapstructure:"allow_origins"`         // Exact origins, "*" or "https://*.example.com", empty disables CORS
+		AllowMethods     []string `json:"allow_methods" mapstructure:"allow_methods"`         // Default GET, POST, OPTIONS
+		AllowHeaders     []string `json:"allow_headers" mapstructure:"allow_headers"`         // Default content type + auth headers
+		AllowCredentials bool     `json:"allow_credentials" mapstructure:"allow_credentials"` // Not honored by browsers with "*" origin
+		MaxAge           int      `json:"max_age" mapstructure:"max_age"`                     // Preflight cache seconds, 0 omits header
+	}
+
+	bodyLimit struct {
+		MaxBytes      int64 `json:"max_bytes" mapstructure:"max_bytes"`             // Ingest request body limit (default 1MB)
+		BatchMaxBytes int64 `json:"batch_max_bytes" mapstructure:"batch_max_bytes"` // Limit for batch endpoints (default 10MB)
+	}
+
+	health struct {
+		Timeout          string            `json:"timeout" mapstructure:"timeout"`   // Default per-service check timeout (default 3s)
+		Timeouts         map[string]string `json:"timeouts" mapstructure:"timeouts"` // Per-service override: influxdb, redis, asynq, maxmind
+		InfluxWriteProbe struct {
+			Enabled bool   `json:"enabled" mapstructure:"enabled"` // Write & read back probe point on health/readiness checks
+			Bucket  string `json:"bucket" mapstructure:"bucket"`   // Optional short-retention bucket (v2-oss), empty uses main bucket
+		} `json:"influxdb_write_probe" mapstructure:"influxdb_write_probe"`
+		InfluxDBData struct {
+			Enabled      bool              `json:"enabled" mapstructure:"enabled"`           // Report oldest/newest record & lag per measurement on health checks
+			MaxLag       string            `json:"max_lag" mapstructure:"max_lag"`           // Degraded when newest record is older (default 15m)
+			MaxLags      map[string]string `json:"max_lags" mapstructure:"max_lags"`         // Per-measurement override (low traffic measurements)
+			CacheTTL     string            `json:"cache_ttl" mapstructure:"cache_ttl"`       // Query result cache (default 5m)
+			Measurements []string          `json:"measurements" mapstructure:"measurements"` // Checked measurements (default all ingest measurements)
+		} `json:"influxdb_data" mapstructure:"influxdb_data"`
+	}
+
+	details struct {
+		Flatten            bool `json:"flatten" mapstructure:"flatten"`                           // Promote top-level scalar details keys into detail_<key> fields
+		MaxFlattenedFields int  `json:"max_flattened_fields" mapstructure:"max_flattened_fields"` // Cap promoted keys per point (default 20)
+
+		MaxBytes int    `json:"max_bytes" mapstructure:"max_bytes"` // Serialized details JSON limit (default 16KB)
+		MaxKeys  int    `json:"max_keys" mapstructure:"max_keys"`   // Keys counted over all nesting levels (default 100)
+		MaxDepth int    `json:"max_depth" mapstructure:"max_depth"` // Nesting depth, top-level object is 1 (default 5)
+		Oversize string `json:"oversize" mapstructure:"oversize"`   // "reject" (default) or "truncate" when max_bytes is exceeded
+	}
+
+	// RateLimitConfig holds per-client rate limit override
+	RateLimitConfig struct {
+		RequestsPerSecond float64 `json:"requests_per_second" mapstructure:"requests_per_second"`
+		Burst             int     `json:"burst,omitempty" mapstructure:"burst"`
 	}
 
 	ClientConfig struct {
 		ClientID    string   `json:"client_id" mapstructure:"client_id"`
 		ClientName  string   `json:"client_name" mapstructure:"client_name"`
-		AuthType    string   `json:"auth_type" mapstructure:"auth_type"`             // "rsa" or "hmac"
-		KeyPath     string   `json:"key_path,omitempty" mapstructure:"key_path"`     // for RSA public key
-		SecretKey   string   `json:"secret_key,omitempty" mapstructure:"secret_key"` // for HMAC
+		AuthType    string   `json:"auth_type" mapstructure:"auth_type"`                 // "rsa", "ed25519", "hmac" or "apikey"
+		KeyPath     string   `json:"key_path,omitempty" mapstructure:"key_path"`         // for RSA/Ed25519 public key
+		SecretKey   string   `json:"secret_key,omitempty" mapstructure:"secret_key"`     // for HMAC
+		APIKeyHash  string   `json:"api_key_hash,omitempty" mapstructure:"api_key_hash"` // SHA256 (hex) of API key
 		Permissions []string `json:"permissions" mapstructure:"permissions"`
 		Active      bool     `json:"active" mapstructure:"active"`
+
+		SignatureWindowSeconds int              `json:"signature_window_seconds,omitempty" mapstructure:"signature_window_seconds"` // Optional, 0 uses global default (30s), max 150
+		AllowedIPs             []string         `json:"allowed_ips,omitempty" mapstructure:"allowed_ips"`                           // Optional IP/CIDR allowlist, empty allows all
+		RateLimit              *RateLimitConfig `json:"rate_limit,omitempty" mapstructure:"rate_limit"`                             // Optional, nil uses global rate_limit
 	}
 
 	Config struct {
-		App      app      `json:"app" mapstructure:"app"`
-		InfluxDB influxDb `json:"influxdb" mapstructure:"influxdb"`
-		Redis    redis    `json:"redis" mapstructure:"redis"`
-		Asynq    asynq    `json:"asynq" mapstructure:"asynq"`
-		Auth     auth     `json:"auth" mapstructure:"auth"`
-		MaxMind  maxmind  `json:"maxmind" mapstructure:"maxmind"`
+		App       app       `json:"app" mapstructure:"app"`
+		InfluxDB  influxDb  `json:"influxdb" mapstructure:"influxdb"`
+		Redis     redis     `json:"redis" mapstructure:"redis"`
+		Asynq     asynq     `json:"asynq" mapstructure:"asynq"`
+		Auth      auth      `json:"auth" mapstructure:"auth"`
+		MaxMind   maxmind   `json:"maxmind" mapstructure:"maxmind"`
+		Privacy   privacy   `json:"privacy" mapstructure:"privacy"`
+		RateLimit rateLimit `json:"rate_limit" mapstructure:"rate_limit"`
+		BodyLimit bodyLimit `json:"body_limit" mapstructure:"body_limit"`
+		CORS      cors      `json:"cors" mapstructure:"cors"`
+		Health    health    `json:"health" mapstructure:"health"`
+		Details   details   `json:"details" mapstructure:"details"`
 	}
 
 	// RedisConfig is an alias for the internal redis struct for external access
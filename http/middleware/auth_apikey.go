@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+// APIKeyAuthMiddleware creates API key (X-API-Key header) authentication middleware with required permission
+func APIKeyAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Setup logger scope
+			log := logger.WithScope("APIKeyAuthMiddleware")
+
+			// Check if auth is enabled
+			authConfig := config.Get().Auth
+			if !authConfig.Enabled {
+				log.Debug().
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Auth disabled, skipping API key authentication")
+				return next(c)
+			}
+
+			// Extract API key from header
+			apiKey := c.Request().Header.Get("X-API-Key")
+			if apiKey == "" {
+				log.Warn().
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Missing X-API-Key header")
+				return response.FailWithCode(c, constants.CodeMissingAuth)
+			}
+
+			// Verify API key
+			clientConfig, err := auth.VerifyAPIKey(apiKey, requestSource(c))
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("API key verification failed")
+				return response.FailWithCode(c, constants.CodeInvalidAPIKey)
+			}
+
+			// Check source IP allowlist
+			if !clientIPAllowed(c, clientConfig.ClientID) {
+				log.Warn().
+					Str("client_id", clientConfig.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Str("ip", c.RealIP()).
+					Strs("allowed_ips", clientConfig.AllowedIPs).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Source IP not allowed")
+				return response.FailWithCode(c, constants.CodeIPNotAllowed)
+			}
+
+			// Check required permission using config permissions
+			if requiredPermission != "" && !auth.HasPermission(*clientConfig, requiredPermission) {
+				log.Warn().
+					Str("client_id", clientConfig.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Str("auth_type", clientConfig.AuthType).
+					Str("required_permission", requiredPermission).
+					Strs("user_permissions", clientConfig.Permissions).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Insufficient permissions")
+				return response.FailWithCode(c, constants.CodeInsufficientPerms)
+			}
+
+			// Set client context for handlers (using config data)
+			ctx := context.WithValue(c.Request().Context(), ClientIDKey, clientConfig.ClientID)
+			ctx = context.WithValue(ctx, ClientNameKey, clientConfig.ClientName)
+			ctx = context.WithValue(ctx, PermissionsKey, clientConfig.Permissions)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			log.Info().
+				Str("client_id", clientConfig.ClientID).
+				Str("client_name", clientConfig.ClientName).
+				Str("required_permission", requiredPermission).
+				Str("path", c.Request().URL.Path).
+				Str("method", c.Request().Method).
+				Msg("API key authentication successful")
+
+			return next(c)
+		}
+	}
+}
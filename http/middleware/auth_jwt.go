@@ -20,6 +20,17 @@ const (
 	PermissionsKey contextKey = "permissions"
 )
 
+// requestSource describes request for auth failure audit
+func requestSource(c echo.Context) auth.RequestSource {
+	return auth.RequestSource{
+		IP:        c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+		Method:    c.Request().Method,
+		Endpoint:  c.Request().URL.Path,
+		RequestID: constants.GetRequestID(c),
+	}
+}
+
 // JWTAuthMiddleware creates JWT authentication middleware with required permission
 func JWTAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -66,7 +77,7 @@ func JWTAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 			}
 
 			// Verify JWT token
-			claims, err := auth.VerifyJWT(tokenString)
+			claims, err := auth.VerifyJWT(tokenString, requestSource(c))
 			if err != nil {
 				log.Warn().
 					Err(err).
@@ -85,8 +96,21 @@ func JWTAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 				return response.FailWithCode(c, constants.CodeInvalidToken)
 			}
 
+			// Check source IP allowlist
+			if !clientIPAllowed(c, clientConfig.ClientID) {
+				log.Warn().
+					Str("client_id", claims.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Str("ip", c.RealIP()).
+					Strs("allowed_ips", clientConfig.AllowedIPs).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Source IP not allowed")
+				return response.FailWithCode(c, constants.CodeIPNotAllowed)
+			}
+
 			// Check required permission using config permissions
-			if requiredPermission != "" && !auth.HasPermission(clientConfig.Permissions, requiredPermission) {
+			if requiredPermission != "" && !auth.HasPermission(clientConfig, requiredPermission) {
 				log.Warn().
 					Str("client_id", claims.ClientID).
 					Str("client_name", clientConfig.ClientName).
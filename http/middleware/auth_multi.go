@@ -10,7 +10,7 @@ import (
 	"github.com/benedict-erwin/insight-collector/pkg/response"
 )
 
-// MultiAuthMiddleware creates middleware that supports both JWT and Signature authentication
+// MultiAuthMiddleware creates middleware that supports JWT, Signature and API key authentication
 func MultiAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -46,6 +46,15 @@ func MultiAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 				return SignatureAuthMiddleware(requiredPermission)(next)(c)
 			}
 
+			// Check for API key authentication (X-API-Key header)
+			if c.Request().Header.Get("X-API-Key") != "" {
+				log.Debug().
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Using API key authentication")
+				return APIKeyAuthMiddleware(requiredPermission)(next)(c)
+			}
+
 			// No authentication method found
 			log.Warn().
 				Str("path", c.Request().URL.Path).
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// OptionalAuthMiddleware authenticates request only when credentials are provided, anonymous requests pass through
+func OptionalAuthMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// No credentials, continue as anonymous request
+			header := c.Request().Header
+			if header.Get("Authorization") == "" && header.Get("X-Signature") == "" && header.Get("X-API-Key") == "" {
+				return next(c)
+			}
+
+			// Credentials provided, must be valid (no specific permission required)
+			return MultiAuthMiddleware("")(next)(c)
+		}
+	}
+}
+
+// HasRequestPermission checks if authenticated request has required permission (always true when auth disabled)
+func HasRequestPermission(c echo.Context, required string) bool {
+	if !config.Get().Auth.Enabled {
+		return true
+	}
+	return auth.HasPermissionIn(GetPermissions(c), required)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"strings"
@@ -34,6 +35,10 @@ func SignatureAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 			timestamp := c.Request().Header.Get("X-Timestamp")
 			nonce := c.Request().Header.Get("X-Nonce") // Optional
 			signature := c.Request().Header.Get("X-Signature")
+			version := c.Request().Header.Get("X-Signature-Version") // Optional, default 1 (raw body)
+			if version == "" {
+				version = auth.SignatureVersionRawBody
+			}
 
 			if clientID == "" {
 				log.Warn().
@@ -61,21 +66,14 @@ func SignatureAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 				return response.FailWithCode(c, constants.CodeMissingAuth)
 			}
 
-			// Read request body for signature verification
-			body := ""
-			if c.Request().Body != nil {
-				bodyBytes, err := io.ReadAll(c.Request().Body)
-				if err != nil {
-					log.Warn().
-						Err(err).
-						Str("client_id", clientID).
-						Msg("Failed to read request body")
-					return response.FailWithCode(c, constants.CodeBadRequest)
-				}
-				body = string(bodyBytes)
-
-				// Restore request body for downstream handlers
-				c.Request().Body = io.NopCloser(strings.NewReader(body))
+			// Read request body for signature verification (raw body for version 1, streamed digest for version 2)
+			body, err := readSignedBody(c, version)
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("client_id", clientID).
+					Msg("Failed to read request body")
+				return response.FailWithCode(c, constants.CodeBadRequest)
 			}
 
 			// Verify signature
@@ -87,6 +85,7 @@ func SignatureAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 				c.Request().URL.Path,
 				body,
 				signature,
+				requestSource(c),
 			)
 			if err != nil {
 				log.Warn().
@@ -98,8 +97,21 @@ func SignatureAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 				return response.FailWithCode(c, constants.CodeInvalidSignature)
 			}
 
+			// Check source IP allowlist
+			if !clientIPAllowed(c, clientConfig.ClientID) {
+				log.Warn().
+					Str("client_id", clientID).
+					Str("client_name", clientConfig.ClientName).
+					Str("ip", c.RealIP()).
+					Strs("allowed_ips", clientConfig.AllowedIPs).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Source IP not allowed")
+				return response.FailWithCode(c, constants.CodeIPNotAllowed)
+			}
+
 			// Check required permission using config permissions
-			if requiredPermission != "" && !auth.HasPermission(clientConfig.Permissions, requiredPermission) {
+			if requiredPermission != "" && !auth.HasPermission(*clientConfig, requiredPermission) {
 				log.Warn().
 					Str("client_id", clientID).
 					Str("client_name", clientConfig.ClientName).
@@ -131,3 +143,33 @@ func SignatureAuthMiddleware(requiredPermission string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// readSignedBody reads request body covered by signature and restores it for downstream handlers.
+// Version 2 hashes body while buffering it once, so canonical payload holds digest instead of body copy.
+// Unknown versions skip reading, they are rejected by signature verification.
+func readSignedBody(c echo.Context, version string) (auth.SignedBody, error) {
+	signed := auth.SignedBody{Version: version}
+	req := c.Request()
+
+	switch version {
+	case auth.SignatureVersionBodyHash:
+		var buf bytes.Buffer
+		digest, err := auth.BodySHA256(req.Body, &buf)
+		if err != nil {
+			return signed, err
+		}
+		signed.SHA256 = digest
+		req.Body = io.NopCloser(bytes.NewReader(buf.Bytes()))
+	case auth.SignatureVersionRawBody:
+		if req.Body == nil {
+			return signed, nil
+		}
+		bodyBytes, err := io.ReadAll(req.Body)
+		if err != nil {
+			return signed, err
+		}
+		signed.Raw = string(bodyBytes)
+		req.Body = io.NopCloser(strings.NewReader(signed.Raw))
+	}
+	return signed, nil
+}
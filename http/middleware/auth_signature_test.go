@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func TestReadSignedBodyRestoresBody(t *testing.T) {
+	const body = `{"user_id":"user-1"}`
+	digest, _ := auth.BodySHA256(strings.NewReader(body), nil)
+
+	tests := []struct {
+		version string
+		want    auth.SignedBody
+	}{
+		{auth.SignatureVersionRawBody, auth.SignedBody{Version: auth.SignatureVersionRawBody, Raw: body}},
+		{auth.SignatureVersionBodyHash, auth.SignedBody{Version: auth.SignatureVersionBodyHash, SHA256: digest}},
+	}
+	for _, tt := range tests {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/v1/user-activities/insert", strings.NewReader(body))
+		c := e.NewContext(req, httptest.NewRecorder())
+
+		signed, err := readSignedBody(c, tt.version)
+		if err != nil {
+			t.Fatalf("version %s: unexpected error: %v", tt.version, err)
+		}
+		if signed != tt.want {
+			t.Errorf("version %s: expected %+v, got %+v", tt.version, tt.want, signed)
+		}
+
+		// Downstream handler still reads full body
+		restored, _ := io.ReadAll(c.Request().Body)
+		if string(restored) != body {
+			t.Errorf("version %s: expected restored body %q, got %q", tt.version, body, restored)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+const (
+	defaultMaxBodyBytes      int64 = 1 << 20  // 1MB
+	defaultBatchMaxBodyBytes int64 = 10 << 20 // 10MB
+)
+
+// BodyLimitMiddleware rejects request bodies larger than body_limit.max_bytes (default 1MB).
+// Bodies with Content-Encoding: gzip are decompressed here, the limit applies to both compressed and decompressed size.
+// Must be registered before auth middleware, signature verification reads the whole (decompressed) body.
+func BodyLimitMiddleware() echo.MiddlewareFunc {
+	return bodyLimit(func() int64 {
+		if cfg := config.Get(); cfg != nil && cfg.BodyLimit.MaxBytes > 0 {
+			return cfg.BodyLimit.MaxBytes
+		}
+		return defaultMaxBodyBytes
+	})
+}
+
+// BatchBodyLimitMiddleware is BodyLimitMiddleware for batch endpoints, limited by body_limit.batch_max_bytes (default 10MB)
+func BatchBodyLimitMiddleware() echo.MiddlewareFunc {
+	return bodyLimit(func() int64 {
+		if cfg := config.Get(); cfg != nil && cfg.BodyLimit.BatchMaxBytes > 0 {
+			return cfg.BodyLimit.BatchMaxBytes
+		}
+		return defaultBatchMaxBodyBytes
+	})
+}
+
+// bodyLimit reads at most limit+1 bytes so oversized payloads are never fully buffered
+func bodyLimit(limitFn func() int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			limit := limitFn()
+
+			// Declared size is enough to reject without reading
+			if req.ContentLength > limit {
+				return rejectBody(c, limit)
+			}
+
+			// Chunked or understated Content-Length, read up to limit+1
+			body, err := io.ReadAll(io.LimitReader(req.Body, limit+1))
+			req.Body.Close()
+			if err != nil {
+				return response.FailWithCodeAndMessage(c, constants.CodeBadRequest, "Failed to read request body")
+			}
+			if int64(len(body)) > limit {
+				return rejectBody(c, limit)
+			}
+
+			// Gzipped body, decompressed size is capped by same limit (zip bomb protection)
+			if isGzipEncoded(req) {
+				body, err = gunzipBody(body, limit)
+				if errors.Is(err, errDecompressedTooLarge) {
+					return rejectBody(c, limit)
+				}
+				if err != nil {
+					return response.FailWithCodeAndMessage(c, constants.CodeBadRequest, "Invalid gzip request body")
+				}
+				req.Header.Del(echo.HeaderContentEncoding)
+				req.ContentLength = int64(len(body))
+			}
+
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			return next(c)
+		}
+	}
+}
+
+// rejectBody responds 413 with CodePayloadTooLarge
+func rejectBody(c echo.Context, limit int64) error {
+	logger.WithScope("BodyLimitMiddleware").Warn().
+		Int64("limit", limit).
+		Int64("content_length", c.Request().ContentLength).
+		Str("path", c.Request().URL.Path).
+		Str("ip", c.RealIP()).
+		Msg("Request body too large")
+	return response.Fail(c, http.StatusRequestEntityTooLarge, constants.CodePayloadTooLarge,
+		fmt.Sprintf("Request body exceeds %d bytes", limit))
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// countingReader records how many bytes were pulled from request body
+type countingReader struct {
+	r    io.Reader
+	read int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += int64(n)
+	return n, err
+}
+
+func runBodyLimit(t *testing.T, limit int64, body io.Reader, contentLength int64) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/v1/security-events/insert", body)
+	req.ContentLength = contentLength
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	called := false
+	handler := bodyLimit(func() int64 { return limit })(func(c echo.Context) error {
+		called = true
+		var payload map[string]interface{}
+		if err := c.Bind(&payload); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+	if err := handler(c); err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+	return rec, called
+}
+
+func TestBodyLimitRejectsBeforeBind(t *testing.T) {
+	const limit = 64
+	oversized := `{"data":"` + strings.Repeat("x", 4096) + `"}`
+
+	// Chunked body (unknown length) is cut off at limit+1 bytes
+	counter := &countingReader{r: strings.NewReader(oversized)}
+	rec, called := runBodyLimit(t, limit, counter, -1)
+	if called {
+		t.Error("handler should not run for oversized body")
+	}
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", rec.Code)
+	}
+	if counter.read > limit+1 {
+		t.Errorf("expected at most %d bytes read, got %d", limit+1, counter.read)
+	}
+
+	// Declared Content-Length over limit is rejected without reading
+	counter = &countingReader{r: strings.NewReader(oversized)}
+	rec, called = runBodyLimit(t, limit, counter, int64(len(oversized)))
+	if called || rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 without handler, got status %d (handler called: %v)", rec.Code, called)
+	}
+	if counter.read != 0 {
+		t.Errorf("expected no bytes read, got %d", counter.read)
+	}
+}
+
+func TestBodyLimitAllowsBodyWithinLimit(t *testing.T) {
+	body := `{"data":"ok"}`
+	rec, called := runBodyLimit(t, int64(len(body)), strings.NewReader(body), -1)
+	if !called {
+		t.Fatal("handler should run for body within limit")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+}
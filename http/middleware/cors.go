@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodOptions}
+	defaultCORSHeaders = []string{
+		echo.HeaderContentType, echo.HeaderContentEncoding, echo.HeaderAuthorization, constants.HeaderRequestID,
+		constants.HeaderTraceparent, "X-Client-ID", "X-Signature", "X-Timestamp", "X-Nonce", "X-API-Key",
+	}
+)
+
+// CORSMiddleware applies cors config to requests under pathPrefix (e.g. "/v1").
+// Register with e.Pre: preflight OPTIONS is answered before routing, so it never reaches auth middleware.
+// Without cors.allow_origins no CORS headers are sent and browsers keep same-origin policy.
+func CORSMiddleware(pathPrefix string) echo.MiddlewareFunc {
+	log := logger.WithScope("CORSMiddleware")
+
+	cfg := config.Get()
+	if cfg == nil || len(cfg.CORS.AllowOrigins) == 0 {
+		log.Info().Msg("CORS disabled, no allowed origins configured")
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+
+	corsConfig := cfg.CORS
+	methods := corsConfig.AllowMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := corsConfig.AllowHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+
+	for _, origin := range corsConfig.AllowOrigins {
+		if origin == "*" && corsConfig.AllowCredentials {
+			log.Warn().Msg("Wildcard origin with allow_credentials, browsers will reject credentialed requests")
+			break
+		}
+	}
+
+	log.Info().
+		Strs("allow_origins", corsConfig.AllowOrigins).
+		Strs("allow_methods", methods).
+		Bool("allow_credentials", corsConfig.AllowCredentials).
+		Msg("CORS enabled")
+
+	return echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		Skipper: func(c echo.Context) bool {
+			path := c.Request().URL.Path
+			return path != pathPrefix && !strings.HasPrefix(path, pathPrefix+"/")
+		},
+		AllowOrigins:     corsConfig.AllowOrigins, // Exact origins, "*" or subdomain pattern "https://*.example.com"
+		AllowMethods:     methods,
+		AllowHeaders:     headers,
+		AllowCredentials: corsConfig.AllowCredentials,
+		ExposeHeaders:    []string{constants.HeaderRequestID, "Retry-After"},
+		MaxAge:           corsConfig.MaxAge,
+	})
+}
+
+// IsOriginAllowed reports whether origin matches cors.allow_origins (used by WebSocket handshake,
+// where browsers send Origin but CORS headers are not enforced)
+func IsOriginAllowed(origin string) bool {
+	cfg := config.Get()
+	if cfg == nil {
+		return false
+	}
+	return originAllowed(origin, cfg.CORS.AllowOrigins)
+}
+
+// originAllowed matches origin against exact origins, "*" or subdomain pattern "https://*.example.com"
+func originAllowed(origin string, allowOrigins []string) bool {
+	origin = strings.ToLower(strings.TrimSpace(origin))
+	if origin == "" {
+		return false
+	}
+
+	for _, allowed := range allowOrigins {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+
+		scheme, host, ok := strings.Cut(allowed, "://*.")
+		if !ok {
+			continue
+		}
+		prefix := scheme + "://"
+		if strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, "."+host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import "testing"
+
+func TestOriginAllowed(t *testing.T) {
+	allow := []string{"https://app.example.com", "https://*.example.org"}
+
+	tests := []struct {
+		name     string
+		origin   string
+		allow    []string
+		expected bool
+	}{
+		{"exact match", "https://app.example.com", allow, true},
+		{"exact match case insensitive", "HTTPS://App.Example.com", allow, true},
+		{"scheme mismatch", "http://app.example.com", allow, false},
+		{"subdomain pattern", "https://admin.example.org", allow, true},
+		{"nested subdomain pattern", "https://a.b.example.org", allow, true},
+		{"pattern requires subdomain", "https://example.org", allow, false},
+		{"pattern suffix lookalike", "https://evilexample.org", allow, false},
+		{"pattern scheme mismatch", "http://admin.example.org", allow, false},
+		{"unlisted origin", "https://evil.com", allow, false},
+		{"empty origin", "", allow, false},
+		{"no allowlist", "https://app.example.com", nil, false},
+		{"wildcard", "https://evil.com", []string{"*"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := originAllowed(tt.origin, tt.allow); got != tt.expected {
+			t.Errorf("%s: originAllowed(%q) = %v, want %v", tt.name, tt.origin, got, tt.expected)
+		}
+	}
+}
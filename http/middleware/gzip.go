@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// gzipMinResponseBytes skips compressing small responses (gzip overhead outweighs gain)
+const gzipMinResponseBytes = 1024
+
+// errDecompressedTooLarge is returned when gzip body expands past body limit
+var errDecompressedTooLarge = errors.New("decompressed body exceeds limit")
+
+// isGzipEncoded checks Content-Encoding header of request
+func isGzipEncoded(req *http.Request) bool {
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get(echo.HeaderContentEncoding)))
+	return encoding == "gzip" || encoding == "x-gzip"
+}
+
+// gunzipBody decompresses body reading at most limit+1 bytes, so zip bombs are never fully inflated
+func gunzipBody(body []byte, limit int64) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > limit {
+		return nil, errDecompressedTooLarge
+	}
+	return decompressed, nil
+}
+
+// GzipResponseMiddleware compresses responses when client sends Accept-Encoding: gzip (list/export endpoints).
+// Responses smaller than 1KB are sent uncompressed.
+func GzipResponseMiddleware() echo.MiddlewareFunc {
+	return echoMiddleware.GzipWithConfig(echoMiddleware.GzipConfig{
+		Level:     gzip.DefaultCompression,
+		MinLength: gzipMinResponseBytes,
+	})
+}
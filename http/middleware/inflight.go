@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// inFlight counts requests currently executing handlers
+var inFlight atomic.Int64
+
+// InFlight tracks requests being handled so graceful shutdown can report drain progress
+func InFlight(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+		return next(c)
+	}
+}
+
+// InFlightRequests returns number of requests still being handled
+func InFlightRequests() int64 {
+	return inFlight.Load()
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestInFlightCountsRunningHandlers(t *testing.T) {
+	e := echo.New()
+	started, release := make(chan struct{}), make(chan struct{})
+	e.Use(InFlight)
+	e.POST("/v1/user-activities/insert", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusAccepted)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/user-activities/insert", nil))
+	}()
+
+	<-started
+	if got := InFlightRequests(); got != 1 {
+		t.Errorf("expected 1 in-flight request, got %d", got)
+	}
+
+	close(release)
+	<-done
+	if got := InFlightRequests(); got != 0 {
+		t.Errorf("expected no in-flight requests after handler returned, got %d", got)
+	}
+}
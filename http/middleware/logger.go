@@ -50,6 +50,7 @@ func Logger(next echo.HandlerFunc) echo.HandlerFunc {
 			Int("status", status).
 			Int64("latency", latency).
 			Str("request-id", reqId).
+			Str("trace_id", constants.GetTraceID(c)).
 			Msg("HTTP Request")
 
 		return err
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/ratelimit"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+// rateLimitStoreDown tracks store health so fail-open warning is logged once per outage, not per request
+var rateLimitStoreDown atomic.Bool
+
+// markRateLimitStore records store health, returns true when state changed (down -> up or up -> down)
+func markRateLimitStore(down bool) bool {
+	return rateLimitStoreDown.CompareAndSwap(!down, down)
+}
+
+// RateLimitMiddleware enforces per-client token bucket limit (keyed by client_id, source IP for anonymous requests).
+// Must run after auth middleware so client ID is available. Fails open when Redis is unavailable.
+func RateLimitMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Setup logger scope
+			log := logger.WithScope("RateLimitMiddleware")
+
+			// Check if rate limiting is enabled
+			rateLimitConfig := config.Get().RateLimit
+			if !rateLimitConfig.Enabled {
+				return next(c)
+			}
+
+			global := config.RateLimitConfig{
+				RequestsPerSecond: rateLimitConfig.RequestsPerSecond,
+				Burst:             rateLimitConfig.Burst,
+			}
+
+			// Resolve bucket key & limit (per-client override when authenticated)
+			key := "ip:" + c.RealIP()
+			limit := ratelimit.Resolve(global, nil)
+			if clientID := GetClientID(c); clientID != "" {
+				key = "client:" + clientID
+				if _, clientConfig, exists := auth.GetClientInfo(clientID); exists {
+					limit = ratelimit.Resolve(global, clientConfig.RateLimit)
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), 500*time.Millisecond)
+			defer cancel()
+
+			allowed, retryAfter, err := ratelimit.Allow(ctx, key, limit)
+			if err != nil {
+				if markRateLimitStore(true) {
+					log.Warn().
+						Err(err).
+						Str("key", key).
+						Msg("Rate limit store unavailable, allowing requests until it recovers")
+				} else {
+					log.Debug().
+						Err(err).
+						Str("key", key).
+						Msg("Rate limit check failed, allowing request")
+				}
+				return next(c)
+			}
+			if markRateLimitStore(false) {
+				log.Info().Msg("Rate limit store recovered, limiting resumed")
+			}
+
+			if !allowed {
+				// Retry-After is whole seconds, at least 1
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+
+				log.Warn().
+					Str("key", key).
+					Float64("requests_per_second", limit.RequestsPerSecond).
+					Int("burst", limit.Burst).
+					Str("path", c.Request().URL.Path).
+					Str("method", c.Request().Method).
+					Msg("Rate limit exceeded")
+				return response.FailWithCode(c, constants.CodeRateLimit)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import "testing"
+
+func TestMarkRateLimitStoreReportsStateChangesOnly(t *testing.T) {
+	rateLimitStoreDown.Store(false)
+	t.Cleanup(func() { rateLimitStoreDown.Store(false) })
+
+	steps := []struct {
+		down     bool
+		expected bool
+	}{
+		{false, false}, // healthy stays healthy
+		{true, true},   // outage starts, warn once
+		{true, false},  // still down, no repeated warning
+		{true, false},
+		{false, true}, // recovered
+		{false, false},
+		{true, true}, // next outage warns again
+	}
+
+	for i, step := range steps {
+		if got := markRateLimitStore(step.down); got != step.expected {
+			t.Errorf("step %d: markRateLimitStore(%v) = %v, want %v", i, step.down, got, step.expected)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns client IP extractor used by echo c.RealIP().
+// Without app.trusted_proxies only TCP peer address is trusted, X-Forwarded-For / X-Real-IP are ignored so callers
+// cannot spoof IP allowlist or dodge per-IP rate limit. With trusted proxies, forwarding headers are honored
+// only for requests arriving from a trusted proxy (see ClientIP).
+func IPExtractor() echo.IPExtractor {
+	trusted, err := auth.ParseAllowedIPs(TrustedProxies())
+	if err != nil {
+		// Fail closed: invalid list trusts no proxy
+		logger.WithScope("IPExtractor").Error().Err(err).Msg("Invalid app.trusted_proxies, forwarding headers ignored")
+		trusted = nil
+	}
+
+	direct := echo.ExtractIPDirect()
+	return func(req *http.Request) string {
+		if ip := resolveClientIP(req, trusted); ip != nil {
+			return ip.String()
+		}
+		return direct(req)
+	}
+}
+
+// TrustedProxies returns configured proxy IPs/CIDRs whose forwarding headers are trusted
+func TrustedProxies() []string {
+	cfg := config.Get()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.App.TrustedProxies
+}
+
+// ClientIP resolves originating client IP of request behind trusted proxies (IPs or CIDRs).
+// X-Forwarded-For is walked from the right skipping trusted hops, first untrusted hop is the client
+// (entries left of it are client supplied and ignored), X-Real-IP is used when X-Forwarded-For is absent.
+// Headers are ignored when TCP peer is not trusted. Falls back to c.RealIP() on malformed headers or proxy list.
+func ClientIP(c echo.Context, trustedProxies []string) net.IP {
+	trusted, err := auth.ParseAllowedIPs(trustedProxies)
+	if err == nil {
+		if ip := resolveClientIP(c.Request(), trusted); ip != nil {
+			return ip
+		}
+	}
+	return net.ParseIP(c.RealIP())
+}
+
+// resolveClientIP returns client IP from peer & forwarding headers, nil when peer or header chain is malformed
+func resolveClientIP(req *http.Request, trusted []*net.IPNet) net.IP {
+	peer := parseHopIP(req.RemoteAddr)
+	if peer == nil {
+		return nil
+	}
+	if !ipInNets(peer, trusted) {
+		return peer
+	}
+
+	// Multiple X-Forwarded-For headers form one chain in order
+	if values := req.Header.Values(echo.HeaderXForwardedFor); len(values) > 0 {
+		hops := strings.Split(strings.Join(values, ","), ",")
+		ips := make([]net.IP, 0, len(hops))
+		for _, hop := range hops {
+			ip := parseHopIP(hop)
+			if ip == nil {
+				return nil
+			}
+			ips = append(ips, ip)
+		}
+
+		for i := len(ips) - 1; i >= 0; i-- {
+			if !ipInNets(ips[i], trusted) {
+				return ips[i]
+			}
+		}
+		// Every hop is a trusted proxy, left-most is the closest we get to client
+		return ips[0]
+	}
+
+	if realIP := req.Header.Get(echo.HeaderXRealIP); realIP != "" {
+		return parseHopIP(realIP)
+	}
+	return peer
+}
+
+// parseHopIP parses forwarding hop or remote address, with optional port or IPv6 brackets
+func parseHopIP(hop string) net.IP {
+	hop = strings.TrimSpace(hop)
+	if hop == "" {
+		return nil
+	}
+	if ip := net.ParseIP(hop); ip != nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		return net.ParseIP(host)
+	}
+	return net.ParseIP(strings.Trim(hop, "[]"))
+}
+
+// ipInNets checks whether ip belongs to any of nets
+func ipInNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIPAllowed checks request source IP (resolved by server IPExtractor) against client allowlist
+func clientIPAllowed(c echo.Context, clientID string) bool {
+	return auth.IsIPAllowed(clientID, net.ParseIP(c.RealIP()))
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/labstack/echo/v4"
+)
+
+func TestClientIPAllowedIgnoresSpoofedForwardingHeaders(t *testing.T) {
+	if err := auth.AddClient(config.ClientConfig{
+		ClientID:   "xff-test-client",
+		AuthType:   "hmac",
+		SecretKey:  "secret",
+		AllowedIPs: []string{"10.0.0.0/8"},
+	}); err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	t.Cleanup(func() { _ = auth.RemoveClient("xff-test-client") })
+
+	e := echo.New()
+	e.IPExtractor = IPExtractor()
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		allowed    bool
+	}{
+		{"direct allowed peer", "10.1.2.3:4000", nil, true},
+		{"direct outside allowlist", "203.0.113.9:4000", nil, false},
+		{"spoofed X-Forwarded-For", "203.0.113.9:4000", map[string]string{echo.HeaderXForwardedFor: "10.1.2.3"}, false},
+		{"spoofed X-Real-IP", "203.0.113.9:4000", map[string]string{echo.HeaderXRealIP: "10.1.2.3"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			if got := clientIPAllowed(c, "xff-test-client"); got != tt.allowed {
+				t.Errorf("clientIPAllowed = %v, want %v (RealIP %s)", got, tt.allowed, c.RealIP())
+			}
+		})
+	}
+}
+
+func TestClientIPTrustedProxies(t *testing.T) {
+	e := echo.New()
+	e.IPExtractor = IPExtractor()
+	trusted := []string{"10.0.0.0/8", "192.0.2.1"}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		realIP     string
+		want       string
+	}{
+		{"untrusted peer ignores headers", "198.51.100.7:4000", []string{"36.86.63.182"}, "", "198.51.100.7"},
+		{"single proxy", "10.0.0.2:4000", []string{"36.86.63.182"}, "", "36.86.63.182"},
+		{"proxy chain skips trusted hops", "10.0.0.2:4000", []string{"36.86.63.182, 192.0.2.1, 10.0.0.9"}, "", "36.86.63.182"},
+		{"spoofed left entries ignored", "10.0.0.2:4000", []string{"1.1.1.1, 36.86.63.182, 10.0.0.9"}, "", "36.86.63.182"},
+		{"multiple headers form one chain", "10.0.0.2:4000", []string{"36.86.63.182", "10.0.0.9"}, "", "36.86.63.182"},
+		{"hop with port", "10.0.0.2:4000", []string{"36.86.63.182:51234"}, "", "36.86.63.182"},
+		{"ipv6 hop with brackets", "10.0.0.2:4000", []string{"[2001:4860::1]:443"}, "", "2001:4860::1"},
+		{"all hops trusted uses left-most", "10.0.0.2:4000", []string{"10.1.1.1, 10.0.0.9"}, "", "10.1.1.1"},
+		{"x-real-ip without xff", "10.0.0.2:4000", nil, "36.86.63.182", "36.86.63.182"},
+		{"no headers uses peer", "10.0.0.2:4000", nil, "", "10.0.0.2"},
+		{"malformed chain falls back to RealIP", "10.0.0.2:4000", []string{"36.86.63.182, not-an-ip"}, "", "10.0.0.2"},
+		{"empty hop falls back to RealIP", "10.0.0.2:4000", []string{"36.86.63.182,,10.0.0.9"}, "", "10.0.0.2"},
+		{"malformed x-real-ip falls back to RealIP", "10.0.0.2:4000", nil, "garbage", "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.xff {
+				req.Header.Add(echo.HeaderXForwardedFor, value)
+			}
+			if tt.realIP != "" {
+				req.Header.Set(echo.HeaderXRealIP, tt.realIP)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			if got := ClientIP(c, trusted); got.String() != tt.want {
+				t.Errorf("ClientIP = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPInvalidTrustedProxiesFallsBack(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+	req.RemoteAddr = "10.0.0.2:4000"
+	req.Header.Set(echo.HeaderXForwardedFor, "36.86.63.182")
+	e := echo.New()
+	e.IPExtractor = IPExtractor()
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	if got := ClientIP(c, []string{"not-a-cidr"}); got.String() != "10.0.0.2" {
+		t.Errorf("expected peer IP with invalid proxy list, got %s", got)
+	}
+}
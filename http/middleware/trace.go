@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+// traceparentLength is length of version 00 traceparent header (2-32-16-2 hex with separators)
+const traceparentLength = 55
+
+// Trace middleware resolves trace ID from W3C traceparent header or generates new one, saved in context
+// (constants.TraceIDKey) for access log, handler logs, ingested entities and error responses
+func Trace(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		traceID, ok := ParseTraceparent(c.Request().Header.Get(constants.HeaderTraceparent))
+		if !ok {
+			traceID = generateTraceID()
+		}
+		c.Set(constants.TraceIDKey, traceID)
+		return next(c)
+	}
+}
+
+// ParseTraceparent returns trace ID of W3C traceparent header, false when header is missing or invalid.
+// Future versions are accepted when they start with version 00 layout, version ff and all-zero IDs are invalid.
+func ParseTraceparent(header string) (string, bool) {
+	header = strings.TrimSpace(header)
+	if len(header) < traceparentLength {
+		return "", false
+	}
+
+	version := header[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return "", false
+	}
+	if version == "00" && len(header) != traceparentLength {
+		return "", false
+	}
+	if len(header) > traceparentLength && header[traceparentLength] != '-' {
+		return "", false
+	}
+	if header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return "", false
+	}
+
+	traceID, parentID, flags := header[3:35], header[36:52], header[53:55]
+	if !isLowerHex(traceID) || !isLowerHex(parentID) || !isLowerHex(flags) {
+		return "", false
+	}
+	if isZeroHex(traceID) || isZeroHex(parentID) {
+		return "", false
+	}
+	return traceID, true
+}
+
+// generateTraceID creates random 32 hex trace ID (W3C format, never all zeros)
+func generateTraceID() string {
+	high, low := rand.Uint64(), rand.Uint64()
+	if high == 0 && low == 0 {
+		low = 1
+	}
+	return fmt.Sprintf("%016x%016x", high, low)
+}
+
+// isLowerHex reports whether s only contains lowercase hex digits
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !(s[i] >= '0' && s[i] <= '9' || s[i] >= 'a' && s[i] <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZeroHex reports whether hex ID is all zeros (invalid per W3C trace context)
+func isZeroHex(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
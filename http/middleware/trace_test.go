@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+		ok     bool
+	}{
+		{"valid sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"surrounding spaces", " 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00 ", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"future version with extra field", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"missing", "", "", false},
+		{"version 00 with extra field", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", "", false},
+		{"invalid version ff", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", false},
+		{"uppercase hex", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", "", false},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", false},
+		{"zero parent id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", false},
+		{"bad separator", "00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", false},
+		{"truncated", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseTraceparent(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: ParseTraceparent(%q) = %q, %v, want %q, %v", tt.name, tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTraceStoresTraceID(t *testing.T) {
+	e := echo.New()
+	var traceID string
+	e.Use(Trace)
+	e.POST("/v1/error-events/insert", func(c echo.Context) error {
+		traceID = constants.GetTraceID(c)
+		return c.NoContent(http.StatusAccepted)
+	})
+
+	// Propagated from traceparent
+	req := httptest.NewRequest(http.MethodPost, "/v1/error-events/insert", nil)
+	req.Header.Set(constants.HeaderTraceparent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected propagated trace id, got %q", traceID)
+	}
+
+	// Generated when header is missing or invalid
+	for _, header := range []string{"", "garbage"} {
+		req = httptest.NewRequest(http.MethodPost, "/v1/error-events/insert", nil)
+		req.Header.Set(constants.HeaderTraceparent, header)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+		if len(traceID) != 32 || !isLowerHex(traceID) || isZeroHex(traceID) {
+			t.Errorf("expected generated W3C trace id for header %q, got %q", header, traceID)
+		}
+	}
+}
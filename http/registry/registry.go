@@ -1,8 +1,12 @@
 package registry
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
 )
 
@@ -42,16 +46,60 @@ func SetupAllRoutes(e *echo.Echo) {
 
 // setupValidator configures request validation using go-playground/validator
 func setupValidator(e *echo.Echo) {
-	v := validator.New()
-	e.Validator = &CustomValidator{validator: v}
+	e.Validator = NewValidator()
 	logger.WithScope("RegistrysetupValidator").Info().Msg("Validator setup completed")
 }
 
+// NewValidator creates request validator with custom validations (also used outside HTTP, e.g. replay CLI)
+func NewValidator() *CustomValidator {
+	v := validator.New()
+
+	// Register custom validations
+	if err := v.RegisterValidation("enum", validateEnum); err != nil {
+		logger.WithScope("RegistrysetupValidator").Error().Err(err).Msg("Failed to register enum validation")
+	}
+
+	return &CustomValidator{validator: v}
+}
+
 type CustomValidator struct {
 	validator *validator.Validate
 }
 
 // Validate validates struct fields using validator tags
 func (cv *CustomValidator) Validate(i interface{}) error {
-	return cv.validator.Struct(i)
+	err := cv.validator.Struct(i)
+	if err == nil {
+		// Entity-level checks (e.g. amount precision per currency)
+		if v, ok := i.(entity.Validatable); ok {
+			return v.Validate()
+		}
+		return nil
+	}
+
+	// Readable message for enum violations (offending field & allowed values)
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		for _, fe := range validationErrors {
+			if fe.Tag() != "enum" {
+				continue
+			}
+			if set, exists := entity.GetEnum(fe.Param()); exists {
+				return fmt.Errorf("field '%s' has invalid value '%v', allowed values: %s",
+					fe.Field(), fe.Value(), strings.Join(entity.EnumValues(set), ", "))
+			}
+		}
+	}
+
+	return err
+}
+
+// validateEnum checks string field against enum set registered under tag param (case insensitive)
+func validateEnum(fl validator.FieldLevel) bool {
+	set, exists := entity.GetEnum(fl.Param())
+	if !exists {
+		return false
+	}
+
+	_, ok := entity.MatchEnum(set, fl.Field().String())
+	return ok
 }
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"crypto/md5"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	"github.com/benedict-erwin/insight-collector/internal/services/erase"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// EraseUserData deletes events of user within time range (GDPR erasure) and records it as security event
+func EraseUserData(c echo.Context) error {
+	var req erase.Request
+
+	// set logger scope
+	log := logger.WithScope("EraseUserData")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+	if req.Stop.Before(req.Start) {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidParameter, "stop must not be before start")
+	}
+
+	measurements := req.Measurements
+	if len(measurements) == 0 {
+		measurements = erase.Measurements
+	}
+
+	result := erase.EraseUser(req.UserID, req.Start, req.Stop, measurements)
+	complete := true
+	for _, item := range result.Measurements {
+		if item.Error != "" {
+			complete = false
+		}
+	}
+
+	log.Info().
+		Str("client_id", middleware.GetClientID(c)).
+		Int("measurements_processed", result.MeasurementsProcessed).
+		Int("points_deleted", result.PointsDeleted).
+		Bool("complete", complete).
+		Msg("User data erased")
+
+	// Audit trail (target user kept in details only, so later erasure doesn't remove the audit event)
+	dispatchEraseAudit(c, &req, result, complete)
+
+	data := map[string]interface{}{
+		"measurements_processed": result.MeasurementsProcessed,
+		"points_deleted":         result.PointsDeleted,
+		"measurements":           result.Measurements,
+		"complete":               complete,
+		"timestamp":              utils.NowFormatted(),
+	}
+
+	return response.Success(c, data)
+}
+
+// dispatchEraseAudit logs erase operation as security event (best effort)
+func dispatchEraseAudit(c echo.Context, req *erase.Request, result *erase.Result, complete bool) {
+	actionTaken := "erased"
+	if !complete {
+		actionTaken = "partially_erased"
+	}
+
+	requestID, _ := c.Get(constants.RequestIDKey).(string)
+	audit := seEntities.SecurityEventsRequest{
+		IdentifierType:   "client_id",
+		IdentifierValue:  middleware.GetClientID(c),
+		EventType:        "data_erasure",
+		Severity:         "high",
+		AuthStage:        "authorized",
+		ActionTaken:      actionTaken,
+		DetectionMethod:  "admin_request",
+		Channel:          "api",
+		Method:           c.Request().Method,
+		RequestID:        requestID,
+		AffectedResource: "user_data",
+		IPAddress:        c.RealIP(),
+		UserAgent:        c.Request().UserAgent(),
+		Endpoint:         c.Request().URL.Path,
+		Details: map[string]interface{}{
+			"target_user_id":         req.UserID,
+			"start":                  req.Start,
+			"stop":                   req.Stop,
+			"measurements_processed": result.MeasurementsProcessed,
+			"points_deleted":         result.PointsDeleted,
+		},
+		Timestamp: utils.Now(),
+	}
+
+	// Every erase request is recorded (no dedup by endpoint & second like client events)
+	// Enqueue errors (including duplicates) are logged by dispatcher
+	payload := newSecurityEventsPayload(generateEraseAuditJobId(&audit, req.UserID), &audit)
+	asynq.DispatchJobAsync(&payload)
+}
+
+// generateEraseAuditJobId for unique jobid per erase request
+func generateEraseAuditJobId(event *seEntities.SecurityEventsRequest, targetUserID string) string {
+	uniqueId := fmt.Sprintf("%s-%s-%s-%s-%d",
+		event.IdentifierValue,
+		event.EventType,
+		targetUserID,
+		event.RequestID,
+		event.Timestamp.UnixNano(),
+	)
+
+	hash := md5.Sum([]byte(uniqueId))
+	return fmt.Sprintf("se_%x", hash[:8])
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+)
+
+// Regression: erase audit reused client event job id (endpoint & second), so a second erase
+// in the same second was dropped as duplicate
+func TestEraseAuditJobIdUniquePerRequest(t *testing.T) {
+	now := time.Date(2025, 8, 6, 12, 30, 0, 0, time.UTC)
+	audit := seEntities.SecurityEventsRequest{
+		IdentifierType:  "client_id",
+		IdentifierValue: "admin-client",
+		EventType:       "data_erasure",
+		Endpoint:        "/v1/admin/erase",
+		RequestID:       "req-1",
+		Timestamp:       now,
+	}
+
+	first := generateEraseAuditJobId(&audit, "user-1")
+	if again := generateEraseAuditJobId(&audit, "user-1"); again != first {
+		t.Errorf("Expected stable job id for same request, got %s and %s", first, again)
+	}
+
+	second := audit
+	second.RequestID = "req-2"
+	second.Timestamp = now.Add(time.Millisecond) // Same second
+	if id := generateEraseAuditJobId(&second, "user-1"); id == first {
+		t.Errorf("Expected different job id for separate erase request in same second, got %s", id)
+	}
+
+	if id := generateEraseAuditJobId(&audit, "user-2"); id == first {
+		t.Errorf("Expected different job id for different target user, got %s", id)
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"crypto/md5"
+	"fmt"
+
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// AuditAuthFailure records failed signature/JWT authentication as auth_failure security event (registered as auth failure auditor)
+func AuditAuthFailure(failure auth.Failure) {
+	event := newAuthFailureEvent(failure)
+
+	// Every attempt is recorded (no dedup by endpoint & second like client events)
+	// Enqueue errors are logged by dispatcher
+	payload := newSecurityEventsPayload(generateAuthFailureJobId(&event), &event)
+	asynq.DispatchJobAsync(&payload)
+}
+
+// newAuthFailureEvent maps auth failure to security event request
+func newAuthFailureEvent(failure auth.Failure) seEntities.SecurityEventsRequest {
+	event := seEntities.SecurityEventsRequest{
+		EventType:       "auth_failure",
+		Severity:        failure.Severity(),
+		AuthStage:       failure.AuthMethod,
+		ActionTaken:     "blocked",
+		DetectionMethod: failure.Reason,
+		Channel:         "api",
+		Method:          failure.Source.Method,
+		RequestID:       failure.Source.RequestID,
+		IPAddress:       failure.Source.IP,
+		UserAgent:       failure.Source.UserAgent,
+		Endpoint:        failure.Source.Endpoint,
+		Details: map[string]interface{}{
+			"reason":      failure.Reason,
+			"error":       failure.Error,
+			"auth_method": failure.AuthMethod,
+		},
+		Timestamp: utils.Now(),
+	}
+
+	// Claimed client_id (unknown when token could not be parsed)
+	if failure.ClientID != "" {
+		event.IdentifierType = "client_id"
+		event.IdentifierValue = failure.ClientID
+	}
+
+	return event
+}
+
+// generateAuthFailureJobId for unique jobid per auth attempt
+func generateAuthFailureJobId(event *seEntities.SecurityEventsRequest) string {
+	uniqueId := fmt.Sprintf("%s-%s-%s-%s-%s-%d",
+		event.IdentifierValue,
+		event.DetectionMethod,
+		event.IPAddress,
+		event.Endpoint,
+		event.RequestID,
+		event.Timestamp.UnixNano(),
+	)
+
+	hash := md5.Sum([]byte(uniqueId))
+	return fmt.Sprintf("se_%x", hash[:8])
+}
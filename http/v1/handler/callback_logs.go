@@ -2,15 +2,18 @@ package handler
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"strings"
 	"time"
 
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
 	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
 	clJobs "github.com/benedict-erwin/insight-collector/internal/jobs/callback_logs"
 	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
@@ -70,7 +73,8 @@ func SaveCallbackLogs(c echo.Context) error {
 
 	// Dispatch the job
 	err := asynq.DispatchJob(&payload)
-	if err != nil {
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
 		log.Error().
 			Err(err).
 			Str("callback_id", req.CallbackID).
@@ -87,6 +91,12 @@ func SaveCallbackLogs(c echo.Context) error {
 		"timestamp": utils.NowFormatted(),
 	}
 
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
 	return response.Success(c, data)
 }
 
@@ -126,6 +136,11 @@ func ListCallbackLogs(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
 	}
 
+	// Debug output requires debug:query permission
+	if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+		return response.FailWithCode(c, constants.CodeInsufficientPerms)
+	}
+
 	// Get InfluxDB client and configuration
 	client := influxdb.GetCurrentClient()
 	if client == nil {
@@ -144,7 +159,12 @@ func ListCallbackLogs(c echo.Context) error {
 	queryConfig := clEntities.GetQueryConfig()
 
 	// Create query builder
-	qb := v2oss.NewQueryBuilder(queryConfig)
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+
+	// Validate request before any query is built (count query runs first)
+	if err := qb.ValidateRequest(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
 
 	// Get total count using client and bucket
 	totalRecords := qb.GetTotalCount(&req, v2ossClient)
@@ -170,6 +190,17 @@ func ListCallbackLogs(c echo.Context) error {
 		Data:       records,
 		Pagination: paginationInfo,
 	}
+
+	// Include generated Flux queries when debug requested
+	if req.Debug {
+		queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build debug query string")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+		responseData.Debug = queryDebug
+	}
+
 	return response.Success(c, responseData)
 }
 
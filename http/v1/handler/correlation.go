@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+// defaultCorrelationIDs fills trace & request IDs omitted by client with IDs of current HTTP request
+// (traceparent or generated trace ID, X-Request-ID or generated request ID), nil pointer for missing entity field
+func defaultCorrelationIDs(c echo.Context, traceID, requestID *string) {
+	if traceID != nil && *traceID == "" {
+		*traceID = constants.GetTraceID(c)
+	}
+	if requestID != nil && *requestID == "" {
+		*requestID = constants.GetRequestID(c)
+	}
+}
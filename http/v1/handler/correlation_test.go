@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/labstack/echo/v4"
+)
+
+func TestDefaultCorrelationIDs(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/v1/transaction-events/insert", nil), httptest.NewRecorder())
+	c.Set(constants.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736")
+	c.Set(constants.RequestIDKey, "req-1754480000-0000abcd")
+
+	// Omitted IDs filled from HTTP request
+	var traceID, requestID string
+	defaultCorrelationIDs(c, &traceID, &requestID)
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || requestID != "req-1754480000-0000abcd" {
+		t.Errorf("expected IDs from context, got trace %q request %q", traceID, requestID)
+	}
+
+	// Client IDs kept, nil field skipped
+	traceID = "client-trace"
+	defaultCorrelationIDs(c, &traceID, nil)
+	if traceID != "client-trace" {
+		t.Errorf("expected client trace id kept, got %q", traceID)
+	}
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	eeJobs "github.com/benedict-erwin/insight-collector/internal/jobs/error_events"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// SaveErrorEvents handles saving data for error events
+func SaveErrorEvents(c echo.Context) error {
+	var req eeEntities.ErrorEventsRequest
+
+	// set logger scope
+	log := logger.WithScope("SaveErrorEvents")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Default trace & request IDs from HTTP request when client omitted them, ingest logs carry stored trace ID
+	defaultCorrelationIDs(c, &req.TraceID, &req.RequestID)
+	log = log.WithTraceID(req.TraceID)
+
+	// Default to client IP resolved behind trusted proxies when caller (e.g. browser) doesn't report it
+	if req.IPAddress == "" {
+		if ip := middleware.ClientIP(c, middleware.TrustedProxies()); ip != nil {
+			req.IPAddress = ip.String()
+		}
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Generate JobId
+	jobID := generateErrorEventsJobId(&req)
+
+	// Job Payload
+	payload := asynq.Payload{
+		TaskId:   jobID,
+		TaskType: eeJobs.TypeErrorEventsLogging,
+		Data: eeEntities.ErrorEventsRequest{
+			Service:      req.Service,
+			Environment:  req.Environment,
+			AppVersion:   req.AppVersion,
+			ErrorType:    req.ErrorType,
+			Severity:     req.Severity,
+			ErrorCode:    req.ErrorCode,
+			Message:      req.Message,
+			StackTrace:   req.StackTrace,
+			Handled:      req.Handled,
+			Channel:      req.Channel,
+			Method:       req.Method,
+			Endpoint:     req.Endpoint,
+			ResponseCode: req.ResponseCode,
+			DurationMs:   req.DurationMs,
+			UserID:       req.UserID,
+			SessionID:    req.SessionID,
+			RequestID:    req.RequestID,
+			TraceID:      req.TraceID,
+			IPAddress:    req.IPAddress,
+			UserAgent:    req.UserAgent,
+			Details:      req.Details,
+			Timestamp:    req.Timestamp,
+		},
+	}
+
+	// Dispatch the job
+	err := asynq.DispatchJob(&payload)
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
+		log.Error().
+			Err(err).
+			Str("request_id", req.RequestID).
+			Str("job_id", jobID).
+			Msg("Failed to enqueue job")
+
+		return response.FailWithCodeAndMessage(c, constants.CodeInternalError, "Failed to dispatch job")
+	}
+
+	// Return immediate response
+	data := map[string]interface{}{
+		"message":   "Job dispatched!",
+		"job_id":    jobID,
+		"timestamp": utils.NowFormatted(),
+	}
+
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
+	return response.Success(c, data)
+}
+
+// generateErrorEventsJobId for unique jobid
+func generateErrorEventsJobId(payload *eeEntities.ErrorEventsRequest) string {
+	// Clean endpoint
+	safeEndpoint := strings.ReplaceAll(payload.Endpoint, "/", "_")
+	safeEndpoint = strings.ReplaceAll(safeEndpoint, ":", "_")
+
+	// Concat to make some unique error occurrence
+	uniqueId := fmt.Sprintf("%s-%s-%s-%s-%s-%d",
+		payload.Service,
+		payload.RequestID,
+		payload.ErrorType,
+		payload.ErrorCode,
+		safeEndpoint,
+		payload.Timestamp.UnixNano(),
+	)
+
+	hash := md5.Sum([]byte(uniqueId))
+	return fmt.Sprintf("ee_%x", hash[:8])
+}
+
+// ListErrorEvents handles paginated listing of error events
+func ListErrorEvents(c echo.Context) error {
+	var req v2oss.PaginationRequest
+
+	// set logger scope
+	log := logger.WithScope("ListErrorEvents")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Debug output requires debug:query permission
+	if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+		return response.FailWithCode(c, constants.CodeInsufficientPerms)
+	}
+
+	// Get InfluxDB client and configuration
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Warn().Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Warn().Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Get query config for error events
+	queryConfig := eeEntities.GetQueryConfig()
+
+	// Create query builder
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+
+	// Validate request before any query is built (count query runs first)
+	if err := qb.ValidateRequest(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Get total count using client and bucket
+	totalRecords := qb.GetTotalCount(&req, v2ossClient)
+
+	// Execute data query and get results using client and bucket
+	results, err := qb.ExecuteDataQuery(&req, v2ossClient)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to execute data query")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Convert raw results to structured response
+	var records []eeEntities.ErrorEventsResponse
+	for _, record := range results {
+		records = append(records, eeEntities.MapToErrorEventsResponse(record))
+	}
+
+	// Get cursor-based pagination info
+	paginationInfo := qb.GetPaginationInfo(&req, results, totalRecords)
+
+	// Build response
+	responseData := v2oss.PaginationResponse{
+		Data:       records,
+		Pagination: paginationInfo,
+	}
+
+	// Include generated Flux queries when debug requested
+	if req.Debug {
+		queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build debug query string")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+		responseData.Debug = queryDebug
+	}
+
+	return response.Success(c, responseData)
+}
+
+func DetailErrorEvents(c echo.Context) error {
+	// Get encoded ID from path parameter
+	encodedID := c.Param("id")
+
+	// set logger scope
+	log := logger.WithScope("DetailErrorEvents")
+
+	// Decode timestamp and request_id
+	// time RFC3339 format: "2025-08-06T12:30:00Z"
+	// request_id e.g., "req-1234567890-abcdef12"
+	timestamp, requestID, err := utils.ParseRecordID(encodedID)
+	if err != nil {
+		log.Error().Err(err).Str("encoded_id", encodedID).Msg("Invalid record ID format")
+		return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, "Invalid record ID format")
+	}
+
+	// Validate timestamp format
+	if _, parseErr := time.Parse(time.RFC3339, timestamp); parseErr != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, "Invalid timestamp format")
+	}
+
+	// Get InfluxDB client and configuration
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Error().Str("request_id", requestID).Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Error().Str("request_id", requestID).Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Get query config for error events
+	queryConfig := eeEntities.GetQueryConfig()
+
+	// Create query builder
+	qb := v2oss.NewQueryBuilder(queryConfig)
+
+	// Get record by timestamp & request_id
+	record, err := qb.GetByTimestampAndUniqueID(timestamp, "request_id", requestID, v2ossClient)
+	if err != nil {
+		log.Error().Err(err).
+			Str("timestamp", timestamp).
+			Str("request_id", requestID).
+			Msg("Failed to retrieve error events")
+		return response.FailWithCodeAndMessage(c, constants.CodeNotFound, "Record not found")
+	}
+
+	// Convert raw record to structured response
+	structuredResponse := eeEntities.MapToErrorEventsResponse(record)
+
+	// Success
+	return response.Success(c, structuredResponse)
+}
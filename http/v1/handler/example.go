@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -79,7 +80,8 @@ func ExampleJob(c echo.Context) error {
 
 	// Dispatch the job
 	err := asynq.DispatchJob(&payload)
-	if err != nil {
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
 		logger.Error().
 			Err(err).
 			Str("job_id", jobID).
@@ -96,5 +98,11 @@ func ExampleJob(c echo.Context) error {
 		"timestamp": utils.NowFormatted(),
 	}
 
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
 	return response.Success(c, data)
 }
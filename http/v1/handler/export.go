@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+// maxExportRange limits export date range to avoid runaway exports
+const maxExportRange = 90 * 24 * time.Hour
+
+// exportFlushEvery controls how many rows are buffered before flushing to client
+const exportFlushEvery = 500
+
+// streamCSVExport streams records matching export request as CSV attachment named <name>_<range>.csv.
+// Errors before first row is written return JSON failure, after that stream is ended and error logged.
+func streamCSVExport(c echo.Context, log *logger.ScopedLogger, name string, queryConfig v2oss.QueryBuilderConfig, req *v2oss.ExportRequest, header []string, toRow func(record map[string]interface{}) []string) error {
+	// Guard export range
+	span, err := v2oss.RangeDuration(req.Range)
+	if err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+	if span > maxExportRange {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed,
+			fmt.Sprintf("export range cannot exceed %d days", int(maxExportRange.Hours()/24)))
+	}
+
+	// Validate filters & sort before streaming
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+	streamReq := req.ToPaginationRequest()
+	if err := qb.ValidateRequest(streamReq); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Get InfluxDB client
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Warn().Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Warn().Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	filename := fmt.Sprintf("%s_%s.csv", name, v2oss.RangeLabel(req.Range))
+	writer := csv.NewWriter(c.Response())
+	started := false
+	rows := 0
+
+	// Headers are written lazily so query errors can still be reported as JSON
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+		c.Response().WriteHeader(http.StatusOK)
+		return writer.Write(header)
+	}
+
+	err = qb.StreamQuery(streamReq, v2ossClient, func(record map[string]interface{}) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := writer.Write(toRow(record)); err != nil {
+			return err
+		}
+
+		// Flush periodically to keep memory flat on large exports
+		rows++
+		if rows%exportFlushEvery == 0 {
+			writer.Flush()
+			if err := writer.Error(); err != nil {
+				return err
+			}
+			c.Response().Flush()
+		}
+		return nil
+	})
+
+	if err != nil && !started {
+		log.Error().Err(err).Msg("Failed to execute export query")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+	if err != nil {
+		// Response already committed, client receives truncated file
+		log.Error().Err(err).Int("rows", rows).Msg("Export stream interrupted")
+		writer.Flush()
+		return nil
+	}
+
+	// Empty result still returns header row
+	if err := start(); err != nil {
+		log.Error().Err(err).Msg("Failed to write export header")
+		return nil
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Error().Err(err).Int("rows", rows).Msg("Failed to flush export")
+		return nil
+	}
+
+	log.Info().
+		Str("file", filename).
+		Int("rows", rows).
+		Msg("Export completed")
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	geoEntities "github.com/benedict-erwin/insight-collector/internal/entities/geo"
+	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// LookupGeo handles geo & ASN lookup of single IP address (?ip=...)
+func LookupGeo(c echo.Context) error {
+	ip := c.QueryParam("ip")
+	if ip == "" {
+		return response.FailWithCodeAndMessage(c, constants.CodeMissingParameter, "ip is required")
+	}
+	if net.ParseIP(ip) == nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, fmt.Sprintf("invalid IP address: %s", ip))
+	}
+
+	data := map[string]interface{}{
+		"result":    lookupGeo(ip, maxmind.HasAnonymousDB()),
+		"timestamp": utils.NowFormatted(),
+	}
+
+	return response.Success(c, data)
+}
+
+// LookupGeoBatch handles geo & ASN lookup of multiple IP addresses
+func LookupGeoBatch(c echo.Context) error {
+	var req geoEntities.BatchLookupRequest
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Reject whole batch on first malformed IP
+	for _, ip := range req.IPs {
+		if net.ParseIP(ip) == nil {
+			return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, fmt.Sprintf("invalid IP address: %s", ip))
+		}
+	}
+
+	hasAnon := maxmind.HasAnonymousDB()
+	results := make([]geoEntities.LookupResult, len(req.IPs))
+	for i, ip := range req.IPs {
+		results[i] = lookupGeo(ip, hasAnon)
+	}
+
+	data := map[string]interface{}{
+		"results":   results,
+		"timestamp": utils.NowFormatted(),
+	}
+
+	return response.Success(c, data)
+}
+
+// lookupGeo performs city, ASN and optional anonymous IP lookup of validated IP address
+func lookupGeo(ip string, hasAnon bool) geoEntities.LookupResult {
+	result := geoEntities.LookupResult{
+		IP:  ip,
+		Geo: maxmind.LookupCityFromString(ip),
+		ASN: maxmind.LookupASNFromString(ip),
+	}
+	if hasAnon {
+		result.Anon = maxmind.LookupAnonymousFromString(ip)
+	}
+	return result
+}
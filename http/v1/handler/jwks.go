@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+// JWKS returns active RSA client public keys in standard JWKS format (raw, not wrapped in response envelope)
+func JWKS(c echo.Context) error {
+	// Setup logger scope
+	log := logger.WithScope("JWKS")
+
+	data, err := auth.GetJWKS()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to build JWKS")
+		return response.FailWithCode(c, constants.CodeInternalError)
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSONBlob(http.StatusOK, data)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// MakeListHandler returns paginated list handler of any measurement: request binding & validation, debug permission,
+// count & data queries, cursor pagination and debug query output. mapFn converts raw InfluxDB record into response item.
+//
+// Query config usually depends on runtime config (bucket overrides), so entities build the handler per request
+// from named handler (named function also keeps route & OpenAPI doc lookup per entity):
+//
+//	func ListFoo(c echo.Context) error {
+//		return MakeListHandler(fooEntities.GetQueryConfig(), func(record map[string]interface{}) interface{} {
+//			return fooEntities.MapToFooResponse(record)
+//		})(c)
+//	}
+func MakeListHandler(cfg v2oss.QueryBuilderConfig, mapFn func(map[string]interface{}) interface{}) echo.HandlerFunc {
+	scope := listScope(cfg.Measurement)
+
+	return func(c echo.Context) error {
+		var req v2oss.PaginationRequest
+
+		// set logger scope
+		log := logger.WithScope(scope)
+
+		// Bind JSON into struct
+		if err := c.Bind(&req); err != nil {
+			return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+		}
+
+		// Validate using echo.Validator (with struct tags)
+		if err := c.Validate(&req); err != nil {
+			return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+		}
+
+		// Debug output requires debug:query permission
+		if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+			return response.FailWithCode(c, constants.CodeInsufficientPerms)
+		}
+
+		// Get InfluxDB client and configuration
+		client := influxdb.GetCurrentClient()
+		if client == nil {
+			log.Warn().Msg("InfluxDB client not initialized")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+
+		// Type assert to v2-oss client (assuming v2-oss is default)
+		v2ossClient, ok := client.(*v2oss.Client)
+		if !ok {
+			log.Warn().Msg("Invalid InfluxDB client type")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+
+		// Create query builder
+		qb := newListQueryBuilder(c, log, cfg, req.Filters)
+
+		// Validate request before any query is built (count query runs first)
+		if err := qb.ValidateRequest(&req); err != nil {
+			return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+		}
+
+		// Get total count using client and bucket
+		totalRecords := qb.GetTotalCount(&req, v2ossClient)
+
+		// Execute data query and get results using client and bucket
+		results, err := qb.ExecuteDataQuery(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to execute data query")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+
+		// Convert raw results to structured response (nil when empty, same as typed entity slices)
+		var records []interface{}
+		for _, record := range results {
+			records = append(records, mapFn(record))
+		}
+
+		// Get cursor-based pagination info
+		paginationInfo := qb.GetPaginationInfo(&req, results, totalRecords)
+
+		// Build response
+		responseData := v2oss.PaginationResponse{
+			Data:       records,
+			Pagination: paginationInfo,
+		}
+
+		// Include generated Flux queries when debug requested
+		if req.Debug {
+			queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to build debug query string")
+				return response.FailWithCode(c, constants.CodeInfluxDBError)
+			}
+			responseData.Debug = queryDebug
+		}
+
+		return response.Success(c, responseData)
+	}
+}
+
+// listScope returns logger scope of measurement list handler (security_events → ListSecurityEvents)
+func listScope(measurement string) string {
+	var scope strings.Builder
+	scope.WriteString("List")
+	for _, part := range strings.Split(measurement, "_") {
+		if part == "" {
+			continue
+		}
+		scope.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return scope.String()
+}
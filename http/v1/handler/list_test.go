@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/labstack/echo/v4"
+)
+
+func TestListScope(t *testing.T) {
+	tests := map[string]string{
+		"security_events":    "ListSecurityEvents",
+		"user_activities":    "ListUserActivities",
+		"callback_logs":      "ListCallbackLogs",
+		"orders":             "ListOrders",
+		"double__underscore": "ListDoubleUnderscore",
+	}
+	for measurement, want := range tests {
+		if got := listScope(measurement); got != want {
+			t.Errorf("listScope(%q) = %q, want %q", measurement, got, want)
+		}
+	}
+}
+
+func TestMakeListHandlerRejectsInvalidJSON(t *testing.T) {
+	mapped := false
+	h := MakeListHandler(v2oss.QueryBuilderConfig{Measurement: "orders"}, func(record map[string]interface{}) interface{} {
+		mapped = true
+		return record
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders/list", strings.NewReader(`{"limit":`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	if err := h(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+
+	var body struct {
+		Success bool `json:"success"`
+		Code    int  `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid response body %s: %v", rec.Body.String(), err)
+	}
+	if body.Success || body.Code != constants.CodeInvalidJSON {
+		t.Errorf("expected invalid JSON failure, got %s", rec.Body.String())
+	}
+	if mapped {
+		t.Error("mapFn must not be called for rejected request")
+	}
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// dynamicFilterPermission lets client filter list & export queries on keys outside entity query config
+var dynamicFilterPermission = auth.ActionAdmin + ":query"
+
+// newListQueryBuilder creates query builder for list/export request. Clients holding admin:query may filter on
+// any sanitized key (AllowDynamicFields), dynamic keys in use are logged together with requesting client.
+func newListQueryBuilder(c echo.Context, log *logger.ScopedLogger, queryConfig v2oss.QueryBuilderConfig, filters []v2oss.FilterItem) *v2oss.QueryBuilder {
+	queryConfig.AllowDynamicFields = middleware.HasRequestPermission(c, dynamicFilterPermission)
+	qb := v2oss.NewQueryBuilder(queryConfig)
+
+	if keys := qb.DynamicFilterKeys(filters); len(keys) > 0 {
+		log.Info().
+			Str("client_id", middleware.GetClientID(c)).
+			Str("client_name", middleware.GetClientName(c)).
+			Str("measurement", queryConfig.Measurement).
+			Strs("dynamic_keys", keys).
+			Msg("Dynamic filter keys used")
+	}
+	return qb
+}
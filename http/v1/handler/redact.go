@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/labstack/echo/v4"
+)
+
+// piiPermission lets client read raw PII (ip_address, destination_account, user_agent) in list, detail & export
+var piiPermission = auth.ActionRead + ":pii"
+
+// hasPIIAccess reports whether client may see raw PII, responses of other clients are redacted (always true when auth is disabled)
+func hasPIIAccess(c echo.Context) bool {
+	return middleware.HasRequestPermission(c, piiPermission)
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
 	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
 	seJobs "github.com/benedict-erwin/insight-collector/internal/jobs/security_events"
 	"github.com/benedict-erwin/insight-collector/pkg/asynq"
@@ -30,6 +33,17 @@ func SaveSecurityEvents(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
 	}
 
+	// Default trace & request IDs from HTTP request when client omitted them, ingest logs carry stored trace ID
+	defaultCorrelationIDs(c, &req.TraceID, &req.RequestID)
+	log = log.WithTraceID(req.TraceID)
+
+	// Default to client IP resolved behind trusted proxies when caller (e.g. browser) doesn't report it
+	if req.IPAddress == "" {
+		if ip := middleware.ClientIP(c, middleware.TrustedProxies()); ip != nil {
+			req.IPAddress = ip.String()
+		}
+	}
+
 	// Validate using echo.Validator (with struct tags)
 	if err := c.Validate(&req); err != nil {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
@@ -39,7 +53,40 @@ func SaveSecurityEvents(c echo.Context) error {
 	jobID := generateSecurityEventsJobId(&req)
 
 	// Job Payload
-	payload := asynq.Payload{
+	payload := newSecurityEventsPayload(jobID, &req)
+
+	// Dispatch the job
+	err := asynq.DispatchJob(&payload)
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
+		log.Error().
+			Err(err).
+			Str("request_id", req.RequestID).
+			Str("job_id", jobID).
+			Msg("Failed to enqueue job")
+
+		return response.FailWithCodeAndMessage(c, constants.CodeInternalError, "Failed to dispatch job")
+	}
+
+	// Return immediate response
+	data := map[string]interface{}{
+		"message":   "Job dispatched!",
+		"job_id":    jobID,
+		"timestamp": utils.NowFormatted(),
+	}
+
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
+	return response.Success(c, data)
+}
+
+// newSecurityEventsPayload builds job payload with explicit field mapping from request
+func newSecurityEventsPayload(jobID string, req *seEntities.SecurityEventsRequest) asynq.Payload {
+	return asynq.Payload{
 		TaskId:   jobID,
 		TaskType: seJobs.TypeSecurityEventsLogging,
 		Data: seEntities.SecurityEventsRequest{
@@ -56,7 +103,7 @@ func SaveSecurityEvents(c echo.Context) error {
 			Method:              req.Method,
 			RequestID:           req.RequestID,
 			TraceID:             req.TraceID,
-			IdentifierValue:     req.IdentifierType,
+			IdentifierValue:     req.IdentifierValue,
 			AttemptCount:        req.AttemptCount,
 			RiskScore:           req.RiskScore,
 			ConfidenceScore:     req.ConfidenceScore,
@@ -72,27 +119,6 @@ func SaveSecurityEvents(c echo.Context) error {
 			Timestamp:           req.Timestamp,
 		},
 	}
-
-	// Dispatch the job
-	err := asynq.DispatchJob(&payload)
-	if err != nil {
-		log.Error().
-			Err(err).
-			Str("request_id", req.RequestID).
-			Str("job_id", jobID).
-			Msg("Failed to enqueue job")
-
-		return response.FailWithCodeAndMessage(c, constants.CodeInternalError, "Failed to dispatch job")
-	}
-
-	// Return immediate response
-	data := map[string]interface{}{
-		"message":   "Job dispatched!",
-		"job_id":    jobID,
-		"timestamp": utils.NowFormatted(),
-	}
-
-	return response.Success(c, data)
 }
 
 // generateSecurityEventsJobId for unique jobid
@@ -116,10 +142,20 @@ func generateSecurityEventsJobId(payload *seEntities.SecurityEventsRequest) stri
 
 // ListSecurityEvents handles paginated listing of security events
 func ListSecurityEvents(c echo.Context) error {
-	var req v2oss.PaginationRequest
+	allowPII := hasPIIAccess(c)
+	return MakeListHandler(seEntities.GetQueryConfig(), func(record map[string]interface{}) interface{} {
+		resp := seEntities.MapToSecurityEventsResponse(record)
+		seEntities.Redact(&resp, allowPII)
+		return resp
+	})(c)
+}
+
+// ExportSecurityEvents streams security events matching list filters as CSV
+func ExportSecurityEvents(c echo.Context) error {
+	var req v2oss.ExportRequest
 
 	// set logger scope
-	log := logger.WithScope("ListSecurityEvents")
+	log := logger.WithScope("ExportSecurityEvents")
 
 	// Bind JSON into struct
 	if err := c.Bind(&req); err != nil {
@@ -131,6 +167,40 @@ func ListSecurityEvents(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
 	}
 
+	allowPII := hasPIIAccess(c)
+	return streamCSVExport(c, log, "security_events", seEntities.GetQueryConfig(), &req,
+		entity.CSVHeader(seEntities.SecurityEventsResponse{}),
+		func(record map[string]interface{}) []string {
+			resp := seEntities.MapToSecurityEventsResponse(record)
+			seEntities.Redact(&resp, allowPII)
+			return entity.CSVRow(resp)
+		})
+}
+
+// TimeSeriesSecurityEvents handles security events counts per time window (dashboards)
+func TimeSeriesSecurityEvents(c echo.Context) error {
+	var req v2oss.TimeSeriesRequest
+
+	// set logger scope
+	log := logger.WithScope("TimeSeriesSecurityEvents")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Validate window, group by tags & filters before querying
+	qb := v2oss.NewQueryBuilder(seEntities.GetQueryConfig())
+	seriesReq := req.ToPaginationRequest()
+	if err := qb.ValidateTimeSeries(seriesReq, req.Window, req.GroupBy); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
 	// Get InfluxDB client and configuration
 	client := influxdb.GetCurrentClient()
 	if client == nil {
@@ -145,37 +215,13 @@ func ListSecurityEvents(c echo.Context) error {
 		return response.FailWithCode(c, constants.CodeInfluxDBError)
 	}
 
-	// Get query config for security events
-	queryConfig := seEntities.GetQueryConfig()
-
-	// Create query builder
-	qb := v2oss.NewQueryBuilder(queryConfig)
-
-	// Get total count using client and bucket
-	totalRecords := qb.GetTotalCount(&req, v2ossClient)
-
-	// Execute data query and get results using client and bucket
-	results, err := qb.ExecuteDataQuery(&req, v2ossClient)
+	buckets, err := qb.ExecuteTimeSeries(seriesReq, req.Window, req.GroupBy, v2ossClient)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to execute data query")
+		log.Error().Err(err).Str("window", req.Window).Msg("Failed to execute time-series query")
 		return response.FailWithCode(c, constants.CodeInfluxDBError)
 	}
 
-	// Convert raw results to structured response
-	var records []seEntities.SecurityEventsResponse
-	for _, record := range results {
-		records = append(records, seEntities.MapToSecurityEventsResponse(record))
-	}
-
-	// Get cursor-based pagination info
-	paginationInfo := qb.GetPaginationInfo(&req, results, totalRecords)
-
-	// Build response
-	responseData := v2oss.PaginationResponse{
-		Data:       records,
-		Pagination: paginationInfo,
-	}
-	return response.Success(c, responseData)
+	return response.Success(c, buckets)
 }
 
 func DetailSecurityEvents(c echo.Context) error {
@@ -231,6 +277,7 @@ func DetailSecurityEvents(c echo.Context) error {
 
 	// Convert raw record to structured response
 	structuredResponse := seEntities.MapToSecurityEventsResponse(record)
+	seEntities.Redact(&structuredResponse, hasPIIAccess(c))
 
 	// Success
 	return response.Success(c, structuredResponse)
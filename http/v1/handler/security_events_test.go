@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/benedict-erwin/insight-collector/config"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	seJobs "github.com/benedict-erwin/insight-collector/internal/jobs/security_events"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+)
+
+// useV2OSSConfig loads minimal config so entity ToPoint builds a v2-oss point (exposes tags & fields)
+func useV2OSSConfig(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".config"), []byte(`{"influxdb":{"version":"v2-oss"}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Chdir(dir)
+	if err := config.Init(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+}
+
+func newTestSecurityEventsRequest() seEntities.SecurityEventsRequest {
+	return seEntities.SecurityEventsRequest{
+		UserID:          "user-1",
+		IdentifierType:  "email",
+		IdentifierValue: "user-1@example.com",
+		EventType:       "failed_login",
+		Severity:        "medium",
+		AuthStage:       "password",
+		ActionTaken:     "logged",
+		Method:          "POST",
+		IPAddress:       "203.0.113.10",
+		UserAgent:       "Mozilla/5.0",
+		Endpoint:        "/api/v1/login",
+		RiskScore:       0.7,
+		Timestamp:       time.Date(2025, 8, 6, 12, 30, 0, 0, time.UTC),
+	}
+}
+
+// Regression: identifier type was copied into identifier value on dispatch
+func TestSecurityEventsPayloadKeepsIdentifierValue(t *testing.T) {
+	req := newTestSecurityEventsRequest()
+	payload := newSecurityEventsPayload("se_test", &req)
+
+	if payload.TaskType != seJobs.TypeSecurityEventsLogging {
+		t.Errorf("Expected TaskType=%s, got %s", seJobs.TypeSecurityEventsLogging, payload.TaskType)
+	}
+
+	// Same wire format as queued task payload
+	data, err := json.Marshal(payload.Data)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	var decoded seEntities.SecurityEventsRequest
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+
+	// Entity written as point by job processor
+	se := seJobs.NewSecurityEvents(decoded)
+	if se.IdentifierType != "email" {
+		t.Errorf("Expected IdentifierType=email, got %q", se.IdentifierType)
+	}
+	if se.IdentifierValue != "user-1@example.com" {
+		t.Errorf("Expected IdentifierValue=user-1@example.com, got %q", se.IdentifierValue)
+	}
+
+	// Point actually written to InfluxDB
+	useV2OSSConfig(t)
+	point, ok := se.ToPoint().(influxdb.Point)
+	if !ok {
+		t.Fatalf("Expected influxdb.Point, got %T", se.ToPoint())
+	}
+	if point.GetMeasurement() != "security_events" {
+		t.Errorf("Expected measurement=security_events, got %q", point.GetMeasurement())
+	}
+	if !point.GetTime().Equal(req.Timestamp) {
+		t.Errorf("Expected time=%v, got %v", req.Timestamp, point.GetTime())
+	}
+
+	expectedTags := map[string]string{
+		"event_type":   "failed_login",
+		"severity":     "medium",
+		"action_taken": "logged",
+		"channel":      "-", // Empty tag values replaced
+	}
+	tags := point.GetTags()
+	for key, want := range expectedTags {
+		if tags[key] != want {
+			t.Errorf("Expected tag %s=%q, got %q", key, want, tags[key])
+		}
+	}
+	if _, exists := tags["identifier_value"]; exists {
+		t.Error("identifier_value must be a field, not a tag (high cardinality)")
+	}
+
+	expectedFields := map[string]interface{}{
+		"identifier_type":  "email",
+		"identifier_value": "user-1@example.com",
+		"user_id":          "user-1",
+		"auth_stage":       "password",
+		"method":           "POST",
+		"endpoint":         "/api/v1/login",
+		"risk_score":       0.7,
+	}
+	fields := point.GetFields()
+	for key, want := range expectedFields {
+		if fields[key] != want {
+			t.Errorf("Expected field %s=%v, got %v", key, want, fields[key])
+		}
+	}
+}
+
+func TestSecurityEventsRequestIdentifierValueRequiredWithType(t *testing.T) {
+	v := validator.New()
+
+	req := newTestSecurityEventsRequest()
+	if err := v.Struct(req); err != nil {
+		t.Fatalf("Expected valid request, got %v", err)
+	}
+
+	req.IdentifierValue = ""
+	if err := v.Struct(req); err == nil {
+		t.Error("Expected validation error when identifier_type set without identifier_value")
+	}
+
+	req.IdentifierType = ""
+	if err := v.Struct(req); err != nil {
+		t.Errorf("Expected valid request without identifier, got %v", err)
+	}
+}
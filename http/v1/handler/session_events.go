@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	sesEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	sesJobs "github.com/benedict-erwin/insight-collector/internal/jobs/session_events"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// SaveSessionEvents handles saving data for session events
+func SaveSessionEvents(c echo.Context) error {
+	var req sesEntities.SessionEventsRequest
+
+	// set logger scope
+	log := logger.WithScope("SaveSessionEvents")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Default trace & request IDs from HTTP request when client omitted them, ingest logs carry stored trace ID
+	defaultCorrelationIDs(c, &req.TraceID, nil)
+	log = log.WithTraceID(req.TraceID)
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Generate JobId
+	jobID := generateSessionEventsJobId(&req)
+
+	// Job Payload
+	payload := newSessionEventsPayload(jobID, &req)
+
+	// Dispatch the job
+	err := asynq.DispatchJob(&payload)
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
+		log.Error().
+			Err(err).
+			Str("session_id", req.SessionID).
+			Str("job_id", jobID).
+			Msg("Failed to enqueue job")
+
+		return response.FailWithCodeAndMessage(c, constants.CodeInternalError, "Failed to dispatch job")
+	}
+
+	// Return immediate response
+	data := map[string]interface{}{
+		"message":   "Job dispatched!",
+		"job_id":    jobID,
+		"timestamp": utils.NowFormatted(),
+	}
+
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
+	return response.Success(c, data)
+}
+
+// newSessionEventsPayload builds job payload with explicit field mapping from request
+func newSessionEventsPayload(jobID string, req *sesEntities.SessionEventsRequest) asynq.Payload {
+	return asynq.Payload{
+		TaskId:   jobID,
+		TaskType: sesJobs.TypeSessionEventsLogging,
+		Data: sesEntities.SessionEventsRequest{
+			SessionID:         req.SessionID,
+			UserID:            req.UserID,
+			EventType:         req.EventType,
+			TerminationReason: req.TerminationReason,
+			Channel:           req.Channel,
+			StartTime:         req.StartTime,
+			EndTime:           req.EndTime,
+			DurationSeconds:   req.DurationSeconds,
+			DeviceFingerprint: req.DeviceFingerprint,
+			IPStart:           req.IPStart,
+			IPEnd:             req.IPEnd,
+			UserAgent:         req.UserAgent,
+			AppVersion:        req.AppVersion,
+			TraceID:           req.TraceID,
+			Details:           req.Details,
+			Timestamp:         req.Timestamp,
+		},
+	}
+}
+
+// generateSessionEventsJobId for unique jobid
+func generateSessionEventsJobId(payload *sesEntities.SessionEventsRequest) string {
+	// Concat to make some unique lifecycle event
+	uniqueId := fmt.Sprintf("%s-%s-%s-%d",
+		payload.UserID,
+		payload.SessionID,
+		payload.EventType,
+		payload.Timestamp.Unix(),
+	)
+
+	hash := md5.Sum([]byte(uniqueId))
+	return fmt.Sprintf("ss_%x", hash[:8])
+}
+
+// ListSessionEvents handles paginated listing of session events
+func ListSessionEvents(c echo.Context) error {
+	var req v2oss.PaginationRequest
+
+	// set logger scope
+	log := logger.WithScope("ListSessionEvents")
+
+	// Bind JSON into struct
+	if err := c.Bind(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
+	}
+
+	// Validate using echo.Validator (with struct tags)
+	if err := c.Validate(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Debug output requires debug:query permission
+	if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+		return response.FailWithCode(c, constants.CodeInsufficientPerms)
+	}
+
+	// Get InfluxDB client and configuration
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Warn().Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Warn().Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Get query config for session events
+	queryConfig := sesEntities.GetQueryConfig()
+
+	// Create query builder
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+
+	// Validate request before any query is built (count query runs first)
+	if err := qb.ValidateRequest(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Get total count using client and bucket
+	totalRecords := qb.GetTotalCount(&req, v2ossClient)
+
+	// Execute data query and get results using client and bucket
+	results, err := qb.ExecuteDataQuery(&req, v2ossClient)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to execute data query")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Convert raw results to structured response
+	var records []sesEntities.SessionEventsResponse
+	for _, record := range results {
+		records = append(records, sesEntities.MapToSessionEventsResponse(record))
+	}
+
+	// Get cursor-based pagination info
+	paginationInfo := qb.GetPaginationInfo(&req, results, totalRecords)
+
+	// Build response
+	responseData := v2oss.PaginationResponse{
+		Data:       records,
+		Pagination: paginationInfo,
+	}
+
+	// Include generated Flux queries when debug requested
+	if req.Debug {
+		queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build debug query string")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+		responseData.Debug = queryDebug
+	}
+
+	return response.Success(c, responseData)
+}
+
+func DetailSessionEvents(c echo.Context) error {
+	// Get encoded ID from path parameter
+	encodedID := c.Param("id")
+
+	// set logger scope
+	log := logger.WithScope("DetailSessionEvents")
+
+	// Decode timestamp and session_id
+	// time RFC3339 format: "2025-08-06T12:30:00Z"
+	// session_id e.g., "sess-1234567890-abcdef12"
+	timestamp, sessionID, err := utils.ParseRecordID(encodedID)
+	if err != nil {
+		log.Error().Err(err).Str("encoded_id", encodedID).Msg("Invalid record ID format")
+		return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, "Invalid record ID format")
+	}
+
+	// Validate timestamp format
+	if _, parseErr := time.Parse(time.RFC3339, timestamp); parseErr != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeUnprocessable, "Invalid timestamp format")
+	}
+
+	// Get InfluxDB client and configuration
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Error().Str("session_id", sessionID).Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Error().Str("session_id", sessionID).Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Get query config for session events
+	queryConfig := sesEntities.GetQueryConfig()
+
+	// Create query builder
+	qb := v2oss.NewQueryBuilder(queryConfig)
+
+	// Get record by timestamp & session_id
+	record, err := qb.GetByTimestampAndUniqueID(timestamp, "session_id", sessionID, v2ossClient)
+	if err != nil {
+		log.Error().Err(err).
+			Str("timestamp", timestamp).
+			Str("session_id", sessionID).
+			Msg("Failed to retrieve session events")
+		return response.FailWithCodeAndMessage(c, constants.CodeNotFound, "Record not found")
+	}
+
+	// Convert raw record to structured response
+	structuredResponse := sesEntities.MapToSessionEventsResponse(record)
+
+	// Success
+	return response.Success(c, structuredResponse)
+}
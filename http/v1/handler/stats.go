@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/internal/services/stats"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/labstack/echo/v4"
+)
+
+// StatsSummary returns dashboard totals (counts, top statuses & countries, bot ratio) over range preset
+func StatsSummary(c echo.Context) error {
+	rangePreset := c.QueryParam("range")
+
+	// set logger scope
+	log := logger.WithScope("StatsSummary")
+
+	if err := stats.ValidateRange(rangePreset); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
+
+	// Get InfluxDB client and configuration
+	client := influxdb.GetCurrentClient()
+	if client == nil {
+		log.Warn().Msg("InfluxDB client not initialized")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	// Type assert to v2-oss client (assuming v2-oss is default)
+	v2ossClient, ok := client.(*v2oss.Client)
+	if !ok {
+		log.Warn().Msg("Invalid InfluxDB client type")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	summary, err := stats.GetSummary(rangePreset, v2ossClient)
+	if err != nil {
+		log.Error().Err(err).Str("range", rangePreset).Msg("Failed to build stats summary")
+		return response.FailWithCode(c, constants.CodeInfluxDBError)
+	}
+
+	return response.Success(c, summary)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
+	"golang.org/x/net/websocket"
+)
+
+// StreamEvents upgrades to WebSocket and pushes newly ingested events matching optional filters.
+// Query params: type (entity names, comma separated), risk_level (comma separated).
+func StreamEvents(c echo.Context) error {
+	// Setup logger scope
+	log := logger.WithScope("StreamEvents")
+
+	// Reject cross-site upgrades before any Redis work (browsers always send Origin)
+	if err := checkStreamOrigin(c.Request()); err != nil {
+		log.Warn().
+			Err(err).
+			Str("origin", c.Request().Header.Get("Origin")).
+			Str("ip", c.RealIP()).
+			Msg("Event stream origin rejected")
+		return response.FailWithCode(c, constants.CodeForbidden)
+	}
+
+	filter := stream.ParseFilter(c.QueryParam("type"), c.QueryParam("risk_level"))
+	clientID := middleware.GetClientID(c)
+	allowPII := hasPIIAccess(c) // Per connection, events are redacted like list & detail responses
+
+	// Subscribe before upgrade so Redis errors can still be returned as JSON
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pubsub, err := stream.Subscribe(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to subscribe to event stream")
+		return response.FailWithCode(c, constants.CodeRedisUnavailable)
+	}
+	defer func() { _ = pubsub.Close() }()
+
+	server := websocket.Server{
+		// Origin already validated above, checked again so handshake never accepts foreign origins
+		Handshake: func(_ *websocket.Config, req *http.Request) error { return checkStreamOrigin(req) },
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+
+			buffer := stream.NewBuffer(stream.DefaultBufferSize)
+
+			// Detect client disconnect (incoming messages are ignored)
+			go func() {
+				defer cancel()
+				var msg string
+				for {
+					if err := websocket.Message.Receive(ws, &msg); err != nil {
+						return
+					}
+				}
+			}()
+
+			// Forward matching events from Redis into send buffer
+			go func() {
+				defer cancel()
+				messages := pubsub.Channel()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case msg, ok := <-messages:
+						if !ok {
+							return
+						}
+						var event stream.Event
+						if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+							continue
+						}
+						if !filter.Matches(event) {
+							continue
+						}
+						payload := []byte(msg.Payload)
+						if !allowPII {
+							if payload, err = redactStreamEvent(event.Type, payload); err != nil {
+								continue // Never forward unredacted event
+							}
+						}
+						buffer.Push(payload)
+					}
+				}
+			}()
+
+			log.Info().
+				Str("client_id", clientID).
+				Str("ip", c.RealIP()).
+				Msg("Event stream connected")
+
+			// Write buffered events until client disconnects
+			sent := 0
+			defer func() {
+				log.Info().
+					Str("client_id", clientID).
+					Int("sent", sent).
+					Int64("dropped", buffer.Dropped()).
+					Msg("Event stream disconnected")
+			}()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case payload := <-buffer.C():
+					if err := websocket.Message.Send(ws, string(payload)); err != nil {
+						cancel()
+						return
+					}
+					sent++
+				}
+			}
+		},
+	}
+
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// streamPIIMasks holds PII masks of event types redacted for clients without read:pii
+var streamPIIMasks = map[string]map[string]func(string) string{
+	"security_events":    seEntities.PIIMasks,
+	"transaction_events": teEntities.PIIMasks,
+}
+
+// redactStreamEvent masks PII fields in data of published event, payload of other event types is returned unchanged
+func redactStreamEvent(eventType string, payload []byte) ([]byte, error) {
+	masks, ok := streamPIIMasks[eventType]
+	if !ok {
+		return payload, nil
+	}
+
+	// Numbers kept as json.Number so re-encoding doesn't lose precision
+	var event map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return nil, err
+	}
+	if data, ok := event["data"].(map[string]interface{}); ok {
+		entity.RedactRecord(data, masks)
+	}
+	return json.Marshal(event)
+}
+
+// errStreamOriginNotAllowed is returned when WebSocket Origin is neither same host nor in cors.allow_origins
+var errStreamOriginNotAllowed = errors.New("origin not allowed")
+
+// checkStreamOrigin guards against cross-site WebSocket hijacking.
+// Requests without Origin come from non-browser clients and are allowed; browser origins
+// must match the request host or the CORS allowlist.
+func checkStreamOrigin(req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(origin)
+	if err == nil && parsed.Host != "" && strings.EqualFold(parsed.Host, req.Host) {
+		return nil
+	}
+	if middleware.IsOriginAllowed(origin) {
+		return nil
+	}
+	return errStreamOriginNotAllowed
+}
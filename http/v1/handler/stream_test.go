@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckStreamOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		origin  string
+		wantErr bool
+	}{
+		{"non-browser client without origin", "collector.example.com", "", false},
+		{"same host origin", "collector.example.com", "https://collector.example.com", false},
+		{"same host origin with port", "localhost:8080", "http://localhost:8080", false},
+		{"cross-site origin", "collector.example.com", "https://evil.com", true},
+		{"lookalike host", "collector.example.com", "https://collector.example.com.evil.com", true},
+		{"malformed origin", "collector.example.com", "://bad", true},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/v1/stream", nil)
+		req.Host = tt.host
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		if err := checkStreamOrigin(req); (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkStreamOrigin error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRedactStreamEvent(t *testing.T) {
+	payload := []byte(`{"type":"transaction_events","risk_level":"high","time":"2026-01-02T03:04:05Z","data":{"ip_address":"203.0.113.42","destination_account":"1234567890","user_agent":"Mozilla/5.0 (X11; Linux x86_64) Chrome/120.0","amount":12345678901234567}}`)
+
+	redacted, err := redactStreamEvent("transaction_events", payload)
+	if err != nil {
+		t.Fatalf("redactStreamEvent error = %v", err)
+	}
+
+	var event struct {
+		Type string                 `json:"type"`
+		Data map[string]interface{} `json:"data"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(redacted))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		t.Fatalf("redacted payload is not valid JSON: %v", err)
+	}
+	if event.Type != "transaction_events" {
+		t.Errorf("type = %q, want transaction_events", event.Type)
+	}
+	raw := map[string]string{
+		"ip_address":          "203.0.113.42",
+		"destination_account": "1234567890",
+		"user_agent":          "Mozilla/5.0 (X11; Linux x86_64) Chrome/120.0",
+	}
+	for field, value := range raw {
+		if got := event.Data[field]; got == value {
+			t.Errorf("%s forwarded unredacted: %v", field, got)
+		}
+	}
+	if got := event.Data["amount"]; got != json.Number("12345678901234567") {
+		t.Errorf("amount = %v, want precision preserved", got)
+	}
+
+	other := []byte(`{"type":"callback_logs","data":{"ip_address":"203.0.113.42"}}`)
+	if got, err := redactStreamEvent("callback_logs", other); err != nil || !bytes.Equal(got, other) {
+		t.Errorf("callback_logs payload changed: %s (err %v)", got, err)
+	}
+
+	if _, err := redactStreamEvent("security_events", []byte("not json")); err == nil {
+		t.Error("expected error for malformed security_events payload")
+	}
+}
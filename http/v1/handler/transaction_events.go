@@ -2,15 +2,18 @@ package handler
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
 	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
 	teJobs "github.com/benedict-erwin/insight-collector/internal/jobs/transaction_events"
 	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
@@ -30,6 +33,20 @@ func SaveTransactionEvents(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
 	}
 
+	// Default trace & request IDs from HTTP request when client omitted them, ingest logs carry stored trace ID
+	defaultCorrelationIDs(c, &req.TraceID, &req.RequestID)
+	log = log.WithTraceID(req.TraceID)
+
+	// Normalize enum fields (trim & canonical case)
+	req.Normalize()
+
+	// Default to client IP resolved behind trusted proxies when caller (e.g. browser) doesn't report it
+	if req.IPAddress == "" {
+		if ip := middleware.ClientIP(c, middleware.TrustedProxies()); ip != nil {
+			req.IPAddress = ip.String()
+		}
+	}
+
 	// Validate using echo.Validator (with struct tags)
 	if err := c.Validate(&req); err != nil {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
@@ -81,7 +98,8 @@ func SaveTransactionEvents(c echo.Context) error {
 
 	// Dispatch the job
 	err := asynq.DispatchJob(&payload)
-	if err != nil {
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
 		log.Error().
 			Err(err).
 			Str("request_id", req.RequestID).
@@ -98,6 +116,12 @@ func SaveTransactionEvents(c echo.Context) error {
 		"timestamp": utils.NowFormatted(),
 	}
 
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
 	return response.Success(c, data)
 }
 
@@ -137,6 +161,11 @@ func ListTransactionEvents(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
 	}
 
+	// Debug output requires debug:query permission
+	if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+		return response.FailWithCode(c, constants.CodeInsufficientPerms)
+	}
+
 	// Get InfluxDB client and configuration
 	client := influxdb.GetCurrentClient()
 	if client == nil {
@@ -155,7 +184,12 @@ func ListTransactionEvents(c echo.Context) error {
 	queryConfig := teEntities.GetQueryConfig()
 
 	// Create query builder
-	qb := v2oss.NewQueryBuilder(queryConfig)
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+
+	// Validate request before any query is built (count query runs first)
+	if err := qb.ValidateRequest(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
 
 	// Get total count using client and bucket
 	totalRecords := qb.GetTotalCount(&req, v2ossClient)
@@ -169,8 +203,11 @@ func ListTransactionEvents(c echo.Context) error {
 
 	// Convert raw results to structured response
 	var records []teEntities.TransactionEventsResponse
+	allowPII := hasPIIAccess(c)
 	for _, record := range results {
-		records = append(records, teEntities.MapToTransactionEventsResponse(record))
+		resp := teEntities.MapToTransactionEventsResponse(record)
+		teEntities.Redact(&resp, allowPII)
+		records = append(records, resp)
 	}
 
 	// Get cursor-based pagination info
@@ -181,6 +218,17 @@ func ListTransactionEvents(c echo.Context) error {
 		Data:       records,
 		Pagination: paginationInfo,
 	}
+
+	// Include generated Flux queries when debug requested
+	if req.Debug {
+		queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build debug query string")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+		responseData.Debug = queryDebug
+	}
+
 	return response.Success(c, responseData)
 }
 
@@ -237,6 +285,7 @@ func DetailTransactionEvents(c echo.Context) error {
 
 	// Convert raw record to structured response
 	structuredResponse := teEntities.MapToTransactionEventsResponse(record)
+	teEntities.Redact(&structuredResponse, hasPIIAccess(c))
 
 	// Success
 	return response.Success(c, structuredResponse)
@@ -2,15 +2,18 @@ package handler
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
 	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
 	uaJob "github.com/benedict-erwin/insight-collector/internal/jobs/user_activities"
 	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
@@ -30,6 +33,17 @@ func SaveUserActivities(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeInvalidJSON, err.Error())
 	}
 
+	// Default trace & request IDs from HTTP request when client omitted them, ingest logs carry stored trace ID
+	defaultCorrelationIDs(c, &req.TraceID, &req.RequestID)
+	log = log.WithTraceID(req.TraceID)
+
+	// Default to client IP resolved behind trusted proxies when caller (e.g. browser) doesn't report it
+	if req.IPAddress == "" {
+		if ip := middleware.ClientIP(c, middleware.TrustedProxies()); ip != nil {
+			req.IPAddress = ip.String()
+		}
+	}
+
 	// Validate using echo.Validator (with struct tags)
 	if err := c.Validate(&req); err != nil {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
@@ -71,7 +85,8 @@ func SaveUserActivities(c echo.Context) error {
 
 	// Dispatch the job
 	err := asynq.DispatchJob(&payload)
-	if err != nil {
+	duplicate := errors.Is(err, asynq.ErrDuplicateJob)
+	if err != nil && !duplicate {
 		log.Error().
 			Err(err).
 			Str("request_id", req.RequestID).
@@ -88,6 +103,12 @@ func SaveUserActivities(c echo.Context) error {
 		"timestamp": utils.NowFormatted(),
 	}
 
+	// Retried request within dedup window: echo original job id
+	if duplicate {
+		data["message"] = "Job already dispatched"
+		data["duplicate"] = true
+	}
+
 	return response.Success(c, data)
 }
 
@@ -127,6 +148,11 @@ func ListUserActivities(c echo.Context) error {
 		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
 	}
 
+	// Debug output requires debug:query permission
+	if req.Debug && !middleware.HasRequestPermission(c, auth.ActionDebug+":query") {
+		return response.FailWithCode(c, constants.CodeInsufficientPerms)
+	}
+
 	// Get InfluxDB client and configuration
 	client := influxdb.GetCurrentClient()
 	if client == nil {
@@ -145,7 +171,12 @@ func ListUserActivities(c echo.Context) error {
 	queryConfig := uaEntities.GetQueryConfig()
 
 	// Create query builder
-	qb := v2oss.NewQueryBuilder(queryConfig)
+	qb := newListQueryBuilder(c, log, queryConfig, req.Filters)
+
+	// Validate request before any query is built (count query runs first)
+	if err := qb.ValidateRequest(&req); err != nil {
+		return response.FailWithCodeAndMessage(c, constants.CodeValidationFailed, err.Error())
+	}
 
 	// Get total count using client and bucket
 	totalRecords := qb.GetTotalCount(&req, v2ossClient)
@@ -171,6 +202,17 @@ func ListUserActivities(c echo.Context) error {
 		Data:       records,
 		Pagination: paginationInfo,
 	}
+
+	// Include generated Flux queries when debug requested
+	if req.Debug {
+		queryDebug, err := qb.BuildQueryString(&req, v2ossClient)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to build debug query string")
+			return response.FailWithCode(c, constants.CodeInfluxDBError)
+		}
+		responseData.Debug = queryDebug
+	}
+
 	return response.Success(c, responseData)
 }
 
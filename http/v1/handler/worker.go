@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// WorkerMetrics returns live per-queue worker metrics from asynq inspector
+func WorkerMetrics(c echo.Context) error {
+	// Setup logger scope
+	log := logger.WithScope("WorkerMetrics")
+
+	metrics, err := asynq.GetQueueMetrics()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get queue metrics")
+		return response.FailWithCode(c, constants.CodeRedisError)
+	}
+
+	data := map[string]interface{}{
+		"worker_running": asynq.IsServerRunning(), // Queues only drain when worker is running
+		"queues":         metrics,
+		"timestamp":      utils.NowFormatted(),
+	}
+
+	// Enqueue → write latency per task type (omitted when unavailable)
+	if histograms, err := loadIngestLatency(); err != nil {
+		log.Warn().Err(err).Msg("Failed to get ingest latency histogram")
+	} else {
+		data["ingest_latency"] = histograms
+	}
+
+	return response.Success(c, data)
+}
+
+// loadIngestLatency reads ingest latency histograms flushed by workers
+func loadIngestLatency() (map[string]latency.Histogram, error) {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	return latency.Load(client)
+}
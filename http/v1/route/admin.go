@@ -0,0 +1,17 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// init registers v1 admin routes with the registry
+func init() {
+	registry.Register("v1", func(g *echo.Group) {
+		admin := g.Group("/admin")
+		admin.POST("/erase", handler.EraseUserData, middleware.MultiAuthMiddleware(auth.ActionAdmin+":erase")) // GDPR erasure
+	})
+}
@@ -0,0 +1,11 @@
+package route
+
+import (
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// init records signature/JWT failures through security events pipeline (enabled by auth.audit_failures)
+func init() {
+	auth.SetFailureAuditor(handler.AuditAuthFailure)
+}
@@ -2,6 +2,7 @@ package route
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/http/registry"
 	"github.com/benedict-erwin/insight-collector/http/v1/handler"
 )
@@ -10,8 +11,8 @@ func init() {
 	// Register user activities routes for v1
 	registry.Register("v1", func(g *echo.Group) {
 		ua := g.Group("/callback-logs")
-		ua.POST("/insert", handler.SaveCallbackLogs)
-		ua.POST("/list", handler.ListCallbackLogs)
+		ua.POST("/insert", handler.SaveCallbackLogs, middleware.BodyLimitMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ua.POST("/list", handler.ListCallbackLogs, middleware.GzipResponseMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
 		ua.GET("/:id", handler.DetailCallbackLogs)
 	})
 }
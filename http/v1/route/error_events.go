@@ -0,0 +1,18 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+)
+
+func init() {
+	// Register error events routes for v1
+	registry.Register("v1", func(g *echo.Group) {
+		ee := g.Group("/error-events")
+		ee.POST("/insert", handler.SaveErrorEvents, middleware.BodyLimitMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ee.POST("/list", handler.ListErrorEvents, middleware.GzipResponseMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ee.GET("/:id", handler.DetailErrorEvents)
+	})
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// init registers v1 geo lookup routes with the registry
+func init() {
+	registry.Register("v1", func(g *echo.Group) {
+		geo := g.Group("/geo")
+		geo.Use(middleware.BatchBodyLimitMiddleware(), middleware.MultiAuthMiddleware(auth.ActionRead + ":geo"))
+		geo.GET("/lookup", handler.LookupGeo)       // Single IP (?ip=...)
+		geo.POST("/lookup", handler.LookupGeoBatch) // Batch {"ips": [...]}
+	})
+}
@@ -0,0 +1,15 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+)
+
+// init registers v1 JWKS route with the registry
+func init() {
+	registry.Register("v1", func(g *echo.Group) {
+		// public
+		g.GET("/.well-known/jwks.json", handler.JWKS)
+	})
+}
@@ -0,0 +1,196 @@
+package route
+
+import (
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	exampleEntity "github.com/benedict-erwin/insight-collector/internal/entities/example"
+	geoEntities "github.com/benedict-erwin/insight-collector/internal/entities/geo"
+	pingEntity "github.com/benedict-erwin/insight-collector/internal/entities/ping"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	sesEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	"github.com/benedict-erwin/insight-collector/internal/services/erase"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/openapi"
+)
+
+// jobDispatchedResponse documents insert endpoints response data
+type jobDispatchedResponse struct {
+	Message   string `json:"message"`
+	JobID     string `json:"job_id"`
+	Timestamp string `json:"timestamp"`
+}
+
+// pingResponse documents ping endpoints response data
+type pingResponse struct {
+	Responses string `json:"responses"`
+}
+
+// eraseResponse documents erase endpoint response data
+type eraseResponse struct {
+	MeasurementsProcessed int                       `json:"measurements_processed"`
+	PointsDeleted         int                       `json:"points_deleted"`
+	Measurements          []erase.MeasurementResult `json:"measurements"`
+	Complete              bool                      `json:"complete"`
+	Timestamp             string                    `json:"timestamp"`
+}
+
+// geoLookupResponse documents single geo lookup response data
+type geoLookupResponse struct {
+	Result    geoEntities.LookupResult `json:"result"`
+	Timestamp string                   `json:"timestamp"`
+}
+
+// geoBatchLookupResponse documents batch geo lookup response data
+type geoBatchLookupResponse struct {
+	Results   []geoEntities.LookupResult `json:"results"`
+	Timestamp string                     `json:"timestamp"`
+}
+
+// init registers OpenAPI documentation for v1 handlers (used by `openapi generate`)
+func init() {
+	// Entity endpoints (insert, list, detail)
+	registerEntityDocs("transaction-events", "transaction event", "transaction events",
+		handler.SaveTransactionEvents, handler.ListTransactionEvents, handler.DetailTransactionEvents,
+		teEntities.TransactionEventsRequest{}, teEntities.TransactionEventsResponse{})
+	registerEntityDocs("error-events", "error event", "error events",
+		handler.SaveErrorEvents, handler.ListErrorEvents, handler.DetailErrorEvents,
+		eeEntities.ErrorEventsRequest{}, eeEntities.ErrorEventsResponse{})
+	registerEntityDocs("security-events", "security event", "security events",
+		handler.SaveSecurityEvents, handler.ListSecurityEvents, handler.DetailSecurityEvents,
+		seEntities.SecurityEventsRequest{}, seEntities.SecurityEventsResponse{})
+	registerEntityDocs("session-events", "session event", "session events",
+		handler.SaveSessionEvents, handler.ListSessionEvents, handler.DetailSessionEvents,
+		sesEntities.SessionEventsRequest{}, sesEntities.SessionEventsResponse{})
+	registerEntityDocs("callback-logs", "callback log", "callback logs",
+		handler.SaveCallbackLogs, handler.ListCallbackLogs, handler.DetailCallbackLogs,
+		clEntities.CallbackLogsRequest{}, clEntities.CallbackLogsResponse{})
+	registerEntityDocs("user-activities", "user activity", "user activities",
+		handler.SaveUserActivities, handler.ListUserActivities, handler.DetailUserActivities,
+		uaEntities.UserActivitiesRequest{}, uaEntities.UserActivitiesResponse{})
+
+	openapi.Register(handler.ExportSecurityEvents, openapi.Doc{
+		Summary:     "Export security events as CSV",
+		Description: "Streams all matching rows, date range is limited to 90 days.",
+		Tags:        []string{"security-events"},
+		Auth:        openapi.AuthRequired,
+		Permission:  auth.ActionExport + ":security_events",
+		Request:     v2oss.ExportRequest{},
+		ContentType: "text/csv",
+	})
+
+	openapi.Register(handler.TimeSeriesSecurityEvents, openapi.Doc{
+		Summary:     "Security events time-series",
+		Description: "Counts events per window (Flux duration, e.g. 1h), one series per group_by tag combination.",
+		Tags:        []string{"security-events"},
+		Auth:        openapi.AuthOptional,
+		Request:     v2oss.TimeSeriesRequest{},
+		Response:    []v2oss.TimeBucket{},
+	})
+
+	// Live tail
+	openapi.Register(handler.StreamEvents, openapi.Doc{
+		Summary:     "Live event stream (WebSocket)",
+		Description: "Upgrades to WebSocket and pushes newly ingested events.",
+		Tags:        []string{"stream"},
+		Auth:        openapi.AuthRequired,
+		Permission:  auth.ActionRead + ":stream",
+		Query: map[string]string{
+			"type":       "Comma separated entity names (e.g. transaction_events,security_events)",
+			"risk_level": "Comma separated risk levels (low, medium, high, critical)",
+		},
+	})
+
+	// Health & worker
+	openapi.Register(handler.HealthLive, openapi.Doc{Summary: "Liveness probe", Tags: []string{"health"}})
+	openapi.Register(handler.HealthReady, openapi.Doc{Summary: "Readiness probe", Tags: []string{"health"}})
+	openapi.Register(handler.HealthDetailed, openapi.Doc{
+		Summary:    "Detailed health check",
+		Tags:       []string{"health"},
+		Auth:       openapi.AuthRequired,
+		Permission: auth.ActionRead + ":health",
+	})
+	openapi.Register(handler.WorkerMetrics, openapi.Doc{
+		Summary:    "Live worker queue metrics",
+		Tags:       []string{"worker"},
+		Auth:       openapi.AuthRequired,
+		Permission: auth.ActionRead + ":worker",
+	})
+	openapi.Register(handler.LookupGeo, openapi.Doc{
+		Summary:     "Geo lookup of IP address",
+		Description: "Returns geo and ASN info of `ip` query parameter, `anon` section only when Anonymous IP database is loaded.",
+		Tags:        []string{"geo"},
+		Auth:        openapi.AuthRequired,
+		Permission:  auth.ActionRead + ":geo",
+		Response:    geoLookupResponse{},
+	})
+	openapi.Register(handler.LookupGeoBatch, openapi.Doc{
+		Summary:     "Batch geo lookup of IP addresses",
+		Description: "Looks up to 100 IP addresses, whole batch is rejected when any IP is malformed.",
+		Tags:        []string{"geo"},
+		Auth:        openapi.AuthRequired,
+		Permission:  auth.ActionRead + ":geo",
+		Request:     geoEntities.BatchLookupRequest{},
+		Response:    geoBatchLookupResponse{},
+	})
+	openapi.Register(handler.JWKS, openapi.Doc{Summary: "JSON Web Key Set", Tags: []string{"auth"}})
+
+	// Admin
+	openapi.Register(handler.EraseUserData, openapi.Doc{
+		Summary:     "Erase user data (GDPR)",
+		Description: "Deletes events with matching user_id within time range and records a data_erasure security event.",
+		Tags:        []string{"admin"},
+		Auth:        openapi.AuthRequired,
+		Permission:  auth.ActionAdmin + ":erase",
+		Request:     erase.Request{},
+		Response:    eraseResponse{},
+	})
+
+	// Ping & example
+	openapi.Register(handler.Ping, openapi.Doc{
+		Summary:    "Authenticated ping",
+		Tags:       []string{"ping"},
+		Auth:       openapi.AuthRequired,
+		Permission: auth.ActionRead + ":ping",
+		Response:   pingResponse{},
+	})
+	openapi.Register(handler.PingPost, openapi.Doc{
+		Summary:    "Authenticated ping with payload",
+		Tags:       []string{"ping"},
+		Auth:       openapi.AuthRequired,
+		Permission: auth.ActionRead + ":ping",
+		Request:    pingEntity.PingRequest{},
+		Response:   pingResponse{},
+	})
+	openapi.Register(handler.ExamplePost, openapi.Doc{Summary: "Example POST", Tags: []string{"example"}, Request: exampleEntity.ExampleRequest{}})
+	openapi.Register(handler.ExampleGetId, openapi.Doc{Summary: "Example GET by ID", Tags: []string{"example"}})
+	openapi.Register(handler.ExampleJob, openapi.Doc{Summary: "Example job dispatch", Tags: []string{"example"}, Response: jobDispatchedResponse{}})
+}
+
+// registerEntityDocs registers docs for standard entity insert/list/detail handlers
+func registerEntityDocs(tag, name, plural string, save, list, detail interface{}, request, item interface{}) {
+	openapi.Register(save, openapi.Doc{
+		Summary:     "Ingest " + name,
+		Description: "Validates payload and dispatches background write job.",
+		Tags:        []string{tag},
+		Auth:        openapi.AuthOptional,
+		Request:     request,
+		Response:    jobDispatchedResponse{},
+	})
+	openapi.Register(list, openapi.Doc{
+		Summary:  "List " + plural + " (cursor pagination)",
+		Tags:     []string{tag},
+		Auth:     openapi.AuthOptional,
+		Request:  v2oss.PaginationRequest{},
+		Response: v2oss.PaginationResponse{},
+		Items:    item,
+	})
+	openapi.Register(detail, openapi.Doc{
+		Summary:  "Get " + name + " by ID",
+		Tags:     []string{tag},
+		Response: item,
+	})
+}
@@ -2,16 +2,20 @@ package route
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/http/registry"
 	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
 )
 
 func init() {
 	// Register user activities routes for v1
 	registry.Register("v1", func(g *echo.Group) {
 		ua := g.Group("/security-events")
-		ua.POST("/insert", handler.SaveSecurityEvents)
-		ua.POST("/list", handler.ListSecurityEvents)
-		ua.GET("/:id", handler.DetailSecurityEvents)
+		ua.POST("/insert", handler.SaveSecurityEvents, middleware.BodyLimitMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ua.POST("/list", handler.ListSecurityEvents, middleware.GzipResponseMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ua.POST("/timeseries", handler.TimeSeriesSecurityEvents, middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ua.POST("/export", handler.ExportSecurityEvents, middleware.GzipResponseMiddleware(), middleware.MultiAuthMiddleware(auth.ActionExport+":security_events"), middleware.RateLimitMiddleware())
+		ua.GET("/:id", handler.DetailSecurityEvents, middleware.OptionalAuthMiddleware())
 	})
 }
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+)
+
+func init() {
+	// Register session events routes for v1
+	registry.Register("v1", func(g *echo.Group) {
+		ss := g.Group("/session-events")
+		ss.POST("/insert", handler.SaveSessionEvents, middleware.BodyLimitMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ss.POST("/list", handler.ListSessionEvents, middleware.GzipResponseMiddleware(), middleware.OptionalAuthMiddleware(), middleware.RateLimitMiddleware())
+		ss.GET("/:id", handler.DetailSessionEvents)
+	})
+}
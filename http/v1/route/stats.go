@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// init registers v1 dashboard stats routes with the registry
+func init() {
+	registry.Register("v1", func(g *echo.Group) {
+		g.GET("/stats", handler.StatsSummary, middleware.MultiAuthMiddleware(auth.ActionRead+":stats"), middleware.RateLimitMiddleware())
+	})
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+func init() {
+	// Register live-tail WebSocket route for v1
+	registry.Register("v1", func(g *echo.Group) {
+		g.GET("/stream", handler.StreamEvents, middleware.MultiAuthMiddleware(auth.ActionRead+":stream"))
+	})
+}
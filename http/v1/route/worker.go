@@ -0,0 +1,18 @@
+package route
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	"github.com/benedict-erwin/insight-collector/http/registry"
+	"github.com/benedict-erwin/insight-collector/http/v1/handler"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+)
+
+// init registers v1 worker routes with the registry
+func init() {
+	registry.Register("v1", func(g *echo.Group) {
+		worker := g.Group("/worker")
+		worker.Use(middleware.MultiAuthMiddleware(auth.ActionRead + ":worker"))
+		worker.GET("/metrics", handler.WorkerMetrics) // Live queue metrics
+	})
+}
@@ -5,11 +5,15 @@ import "github.com/labstack/echo/v4"
 const (
 	// Internal usage
 	RequestIDKey = "x-req-id"
+	TraceIDKey   = "x-trace-id"
 
 	// Header keys (in order of preference)
 	HeaderRequestID      = "X-Request-ID"     // Primary standard
 	HeaderCorrelationID  = "X-Correlation-ID" // Alternative
 	HeaderRequestIDShort = "Request-ID"       // Modern format
+
+	// W3C trace context header (version-trace_id-parent_id-flags)
+	HeaderTraceparent = "traceparent"
 )
 
 // GetRequestIDFromHeaders extracts request ID from multiple possible headers
@@ -35,3 +39,12 @@ func GetRequestID(c echo.Context) string {
 	}
 	return rid
 }
+
+// GetTraceID extracts trace ID from Echo context
+func GetTraceID(c echo.Context) string {
+	tid, ok := c.Get(TraceIDKey).(string)
+	if !ok {
+		return ""
+	}
+	return tid
+}
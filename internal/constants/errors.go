@@ -29,11 +29,13 @@ const (
 	CodeInvalidClientID       = 41006 // Invalid client ID
 	CodeInactiveClient        = 41007 // Client is inactive
 	CodeNonceReplay           = 41008 // Nonce replay attack detected
+	CodeInvalidAPIKey         = 41009 // Invalid API key
 
 	// 403 Forbidden (43xxx)
 	CodeForbidden             = 43000 // Generic forbidden
 	CodeInsufficientPerms     = 43001 // Insufficient permissions
 	CodeResourceForbidden     = 43002 // Resource access forbidden
+	CodeIPNotAllowed          = 43003 // Source IP not in client allowlist
 
 	// 404 Not Found (44xxx)
 	CodeNotFound              = 44000 // Generic not found
@@ -49,6 +51,7 @@ const (
 	CodeUnprocessable         = 42000 // Generic unprocessable
 	CodeBusinessLogicError    = 42001 // Business logic error
 	CodeDependencyFailed      = 42002 // External dependency failed
+	CodePayloadTooLarge       = 42003 // Request body exceeds size limit (sent as HTTP 413)
 
 	// 429 Too Many Requests (42xxx)
 	CodeRateLimit             = 42900 // Rate limit exceeded
@@ -93,7 +96,7 @@ var ErrorMessages = map[int]string{
 	CodeInvalidFormat:         "Invalid format",
 
 	CodeUnauthorized:          "Unauthorized",
-	CodeMissingAuth:           "Authentication required: provide either Bearer token or X-Signature",
+	CodeMissingAuth:           "Authentication required: provide Bearer token, X-Signature or X-API-Key",
 	CodeInvalidToken:          "Invalid JWT token",
 	CodeExpiredToken:          "Token has expired",
 	CodeInvalidSignature:      "Invalid signature",
@@ -101,10 +104,12 @@ var ErrorMessages = map[int]string{
 	CodeInvalidClientID:       "Invalid client ID",
 	CodeInactiveClient:        "Client is inactive",
 	CodeNonceReplay:           "Nonce replay attack detected",
+	CodeInvalidAPIKey:         "Invalid API key",
 
 	CodeForbidden:             "Forbidden",
 	CodeInsufficientPerms:     "Insufficient permissions",
 	CodeResourceForbidden:     "Resource access forbidden",
+	CodeIPNotAllowed:          "Source IP not allowed for this client",
 
 	CodeNotFound:              "Not found",
 	CodeResourceNotFound:      "Resource not found",
@@ -117,6 +122,7 @@ var ErrorMessages = map[int]string{
 	CodeUnprocessable:         "Unprocessable entity",
 	CodeBusinessLogicError:    "Business logic error",
 	CodeDependencyFailed:      "External dependency failed",
+	CodePayloadTooLarge:       "Request body too large",
 
 	CodeRateLimit:             "Rate limit exceeded",
 
@@ -160,10 +166,10 @@ func GetHTTPStatusFromCode(code int) int {
 		return 400
 	case code >= 41000 && code < 42000:
 		return 401
-	case code >= 42000 && code < 43000:
-		return 422
 	case code >= 42900 && code < 43000:
 		return 429
+	case code >= 42000 && code < 43000:
+		return 422
 	case code >= 43000 && code < 44000:
 		return 403
 	case code >= 44000 && code < 45000:
@@ -7,6 +7,9 @@ const (
 	QueueLow      = "low"      // Background jobs (cleanup, reports, analytics)
 )
 
+// QueueDead holds tasks that exhausted their retries, never served by workers (see `worker deadletter`)
+const QueueDead = "dead"
+
 // GetAllQueues returns all valid queue names
 func GetAllQueues() []string {
 	return []string{
@@ -1,6 +1,7 @@
 package callbacklogs
 
 import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 )
 
@@ -36,6 +37,12 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			// Payload Data
 			"payloads": true,
 		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"http_status_code": true,
+			"duration_ms":      true,
+			"retry_count":      true,
+		},
 		Columns: []string{
 			// Essential columns for callback logs list view
 			"_time",
@@ -53,5 +60,8 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			"payloads",
 		},
 		CountField: "callback_id", // Use callback_id for counting unique callback logs
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("callback_logs"),
 	}
 }
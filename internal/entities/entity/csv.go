@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CSVHeader returns CSV column names for response struct from `json` tag names (fields tagged `json:"-"` are skipped)
+func CSVHeader(v interface{}) []string {
+	rt := reflect.TypeOf(v)
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil
+	}
+
+	header := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		if name := csvColumn(rt.Field(i)); name != "" {
+			header = append(header, name)
+		}
+	}
+	return header
+}
+
+// CSVRow returns CSV values for response struct in CSVHeader order (maps/slices are JSON encoded)
+func CSVRow(v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rt := rv.Type()
+	row := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		if csvColumn(rt.Field(i)) == "" {
+			continue
+		}
+		row = append(row, csvValue(rv.Field(i)))
+	}
+	return row
+}
+
+// csvColumn resolves CSV column name for struct field, empty when field is not exported to CSV
+func csvColumn(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// csvValue formats field value as CSV cell
+func csvValue(field reflect.Value) string {
+	switch field.Kind() {
+	case reflect.String:
+		return sanitizeCSVCell(field.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Map, reflect.Slice:
+		if field.IsNil() || field.Len() == 0 {
+			return ""
+		}
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			return ""
+		}
+		return sanitizeCSVCell(string(data))
+	}
+	return ""
+}
+
+// sanitizeCSVCell prefixes values spreadsheet apps would evaluate as formula (CSV injection)
+func sanitizeCSVCell(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
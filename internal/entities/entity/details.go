@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+// DetailFieldPrefix is prepended to promoted details keys (details.card_bin -> detail_card_bin)
+const DetailFieldPrefix = "detail_"
+
+// DefaultMaxDetailFields caps promoted details keys per point when not configured
+const DefaultMaxDetailFields = 20
+
+// DetailsOptions controls promotion of details keys into point fields
+type DetailsOptions struct {
+	Flatten   bool
+	MaxFields int
+}
+
+// GetDetailsOptions returns details options from configuration (flatten disabled when not configured)
+func GetDetailsOptions() DetailsOptions {
+	cfg := config.Get()
+	if cfg == nil {
+		return DetailsOptions{}
+	}
+
+	opts := DetailsOptions{
+		Flatten:   cfg.Details.Flatten,
+		MaxFields: cfg.Details.MaxFlattenedFields,
+	}
+	if opts.MaxFields <= 0 {
+		opts.MaxFields = DefaultMaxDetailFields
+	}
+	return opts
+}
+
+// FlattenDetails promotes top-level scalar details keys into fields when enabled in configuration.
+// Full details JSON blob is kept as is, so nested/complex values stay available.
+func FlattenDetails(fields map[string]interface{}, details map[string]interface{}) int {
+	return FlattenDetailsWithOptions(GetDetailsOptions(), fields, details)
+}
+
+// FlattenDetailsWithOptions promotes top-level string/number/bool details values into prefixed fields.
+// Keys are processed in sorted order so the cap keeps the same keys between points.
+// Numbers are always stored as float (JSON numbers decode as float64), nested maps/slices and nulls are skipped.
+// Returns number of promoted keys.
+func FlattenDetailsWithOptions(opts DetailsOptions, fields map[string]interface{}, details map[string]interface{}) int {
+	if !opts.Flatten || opts.MaxFields <= 0 || len(details) == 0 {
+		return 0
+	}
+
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	promoted := 0
+	for _, key := range keys {
+		if promoted >= opts.MaxFields {
+			break
+		}
+
+		value, ok := detailScalar(details[key])
+		if !ok {
+			continue
+		}
+
+		name := detailFieldName(key)
+		if name == "" {
+			continue
+		}
+
+		// Never override regular fields or earlier promoted key with same sanitized name
+		if _, exists := fields[name]; exists {
+			continue
+		}
+
+		fields[name] = value
+		promoted++
+	}
+
+	return promoted
+}
+
+// detailScalar normalizes promotable scalar value (string, number as float64, bool)
+func detailScalar(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return v, true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return nil, false
+	}
+}
+
+// detailFieldName builds prefixed field name, key lowercased with non [a-z0-9_] characters replaced by "_"
+func detailFieldName(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(DetailFieldPrefix)
+	for _, r := range key {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
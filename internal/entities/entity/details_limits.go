@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+// Details limits defaults when not configured
+const (
+	DefaultMaxDetailsBytes = 16 * 1024
+	DefaultMaxDetailsKeys  = 100
+	DefaultMaxDetailsDepth = 5
+)
+
+// Oversize modes applied when details exceed max bytes
+const (
+	OversizeReject   = "reject"
+	OversizeTruncate = "truncate"
+)
+
+// DetailsTruncatedKey marks details whose keys were dropped to fit max bytes
+const DetailsTruncatedKey = "_truncated"
+
+// DetailsLimits caps size of details map accepted on ingest
+type DetailsLimits struct {
+	MaxBytes int
+	MaxKeys  int
+	MaxDepth int
+	Truncate bool // Drop keys exceeding MaxBytes instead of rejecting (keys & depth are always rejected)
+}
+
+// DetailsLimitError reports which details limit was exceeded
+type DetailsLimitError struct {
+	Limit  string // max_bytes, max_keys or max_depth
+	Max    int
+	Actual int
+}
+
+// Error returns readable limit violation
+func (e *DetailsLimitError) Error() string {
+	return fmt.Sprintf("field 'details' exceeds %s limit: %d (max %d)", e.Limit, e.Actual, e.Max)
+}
+
+// GetDetailsLimits returns details limits from configuration, defaults for missing values
+func GetDetailsLimits() DetailsLimits {
+	limits := DetailsLimits{
+		MaxBytes: DefaultMaxDetailsBytes,
+		MaxKeys:  DefaultMaxDetailsKeys,
+		MaxDepth: DefaultMaxDetailsDepth,
+	}
+
+	cfg := config.Get()
+	if cfg == nil {
+		return limits
+	}
+
+	if cfg.Details.MaxBytes > 0 {
+		limits.MaxBytes = cfg.Details.MaxBytes
+	}
+	if cfg.Details.MaxKeys > 0 {
+		limits.MaxKeys = cfg.Details.MaxKeys
+	}
+	if cfg.Details.MaxDepth > 0 {
+		limits.MaxDepth = cfg.Details.MaxDepth
+	}
+	limits.Truncate = strings.EqualFold(cfg.Details.Oversize, OversizeTruncate)
+	return limits
+}
+
+// ValidateDetails checks details against configured limits, returns details to store (truncated when configured)
+func ValidateDetails(details map[string]interface{}) (map[string]interface{}, error) {
+	return ValidateDetailsWithLimits(GetDetailsLimits(), details)
+}
+
+// ValidateDetailsWithLimits checks nesting depth, total keys and serialized size of details.
+// Oversized details are rejected, or truncated when limits.Truncate is set: top-level keys are kept
+// in sorted order while they fit and DetailsTruncatedKey is added.
+func ValidateDetailsWithLimits(limits DetailsLimits, details map[string]interface{}) (map[string]interface{}, error) {
+	if len(details) == 0 {
+		return details, nil
+	}
+
+	keys, depth := detailsShape(details, 1)
+	if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+		return nil, &DetailsLimitError{Limit: "max_depth", Max: limits.MaxDepth, Actual: depth}
+	}
+	if limits.MaxKeys > 0 && keys > limits.MaxKeys {
+		return nil, &DetailsLimitError{Limit: "max_keys", Max: limits.MaxKeys, Actual: keys}
+	}
+
+	if limits.MaxBytes <= 0 {
+		return details, nil
+	}
+
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("field 'details' is invalid: %w", err)
+	}
+	if len(encoded) <= limits.MaxBytes {
+		return details, nil
+	}
+
+	if !limits.Truncate {
+		return nil, &DetailsLimitError{Limit: "max_bytes", Max: limits.MaxBytes, Actual: len(encoded)}
+	}
+	return truncateDetails(details, limits.MaxBytes), nil
+}
+
+// detailsShape returns total keys and max nesting depth of value (maps and slices add one level)
+func detailsShape(value interface{}, depth int) (int, int) {
+	keys, maxDepth := 0, depth
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys = len(v)
+		for _, nested := range v {
+			if nestedKeys, nestedDepth, ok := nestedShape(nested, depth); ok {
+				keys += nestedKeys
+				maxDepth = max(maxDepth, nestedDepth)
+			}
+		}
+	case []interface{}:
+		for _, nested := range v {
+			if nestedKeys, nestedDepth, ok := nestedShape(nested, depth); ok {
+				keys += nestedKeys
+				maxDepth = max(maxDepth, nestedDepth)
+			}
+		}
+	}
+	return keys, maxDepth
+}
+
+// nestedShape returns shape of nested object or array one level deeper, false for scalars
+func nestedShape(value interface{}, depth int) (int, int, bool) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		keys, nestedDepth := detailsShape(value, depth+1)
+		return keys, nestedDepth, true
+	}
+	return 0, 0, false
+}
+
+// truncateDetails keeps top-level keys in sorted order while serialized details (with marker) fit maxBytes
+func truncateDetails(details map[string]interface{}, maxBytes int) map[string]interface{} {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// {"_truncated":true} plus one comma per kept key
+	marker, _ := json.Marshal(map[string]interface{}{DetailsTruncatedKey: true})
+	size := len(marker)
+
+	truncated := map[string]interface{}{DetailsTruncatedKey: true}
+	for _, key := range keys {
+		if key == DetailsTruncatedKey {
+			continue
+		}
+		entry, err := json.Marshal(map[string]interface{}{key: details[key]})
+		if err != nil {
+			continue
+		}
+
+		// Entry without braces plus separating comma
+		entrySize := len(entry) - 2 + 1
+		if size+entrySize > maxBytes {
+			continue
+		}
+		truncated[key] = details[key]
+		size += entrySize
+	}
+	return truncated
+}
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Entity is implemented by every measurement entity written to InfluxDB
+type Entity interface {
+	ToPoint() interface{}
+	GetName() string
+}
+
+// Validatable is implemented by requests with checks beyond struct tags (e.g. cross-field rules),
+// run by request validator after tag validation passes
+type Validatable interface {
+	Validate() error
+}
+
+// EmptyValue is placeholder stored instead of empty strings (InfluxDB requirement)
+const EmptyValue = "-"
+
+// SafeString ensures tag values are never empty (InfluxDB requirement)
+func SafeString(s string) string {
+	if s == "" {
+		return EmptyValue
+	}
+	return s
+}
+
+// MapRecord populates response struct pointed by target from raw InfluxDB record.
+// Record key is taken from `record` tag when present, otherwise from `json` tag name.
+// Fields tagged `record:"-"` or missing from record are left untouched.
+func MapRecord(record map[string]interface{}, target interface{}) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := recordKey(field)
+		if key == "" {
+			continue
+		}
+
+		if value, ok := record[key]; ok && value != nil {
+			setField(rv.Field(i), value)
+		}
+	}
+}
+
+// recordKey resolves record key for struct field from `record` or `json` tag
+func recordKey(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("record"); ok {
+		if tag == "-" {
+			return ""
+		}
+		return tag
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// setField assigns raw InfluxDB value to field, converting between numeric/string/bool representations
+func setField(field reflect.Value, value interface{}) {
+	switch field.Kind() {
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			if v != "" && v != EmptyValue {
+				field.SetString(v)
+			}
+		case time.Time:
+			field.SetString(v.Format(time.RFC3339))
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := value.(type) {
+		case int64:
+			field.SetInt(v)
+		case float64:
+			field.SetInt(int64(v))
+		case int:
+			field.SetInt(int64(v))
+		case uint64:
+			field.SetInt(int64(v))
+		}
+
+	case reflect.Float32, reflect.Float64:
+		switch v := value.(type) {
+		case float64:
+			field.SetFloat(v)
+		case int64:
+			field.SetFloat(float64(v))
+		case int:
+			field.SetFloat(float64(v))
+		case uint64:
+			field.SetFloat(float64(v))
+		}
+
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			field.SetBool(v)
+		case string:
+			field.SetBool(v == "true" || v == "1")
+		}
+
+	case reflect.Map:
+		// Map/Object fields - stored as JSON string
+		if v, ok := value.(string); ok && v != "" {
+			decoded := reflect.New(field.Type())
+			if err := json.Unmarshal([]byte(v), decoded.Interface()); err == nil {
+				field.Set(decoded.Elem())
+			}
+		}
+	}
+}
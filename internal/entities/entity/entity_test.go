@@ -0,0 +1,359 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testResponse struct {
+	ID         string                 `json:"id" record:"-"`
+	Time       string                 `json:"time" record:"_time"`
+	UserID     string                 `json:"user_id"`
+	Status     string                 `json:"status"`
+	DurationMs int                    `json:"duration_ms"`
+	Amount     float64                `json:"amount"`
+	LastSeen   int64                  `json:"last_seen,omitempty"`
+	IsBot      bool                   `json:"is_bot"`
+	Handled    bool                   `json:"handled"`
+	Details    map[string]interface{} `json:"details"`
+	Ignored    string                 `json:"-"`
+	internal   string
+}
+
+func TestMapRecord(t *testing.T) {
+	ts := time.Date(2025, 8, 6, 12, 30, 0, 0, time.UTC)
+
+	record := map[string]interface{}{
+		"id":          "should-not-be-copied",
+		"_time":       ts,
+		"user_id":     "user-1",
+		"status":      "-",
+		"duration_ms": float64(125),
+		"amount":      int64(1500),
+		"last_seen":   int64(1754483400),
+		"is_bot":      true,
+		"handled":     "1",
+		"details":     `{"reason":"timeout","retries":3}`,
+		"Ignored":     "nope",
+		"internal":    "nope",
+	}
+
+	var response testResponse
+	MapRecord(record, &response)
+
+	if response.ID != "" {
+		t.Errorf("ID should be skipped, got %q", response.ID)
+	}
+	if response.Time != "2025-08-06T12:30:00Z" {
+		t.Errorf("Time = %q, want RFC3339 from _time", response.Time)
+	}
+	if response.UserID != "user-1" {
+		t.Errorf("UserID = %q, want user-1", response.UserID)
+	}
+	if response.Status != "" {
+		t.Errorf("Status placeholder should stay empty, got %q", response.Status)
+	}
+	if response.DurationMs != 125 {
+		t.Errorf("DurationMs = %d, want 125", response.DurationMs)
+	}
+	if response.Amount != 1500 {
+		t.Errorf("Amount = %v, want 1500", response.Amount)
+	}
+	if response.LastSeen != 1754483400 {
+		t.Errorf("LastSeen = %d, want 1754483400", response.LastSeen)
+	}
+	if !response.IsBot || !response.Handled {
+		t.Errorf("bool fields not mapped: is_bot=%v handled=%v", response.IsBot, response.Handled)
+	}
+	if response.Details["reason"] != "timeout" || response.Details["retries"] != float64(3) {
+		t.Errorf("Details not decoded: %v", response.Details)
+	}
+	if response.Ignored != "" || response.internal != "" {
+		t.Errorf("ignored fields should stay empty")
+	}
+
+	// String time value is kept as-is
+	response = testResponse{}
+	MapRecord(map[string]interface{}{"_time": "2025-08-06T12:30:00Z"}, &response)
+	if response.Time != "2025-08-06T12:30:00Z" {
+		t.Errorf("Time = %q, want string passthrough", response.Time)
+	}
+
+	// Non-pointer target is ignored
+	MapRecord(record, testResponse{})
+}
+
+func TestSafeString(t *testing.T) {
+	if got := SafeString(""); got != EmptyValue {
+		t.Errorf("SafeString(\"\") = %q, want %q", got, EmptyValue)
+	}
+	if got := SafeString("web"); got != "web" {
+		t.Errorf("SafeString(\"web\") = %q, want web", got)
+	}
+}
+
+func TestEnum(t *testing.T) {
+	currencies := map[string]bool{"IDR": true, "USD": true}
+	RegisterEnum("test_currency", currencies)
+
+	set, exists := GetEnum("test_currency")
+	if !exists {
+		t.Fatal("registered enum not found")
+	}
+
+	if got, ok := MatchEnum(set, "usd "); !ok || got != "USD" {
+		t.Errorf("MatchEnum(\"usd \") = %q, %v, want USD, true", got, ok)
+	}
+	if got, ok := MatchEnum(set, "EUR"); ok || got != "EUR" {
+		t.Errorf("MatchEnum(\"EUR\") = %q, %v, want EUR, false", got, ok)
+	}
+
+	// Extending exported set is picked up by registered enum
+	currencies["SGD"] = true
+	if _, ok := MatchEnum(set, "sgd"); !ok {
+		t.Error("extended value not matched")
+	}
+
+	if got := NormalizeEnum(set, " idr"); got != "IDR" {
+		t.Errorf("NormalizeEnum(\" idr\") = %q, want IDR", got)
+	}
+	if got := EnumValues(set); len(got) != 3 || got[0] != "IDR" {
+		t.Errorf("EnumValues = %v, want sorted values", got)
+	}
+}
+
+func TestCSVHeaderAndRow(t *testing.T) {
+	response := testResponse{
+		ID:         "id-1",
+		Time:       "2025-08-06T12:30:00Z",
+		UserID:     "=HYPERLINK(\"x\")",
+		DurationMs: 125,
+		Amount:     -12.5,
+		IsBot:      true,
+		Details:    map[string]interface{}{"reason": "timeout"},
+		Ignored:    "nope",
+	}
+
+	header := CSVHeader(&response)
+	wantHeader := []string{"id", "time", "user_id", "status", "duration_ms", "amount", "last_seen", "is_bot", "handled", "details"}
+	if strings.Join(header, ",") != strings.Join(wantHeader, ",") {
+		t.Fatalf("CSVHeader = %v, want %v", header, wantHeader)
+	}
+
+	row := CSVRow(response)
+	wantRow := []string{"id-1", "2025-08-06T12:30:00Z", "'=HYPERLINK(\"x\")", "", "125", "-12.5", "0", "true", "false", `{"reason":"timeout"}`}
+	if len(row) != len(header) {
+		t.Fatalf("CSVRow has %d values, header has %d", len(row), len(header))
+	}
+	for i := range wantRow {
+		if row[i] != wantRow[i] {
+			t.Errorf("CSVRow[%s] = %q, want %q", header[i], row[i], wantRow[i])
+		}
+	}
+
+	// Non-struct input yields nothing
+	if CSVHeader("x") != nil || CSVRow(42) != nil {
+		t.Error("expected nil for non-struct input")
+	}
+}
+
+func TestFlattenDetails(t *testing.T) {
+	details := map[string]interface{}{
+		"card_bin":    "411111",
+		"amount":      float64(150000),
+		"3ds":         true,
+		"Merchant-ID": "m-1",
+		"nested":      map[string]interface{}{"a": 1},
+		"items":       []interface{}{"x"},
+		"empty":       nil,
+		"user_id":     "ignored",
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		fields := map[string]interface{}{}
+		if n := FlattenDetailsWithOptions(DetailsOptions{}, fields, details); n != 0 || len(fields) != 0 {
+			t.Errorf("Expected no promoted fields when disabled, got %d (%v)", n, fields)
+		}
+	})
+
+	t.Run("Scalars Promoted", func(t *testing.T) {
+		fields := map[string]interface{}{"detail_user_id": "existing"}
+		n := FlattenDetailsWithOptions(DetailsOptions{Flatten: true, MaxFields: 20}, fields, details)
+		if n != 4 {
+			t.Errorf("Expected 4 promoted fields, got %d (%v)", n, fields)
+		}
+
+		expected := map[string]interface{}{
+			"detail_card_bin":    "411111",
+			"detail_amount":      float64(150000),
+			"detail_3ds":         true,
+			"detail_merchant_id": "m-1",
+			"detail_user_id":     "existing", // Existing field never overridden
+		}
+		for key, value := range expected {
+			if fields[key] != value {
+				t.Errorf("Expected %s=%v, got %v", key, value, fields[key])
+			}
+		}
+		for _, key := range []string{"detail_nested", "detail_items", "detail_empty"} {
+			if _, exists := fields[key]; exists {
+				t.Errorf("Expected %s not to be promoted", key)
+			}
+		}
+	})
+
+	t.Run("Capped In Sorted Key Order", func(t *testing.T) {
+		fields := map[string]interface{}{}
+		if n := FlattenDetailsWithOptions(DetailsOptions{Flatten: true, MaxFields: 2}, fields, details); n != 2 {
+			t.Fatalf("Expected 2 promoted fields, got %d", n)
+		}
+		// Sorted keys: "3ds", "Merchant-ID", "amount", ...
+		if _, ok := fields["detail_3ds"]; !ok {
+			t.Errorf("Expected detail_3ds to be promoted, got %v", fields)
+		}
+		if _, ok := fields["detail_merchant_id"]; !ok {
+			t.Errorf("Expected detail_merchant_id to be promoted, got %v", fields)
+		}
+	})
+}
+
+func TestValidateDetailsLimits(t *testing.T) {
+	limits := DetailsLimits{MaxBytes: 200, MaxKeys: 10, MaxDepth: 3}
+
+	// nested builds details nested depth levels deep
+	nested := func(depth int) map[string]interface{} {
+		details := map[string]interface{}{"leaf": "value"}
+		for i := 1; i < depth; i++ {
+			details = map[string]interface{}{"level": details}
+		}
+		return details
+	}
+
+	assertLimit := func(t *testing.T, err error, limit string) {
+		t.Helper()
+		limitErr, ok := err.(*DetailsLimitError)
+		if !ok {
+			t.Fatalf("Expected DetailsLimitError, got %v", err)
+		}
+		if limitErr.Limit != limit || !strings.Contains(err.Error(), limit) {
+			t.Errorf("Expected %s violation, got %v", limit, err)
+		}
+	}
+
+	t.Run("Within Limits", func(t *testing.T) {
+		details := map[string]interface{}{"card_bin": "411111", "items": []interface{}{map[string]interface{}{"sku": "a"}}}
+		got, err := ValidateDetailsWithLimits(limits, details)
+		if err != nil || len(got) != 2 {
+			t.Errorf("Expected details unchanged, got %v (%v)", got, err)
+		}
+		if got, err := ValidateDetailsWithLimits(limits, nil); err != nil || got != nil {
+			t.Errorf("Expected nil details accepted, got %v (%v)", got, err)
+		}
+	})
+
+	t.Run("Over Size", func(t *testing.T) {
+		details := map[string]interface{}{"blob": strings.Repeat("x", 2*1024*1024)}
+		_, err := ValidateDetailsWithLimits(limits, details)
+		assertLimit(t, err, "max_bytes")
+	})
+
+	t.Run("Too Many Keys", func(t *testing.T) {
+		details := map[string]interface{}{}
+		for i := 0; i < 6; i++ {
+			details[fmt.Sprintf("k%d", i)] = map[string]interface{}{"v": i} // 6 top-level + 6 nested keys
+		}
+		_, err := ValidateDetailsWithLimits(limits, details)
+		assertLimit(t, err, "max_keys")
+		if err.(*DetailsLimitError).Actual != 12 {
+			t.Errorf("Expected keys counted over all levels, got %v", err)
+		}
+	})
+
+	t.Run("Deeply Nested", func(t *testing.T) {
+		if _, err := ValidateDetailsWithLimits(limits, nested(3)); err != nil {
+			t.Errorf("Expected depth 3 accepted, got %v", err)
+		}
+		_, err := ValidateDetailsWithLimits(limits, nested(4))
+		assertLimit(t, err, "max_depth")
+
+		// Arrays count as nesting level
+		_, err = ValidateDetailsWithLimits(limits, map[string]interface{}{"a": []interface{}{[]interface{}{[]interface{}{1}}}})
+		assertLimit(t, err, "max_depth")
+	})
+
+	t.Run("Truncate Over Size", func(t *testing.T) {
+		truncating := limits
+		truncating.Truncate = true
+		details := map[string]interface{}{
+			"a_small": "keep",
+			"b_blob":  strings.Repeat("x", 500),
+			"c_small": "keep too",
+		}
+
+		got, err := ValidateDetailsWithLimits(truncating, details)
+		if err != nil {
+			t.Fatalf("Expected truncation instead of error, got %v", err)
+		}
+		if got[DetailsTruncatedKey] != true || got["a_small"] != "keep" || got["c_small"] != "keep too" {
+			t.Errorf("Expected small keys kept with marker, got %v", got)
+		}
+		if _, exists := got["b_blob"]; exists {
+			t.Error("Expected oversized key dropped")
+		}
+		if encoded, _ := json.Marshal(got); len(encoded) > truncating.MaxBytes {
+			t.Errorf("Expected truncated details within %d bytes, got %d", truncating.MaxBytes, len(encoded))
+		}
+
+		// Key & depth limits are never truncated
+		_, err = ValidateDetailsWithLimits(truncating, nested(4))
+		assertLimit(t, err, "max_depth")
+	})
+}
+
+func TestMaskPII(t *testing.T) {
+	ips := map[string]string{
+		"192.168.1.42":                 "192.168.1.*",
+		"192.168.1.7":                  "192.168.1.*",
+		"::ffff:10.0.0.1":              "10.0.0.*",
+		"2001:db8:85a3::8a2e:370:7334": "2001:db8:85a3:*",
+		"2001:0db8:0000:0001::1":       "2001:db8:0:*",
+		"not-an-ip":                    "*",
+		"":                             "",
+		"-":                            "-",
+	}
+	for ip, want := range ips {
+		if got := MaskIP(ip); got != want {
+			t.Errorf("MaskIP(%q) = %q, want %q", ip, got, want)
+		}
+	}
+
+	accounts := map[string]string{
+		"1234567890": "******7890",
+		"1234":       "****",
+		"":           "",
+	}
+	for account, want := range accounts {
+		if got := MaskAccount(account); got != want {
+			t.Errorf("MaskAccount(%q) = %q, want %q", account, got, want)
+		}
+	}
+
+	userAgents := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0": "Mozilla/5.0 *",
+		"curl/8.4.0": "curl/8.4.0",
+		"-":          "-",
+	}
+	for userAgent, want := range userAgents {
+		if got := MaskUserAgent(userAgent); got != want {
+			t.Errorf("MaskUserAgent(%q) = %q, want %q", userAgent, got, want)
+		}
+	}
+
+	// Deterministic: same input always masks to same value
+	if MaskIP("203.0.113.9") != MaskIP("203.0.113.9") || MaskAccount("ACC-998877") != MaskAccount("ACC-998877") {
+		t.Error("expected deterministic masking")
+	}
+}
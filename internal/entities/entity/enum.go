@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	enumSets      = make(map[string]map[string]bool)
+	enumSetsMutex sync.RWMutex
+)
+
+// RegisterEnum registers allowed value set under name used by `enum=<name>` validation tag.
+// Set is stored by reference, values added to it later are picked up by validation.
+func RegisterEnum(name string, set map[string]bool) {
+	enumSetsMutex.Lock()
+	defer enumSetsMutex.Unlock()
+	enumSets[name] = set
+}
+
+// GetEnum returns registered value set by name
+func GetEnum(name string) (map[string]bool, bool) {
+	enumSetsMutex.RLock()
+	defer enumSetsMutex.RUnlock()
+	set, exists := enumSets[name]
+	return set, exists
+}
+
+// MatchEnum returns canonical set value matching input (trimmed, case insensitive)
+func MatchEnum(set map[string]bool, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if set[value] {
+		return value, true
+	}
+
+	for allowed := range set {
+		if strings.EqualFold(allowed, value) {
+			return allowed, true
+		}
+	}
+	return value, false
+}
+
+// NormalizeEnum returns canonical set value for input, or trimmed input when not in set
+func NormalizeEnum(set map[string]bool, value string) string {
+	normalized, _ := MatchEnum(set, value)
+	return normalized
+}
+
+// EnumValues returns sorted values of set (for error messages)
+func EnumValues(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// RedactedValue replaces masked parts of PII values
+const RedactedValue = "*"
+
+// MaskIP keeps network part of IP address: first 3 octets of IPv4 (192.168.1.*), first 3 hextets of IPv6
+// (2001:db8:85a3:*). Unparseable values are fully masked, empty and placeholder values are kept.
+func MaskIP(ip string) string {
+	if ip == "" || ip == EmptyValue {
+		return ip
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return RedactedValue
+	}
+	addr = addr.Unmap()
+
+	if addr.Is4() {
+		octets := addr.As4()
+		return fmt.Sprintf("%d.%d.%d.%s", octets[0], octets[1], octets[2], RedactedValue)
+	}
+
+	hextets := strings.Split(addr.StringExpanded(), ":")
+	for i := range hextets[:3] {
+		hextets[i] = strings.TrimLeft(hextets[i], "0")
+		if hextets[i] == "" {
+			hextets[i] = "0"
+		}
+	}
+	return strings.Join(hextets[:3], ":") + ":" + RedactedValue
+}
+
+// MaskAccount keeps last 4 characters of account identifier, others are masked preserving length
+func MaskAccount(account string) string {
+	if account == "" || account == EmptyValue {
+		return account
+	}
+
+	chars := []rune(account)
+	keep := 4
+	if len(chars) <= keep {
+		keep = 0
+	}
+	return strings.Repeat(RedactedValue, len(chars)-keep) + string(chars[len(chars)-keep:])
+}
+
+// MaskUserAgent keeps first product token of user agent (e.g. Mozilla/5.0), remaining details are masked
+func MaskUserAgent(userAgent string) string {
+	if userAgent == "" || userAgent == EmptyValue {
+		return userAgent
+	}
+
+	product, rest, _ := strings.Cut(strings.TrimSpace(userAgent), " ")
+	if rest == "" {
+		return product
+	}
+	return product + " " + RedactedValue
+}
+
+// RedactRecord masks PII fields of generic record (e.g. live stream event data), masks are keyed by JSON field.
+// Non-string values are left untouched.
+func RedactRecord(record map[string]interface{}, masks map[string]func(string) string) {
+	for field, mask := range masks {
+		if value, ok := record[field].(string); ok {
+			record[field] = mask(value)
+		}
+	}
+}
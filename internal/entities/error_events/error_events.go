@@ -0,0 +1,384 @@
+package errorevents
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// ERROR & EXCEPTION FOCUSED
+type (
+	ErrorEvents struct {
+		// === SERVICE CONTEXT GROUP ===
+		Service     string `json:"service"`     // Service/application name emitting the error
+		Environment string `json:"environment"` // production/staging/development
+		AppVersion  string `json:"app_version"` // Application version
+
+		// === ERROR CONTEXT GROUP ===
+		ErrorType  string `json:"error_type"`  // database_error/timeout/validation_error/panic/upstream_error/unhandled_exception
+		Severity   string `json:"severity"`    // debug/info/warning/error/critical/fatal
+		ErrorCode  string `json:"error_code"`  // Application specific error code
+		Message    string `json:"message"`     // Error message
+		StackTrace string `json:"stack_trace"` // Full stack trace
+		Handled    bool   `json:"handled"`     // Whether error was caught and handled gracefully
+
+		// === REQUEST CONTEXT GROUP ===
+		Channel      string `json:"channel"`       // web/mobile_app/api
+		Method       string `json:"method"`        // GET/POST/PUT/DELETE
+		Endpoint     string `json:"endpoint"`      // Endpoint path where error occurred
+		ResponseCode int    `json:"response_code"` // HTTP response code
+		DurationMs   int    `json:"duration_ms"`   // Request processing time until error in milliseconds
+
+		// === CORRELATION GROUP ===
+		UserID    string `json:"user_id"`    // User identifier (empty if anonymous)
+		SessionID string `json:"session_id"` // Session correlation key
+		RequestID string `json:"request_id"` // Request correlation ID
+		TraceID   string `json:"trace_id"`   // Distributed tracing ID
+
+		// === TECHNICAL CONTEXT GROUP ===
+		DeviceType string `json:"device_type"` // desktop/mobile/tablet
+		OS         string `json:"os"`          // windows/linux/ios
+		Browser    string `json:"browser"`     // chrome/firefox/safari
+		IsBot      bool   `json:"is_bot"`      // Automated traffic flag
+
+		// === NETWORK & CLIENT CONTEXT GROUP ===
+		IPAddress string `json:"ip_address"` // Client IP address
+		UserAgent string `json:"user_agent"` // Client user agent string
+
+		// === GEOGRAPHIC GROUP ===
+		GeoCountry     string `json:"geo_country"`     // ID/SG/MY/TH/US/PH
+		GeoCity        string `json:"geo_city"`        // City from IP geolocation
+		GeoCoordinates string `json:"geo_coordinates"` // Latitude,Longitude format
+		GeoTimezone    string `json:"geo_timezone"`    // Timezone from geolocation
+		GeoPostal      string `json:"geo_postal"`      // Postal code from geolocation
+		GeoISP         string `json:"geo_isp"`         // Internet service provider information
+
+		// === METADATA GROUP ===
+		OSVersion      string                 `json:"os_version"`      // OS version
+		BrowserVersion string                 `json:"browser_version"` // Browser version
+		Details        map[string]interface{} `json:"details"`
+
+		// Timestamp
+		Timestamp time.Time
+	}
+
+	ErrorEventsRequest struct {
+		Service      string                 `json:"service" validate:"required"`
+		Environment  string                 `json:"environment"`
+		AppVersion   string                 `json:"app_version"`
+		ErrorType    string                 `json:"error_type" validate:"required"`
+		Severity     string                 `json:"severity" validate:"required"`
+		ErrorCode    string                 `json:"error_code"`
+		Message      string                 `json:"message" validate:"required"`
+		StackTrace   string                 `json:"stack_trace"`
+		Handled      bool                   `json:"handled"`
+		Channel      string                 `json:"channel"`
+		Method       string                 `json:"method"`
+		Endpoint     string                 `json:"endpoint"`
+		ResponseCode int                    `json:"response_code"`
+		DurationMs   int                    `json:"duration_ms"`
+		UserID       string                 `json:"user_id"`
+		SessionID    string                 `json:"session_id"`
+		RequestID    string                 `json:"request_id" validate:"required"`
+		TraceID      string                 `json:"trace_id"`
+		IPAddress    string                 `json:"ip_address"`
+		UserAgent    string                 `json:"user_agent"`
+		Details      map[string]interface{} `json:"details"`
+		Timestamp    time.Time              `json:"time" validate:"required"`
+	}
+
+	ErrorEventsResponse struct {
+		ID             string                 `json:"id"`
+		Time           string                 `json:"time"`
+		Service        string                 `json:"service"`
+		Environment    string                 `json:"environment"`
+		AppVersion     string                 `json:"app_version"`
+		ErrorType      string                 `json:"error_type"`
+		Severity       string                 `json:"severity"`
+		ErrorCode      string                 `json:"error_code"`
+		Message        string                 `json:"message"`
+		StackTrace     string                 `json:"stack_trace"`
+		Handled        bool                   `json:"handled"`
+		Channel        string                 `json:"channel"`
+		Method         string                 `json:"method"`
+		Endpoint       string                 `json:"endpoint"`
+		ResponseCode   int                    `json:"response_code"`
+		DurationMs     int                    `json:"duration_ms"`
+		UserID         string                 `json:"user_id"`
+		SessionID      string                 `json:"session_id"`
+		RequestID      string                 `json:"request_id"`
+		TraceID        string                 `json:"trace_id"`
+		DeviceType     string                 `json:"device_type"`
+		OS             string                 `json:"os"`
+		Browser        string                 `json:"browser"`
+		IsBot          bool                   `json:"is_bot"`
+		IPAddress      string                 `json:"ip_address"`
+		UserAgent      string                 `json:"user_agent"`
+		GeoCountry     string                 `json:"geo_country"`
+		GeoCity        string                 `json:"geo_city"`
+		GeoCoordinates string                 `json:"geo_coordinates"`
+		GeoTimezone    string                 `json:"geo_timezone"`
+		GeoPostal      string                 `json:"geo_postal"`
+		GeoISP         string                 `json:"geo_isp"`
+		OSVersion      string                 `json:"os_version"`
+		BrowserVersion string                 `json:"browser_version"`
+		Details        map[string]interface{} `json:"details"`
+	}
+)
+
+// ToPoint converts ErrorEvents to InfluxDB point with tags and fields
+func (ee *ErrorEvents) ToPoint() interface{} {
+	// Serialize details to JSON string for InfluxDB storage
+	var detailsJSON string
+	if len(ee.Details) > 0 {
+		if jsonBytes, err := json.Marshal(ee.Details); err == nil {
+			detailsJSON = string(jsonBytes)
+		}
+	}
+
+	fields := map[string]interface{}{
+		"app_version":     safeString(ee.AppVersion),
+		"error_code":      safeString(ee.ErrorCode),
+		"message":         safeString(ee.Message),
+		"stack_trace":     safeString(ee.StackTrace),
+		"handled":         bool(ee.Handled),
+		"channel":         safeString(ee.Channel),
+		"method":          safeString(ee.Method),
+		"endpoint":        safeString(ee.Endpoint),
+		"response_code":   int(ee.ResponseCode),
+		"duration_ms":     int(ee.DurationMs),
+		"user_id":         safeString(ee.UserID),
+		"session_id":      safeString(ee.SessionID),
+		"request_id":      safeString(ee.RequestID),
+		"trace_id":        safeString(ee.TraceID),
+		"device_type":     safeString(ee.DeviceType),
+		"os":              safeString(ee.OS),
+		"browser":         safeString(ee.Browser),
+		"is_bot":          bool(ee.IsBot),
+		"ip_address":      safeString(ee.IPAddress),
+		"user_agent":      safeString(ee.UserAgent),
+		"geo_country":     safeString(ee.GeoCountry),
+		"geo_city":        safeString(ee.GeoCity),
+		"geo_coordinates": safeString(ee.GeoCoordinates),
+		"geo_timezone":    safeString(ee.GeoTimezone),
+		"geo_postal":      safeString(ee.GeoPostal),
+		"geo_isp":         safeString(ee.GeoISP),
+		"os_version":      safeString(ee.OSVersion),
+		"browser_version": safeString(ee.BrowserVersion),
+		"details":         detailsJSON,
+	}
+
+	// Promote scalar details keys into queryable fields (opt-in, details blob kept)
+	entity.FlattenDetails(fields, ee.Details)
+
+	return influxdb.NewPoint(
+		"error_events",
+		map[string]string{
+			// OPTIMIZED: 4 low cardinality tags for error analytics
+			"service":     safeString(ee.Service),     // Error source grouping
+			"error_type":  safeString(ee.ErrorType),   // Error classification
+			"severity":    safeString(ee.Severity),    // Alert prioritization
+			"environment": safeString(ee.Environment), // Environment separation
+		},
+		fields,
+		ee.Timestamp,
+	)
+}
+
+// GetName returns the measurement name for this entity
+func (ee *ErrorEvents) GetName() string {
+	return "error_events"
+}
+
+// Validate enforces details limits on error context
+func (r *ErrorEventsRequest) Validate() error {
+	details, err := entity.ValidateDetails(r.Details)
+	if err != nil {
+		return err
+	}
+	r.Details = details
+	return nil
+}
+
+// safeString ensures tag values are never empty (InfluxDB requirement)
+func safeString(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// MapToErrorEventsResponse converts raw InfluxDB record to ErrorEventsResponse struct
+func MapToErrorEventsResponse(record map[string]interface{}) ErrorEventsResponse {
+	response := ErrorEventsResponse{}
+
+	// Parse time field
+	if v, ok := record["_time"]; ok {
+		switch timeVal := v.(type) {
+		case string:
+			response.Time = timeVal
+		case time.Time:
+			response.Time = timeVal.Format(time.RFC3339)
+		}
+	}
+
+	// Service context fields
+	if v, ok := record["service"].(string); ok && v != "" && v != "-" {
+		response.Service = v
+	}
+	if v, ok := record["environment"].(string); ok && v != "" && v != "-" {
+		response.Environment = v
+	}
+	if v, ok := record["app_version"].(string); ok && v != "" && v != "-" {
+		response.AppVersion = v
+	}
+
+	// Error context fields
+	if v, ok := record["error_type"].(string); ok && v != "" && v != "-" {
+		response.ErrorType = v
+	}
+	if v, ok := record["severity"].(string); ok && v != "" && v != "-" {
+		response.Severity = v
+	}
+	if v, ok := record["error_code"].(string); ok && v != "" && v != "-" {
+		response.ErrorCode = v
+	}
+	if v, ok := record["message"].(string); ok && v != "" && v != "-" {
+		response.Message = v
+	}
+	if v, ok := record["stack_trace"].(string); ok && v != "" && v != "-" {
+		response.StackTrace = v
+	}
+
+	// Request context fields
+	if v, ok := record["channel"].(string); ok && v != "" && v != "-" {
+		response.Channel = v
+	}
+	if v, ok := record["method"].(string); ok && v != "" && v != "-" {
+		response.Method = v
+	}
+	if v, ok := record["endpoint"].(string); ok && v != "" && v != "-" {
+		response.Endpoint = v
+	}
+
+	// Correlation fields
+	if v, ok := record["user_id"].(string); ok && v != "" && v != "-" {
+		response.UserID = v
+	}
+	if v, ok := record["session_id"].(string); ok && v != "" && v != "-" {
+		response.SessionID = v
+	}
+	if v, ok := record["request_id"].(string); ok && v != "" && v != "-" {
+		response.RequestID = v
+	}
+	if v, ok := record["trace_id"].(string); ok && v != "" && v != "-" {
+		response.TraceID = v
+	}
+
+	// Technical context fields
+	if v, ok := record["device_type"].(string); ok && v != "" && v != "-" {
+		response.DeviceType = v
+	}
+	if v, ok := record["os"].(string); ok && v != "" && v != "-" {
+		response.OS = v
+	}
+	if v, ok := record["browser"].(string); ok && v != "" && v != "-" {
+		response.Browser = v
+	}
+
+	// Network and client context fields
+	if v, ok := record["ip_address"].(string); ok && v != "" && v != "-" {
+		response.IPAddress = v
+	}
+	if v, ok := record["user_agent"].(string); ok && v != "" && v != "-" {
+		response.UserAgent = v
+	}
+
+	// Geographic fields
+	if v, ok := record["geo_country"].(string); ok && v != "" && v != "-" {
+		response.GeoCountry = v
+	}
+	if v, ok := record["geo_city"].(string); ok && v != "" && v != "-" {
+		response.GeoCity = v
+	}
+	if v, ok := record["geo_coordinates"].(string); ok && v != "" && v != "-" {
+		response.GeoCoordinates = v
+	}
+	if v, ok := record["geo_timezone"].(string); ok && v != "" && v != "-" {
+		response.GeoTimezone = v
+	}
+	if v, ok := record["geo_postal"].(string); ok && v != "" && v != "-" {
+		response.GeoPostal = v
+	}
+	if v, ok := record["geo_isp"].(string); ok && v != "" && v != "-" {
+		response.GeoISP = v
+	}
+
+	// Version fields
+	if v, ok := record["os_version"].(string); ok && v != "" && v != "-" {
+		response.OSVersion = v
+	}
+	if v, ok := record["browser_version"].(string); ok && v != "" && v != "-" {
+		response.BrowserVersion = v
+	}
+
+	// Integer fields - handle different numeric types from InfluxDB
+	if v, ok := record["response_code"]; ok {
+		switch code := v.(type) {
+		case int64:
+			response.ResponseCode = int(code)
+		case float64:
+			response.ResponseCode = int(code)
+		case int:
+			response.ResponseCode = code
+		}
+	}
+
+	if v, ok := record["duration_ms"]; ok {
+		switch duration := v.(type) {
+		case int64:
+			response.DurationMs = int(duration)
+		case float64:
+			response.DurationMs = int(duration)
+		case int:
+			response.DurationMs = duration
+		}
+	}
+
+	// Boolean fields
+	if v, ok := record["handled"]; ok {
+		switch handled := v.(type) {
+		case bool:
+			response.Handled = handled
+		case string:
+			response.Handled = handled == "true" || handled == "1"
+		}
+	}
+
+	if v, ok := record["is_bot"]; ok {
+		switch bot := v.(type) {
+		case bool:
+			response.IsBot = bot
+		case string:
+			response.IsBot = bot == "true" || bot == "1"
+		}
+	}
+
+	// Map/Object fields - deserialize JSON string back to map
+	if v, ok := record["details"].(string); ok && v != "" {
+		var details map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &details); err == nil {
+			response.Details = details
+		}
+	}
+
+	// Generate ID from timestamp and request_id
+	if response.Time != "" && response.RequestID != "" {
+		response.ID = utils.CreateRecordID(response.Time, response.RequestID)
+	}
+
+	return response
+}
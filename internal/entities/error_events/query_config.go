@@ -0,0 +1,113 @@
+package errorevents
+
+import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+)
+
+// GetQueryConfig returns query builder configuration for error events
+func GetQueryConfig() v2oss.QueryBuilderConfig {
+	return v2oss.QueryBuilderConfig{
+		Measurement: "error_events",
+		ValidTags: map[string]bool{
+			// Tags from ToPoint() method
+			"service":     true,
+			"error_type":  true,
+			"severity":    true,
+			"environment": true,
+		},
+		ValidFields: map[string]bool{
+			// Service & Error Context Group
+			"app_version": true,
+			"error_code":  true,
+			"message":     true,
+			"handled":     true,
+
+			// Request Context Group
+			"channel":       true,
+			"method":        true,
+			"endpoint":      true,
+			"response_code": true,
+			"duration_ms":   true,
+
+			// Correlation Group
+			"user_id":    true,
+			"session_id": true,
+			"request_id": true,
+			"trace_id":   true,
+
+			// Technical Context Group
+			"device_type":     true,
+			"os":              true,
+			"os_version":      true,
+			"browser":         true,
+			"browser_version": true,
+			"is_bot":          true,
+
+			// Network & Client Context Group
+			"ip_address": true,
+			"user_agent": true,
+
+			// Geographic Group
+			"geo_country":     true,
+			"geo_city":        true,
+			"geo_coordinates": true,
+			"geo_timezone":    true,
+			"geo_postal":      true,
+			"geo_isp":         true,
+
+			// Note: stack_trace and details are not filterable (large free text)
+		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"response_code": true,
+			"duration_ms":   true,
+		},
+		BoolFields: map[string]bool{
+			// Boolean fields (eq true/false only)
+			"handled": true,
+			"is_bot":  true,
+		},
+		Columns: []string{
+			// Essential columns for error events list view
+			"_time",
+			"service",
+			"environment",
+			"app_version",
+			"error_type",
+			"severity",
+			"error_code",
+			"message",
+			"stack_trace",
+			"handled",
+			"channel",
+			"method",
+			"endpoint",
+			"response_code",
+			"duration_ms",
+			"user_id",
+			"session_id",
+			"request_id",
+			"trace_id",
+			"device_type",
+			"os",
+			"os_version",
+			"browser",
+			"browser_version",
+			"is_bot",
+			"ip_address",
+			"user_agent",
+			"geo_country",
+			"geo_city",
+			"geo_coordinates",
+			"geo_timezone",
+			"geo_postal",
+			"geo_isp",
+			"details",
+		},
+		CountField: "request_id", // Use request_id for counting unique error events
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("error_events"),
+	}
+}
@@ -0,0 +1,16 @@
+package geo
+
+import "github.com/benedict-erwin/insight-collector/pkg/maxmind"
+
+// BatchLookupRequest holds IP addresses of batch geo lookup (max 100 per request)
+type BatchLookupRequest struct {
+	IPs []string `json:"ips" validate:"required,min=1,max=100"`
+}
+
+// LookupResult holds geo, ASN and (when Anonymous IP database is loaded) anonymous network info of single IP
+type LookupResult struct {
+	IP   string                 `json:"ip"`
+	Geo  *maxmind.GeoLocation   `json:"geo"`
+	ASN  *maxmind.ASNInfo       `json:"asn"`
+	Anon *maxmind.AnonymousInfo `json:"anon,omitempty"`
+}
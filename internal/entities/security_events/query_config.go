@@ -1,6 +1,7 @@
 package securityevents
 
 import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 )
 
@@ -70,6 +71,19 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			// Metadata Group
 			"details": true,
 		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"attempt_count":         true,
+			"risk_score":            true,
+			"confidence_score":      true,
+			"previous_success_time": true,
+			"duration_ms":           true,
+			"response_code":         true,
+		},
+		BoolFields: map[string]bool{
+			// Boolean fields (eq true/false only)
+			"is_bot": true,
+		},
 		Columns: []string{
 			// Essential columns for security events list view
 			"_time",
@@ -113,5 +127,8 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			"details",
 		},
 		CountField: "request_id", // Use request_id for counting unique security events
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("security_events"),
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
@@ -89,7 +90,7 @@ type (
 		Method              string                 `json:"method" validate:"required"`
 		RequestID           string                 `json:"request_id"`
 		TraceID             string                 `json:"trace_id"`
-		IdentifierValue     string                 `json:"identifier_value"`
+		IdentifierValue     string                 `json:"identifier_value" validate:"required_with=IdentifierType"`
 		AttemptCount        int                    `json:"attempt_count"`
 		RiskScore           float64                `json:"risk_score"`
 		ConfidenceScore     float64                `json:"confidence_score"`
@@ -159,6 +160,47 @@ func (se *SecurityEvents) ToPoint() interface{} {
 		}
 	}
 
+	fields := map[string]interface{}{
+		"user_id":               safeString(se.UserID),
+		"session_id":            safeString(se.SessionID),
+		"request_id":            safeString(se.RequestID),
+		"trace_id":              safeString(se.TraceID),
+		"identifier_value":      safeString(se.IdentifierValue),
+		"attempt_count":         int(se.AttemptCount),
+		"risk_score":            float64(se.RiskScore),
+		"confidence_score":      float64(se.ConfidenceScore),
+		"previous_success_time": int64(se.PreviousSuccessTime),
+		"affected_resource":     safeString(se.AffectedResource),
+		"duration_ms":           int(se.DurationMs),
+		"response_code":         int(se.ResponseCode),
+		"is_bot":                bool(se.IsBot),
+		"ip_address":            safeString(se.IPAddress),
+		"user_agent":            safeString(se.UserAgent),
+		"app_version":           safeString(se.AppVersion),
+		"endpoint":              safeString(se.Endpoint),
+		"endpoint_group":        safeString(se.EndpointGroup),
+		"browser":               safeString(se.Browser),
+		"os":                    safeString(se.OS),
+		"geo_city":              safeString(se.GeoCity),
+		"geo_coordinates":       safeString(se.GeoCoordinates),
+		"geo_timezone":          safeString(se.GeoTimezone),
+		"geo_postal":            safeString(se.GeoPostal),
+		"geo_isp":               safeString(se.GeoISP),
+		"os_version":            safeString(se.OSVersion),
+		"browser_version":       safeString(se.BrowserVersion),
+		"details":               detailsJSON,
+
+		// Moved from tags to fields (high cardinality)
+		"identifier_type":  safeString(se.IdentifierType),
+		"auth_stage":       safeString(se.AuthStage),
+		"detection_method": safeString(se.DetectionMethod),
+		"device_type":      safeString(se.DeviceType),
+		"method":           safeString(se.Method),
+	}
+
+	// Promote scalar details keys into queryable fields (opt-in, details blob kept)
+	entity.FlattenDetails(fields, se.Details)
+
 	return influxdb.NewPoint(
 		"security_events",
 		map[string]string{
@@ -169,43 +211,7 @@ func (se *SecurityEvents) ToPoint() interface{} {
 			"geo_country":  safeString(se.GeoCountry),  // Geographic threat analysis
 			"action_taken": safeString(se.ActionTaken), // Response tracking
 		},
-		map[string]interface{}{
-			"user_id":               safeString(se.UserID),
-			"session_id":            safeString(se.SessionID),
-			"request_id":            safeString(se.RequestID),
-			"trace_id":              safeString(se.TraceID),
-			"identifier_value":      safeString(se.IdentifierValue),
-			"attempt_count":         int(se.AttemptCount),
-			"risk_score":            float64(se.RiskScore),
-			"confidence_score":      float64(se.ConfidenceScore),
-			"previous_success_time": int64(se.PreviousSuccessTime),
-			"affected_resource":     safeString(se.AffectedResource),
-			"duration_ms":           int(se.DurationMs),
-			"response_code":         int(se.ResponseCode),
-			"is_bot":                bool(se.IsBot),
-			"ip_address":            safeString(se.IPAddress),
-			"user_agent":            safeString(se.UserAgent),
-			"app_version":           safeString(se.AppVersion),
-			"endpoint":              safeString(se.Endpoint),
-			"endpoint_group":        safeString(se.EndpointGroup),
-			"browser":               safeString(se.Browser),
-			"os":                    safeString(se.OS),
-			"geo_city":              safeString(se.GeoCity),
-			"geo_coordinates":       safeString(se.GeoCoordinates),
-			"geo_timezone":          safeString(se.GeoTimezone),
-			"geo_postal":            safeString(se.GeoPostal),
-			"geo_isp":               safeString(se.GeoISP),
-			"os_version":            safeString(se.OSVersion),
-			"browser_version":       safeString(se.BrowserVersion),
-			"details":               detailsJSON,
-
-			// Moved from tags to fields (high cardinality)
-			"identifier_type":  safeString(se.IdentifierType),
-			"auth_stage":       safeString(se.AuthStage),
-			"detection_method": safeString(se.DetectionMethod),
-			"device_type":      safeString(se.DeviceType),
-			"method":           safeString(se.Method),
-		},
+		fields,
 		se.Timestamp,
 	)
 }
@@ -215,6 +221,16 @@ func (se *SecurityEvents) GetName() string {
 	return "security_events"
 }
 
+// Validate enforces details limits, truncating oversized details when configured
+func (r *SecurityEventsRequest) Validate() error {
+	details, err := entity.ValidateDetails(r.Details)
+	if err != nil {
+		return err
+	}
+	r.Details = details
+	return nil
+}
+
 // safeString ensures tag values are never empty (InfluxDB requirement)
 func safeString(s string) string {
 	if s == "" {
@@ -438,3 +454,18 @@ func MapToSecurityEventsResponse(record map[string]interface{}) SecurityEventsRe
 
 	return response
 }
+
+// PIIMasks maps PII JSON fields of security events to same masking as Redact, for raw records (live stream)
+var PIIMasks = map[string]func(string) string{
+	"ip_address": entity.MaskIP,
+	"user_agent": entity.MaskUserAgent,
+}
+
+// Redact masks PII (ip_address, user_agent) of response for clients without PII access, no-op when allowPII
+func Redact(resp *SecurityEventsResponse, allowPII bool) {
+	if allowPII || resp == nil {
+		return
+	}
+	resp.IPAddress = entity.MaskIP(resp.IPAddress)
+	resp.UserAgent = entity.MaskUserAgent(resp.UserAgent)
+}
@@ -0,0 +1,101 @@
+package sessionevents
+
+import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+)
+
+// GetQueryConfig returns query builder configuration for session events
+func GetQueryConfig() v2oss.QueryBuilderConfig {
+	return v2oss.QueryBuilderConfig{
+		Measurement: "session_events",
+		ValidTags: map[string]bool{
+			// Identity Group - Tags from ToPoint() method
+			"session_id": true,
+
+			// Lifecycle Group
+			"event_type":         true,
+			"termination_reason": true,
+			"channel":            true,
+
+			// Geographic Group
+			"geo_country": true,
+		},
+		ValidFields: map[string]bool{
+			// Identity & Lifecycle Group - Fields from ToPoint() method
+			"user_id":          true,
+			"start_time":       true,
+			"end_time":         true,
+			"duration_seconds": true,
+
+			// Device Binding Group
+			"device_fingerprint": true,
+			"device_type":        true,
+			"os":                 true,
+			"browser":            true,
+			"is_bot":             true,
+
+			// Network & Client Context Group
+			"ip_start":    true,
+			"ip_end":      true,
+			"user_agent":  true,
+			"app_version": true,
+			"trace_id":    true,
+
+			// Geographic Details Group
+			"geo_city":        true,
+			"geo_coordinates": true,
+			"geo_timezone":    true,
+			"geo_postal":      true,
+			"geo_isp":         true,
+			"os_version":      true,
+			"browser_version": true,
+
+			// Metadata Group
+			"details": true,
+		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"duration_seconds": true,
+		},
+		BoolFields: map[string]bool{
+			// Boolean fields (eq true/false only)
+			"is_bot": true,
+		},
+		Columns: []string{
+			// Essential columns for session events list view
+			"_time",
+			"session_id",
+			"user_id",
+			"event_type",
+			"termination_reason",
+			"channel",
+			"start_time",
+			"end_time",
+			"duration_seconds",
+			"device_fingerprint",
+			"device_type",
+			"os",
+			"os_version",
+			"browser",
+			"browser_version",
+			"is_bot",
+			"ip_start",
+			"ip_end",
+			"user_agent",
+			"app_version",
+			"trace_id",
+			"geo_country",
+			"geo_city",
+			"geo_coordinates",
+			"geo_timezone",
+			"geo_postal",
+			"geo_isp",
+			"details",
+		},
+		CountField: "user_id", // session_id is a tag, count on always-present field
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("session_events"),
+	}
+}
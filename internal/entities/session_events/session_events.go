@@ -0,0 +1,221 @@
+package sessionevents
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// Ensure SessionEvents satisfies shared entity interface
+var _ entity.Entity = (*SessionEvents)(nil)
+
+type (
+
+	// SESSION LIFECYCLE FOCUSED
+	SessionEvents struct {
+		// TAGS
+		// === IDENTITY GROUP ===
+		SessionID string `json:"session_id"` // Session identifier (unique key for detail lookup)
+
+		// === LIFECYCLE GROUP ===
+		EventType         string `json:"event_type"`         // started/refreshed/ended
+		TerminationReason string `json:"termination_reason"` // logout/timeout/expired/revoked/replaced (ended only)
+		Channel           string `json:"channel"`            // web/mobile_app/api
+
+		// === GEOGRAPHIC GROUP ===
+		GeoCountry string `json:"geo_country"` // ID/SG/MY/TH/US/PH (from ip_start)
+
+		// FIELDS
+		// === IDENTITY GROUP ===
+		UserID string `json:"user_id"` // User owning the session
+
+		// === LIFECYCLE GROUP ===
+		StartTime       time.Time `json:"start_time"`       // Session start
+		EndTime         time.Time `json:"end_time"`         // Session end (zero while session is active)
+		DurationSeconds int64     `json:"duration_seconds"` // Session length, derived from start/end when not sent
+
+		// === DEVICE BINDING GROUP ===
+		DeviceFingerprint string `json:"device_fingerprint"` // Stable device hash bound to session
+		DeviceType        string `json:"device_type"`        // desktop/mobile/tablet
+		OS                string `json:"os"`                 // windows/linux/ios
+		Browser           string `json:"browser"`            // chrome/firefox/safari
+		IsBot             bool   `json:"is_bot"`             // Automated traffic flag
+
+		// === NETWORK & CLIENT CONTEXT GROUP ===
+		IPStart    string `json:"ip_start"`    // IP address at session start
+		IPEnd      string `json:"ip_end"`      // IP address at session end (differs from ip_start on network change)
+		UserAgent  string `json:"user_agent"`  // Client user agent string
+		AppVersion string `json:"app_version"` // Application version
+
+		// === CORRELATION GROUP ===
+		TraceID string `json:"trace_id"` // Distributed tracing ID
+
+		// === GEOGRAPHIC DETAILS GROUP ===
+		GeoCity        string `json:"geo_city"`        // City from IP geolocation
+		GeoCoordinates string `json:"geo_coordinates"` // Latitude,Longitude format
+		GeoTimezone    string `json:"geo_timezone"`    // Timezone from geolocation
+		GeoPostal      string `json:"geo_postal"`      // Postal code from geolocation
+		GeoISP         string `json:"geo_isp"`         // Internet service provider information
+
+		// === METADATA GROUP ===
+		OSVersion      string                 `json:"os_version"`      // OS version
+		BrowserVersion string                 `json:"browser_version"` // Browser version
+		Details        map[string]interface{} `json:"details"`
+
+		// Timestamp
+		Timestamp time.Time
+	}
+
+	SessionEventsRequest struct {
+		SessionID         string                 `json:"session_id" validate:"required"`
+		UserID            string                 `json:"user_id" validate:"required"`
+		EventType         string                 `json:"event_type" validate:"required"`
+		TerminationReason string                 `json:"termination_reason"`
+		Channel           string                 `json:"channel"`
+		StartTime         time.Time              `json:"start_time" validate:"required"`
+		EndTime           time.Time              `json:"end_time"`
+		DurationSeconds   int64                  `json:"duration_seconds" validate:"gte=0"`
+		DeviceFingerprint string                 `json:"device_fingerprint"`
+		IPStart           string                 `json:"ip_start" validate:"required"`
+		IPEnd             string                 `json:"ip_end"`
+		UserAgent         string                 `json:"user_agent" validate:"required"`
+		AppVersion        string                 `json:"app_version"`
+		TraceID           string                 `json:"trace_id"`
+		Details           map[string]interface{} `json:"details"`
+		Timestamp         time.Time              `json:"time" validate:"required"`
+	}
+
+	// SessionEventsResponse represents the response structure for session events
+	SessionEventsResponse struct {
+		ID                string                 `json:"id"`
+		Time              string                 `json:"time" record:"_time"`
+		SessionID         string                 `json:"session_id"`
+		UserID            string                 `json:"user_id"`
+		EventType         string                 `json:"event_type"`
+		TerminationReason string                 `json:"termination_reason"`
+		Channel           string                 `json:"channel"`
+		StartTime         string                 `json:"start_time"`
+		EndTime           string                 `json:"end_time"`
+		DurationSeconds   int64                  `json:"duration_seconds"`
+		DeviceFingerprint string                 `json:"device_fingerprint"`
+		DeviceType        string                 `json:"device_type"`
+		OS                string                 `json:"os"`
+		Browser           string                 `json:"browser"`
+		IsBot             bool                   `json:"is_bot"`
+		IPStart           string                 `json:"ip_start"`
+		IPEnd             string                 `json:"ip_end"`
+		UserAgent         string                 `json:"user_agent"`
+		AppVersion        string                 `json:"app_version"`
+		TraceID           string                 `json:"trace_id"`
+		GeoCountry        string                 `json:"geo_country"`
+		GeoCity           string                 `json:"geo_city"`
+		GeoCoordinates    string                 `json:"geo_coordinates"`
+		GeoTimezone       string                 `json:"geo_timezone"`
+		GeoPostal         string                 `json:"geo_postal"`
+		GeoISP            string                 `json:"geo_isp"`
+		OSVersion         string                 `json:"os_version"`
+		BrowserVersion    string                 `json:"browser_version"`
+		Details           map[string]interface{} `json:"details"`
+	}
+)
+
+// ToPoint converts SessionEvents to InfluxDB point with tags and fields
+func (se *SessionEvents) ToPoint() interface{} {
+	// Serialize details to JSON string for InfluxDB storage
+	var detailsJSON string
+	if len(se.Details) > 0 {
+		if jsonBytes, err := json.Marshal(se.Details); err == nil {
+			detailsJSON = string(jsonBytes)
+		}
+	}
+
+	fields := map[string]interface{}{
+		// String fields - consistent type
+		"user_id":            entity.SafeString(se.UserID),
+		"start_time":         formatSessionTime(se.StartTime),
+		"end_time":           formatSessionTime(se.EndTime),
+		"device_fingerprint": entity.SafeString(se.DeviceFingerprint),
+		"device_type":        entity.SafeString(se.DeviceType),
+		"os":                 entity.SafeString(se.OS),
+		"browser":            entity.SafeString(se.Browser),
+		"ip_start":           entity.SafeString(se.IPStart),
+		"ip_end":             entity.SafeString(se.IPEnd),
+		"user_agent":         entity.SafeString(se.UserAgent),
+		"app_version":        entity.SafeString(se.AppVersion),
+		"trace_id":           entity.SafeString(se.TraceID),
+		"geo_city":           entity.SafeString(se.GeoCity),
+		"geo_coordinates":    entity.SafeString(se.GeoCoordinates),
+		"geo_timezone":       entity.SafeString(se.GeoTimezone),
+		"geo_postal":         entity.SafeString(se.GeoPostal),
+		"geo_isp":            entity.SafeString(se.GeoISP),
+		"os_version":         entity.SafeString(se.OSVersion),
+		"browser_version":    entity.SafeString(se.BrowserVersion),
+
+		// Integer fields - consistent type
+		"duration_seconds": int64(se.DurationSeconds),
+
+		// Boolean fields - consistent type
+		"is_bot": bool(se.IsBot),
+
+		// Map/Object fields - serialize to JSON string
+		"details": detailsJSON,
+	}
+
+	// Promote scalar details keys into queryable fields (opt-in, details blob kept)
+	entity.FlattenDetails(fields, se.Details)
+
+	return influxdb.NewPoint(
+		"session_events",
+		map[string]string{
+			// OPTIMIZED: session_id tag groups lifecycle points of one session, others are low cardinality
+			"session_id":         entity.SafeString(se.SessionID),         // Session correlation
+			"event_type":         entity.SafeString(se.EventType),         // Lifecycle stage
+			"termination_reason": entity.SafeString(se.TerminationReason), // Why session ended
+			"channel":            entity.SafeString(se.Channel),           // Session origin
+			"geo_country":        entity.SafeString(se.GeoCountry),        // Geographic analysis
+		},
+		fields,
+		se.Timestamp,
+	)
+}
+
+// GetName returns the measurement name for this entity
+func (se *SessionEvents) GetName() string {
+	return "session_events"
+}
+
+// Validate enforces details limits (depth, keys, size)
+func (r *SessionEventsRequest) Validate() error {
+	details, err := entity.ValidateDetails(r.Details)
+	if err != nil {
+		return err
+	}
+	r.Details = details
+	return nil
+}
+
+// formatSessionTime formats start/end time as RFC3339 (EmptyValue when not set)
+func formatSessionTime(t time.Time) string {
+	if t.IsZero() {
+		return entity.EmptyValue
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// MapToSessionEventsResponse converts raw InfluxDB record to SessionEventsResponse struct
+func MapToSessionEventsResponse(record map[string]interface{}) SessionEventsResponse {
+	response := SessionEventsResponse{}
+
+	// Populate fields by json/record tags
+	entity.MapRecord(record, &response)
+
+	// Generate ID from timestamp and session_id
+	if response.Time != "" && response.SessionID != "" {
+		response.ID = utils.CreateRecordID(response.Time, response.SessionID)
+	}
+
+	return response
+}
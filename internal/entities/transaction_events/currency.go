@@ -0,0 +1,100 @@
+package transactionevents
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+)
+
+// DefaultCurrencyDecimals is used for currencies without registered minor unit
+const DefaultCurrencyDecimals = 2
+
+// maxExactMinor is largest minor amount float64 represents exactly (2^53)
+const maxExactMinor = 1 << 53
+
+// Minor unit decimals per currency (IDR has no minor unit in practice), extend with RegisterCurrencyDecimals
+var (
+	currencyDecimals = map[string]int{
+		"IDR": 0,
+		"USD": 2,
+		"SGD": 2,
+		"MYR": 2,
+		"THB": 2,
+		"PHP": 2,
+		"JPY": 0,
+		"KRW": 0,
+		"VND": 0,
+	}
+	currencyDecimalsMutex sync.RWMutex
+)
+
+// RegisterCurrencyDecimals sets number of minor unit decimals for currency (safe at runtime).
+// New currencies must also be added to ValidCurrencies to pass `enum=currency` validation.
+func RegisterCurrencyDecimals(currency string, decimals int) {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	currencyDecimalsMutex.Lock()
+	defer currencyDecimalsMutex.Unlock()
+	currencyDecimals[currency] = decimals
+}
+
+// CurrencyDecimals returns minor unit decimals for currency (DefaultCurrencyDecimals when unknown)
+func CurrencyDecimals(currency string) int {
+	currencyDecimalsMutex.RLock()
+	defer currencyDecimalsMutex.RUnlock()
+	if decimals, ok := currencyDecimals[currency]; ok {
+		return decimals
+	}
+	return DefaultCurrencyDecimals
+}
+
+// ToMinorUnits converts amount to integer minor units of currency (USD 12.34 => 1234, IDR 15000 => 15000).
+// Returns error when amount has more decimals than currency allows or is too large to be exact,
+// rounded value is still returned so callers may fall back to it.
+func ToMinorUnits(amount float64, currency string) (int64, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("amount %v is not a finite number", amount)
+	}
+
+	decimals := CurrencyDecimals(currency)
+	scaled := amount * math.Pow10(decimals)
+	minor := math.Round(scaled)
+	if math.Abs(minor) > maxExactMinor {
+		return int64(minor), fmt.Errorf("amount %v is too large to be represented exactly", amount)
+	}
+
+	// Tolerate binary float noise (19.99*100 = 1998.9999999999998), reject real extra precision
+	if math.Abs(scaled-minor) > 1e-6 {
+		return int64(minor), fmt.Errorf("amount %v has more than %d decimal places allowed for %s", amount, decimals, currency)
+	}
+	return int64(minor), nil
+}
+
+// Validate checks amount precision against request currency and details limits (runs after struct tag validation)
+func (r *TransactionEventsRequest) Validate() error {
+	amounts := []struct {
+		field string
+		value float64
+	}{
+		{"amount", r.Amount},
+		{"fee_amount", r.FeeAmount},
+		{"net_amount", r.NetAmount},
+	}
+
+	for _, a := range amounts {
+		if _, err := ToMinorUnits(a.value, r.Currency); err != nil {
+			return fmt.Errorf("field '%s' is invalid: %v", a.field, err)
+		}
+	}
+
+	details, err := entity.ValidateDetails(r.Details)
+	if err != nil {
+		return err
+	}
+	r.Details = details
+	return nil
+}
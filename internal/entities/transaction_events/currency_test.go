@@ -0,0 +1,61 @@
+package transactionevents
+
+import "testing"
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{12.34, "USD", 1234, false},
+		{19.99, "USD", 1999, false},
+		{0.1 + 0.2, "USD", 30, false},
+		{15000, "IDR", 15000, false},
+		{10, "ZZZ", 1000, false}, // Unknown currency uses default decimals
+		{12.345, "USD", 1235, true},
+		{15000.5, "IDR", 15001, true},
+		{1e17, "IDR", 1e17, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ToMinorUnits(tt.amount, tt.currency)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ToMinorUnits(%v, %s) error = %v, wantErr %v", tt.amount, tt.currency, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ToMinorUnits(%v, %s) = %d, want %d", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestRegisterCurrencyDecimals(t *testing.T) {
+	RegisterCurrencyDecimals("KWD", 3)
+	t.Cleanup(func() {
+		currencyDecimalsMutex.Lock()
+		delete(currencyDecimals, "KWD")
+		currencyDecimalsMutex.Unlock()
+	})
+
+	if got, err := ToMinorUnits(1.234, "KWD"); err != nil || got != 1234 {
+		t.Errorf("expected 1234 minor units for KWD, got %d (%v)", got, err)
+	}
+}
+
+func TestTransactionEventsRequestValidate(t *testing.T) {
+	req := TransactionEventsRequest{Currency: "USD", Amount: 10.5, FeeAmount: 0.25, NetAmount: 10.25}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	req.FeeAmount = 0.001
+	if err := req.Validate(); err == nil {
+		t.Error("expected error for fee_amount with 3 decimals in USD")
+	}
+
+	req = TransactionEventsRequest{Currency: "IDR", Amount: 1500000.75}
+	if err := req.Validate(); err == nil {
+		t.Error("expected error for IDR amount with decimals")
+	}
+}
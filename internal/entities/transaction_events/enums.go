@@ -0,0 +1,98 @@
+package transactionevents
+
+import (
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
+)
+
+// Enum set names used by `enum=<name>` validation tag
+const (
+	EnumTransactionType   = "transaction_type"
+	EnumCurrency          = "currency"
+	EnumPaymentMethod     = "payment_method"
+	EnumStatus            = "transaction_status"
+	EnumTransactionNature = "transaction_nature"
+	EnumMerchantCategory  = "merchant_category"
+	EnumRiskLevel         = "risk_level"
+)
+
+// Allowed values for transaction enum fields, extend by adding keys
+var (
+	ValidTransactionTypes = map[string]bool{
+		"transfer": true,
+		"payment":  true,
+		"topup":    true,
+		"withdraw": true,
+		"refund":   true,
+	}
+
+	ValidCurrencies = map[string]bool{
+		"IDR": true,
+		"USD": true,
+		"SGD": true,
+		"MYR": true,
+		"THB": true,
+		"PHP": true,
+	}
+
+	ValidPaymentMethods = map[string]bool{
+		"bank_transfer":   true,
+		"ewallet":         true,
+		"virtual_account": true,
+		"qris":            true,
+		"credit_card":     true,
+	}
+
+	ValidStatuses = map[string]bool{
+		"initiated":  true,
+		"validated":  true,
+		"processing": true,
+		"completed":  true,
+		"failed":     true,
+		"cancelled":  true,
+		"expired":    true,
+	}
+
+	ValidTransactionNatures = map[string]bool{
+		"normal":     true,
+		"reversal":   true,
+		"chargeback": true,
+		"refund":     true,
+	}
+
+	ValidMerchantCategories = map[string]bool{
+		"retail":     true,
+		"food":       true,
+		"transport":  true,
+		"utilities":  true,
+		"healthcare": true,
+		"other":      true,
+	}
+
+	ValidRiskLevels = map[string]bool{
+		"low":      true,
+		"medium":   true,
+		"high":     true,
+		"critical": true,
+	}
+)
+
+func init() {
+	entity.RegisterEnum(EnumTransactionType, ValidTransactionTypes)
+	entity.RegisterEnum(EnumCurrency, ValidCurrencies)
+	entity.RegisterEnum(EnumPaymentMethod, ValidPaymentMethods)
+	entity.RegisterEnum(EnumStatus, ValidStatuses)
+	entity.RegisterEnum(EnumTransactionNature, ValidTransactionNatures)
+	entity.RegisterEnum(EnumMerchantCategory, ValidMerchantCategories)
+	entity.RegisterEnum(EnumRiskLevel, ValidRiskLevels)
+}
+
+// Normalize trims enum fields and converts them to canonical case before validation
+func (r *TransactionEventsRequest) Normalize() {
+	r.TransactionType = entity.NormalizeEnum(ValidTransactionTypes, r.TransactionType)
+	r.Currency = entity.NormalizeEnum(ValidCurrencies, r.Currency)
+	r.PaymentMethod = entity.NormalizeEnum(ValidPaymentMethods, r.PaymentMethod)
+	r.Status = entity.NormalizeEnum(ValidStatuses, r.Status)
+	r.TransactionNature = entity.NormalizeEnum(ValidTransactionNatures, r.TransactionNature)
+	r.MerchantCategory = entity.NormalizeEnum(ValidMerchantCategories, r.MerchantCategory)
+	r.RiskLevel = entity.NormalizeEnum(ValidRiskLevels, r.RiskLevel)
+}
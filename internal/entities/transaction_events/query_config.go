@@ -1,6 +1,7 @@
 package transactionevents
 
 import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 )
 
@@ -80,6 +81,23 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			// Metadata Group
 			"details": true,
 		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"amount":             true,
+			"fee_amount":         true,
+			"net_amount":         true,
+			"exchange_rate":      true,
+			"compliance_score":   true,
+			"processing_time_ms": true,
+			"duration_ms":        true,
+			"retry_count":        true,
+			"response_code":      true,
+		},
+		BoolFields: map[string]bool{
+			// Boolean fields (eq true/false only)
+			"approval_required": true,
+			"is_bot":            true,
+		},
 		Columns: []string{
 			// Essential columns for transaction events list view
 			"_time",
@@ -129,5 +147,8 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			"details",
 		},
 		CountField: "request_id", // Use request_id for counting unique transaction events
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("transaction_events"),
 	}
 }
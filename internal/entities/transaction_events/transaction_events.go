@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
@@ -43,6 +44,7 @@ type (
 
 		// === FINANCIAL DATA GROUP ===
 		Amount       float64 `json:"amount"`        // Primary transaction amount
+		AmountMinor  int64   `json:"amount_minor"`  // Amount in currency minor units (exact arithmetic)
 		FeeAmount    float64 `json:"fee_amount"`    // Fee charged to user
 		NetAmount    float64 `json:"net_amount"`    // Final amount after fee deduction
 		ExchangeRate float64 `json:"exchange_rate"` // Currency conversion rate (if applicable)
@@ -90,14 +92,14 @@ type (
 	TransactionEventsRequest struct {
 		UserID              string                 `json:"user_id" validate:"required"`
 		SessionID           string                 `json:"session_id" validate:"required"`
-		TransactionType     string                 `json:"transaction_type"`
-		Currency            string                 `json:"currency" validate:"required"`
-		PaymentMethod       string                 `json:"payment_method"`
-		Status              string                 `json:"status"`
-		TransactionNature   string                 `json:"transaction_nature"`
-		MerchantCategory    string                 `json:"merchant_category"`
+		TransactionType     string                 `json:"transaction_type" validate:"omitempty,enum=transaction_type"`
+		Currency            string                 `json:"currency" validate:"required,enum=currency"`
+		PaymentMethod       string                 `json:"payment_method" validate:"omitempty,enum=payment_method"`
+		Status              string                 `json:"status" validate:"omitempty,enum=transaction_status"`
+		TransactionNature   string                 `json:"transaction_nature" validate:"omitempty,enum=transaction_nature"`
+		MerchantCategory    string                 `json:"merchant_category" validate:"omitempty,enum=merchant_category"`
 		Channel             string                 `json:"channel"`
-		RiskLevel           string                 `json:"risk_level"`
+		RiskLevel           string                 `json:"risk_level" validate:"omitempty,enum=risk_level"`
 		RequestID           string                 `json:"request_id"`
 		TraceID             string                 `json:"trace_id"`
 		TransactionID       string                 `json:"transaction_id"`
@@ -144,6 +146,7 @@ type (
 		TransactionID       string                 `json:"transaction_id"`
 		ExternalReferenceID string                 `json:"external_reference_id"`
 		Amount              float64                `json:"amount"`
+		AmountMinor         int64                  `json:"amount_minor"`
 		FeeAmount           float64                `json:"fee_amount"`
 		NetAmount           float64                `json:"net_amount"`
 		ExchangeRate        float64                `json:"exchange_rate"`
@@ -181,6 +184,54 @@ func (te *TransactionEvents) ToPoint() interface{} {
 		}
 	}
 
+	fields := map[string]interface{}{
+		"user_id":               safeString(te.UserID),
+		"session_id":            safeString(te.SessionID),
+		"request_id":            safeString(te.RequestID),
+		"trace_id":              safeString(te.TraceID),
+		"transaction_id":        safeString(te.TransactionID),
+		"external_reference_id": safeString(te.ExternalReferenceID),
+		"amount":                float64(te.Amount),
+		"amount_minor":          int64(te.AmountMinor),
+		"fee_amount":            float64(te.FeeAmount),
+		"net_amount":            float64(te.NetAmount),
+		"exchange_rate":         float64(te.ExchangeRate),
+		"processing_time_ms":    int(te.ProcessingTimeMs),
+		"duration_ms":           int(te.DurationMs),
+		"retry_count":           int(te.RetryCount),
+		"response_code":         int(te.ResponseCode),
+		"approval_required":     bool(te.ApprovalRequired),
+		"compliance_score":      float64(te.ComplianceScore),
+		"is_bot":                bool(te.IsBot),
+		"merchant_id":           safeString(te.MerchantID),
+		"destination_account":   safeString(te.DestinationAccount),
+		"ip_address":            safeString(te.IPAddress),
+		"user_agent":            safeString(te.UserAgent),
+		"browser":               safeString(te.Browser),
+		"os":                    safeString(te.OS),
+		"app_version":           safeString(te.AppVersion),
+		"endpoint":              safeString(te.Endpoint),
+		"method":                safeString(te.Method),
+		"geo_city":              safeString(te.GeoCity),
+		"geo_coordinates":       safeString(te.GeoCoordinates),
+		"geo_timezone":          safeString(te.GeoTimezone),
+		"geo_postal":            safeString(te.GeoPostal),
+		"geo_isp":               safeString(te.GeoISP),
+		"os_version":            safeString(te.OSVersion),
+		"browser_version":       safeString(te.BrowserVersion),
+		"details":               detailsJSON,
+
+		// Moved from tags to fields (high cardinality)
+		"payment_method":     safeString(te.PaymentMethod),
+		"transaction_nature": safeString(te.TransactionNature),
+		"merchant_category":  safeString(te.MerchantCategory),
+		"device_type":        safeString(te.DeviceType),
+		"geo_country":        safeString(te.GeoCountry),
+	}
+
+	// Promote scalar details keys into queryable fields (opt-in, details blob kept)
+	entity.FlattenDetails(fields, te.Details)
+
 	return influxdb.NewPoint(
 		"transaction_events",
 		map[string]string{
@@ -191,49 +242,7 @@ func (te *TransactionEvents) ToPoint() interface{} {
 			"channel":          safeString(te.Channel),         // User journey tracking
 			"risk_level":       safeString(te.RiskLevel),       // Security monitoring
 		},
-		map[string]interface{}{
-			"user_id":               safeString(te.UserID),
-			"session_id":            safeString(te.SessionID),
-			"request_id":            safeString(te.RequestID),
-			"trace_id":              safeString(te.TraceID),
-			"transaction_id":        safeString(te.TransactionID),
-			"external_reference_id": safeString(te.ExternalReferenceID),
-			"amount":                float64(te.Amount),
-			"fee_amount":            float64(te.FeeAmount),
-			"net_amount":            float64(te.NetAmount),
-			"exchange_rate":         float64(te.ExchangeRate),
-			"processing_time_ms":    int(te.ProcessingTimeMs),
-			"duration_ms":           int(te.DurationMs),
-			"retry_count":           int(te.RetryCount),
-			"response_code":         int(te.ResponseCode),
-			"approval_required":     bool(te.ApprovalRequired),
-			"compliance_score":      float64(te.ComplianceScore),
-			"is_bot":                bool(te.IsBot),
-			"merchant_id":           safeString(te.MerchantID),
-			"destination_account":   safeString(te.DestinationAccount),
-			"ip_address":            safeString(te.IPAddress),
-			"user_agent":            safeString(te.UserAgent),
-			"browser":               safeString(te.Browser),
-			"os":                    safeString(te.OS),
-			"app_version":           safeString(te.AppVersion),
-			"endpoint":              safeString(te.Endpoint),
-			"method":                safeString(te.Method),
-			"geo_city":              safeString(te.GeoCity),
-			"geo_coordinates":       safeString(te.GeoCoordinates),
-			"geo_timezone":          safeString(te.GeoTimezone),
-			"geo_postal":            safeString(te.GeoPostal),
-			"geo_isp":               safeString(te.GeoISP),
-			"os_version":            safeString(te.OSVersion),
-			"browser_version":       safeString(te.BrowserVersion),
-			"details":               detailsJSON,
-
-			// Moved from tags to fields (high cardinality)
-			"payment_method":     safeString(te.PaymentMethod),
-			"transaction_nature": safeString(te.TransactionNature),
-			"merchant_category":  safeString(te.MerchantCategory),
-			"device_type":        safeString(te.DeviceType),
-			"geo_country":        safeString(te.GeoCountry),
-		},
+		fields,
 		te.Timestamp,
 	)
 }
@@ -395,6 +404,17 @@ func MapToTransactionEventsResponse(record map[string]interface{}) TransactionEv
 		}
 	}
 
+	if v, ok := record["amount_minor"]; ok {
+		switch minor := v.(type) {
+		case int64:
+			response.AmountMinor = minor
+		case float64:
+			response.AmountMinor = int64(minor)
+		case int:
+			response.AmountMinor = int64(minor)
+		}
+	}
+
 	if v, ok := record["fee_amount"]; ok {
 		switch fee := v.(type) {
 		case float64:
@@ -526,3 +546,21 @@ func MapToTransactionEventsResponse(record map[string]interface{}) TransactionEv
 
 	return response
 }
+
+// PIIMasks maps PII JSON fields of transaction events to same masking as Redact, for raw records (live stream)
+var PIIMasks = map[string]func(string) string{
+	"ip_address":          entity.MaskIP,
+	"destination_account": entity.MaskAccount,
+	"user_agent":          entity.MaskUserAgent,
+}
+
+// Redact masks PII (ip_address, destination_account, user_agent) of response for clients without PII access,
+// no-op when allowPII
+func Redact(resp *TransactionEventsResponse, allowPII bool) {
+	if allowPII || resp == nil {
+		return
+	}
+	resp.IPAddress = entity.MaskIP(resp.IPAddress)
+	resp.DestinationAccount = entity.MaskAccount(resp.DestinationAccount)
+	resp.UserAgent = entity.MaskUserAgent(resp.UserAgent)
+}
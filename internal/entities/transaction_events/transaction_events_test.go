@@ -0,0 +1,30 @@
+package transactionevents
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	raw := TransactionEventsResponse{
+		IPAddress:          "203.0.113.9",
+		DestinationAccount: "ACC-998877",
+		UserAgent:          "Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X)",
+		UserID:             "user-1",
+	}
+
+	allowed := raw
+	Redact(&allowed, true)
+	if !reflect.DeepEqual(allowed, raw) {
+		t.Errorf("expected response untouched with PII access, got %+v", allowed)
+	}
+
+	redacted := raw
+	Redact(&redacted, false)
+	if redacted.IPAddress != "203.0.113.*" || redacted.DestinationAccount != "******8877" || redacted.UserAgent != "Mozilla/5.0 *" {
+		t.Errorf("unexpected redacted PII %+v", redacted)
+	}
+	if redacted.UserID != raw.UserID {
+		t.Errorf("expected non-PII fields kept, got user_id %q", redacted.UserID)
+	}
+}
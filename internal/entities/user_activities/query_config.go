@@ -1,6 +1,7 @@
 package useractivities
 
 import (
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
 )
 
@@ -62,6 +63,17 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			// Metadata Group
 			"details": true,
 		},
+		NumericFields: map[string]bool{
+			// Numeric fields (range operators: gt/gte/lt/lte)
+			"duration_ms":         true,
+			"response_code":       true,
+			"request_size_bytes":  true,
+			"response_size_bytes": true,
+		},
+		BoolFields: map[string]bool{
+			// Boolean fields (eq true/false only)
+			"is_bot": true,
+		},
 		Columns: []string{
 			// Essential columns for list view
 			"_time",
@@ -101,5 +113,8 @@ func GetQueryConfig() v2oss.QueryBuilderConfig {
 			"details",
 		},
 		CountField: "request_id", // Use request_id for counting unique records
+
+		// Hot/cold bucket override from influxdb.buckets (empty = default bucket)
+		Bucket: influxdb.MeasurementBucket("user_activities"),
 	}
 }
@@ -4,10 +4,14 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/benedict-erwin/insight-collector/internal/entities/entity"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
 
+// Ensure UserActivities satisfies shared entity interface
+var _ entity.Entity = (*UserActivities)(nil)
+
 type (
 
 	// TECH & BEHAVIOR FOCUSED
@@ -101,7 +105,7 @@ type (
 	// UserActivitiesResponse represents the response structure for user activities
 	UserActivitiesResponse struct {
 		ID             string                 `json:"id"`
-		Time           string                 `json:"time"`
+		Time           string                 `json:"time" record:"_time"`
 		UserID         string                 `json:"user_id"`
 		SessionID      string                 `json:"session_id"`
 		ActivityType   string                 `json:"activity_type"`
@@ -147,56 +151,61 @@ func (ua *UserActivities) ToPoint() interface{} {
 		}
 	}
 
+	fields := map[string]interface{}{
+		// String fields - consistent type (including moved from tags)
+		"user_id":         entity.SafeString(ua.UserID),
+		"session_id":      entity.SafeString(ua.SessionID),
+		"request_id":      entity.SafeString(ua.RequestID),
+		"trace_id":        entity.SafeString(ua.TraceID),
+		"ip_address":      entity.SafeString(ua.IPAddress),
+		"user_agent":      entity.SafeString(ua.UserAgent),
+		"app_version":     entity.SafeString(ua.AppVersion),
+		"referrer_url":    entity.SafeString(ua.ReferrerURL),
+		"endpoint":        entity.SafeString(ua.Endpoint),
+		"geo_city":        entity.SafeString(ua.GeoCity),
+		"geo_coordinates": entity.SafeString(ua.GeoCoordinates),
+		"geo_timezone":    entity.SafeString(ua.GeoTimezone),
+		"geo_postal":      entity.SafeString(ua.GeoPostal),
+		"geo_isp":         entity.SafeString(ua.GeoISP),
+		"os_version":      entity.SafeString(ua.OSVersion),
+		"browser_version": entity.SafeString(ua.BrowserVersion),
+		"subcategory":     entity.SafeString(ua.Subcategory),
+		"endpoint_group":  entity.SafeString(ua.EndpointGroup),
+		"browser":         entity.SafeString(ua.Browser),
+		"os":              entity.SafeString(ua.OS),
+
+		// Moved from tags to fields (high cardinality)
+		"category":    entity.SafeString(ua.Category),
+		"device_type": entity.SafeString(ua.DeviceType),
+		"method":      entity.SafeString(ua.Method),
+
+		// Integer fields - consistent type
+		"duration_ms":         int64(ua.DurationMs),
+		"response_code":       int64(ua.ResponseCode),
+		"request_size_bytes":  int64(ua.RequestSizeBytes),
+		"response_size_bytes": int64(ua.ResponseSizeBytes),
+
+		// Boolean fields - consistent type
+		"is_bot": bool(ua.IsBot),
+
+		// Map/Object fields - serialize to JSON string
+		"details": detailsJSON,
+	}
+
+	// Promote scalar details keys into queryable fields (opt-in, details blob kept)
+	entity.FlattenDetails(fields, ua.Details)
+
 	return influxdb.NewPoint(
 		"user_activities",
 		map[string]string{
 			// OPTIMIZED: 5 carefully selected tags for user journey analytics
-			"activity_type": safeString(ua.ActivityType), // Core business logic
-			"status":        safeString(ua.Status),       // Operational status
-			"channel":       safeString(ua.Channel),      // User journey tracking
-			"geo_country":   safeString(ua.GeoCountry),   // Geographic analysis
-			"risk_level":    safeString(ua.RiskLevel),    // Security monitoring
-		},
-		map[string]interface{}{
-			// String fields - consistent type (including moved from tags)
-			"user_id":         safeString(ua.UserID),
-			"session_id":      safeString(ua.SessionID),
-			"request_id":      safeString(ua.RequestID),
-			"trace_id":        safeString(ua.TraceID),
-			"ip_address":      safeString(ua.IPAddress),
-			"user_agent":      safeString(ua.UserAgent),
-			"app_version":     safeString(ua.AppVersion),
-			"referrer_url":    safeString(ua.ReferrerURL),
-			"endpoint":        safeString(ua.Endpoint),
-			"geo_city":        safeString(ua.GeoCity),
-			"geo_coordinates": safeString(ua.GeoCoordinates),
-			"geo_timezone":    safeString(ua.GeoTimezone),
-			"geo_postal":      safeString(ua.GeoPostal),
-			"geo_isp":         safeString(ua.GeoISP),
-			"os_version":      safeString(ua.OSVersion),
-			"browser_version": safeString(ua.BrowserVersion),
-			"subcategory":     safeString(ua.Subcategory),
-			"endpoint_group":  safeString(ua.EndpointGroup),
-			"browser":         safeString(ua.Browser),
-			"os":              safeString(ua.OS),
-
-			// Moved from tags to fields (high cardinality)
-			"category":    safeString(ua.Category),
-			"device_type": safeString(ua.DeviceType),
-			"method":      safeString(ua.Method),
-
-			// Integer fields - consistent type
-			"duration_ms":         int64(ua.DurationMs),
-			"response_code":       int64(ua.ResponseCode),
-			"request_size_bytes":  int64(ua.RequestSizeBytes),
-			"response_size_bytes": int64(ua.ResponseSizeBytes),
-
-			// Boolean fields - consistent type
-			"is_bot": bool(ua.IsBot),
-
-			// Map/Object fields - serialize to JSON string
-			"details": detailsJSON,
+			"activity_type": entity.SafeString(ua.ActivityType), // Core business logic
+			"status":        entity.SafeString(ua.Status),       // Operational status
+			"channel":       entity.SafeString(ua.Channel),      // User journey tracking
+			"geo_country":   entity.SafeString(ua.GeoCountry),   // Geographic analysis
+			"risk_level":    entity.SafeString(ua.RiskLevel),    // Security monitoring
 		},
+		fields,
 		ua.Timestamp,
 	)
 }
@@ -206,168 +215,22 @@ func (ua *UserActivities) GetName() string {
 	return "user_activities"
 }
 
-// safeString ensures tag values are never empty (InfluxDB requirement)
-func safeString(s string) string {
-	if s == "" {
-		return "-"
+// Validate enforces details limits (see entity.ValidateDetails), runs after struct tag validation
+func (r *UserActivitiesRequest) Validate() error {
+	details, err := entity.ValidateDetails(r.Details)
+	if err != nil {
+		return err
 	}
-	return s
+	r.Details = details
+	return nil
 }
 
 // MapToUserActivitiesResponse converts raw InfluxDB record to UserActivitiesResponse struct
 func MapToUserActivitiesResponse(record map[string]interface{}) UserActivitiesResponse {
 	response := UserActivitiesResponse{}
 
-	// Parse time field
-	if v, ok := record["_time"]; ok {
-		switch timeVal := v.(type) {
-		case string:
-			response.Time = timeVal
-		case time.Time:
-			response.Time = timeVal.Format(time.RFC3339)
-		}
-	}
-
-	// Core identity fields
-	if v, ok := record["user_id"].(string); ok && v != "" && v != "-" {
-		response.UserID = v
-	}
-	if v, ok := record["session_id"].(string); ok && v != "" && v != "-" {
-		response.SessionID = v
-	}
-
-	// Business context fields
-	if v, ok := record["activity_type"].(string); ok && v != "" && v != "-" {
-		response.ActivityType = v
-	}
-	if v, ok := record["category"].(string); ok && v != "" && v != "-" {
-		response.Category = v
-	}
-	if v, ok := record["subcategory"].(string); ok && v != "" && v != "-" {
-		response.Subcategory = v
-	}
-	if v, ok := record["status"].(string); ok && v != "" && v != "-" {
-		response.Status = v
-	}
-
-	// Technical context fields
-	if v, ok := record["browser"].(string); ok && v != "" && v != "-" {
-		response.Browser = v
-	}
-	if v, ok := record["device_type"].(string); ok && v != "" && v != "-" {
-		response.DeviceType = v
-	}
-	if v, ok := record["os"].(string); ok && v != "" && v != "-" {
-		response.OS = v
-	}
-	if v, ok := record["channel"].(string); ok && v != "" && v != "-" {
-		response.Channel = v
-	}
-	if v, ok := record["endpoint_group"].(string); ok && v != "" && v != "-" {
-		response.EndpointGroup = v
-	}
-	if v, ok := record["method"].(string); ok && v != "" && v != "-" {
-		response.Method = v
-	}
-
-	// Geographic fields
-	if v, ok := record["geo_country"].(string); ok && v != "" && v != "-" {
-		response.GeoCountry = v
-	}
-	if v, ok := record["geo_city"].(string); ok && v != "" && v != "-" {
-		response.GeoCity = v
-	}
-	if v, ok := record["geo_coordinates"].(string); ok && v != "" && v != "-" {
-		response.GeoCoordinates = v
-	}
-	if v, ok := record["geo_timezone"].(string); ok && v != "" && v != "-" {
-		response.GeoTimezone = v
-	}
-	if v, ok := record["geo_postal"].(string); ok && v != "" && v != "-" {
-		response.GeoPostal = v
-	}
-	if v, ok := record["geo_isp"].(string); ok && v != "" && v != "-" {
-		response.GeoISP = v
-	}
-
-	// Security and assessment fields
-	if v, ok := record["risk_level"].(string); ok && v != "" && v != "-" {
-		response.RiskLevel = v
-	}
-
-	// Correlation fields
-	if v, ok := record["request_id"].(string); ok && v != "" && v != "-" {
-		response.RequestID = v
-	}
-	if v, ok := record["trace_id"].(string); ok && v != "" && v != "-" {
-		response.TraceID = v
-	}
-
-	// Network and client context fields
-	if v, ok := record["ip_address"].(string); ok && v != "" && v != "-" {
-		response.IPAddress = v
-	}
-	if v, ok := record["user_agent"].(string); ok && v != "" && v != "-" {
-		response.UserAgent = v
-	}
-	if v, ok := record["app_version"].(string); ok && v != "" && v != "-" {
-		response.AppVersion = v
-	}
-	if v, ok := record["referrer_url"].(string); ok && v != "" && v != "-" {
-		response.ReferrerURL = v
-	}
-	if v, ok := record["endpoint"].(string); ok && v != "" && v != "-" {
-		response.Endpoint = v
-	}
-
-	// Version fields
-	if v, ok := record["os_version"].(string); ok && v != "" && v != "-" {
-		response.OSVersion = v
-	}
-	if v, ok := record["browser_version"].(string); ok && v != "" && v != "-" {
-		response.BrowserVersion = v
-	}
-
-	// Numeric fields with type conversion
-	if v, ok := record["duration_ms"]; ok {
-		switch duration := v.(type) {
-		case int64:
-			response.DurationMs = int(duration)
-		case float64:
-			response.DurationMs = int(duration)
-		case int:
-			response.DurationMs = duration
-		}
-	}
-
-	if v, ok := record["response_code"]; ok {
-		switch code := v.(type) {
-		case int64:
-			response.ResponseCode = int(code)
-		case float64:
-			response.ResponseCode = int(code)
-		case int:
-			response.ResponseCode = code
-		}
-	}
-
-	// Boolean fields
-	if v, ok := record["is_bot"]; ok {
-		switch bot := v.(type) {
-		case bool:
-			response.IsBot = bot
-		case string:
-			response.IsBot = bot == "true" || bot == "1"
-		}
-	}
-
-	// Map/Object fields - deserialize JSON string back to map
-	if v, ok := record["details"].(string); ok && v != "" {
-		var details map[string]interface{}
-		if err := json.Unmarshal([]byte(v), &details); err == nil {
-			response.Details = details
-		}
-	}
+	// Populate fields by json/record tags
+	entity.MapRecord(record, &response)
 
 	// Generate ID from timestamp and request_id
 	if response.Time != "" && response.RequestID != "" {
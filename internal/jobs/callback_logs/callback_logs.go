@@ -7,7 +7,9 @@ import (
 	"github.com/hibiken/asynq"
 	callbacklogs "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
 )
 
 // Job processor function
@@ -41,11 +43,17 @@ func HandleCallbackLogsLogging(ctx context.Context, t *asynq.Task) error {
 
 	// point
 	point := cl.ToPoint()
-	err := influxdb.WritePoint(point)
+	err := influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(cl.GetName()), point)
 	if err != nil {
 		return err
 	}
 
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(cl.GetName(), "", cl.Timestamp, cl)
+
 	log.Info().
 		Str("task_id", t.ResultWriter().TaskID()).
 		Str("task_type", t.Type()).
@@ -0,0 +1,112 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
+	"github.com/benedict-erwin/insight-collector/pkg/netutil"
+	"github.com/benedict-erwin/insight-collector/pkg/useragent"
+)
+
+// Lookup hooks (replaced in tests)
+var (
+	lookupIPInfo    = maxmind.LookupIPInfo
+	detectUserAgent = func(ua string) *useragent.FastDeviceInfo {
+		return useragent.Default().Detect(ua)
+	}
+)
+
+// Result holds device & geo attributes derived from user agent and IP address
+type Result struct {
+	// User agent
+	DeviceType     string
+	OS             string
+	OSVersion      string
+	Browser        string
+	BrowserVersion string
+	IsBot          bool
+
+	// IP geolocation
+	GeoCountry     string
+	GeoCity        string
+	GeoCoordinates string
+	GeoTimezone    string
+	GeoPostal      string
+	GeoISP         string
+}
+
+// Enrich derives device & geo attributes of event (call before PII masking, lookups need original values).
+// Enrichment is best effort: failed lookups leave attributes empty and never fail the job.
+func Enrich(ctx context.Context, ipAddress, userAgent string) Result {
+	var result Result
+	result.applyUserAgent(userAgent)
+	result.applyGeo(ctx, ipAddress)
+	return result
+}
+
+// applyUserAgent fills device attributes from user agent detection
+func (r *Result) applyUserAgent(userAgent string) {
+	defer recoverEnrichment("user_agent")
+
+	info := detectUserAgent(userAgent)
+	if info == nil {
+		return
+	}
+
+	r.Browser = info.Browser
+	r.BrowserVersion = info.BrowserVersion
+	r.DeviceType = info.Type.String()
+	r.IsBot = info.IsBot
+	r.OS = info.OS
+	r.OSVersion = info.OSVersion
+}
+
+// applyGeo fills geo attributes from city & ASN lookup of IP address
+func (r *Result) applyGeo(ctx context.Context, ipAddress string) {
+	if ipAddress == "" {
+		return
+	}
+	defer recoverEnrichment("geo")
+
+	// Non-routable addresses have no geo data (MaxMind returns defaults), mark class instead of lookup
+	if class := netutil.ClassifyIPString(ipAddress); class != netutil.Public && class != netutil.Invalid {
+		r.GeoISP = class.String()
+		return
+	}
+
+	ipInfo := lookupIPInfo(ctx, ipAddress)
+	if ipInfo == nil {
+		return
+	}
+
+	// City Info
+	if geoLoc := ipInfo.City; geoLoc != nil {
+		r.GeoCountry = strings.ToUpper(geoLoc.CountryCode)
+		r.GeoCity = strings.ToLower(geoLoc.City)
+		r.GeoTimezone = geoLoc.Timezone
+		r.GeoPostal = geoLoc.PostalCode
+
+		// Coordinate format: latitude,longitude
+		if geoLoc.Latitude != 0 && geoLoc.Longitude != 0 {
+			r.GeoCoordinates = fmt.Sprintf("%.4f,%.4f", geoLoc.Latitude, geoLoc.Longitude)
+		}
+	}
+
+	// ASN Info
+	if asnInfo := ipInfo.ASN; asnInfo != nil && asnInfo.Organization != "" {
+		r.GeoISP = asnInfo.Organization
+	}
+}
+
+// recoverEnrichment keeps job running when lookup panics (event is written without enrichment)
+func recoverEnrichment(step string) {
+	if rec := recover(); rec != nil {
+		logger.WithScope("Enrich").Warn().
+			Str("step", step).
+			Str("panic", fmt.Sprint(rec)).
+			Msg("Event enrichment failed, continuing without it")
+	}
+}
@@ -0,0 +1,141 @@
+package enrich
+
+import (
+	"context"
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
+	"github.com/benedict-erwin/insight-collector/pkg/useragent"
+)
+
+// stubLookups replaces lookup hooks for single test
+func stubLookups(t *testing.T, geo func(ctx context.Context, ip string) *maxmind.IPInfo, ua func(ua string) *useragent.FastDeviceInfo) {
+	origGeo, origUA := lookupIPInfo, detectUserAgent
+	lookupIPInfo, detectUserAgent = geo, ua
+	t.Cleanup(func() {
+		lookupIPInfo, detectUserAgent = origGeo, origUA
+	})
+}
+
+func testIPInfo(ctx context.Context, ip string) *maxmind.IPInfo {
+	return &maxmind.IPInfo{
+		City: &maxmind.GeoLocation{
+			IP:          ip,
+			CountryCode: "id",
+			City:        "Jakarta",
+			PostalCode:  "10110",
+			Timezone:    "Asia/Jakarta",
+			Latitude:    -6.21462,
+			Longitude:   106.84513,
+		},
+		ASN: &maxmind.ASNInfo{IP: ip, ASN: 7713, Organization: "PT Telekomunikasi Indonesia"},
+	}
+}
+
+func testDeviceInfo(ua string) *useragent.FastDeviceInfo {
+	return &useragent.FastDeviceInfo{
+		Type:           useragent.Mobile,
+		OS:             "Android",
+		OSVersion:      "14",
+		Browser:        "Chrome",
+		BrowserVersion: "126.0",
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	stubLookups(t, testIPInfo, testDeviceInfo)
+
+	result := Enrich(context.Background(), "36.86.63.182", "Mozilla/5.0 (Linux; Android 14)")
+
+	expected := Result{
+		DeviceType:     useragent.Mobile.String(),
+		OS:             "Android",
+		OSVersion:      "14",
+		Browser:        "Chrome",
+		BrowserVersion: "126.0",
+		GeoCountry:     "ID",
+		GeoCity:        "jakarta",
+		GeoCoordinates: "-6.2146,106.8451",
+		GeoTimezone:    "Asia/Jakarta",
+		GeoPostal:      "10110",
+		GeoISP:         "PT Telekomunikasi Indonesia",
+	}
+	if result != expected {
+		t.Errorf("Enrich() = %+v, want %+v", result, expected)
+	}
+}
+
+func TestEnrichSkipsGeoWithoutIP(t *testing.T) {
+	called := false
+	stubLookups(t, func(ctx context.Context, ip string) *maxmind.IPInfo {
+		called = true
+		return testIPInfo(ctx, ip)
+	}, testDeviceInfo)
+
+	result := Enrich(context.Background(), "", "Mozilla/5.0")
+	if called {
+		t.Error("geo lookup must be skipped without IP address")
+	}
+	if result.GeoCountry != "" || result.Browser != "Chrome" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestEnrichFailuresAreNonFatal(t *testing.T) {
+	// Panicking geo lookup keeps user agent attributes
+	stubLookups(t, func(ctx context.Context, ip string) *maxmind.IPInfo {
+		panic("mmdb reader closed")
+	}, testDeviceInfo)
+
+	result := Enrich(context.Background(), "36.86.63.182", "Mozilla/5.0")
+	if result.Browser != "Chrome" || result.GeoCountry != "" {
+		t.Errorf("unexpected result after geo failure %+v", result)
+	}
+
+	// Panicking user agent detection keeps geo attributes
+	stubLookups(t, testIPInfo, func(ua string) *useragent.FastDeviceInfo {
+		panic("pattern index out of range")
+	})
+
+	result = Enrich(context.Background(), "36.86.63.182", "Mozilla/5.0")
+	if result.Browser != "" || result.GeoCountry != "ID" {
+		t.Errorf("unexpected result after user agent failure %+v", result)
+	}
+
+	// Nil lookup results (service disabled / unresolved IP)
+	stubLookups(t, func(ctx context.Context, ip string) *maxmind.IPInfo {
+		return &maxmind.IPInfo{}
+	}, func(ua string) *useragent.FastDeviceInfo {
+		return nil
+	})
+
+	if result = Enrich(context.Background(), "36.86.63.182", "Mozilla/5.0"); result != (Result{}) {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestEnrichSkipsGeoForNonRoutableIP(t *testing.T) {
+	called := false
+	stubLookups(t, func(ctx context.Context, ip string) *maxmind.IPInfo {
+		called = true
+		return testIPInfo(ctx, ip)
+	}, testDeviceInfo)
+
+	tests := map[string]string{
+		"10.0.0.5":     "private",
+		"192.168.1.20": "private",
+		"127.0.0.1":    "loopback",
+		"fe80::1":      "link_local",
+		"100.64.0.1":   "cgnat",
+		"203.0.113.10": "bogon",
+	}
+	for ip, isp := range tests {
+		result := Enrich(context.Background(), ip, "Mozilla/5.0")
+		if result.GeoISP != isp || result.GeoCountry != "" {
+			t.Errorf("Enrich(%s) geo = %q/%q, want isp %q without country", ip, result.GeoISP, result.GeoCountry, isp)
+		}
+	}
+	if called {
+		t.Error("geo lookup must be skipped for non-routable addresses")
+	}
+}
@@ -0,0 +1,97 @@
+package errorevents
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	errorevents "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	"github.com/benedict-erwin/insight-collector/internal/jobs/enrich"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/privacy"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
+)
+
+// Job processor function
+func HandleErrorEventsLogging(ctx context.Context, t *asynq.Task) error {
+	var ee errorevents.ErrorEvents
+	var req errorevents.ErrorEventsRequest
+
+	// Logger scope
+	log := logger.WithScope(TypeErrorEventsLogging)
+
+	// Unmarshal request payload
+	if err := json.Unmarshal(t.Payload(), &req); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal payload")
+		return err
+	}
+
+	// Mapping from request to main entity
+	ee.Service = req.Service
+	ee.Environment = req.Environment
+	ee.AppVersion = req.AppVersion
+	ee.ErrorType = req.ErrorType
+	ee.Severity = req.Severity
+	ee.ErrorCode = req.ErrorCode
+	ee.Message = req.Message
+	ee.StackTrace = req.StackTrace
+	ee.Handled = req.Handled
+	ee.Channel = req.Channel
+	ee.Method = req.Method
+	ee.Endpoint = req.Endpoint
+	ee.ResponseCode = req.ResponseCode
+	ee.DurationMs = req.DurationMs
+	ee.UserID = req.UserID
+	ee.SessionID = req.SessionID
+	ee.RequestID = req.RequestID
+	ee.TraceID = req.TraceID
+	ee.IPAddress = req.IPAddress
+	ee.UserAgent = req.UserAgent
+	ee.Details = req.Details
+	ee.Timestamp = req.Timestamp
+
+	// Device & geo enrichment (best effort, never fails the job)
+	enriched := enrich.Enrich(ctx, ee.IPAddress, ee.UserAgent)
+
+	// Errors may come from backend services without client UA
+	if ee.UserAgent != "" {
+		ee.Browser = enriched.Browser
+		ee.BrowserVersion = enriched.BrowserVersion
+		ee.DeviceType = enriched.DeviceType
+		ee.IsBot = enriched.IsBot
+		ee.OS = enriched.OS
+		ee.OSVersion = enriched.OSVersion
+	}
+	ee.GeoCountry = enriched.GeoCountry
+	ee.GeoCity = enriched.GeoCity
+	ee.GeoCoordinates = enriched.GeoCoordinates
+	ee.GeoTimezone = enriched.GeoTimezone
+	ee.GeoPostal = enriched.GeoPostal
+	ee.GeoISP = enriched.GeoISP
+
+	// PII masking (after geo lookup & UA detection which need original values)
+	ee.IPAddress, ee.UserAgent = privacy.Apply(ee.IPAddress, ee.UserAgent)
+
+	// point
+	point := ee.ToPoint()
+	err := influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(ee.GetName()), point)
+	if err != nil {
+		return err
+	}
+
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(ee.GetName(), "", ee.Timestamp, ee)
+
+	log.Info().
+		Str("task_id", t.ResultWriter().TaskID()).
+		Str("task_type", t.Type()).
+		Str("measurements", ee.GetName()).
+		Msg("Job completed successfully")
+
+	return nil
+}
@@ -0,0 +1,6 @@
+package errorevents
+
+// Task type constant
+const (
+	TypeErrorEventsLogging = "error_events:logging"
+)
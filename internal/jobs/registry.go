@@ -3,23 +3,42 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
 	cl "github.com/benedict-erwin/insight-collector/internal/jobs/callback_logs"
+	ee "github.com/benedict-erwin/insight-collector/internal/jobs/error_events"
 	"github.com/benedict-erwin/insight-collector/internal/jobs/example"
 	se "github.com/benedict-erwin/insight-collector/internal/jobs/security_events"
+	ss "github.com/benedict-erwin/insight-collector/internal/jobs/session_events"
 	te "github.com/benedict-erwin/insight-collector/internal/jobs/transaction_events"
 	ua "github.com/benedict-erwin/insight-collector/internal/jobs/user_activities"
 )
 
+// RetryConfig holds retry behavior for task type (tasks exceeding MaxRetries are archived as dead-letter)
+type RetryConfig struct {
+	MaxRetries int           `json:"max_retries"`
+	Backoff    time.Duration `json:"backoff"` // Base retry delay, doubled on every retry
+}
+
 // JobRegistration holds job metadata for registration and worker generation
 type JobRegistration struct {
 	TaskType string                                   `json:"task_type"`
 	Handler  func(context.Context, *asynq.Task) error `json:"-"` // Not serialized
 	Queue    string                                   `json:"queue"`
+	Retry    *RetryConfig                             `json:"retry,omitempty"` // Nil uses asynq defaults
 }
 
+// Retry presets
+var (
+	// retryLogging retries InfluxDB writes quickly, data is stale after few minutes
+	retryLogging = &RetryConfig{MaxRetries: 5, Backoff: 5 * time.Second}
+
+	// retryBackground retries background processing with longer backoff
+	retryBackground = &RetryConfig{MaxRetries: 3, Backoff: 30 * time.Second}
+)
+
 // RegisterHandlers registers all job handlers with the asynq server mux and returns job metadata
 func RegisterHandlers(mux *asynq.ServeMux) ([]JobRegistration, error) {
 	jobs := []JobRegistration{
@@ -28,21 +47,37 @@ func RegisterHandlers(mux *asynq.ServeMux) ([]JobRegistration, error) {
 			TaskType: ua.TypeUserActivitiesLogging,
 			Handler:  ua.HandleUserActivitiesLogging,
 			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
 		},
 		{
 			TaskType: se.TypeSecurityEventsLogging,
 			Handler:  se.HandleSecurityEventsLogging,
 			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
 		},
 		{
 			TaskType: te.TypeTransactionEventsLogging,
 			Handler:  te.HandleTransactionEventsLogging,
 			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
 		},
 		{
 			TaskType: cl.TypeCallbackLogsLogging,
 			Handler:  cl.HandleCallbackLogsLogging,
 			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
+		},
+		{
+			TaskType: ee.TypeErrorEventsLogging,
+			Handler:  ee.HandleErrorEventsLogging,
+			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
+		},
+		{
+			TaskType: ss.TypeSessionEventsLogging,
+			Handler:  ss.HandleSessionEventsLogging,
+			Queue:    constants.QueueCritical,
+			Retry:    retryLogging,
 		},
 
 		// Default
@@ -52,6 +87,7 @@ func RegisterHandlers(mux *asynq.ServeMux) ([]JobRegistration, error) {
 			TaskType: example.TypeExampleProcessing,
 			Handler:  example.HandleExampleProcessing,
 			Queue:    constants.QueueLow,
+			Retry:    retryBackground,
 		},
 	}
 
@@ -3,20 +3,19 @@ package securityevents
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"strings"
 
 	"github.com/hibiken/asynq"
 	securityevents "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	"github.com/benedict-erwin/insight-collector/internal/jobs/enrich"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
-	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
-	"github.com/benedict-erwin/insight-collector/pkg/useragent"
+	"github.com/benedict-erwin/insight-collector/pkg/privacy"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
 )
 
 // Job processor function
 func HandleSecurityEventsLogging(ctx context.Context, t *asynq.Task) error {
-	var se securityevents.SecurityEvents
 	var req securityevents.SecurityEventsRequest
 
 	// Logger scope
@@ -29,6 +28,51 @@ func HandleSecurityEventsLogging(ctx context.Context, t *asynq.Task) error {
 	}
 
 	// Mapping from request to main entity
+	se := NewSecurityEvents(req)
+
+	// Device & geo enrichment (best effort, never fails the job)
+	enriched := enrich.Enrich(ctx, se.IPAddress, se.UserAgent)
+	se.Browser = enriched.Browser
+	se.BrowserVersion = enriched.BrowserVersion
+	se.DeviceType = enriched.DeviceType
+	se.IsBot = enriched.IsBot
+	se.OS = enriched.OS
+	se.OSVersion = enriched.OSVersion
+	se.GeoCountry = enriched.GeoCountry
+	se.GeoCity = enriched.GeoCity
+	se.GeoCoordinates = enriched.GeoCoordinates
+	se.GeoTimezone = enriched.GeoTimezone
+	se.GeoPostal = enriched.GeoPostal
+	se.GeoISP = enriched.GeoISP
+
+	// PII masking (after geo lookup & UA detection which need original values)
+	se.IPAddress, se.UserAgent = privacy.Apply(se.IPAddress, se.UserAgent)
+
+	// point
+	point := se.ToPoint()
+	err := influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(se.GetName()), point)
+	if err != nil {
+		return err
+	}
+
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(se.GetName(), stream.RiskLevelFromScore(se.RiskScore), se.Timestamp, se)
+
+	log.Info().
+		Str("task_id", t.ResultWriter().TaskID()).
+		Str("task_type", t.Type()).
+		Str("measurements", se.GetName()).
+		Msg("Job completed successfully")
+
+	return nil
+}
+
+// NewSecurityEvents maps request payload to main entity (explicit per field mapping)
+func NewSecurityEvents(req securityevents.SecurityEventsRequest) securityevents.SecurityEvents {
+	var se securityevents.SecurityEvents
 	se.UserID = req.UserID
 	se.SessionID = req.SessionID
 	se.IdentifierType = req.IdentifierType
@@ -57,51 +101,5 @@ func HandleSecurityEventsLogging(ctx context.Context, t *asynq.Task) error {
 	se.Details = req.Details
 	se.Timestamp = req.Timestamp
 
-	// UserAgent Check
-	detector := useragent.NewFastDetector()
-	info := detector.Detect(se.UserAgent)
-	se.Browser = info.Browser
-	se.BrowserVersion = info.BrowserVersion
-	se.DeviceType = info.Type.String()
-	se.IsBot = info.IsBot
-	se.OS = info.OS
-	se.OSVersion = info.OSVersion
-
-	// IP Geolocation Check
-	if se.IPAddress != "" {
-		// Get City Info
-		geoLoc := maxmind.LookupCityFromString(se.IPAddress)
-		if geoLoc != nil {
-			se.GeoCountry = strings.ToUpper(geoLoc.CountryCode)
-			se.GeoCity = strings.ToLower(geoLoc.City)
-			se.GeoTimezone = geoLoc.Timezone
-			se.GeoPostal = geoLoc.PostalCode
-
-			// Coordinate format: latitude,longitude
-			if geoLoc.Latitude != 0 && geoLoc.Longitude != 0 {
-				se.GeoCoordinates = fmt.Sprintf("%.4f,%.4f", geoLoc.Latitude, geoLoc.Longitude)
-			}
-		}
-
-		// Get ASN Info
-		asnInfo := maxmind.LookupASNFromString(se.IPAddress)
-		if asnInfo != nil && asnInfo.Organization != "" {
-			se.GeoISP = asnInfo.Organization
-		}
-	}
-
-	// point
-	point := se.ToPoint()
-	err := influxdb.WritePoint(point)
-	if err != nil {
-		return err
-	}
-
-	log.Info().
-		Str("task_id", t.ResultWriter().TaskID()).
-		Str("task_type", t.Type()).
-		Str("measurements", se.GetName()).
-		Msg("Job completed successfully")
-
-	return nil
+	return se
 }
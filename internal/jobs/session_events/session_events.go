@@ -0,0 +1,100 @@
+package sessionevents
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hibiken/asynq"
+	sessionevents "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	"github.com/benedict-erwin/insight-collector/internal/jobs/enrich"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/privacy"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
+)
+
+// Job processor function
+func HandleSessionEventsLogging(ctx context.Context, t *asynq.Task) error {
+	var req sessionevents.SessionEventsRequest
+
+	// Logger scope
+	log := logger.WithScope(TypeSessionEventsLogging)
+
+	// Unmarshal request payload
+	if err := json.Unmarshal(t.Payload(), &req); err != nil {
+		log.Error().Err(err).Msg("Failed to unmarshal payload")
+		return err
+	}
+
+	// Mapping from request to main entity
+	se := NewSessionEvents(req)
+
+	// Device & geo enrichment from session start IP (best effort, never fails the job)
+	enriched := enrich.Enrich(ctx, se.IPStart, se.UserAgent)
+	se.Browser = enriched.Browser
+	se.BrowserVersion = enriched.BrowserVersion
+	se.DeviceType = enriched.DeviceType
+	se.IsBot = enriched.IsBot
+	se.OS = enriched.OS
+	se.OSVersion = enriched.OSVersion
+	se.GeoCountry = enriched.GeoCountry
+	se.GeoCity = enriched.GeoCity
+	se.GeoCoordinates = enriched.GeoCoordinates
+	se.GeoTimezone = enriched.GeoTimezone
+	se.GeoPostal = enriched.GeoPostal
+	se.GeoISP = enriched.GeoISP
+
+	// PII masking (after geo lookup & UA detection which need original values)
+	se.IPStart, se.UserAgent = privacy.Apply(se.IPStart, se.UserAgent)
+	se.IPEnd, _ = privacy.Apply(se.IPEnd, "")
+
+	// point
+	point := se.ToPoint()
+	err := influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(se.GetName()), point)
+	if err != nil {
+		return err
+	}
+
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(se.GetName(), "", se.Timestamp, se)
+
+	log.Info().
+		Str("task_id", t.ResultWriter().TaskID()).
+		Str("task_type", t.Type()).
+		Str("measurements", se.GetName()).
+		Msg("Job completed successfully")
+
+	return nil
+}
+
+// NewSessionEvents maps request payload to main entity (explicit per field mapping).
+// Duration is derived from start/end time when client does not send it.
+func NewSessionEvents(req sessionevents.SessionEventsRequest) sessionevents.SessionEvents {
+	var se sessionevents.SessionEvents
+	se.SessionID = req.SessionID
+	se.UserID = req.UserID
+	se.EventType = req.EventType
+	se.TerminationReason = req.TerminationReason
+	se.Channel = req.Channel
+	se.StartTime = req.StartTime
+	se.EndTime = req.EndTime
+	se.DurationSeconds = req.DurationSeconds
+	se.DeviceFingerprint = req.DeviceFingerprint
+	se.IPStart = req.IPStart
+	se.IPEnd = req.IPEnd
+	se.UserAgent = req.UserAgent
+	se.AppVersion = req.AppVersion
+	se.TraceID = req.TraceID
+	se.Details = req.Details
+	se.Timestamp = req.Timestamp
+
+	if se.DurationSeconds == 0 && !se.StartTime.IsZero() && se.EndTime.After(se.StartTime) {
+		se.DurationSeconds = int64(se.EndTime.Sub(se.StartTime).Seconds())
+	}
+
+	return se
+}
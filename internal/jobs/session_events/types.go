@@ -0,0 +1,6 @@
+package sessionevents
+
+// Task type constant
+const (
+	TypeSessionEventsLogging = "session_events:logging"
+)
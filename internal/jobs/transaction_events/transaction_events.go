@@ -3,15 +3,16 @@ package transactionevents
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/hibiken/asynq"
 	transactionevents "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	"github.com/benedict-erwin/insight-collector/internal/jobs/enrich"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
-	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
-	"github.com/benedict-erwin/insight-collector/pkg/useragent"
+	"github.com/benedict-erwin/insight-collector/pkg/privacy"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
 )
 
 // Job processor function
@@ -63,46 +64,44 @@ func HandleTransactionEventsLogging(ctx context.Context, t *asynq.Task) error {
 	te.Details = req.Details
 	te.Timestamp = req.Timestamp
 
-	// UserAgent Check
-	detector := useragent.NewFastDetector()
-	info := detector.Detect(te.UserAgent)
-	te.Browser = info.Browser
-	te.BrowserVersion = info.BrowserVersion
-	te.DeviceType = info.Type.String()
-	te.IsBot = info.IsBot
-	te.OS = info.OS
-	te.OSVersion = info.OSVersion
-
-	// IP Geolocation Check
-	if te.IPAddress != "" {
-		// Get City Info
-		geoLoc := maxmind.LookupCityFromString(te.IPAddress)
-		if geoLoc != nil {
-			te.GeoCountry = strings.ToUpper(geoLoc.CountryCode)
-			te.GeoCity = strings.ToLower(geoLoc.City)
-			te.GeoTimezone = geoLoc.Timezone
-			te.GeoPostal = geoLoc.PostalCode
+	// Exact minor unit amount (precision validated on insert, older queued payloads are rounded)
+	amountMinor, err := transactionevents.ToMinorUnits(te.Amount, te.Currency)
+	if err != nil {
+		log.Warn().Err(err).Str("currency", te.Currency).Msg("Amount precision invalid for currency, rounding minor units")
+	}
+	te.AmountMinor = amountMinor
 
-			// Coordinate format: latitude,longitude
-			if geoLoc.Latitude != 0 && geoLoc.Longitude != 0 {
-				te.GeoCoordinates = fmt.Sprintf("%.4f,%.4f", geoLoc.Latitude, geoLoc.Longitude)
-			}
-		}
+	// Device & geo enrichment (best effort, never fails the job)
+	enriched := enrich.Enrich(ctx, te.IPAddress, te.UserAgent)
+	te.Browser = enriched.Browser
+	te.BrowserVersion = enriched.BrowserVersion
+	te.DeviceType = enriched.DeviceType
+	te.IsBot = enriched.IsBot
+	te.OS = enriched.OS
+	te.OSVersion = enriched.OSVersion
+	te.GeoCountry = enriched.GeoCountry
+	te.GeoCity = enriched.GeoCity
+	te.GeoCoordinates = enriched.GeoCoordinates
+	te.GeoTimezone = enriched.GeoTimezone
+	te.GeoPostal = enriched.GeoPostal
+	te.GeoISP = enriched.GeoISP
 
-		// Get ASN Info
-		asnInfo := maxmind.LookupASNFromString(te.IPAddress)
-		if asnInfo != nil && asnInfo.Organization != "" {
-			te.GeoISP = asnInfo.Organization
-		}
-	}
+	// PII masking (after geo lookup & UA detection which need original values)
+	te.IPAddress, te.UserAgent = privacy.Apply(te.IPAddress, te.UserAgent)
 
 	// point
 	point := te.ToPoint()
-	err := influxdb.WritePoint(point)
+	err = influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(te.GetName()), point)
 	if err != nil {
 		return err
 	}
 
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(te.GetName(), te.RiskLevel, te.Timestamp, te)
+
 	log.Info().
 		Str("task_id", t.ResultWriter().TaskID()).
 		Str("task_type", t.Type()).
@@ -3,15 +3,15 @@ package useractivities
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"strings"
 
 	"github.com/hibiken/asynq"
 	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	"github.com/benedict-erwin/insight-collector/internal/jobs/enrich"
 	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
-	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
-	"github.com/benedict-erwin/insight-collector/pkg/useragent"
+	"github.com/benedict-erwin/insight-collector/pkg/privacy"
+	"github.com/benedict-erwin/insight-collector/pkg/stream"
 )
 
 // Job processor function
@@ -53,46 +53,37 @@ func HandleUserActivitiesLogging(ctx context.Context, t *asynq.Task) error {
 	ua.Details = req.Details
 	ua.Timestamp = req.Timestamp
 
-	// UserAgent Check
-	detector := useragent.NewFastDetector()
-	info := detector.Detect(ua.UserAgent)
-	ua.Browser = info.Browser
-	ua.BrowserVersion = info.BrowserVersion
-	ua.DeviceType = info.Type.String()
-	ua.IsBot = info.IsBot
-	ua.OS = info.OS
-	ua.OSVersion = info.OSVersion
+	// Device & geo enrichment (best effort, never fails the job)
+	enriched := enrich.Enrich(ctx, ua.IPAddress, ua.UserAgent)
+	ua.Browser = enriched.Browser
+	ua.BrowserVersion = enriched.BrowserVersion
+	ua.DeviceType = enriched.DeviceType
+	ua.IsBot = enriched.IsBot
+	ua.OS = enriched.OS
+	ua.OSVersion = enriched.OSVersion
+	ua.GeoCountry = enriched.GeoCountry
+	ua.GeoCity = enriched.GeoCity
+	ua.GeoCoordinates = enriched.GeoCoordinates
+	ua.GeoTimezone = enriched.GeoTimezone
+	ua.GeoPostal = enriched.GeoPostal
+	ua.GeoISP = enriched.GeoISP
 
-	// IP Geolocation Check
-	if ua.IPAddress != "" {
-		// Get City Info
-		geoLoc := maxmind.LookupCityFromString(ua.IPAddress)
-		if geoLoc != nil {
-			ua.GeoCountry = strings.ToUpper(geoLoc.CountryCode)
-			ua.GeoCity = strings.ToLower(geoLoc.City)
-			ua.GeoTimezone = geoLoc.Timezone
-			ua.GeoPostal = geoLoc.PostalCode
-
-			// Coordinate format: latitude,longitude
-			if geoLoc.Latitude != 0 && geoLoc.Longitude != 0 {
-				ua.GeoCoordinates = fmt.Sprintf("%.4f,%.4f", geoLoc.Latitude, geoLoc.Longitude)
-			}
-		}
-
-		// Get ASN Info
-		asnInfo := maxmind.LookupASNFromString(ua.IPAddress)
-		if asnInfo != nil && asnInfo.Organization != "" {
-			ua.GeoISP = asnInfo.Organization
-		}
-	}
+	// PII masking (after geo lookup & UA detection which need original values)
+	ua.IPAddress, ua.UserAgent = privacy.Apply(ua.IPAddress, ua.UserAgent)
 
 	// point
 	point := ua.ToPoint()
-	err := influxdb.WritePoint(point)
+	err := influxdb.EnqueuePointToBucket(influxdb.MeasurementBucket(ua.GetName()), point)
 	if err != nil {
 		return err
 	}
 
+	// Enqueue → write latency (data staleness)
+	latency.ObserveTask(t.Type(), t.Payload())
+
+	// Publish to live-tail subscribers (best effort)
+	stream.Publish(ua.GetName(), "", ua.Timestamp, ua)
+
 	log.Info().
 		Str("task_id", t.ResultWriter().TaskID()).
 		Str("task_type", t.Type()).
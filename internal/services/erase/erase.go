@@ -0,0 +1,194 @@
+package erase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// Measurements lists measurements storing user_id field (callback_logs has no user reference)
+var Measurements = []string{"user_activities", "security_events", "transaction_events", "error_events", "session_events"}
+
+// Request is erase request for single user within time range
+type Request struct {
+	UserID       string    `json:"user_id" validate:"required"`
+	Start        time.Time `json:"start" validate:"required"`
+	Stop         time.Time `json:"stop" validate:"required"`
+	Measurements []string  `json:"measurements" validate:"omitempty,dive,oneof=user_activities security_events transaction_events error_events session_events"` // Empty = all Measurements
+}
+
+// MeasurementResult holds erase result for single measurement
+type MeasurementResult struct {
+	Measurement   string `json:"measurement"`
+	PointsDeleted int    `json:"points_deleted"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Result holds erase results across measurements
+type Result struct {
+	MeasurementsProcessed int                 `json:"measurements_processed"`
+	PointsDeleted         int                 `json:"points_deleted"`
+	Measurements          []MeasurementResult `json:"measurements"`
+}
+
+// EraseUser deletes all points with given user_id within [start, stop] from measurements.
+// user_id is a field (delete API only filters on tags), so the user_id values of every series are read first
+// and each consecutive run of the user's points is deleted with one time range + tag set predicate.
+func EraseUser(userID string, start, stop time.Time, measurements []string) *Result {
+	log := logger.WithScope("EraseUser")
+
+	result := &Result{Measurements: make([]MeasurementResult, 0, len(measurements))}
+	for _, measurement := range measurements {
+		deleted, err := eraseMeasurement(measurement, userID, start, stop)
+
+		item := MeasurementResult{Measurement: measurement, PointsDeleted: deleted}
+		if err != nil {
+			item.Error = err.Error()
+			log.Error().Err(err).Str("measurement", measurement).Int("points_deleted", deleted).Msg("Failed to erase user data")
+		}
+
+		result.Measurements = append(result.Measurements, item)
+		result.MeasurementsProcessed++
+		result.PointsDeleted += deleted
+	}
+
+	return result
+}
+
+// eraseMeasurement deletes matching points of single measurement, returns number of deleted points
+func eraseMeasurement(measurement, userID string, start, stop time.Time) (int, error) {
+	bucket := influxdb.MeasurementBucket(measurement)
+	if bucket == "" {
+		bucket = influxdb.GetConfig().Bucket
+	}
+
+	// All users' user_id values are read (not only matches) so a range never spans another user's point
+	query := fmt.Sprintf(
+		`from(bucket: "%s") |> range(start: %s, stop: %s) |> filter(fn: (r) => r._measurement == "%s" and r._field == "user_id") |> sort(columns: ["_time"])`,
+		bucket,
+		start.UTC().Format(time.RFC3339Nano),
+		stop.UTC().Add(time.Nanosecond).Format(time.RFC3339Nano), // range stop is exclusive
+		measurement,
+	)
+
+	iterator, err := influxdb.Query(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find points: %w", err)
+	}
+
+	// Collect ranges before deleting (don't delete while result stream is open)
+	builder := newRangeBuilder(userID)
+	for iterator.Next() {
+		builder.add(iterator.Record())
+	}
+	err = iterator.Err()
+	iterator.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read points: %w", err)
+	}
+
+	deleted := 0
+	for _, r := range builder.finish() {
+		if err := influxdb.DeleteByPredicate(measurement, r.start, r.stop, r.predicate); err != nil {
+			return deleted, err
+		}
+		deleted += r.points
+	}
+	return deleted, nil
+}
+
+// deleteRange is time range [start, stop] of single series holding only the erased user's points
+type deleteRange struct {
+	predicate string
+	start     time.Time
+	stop      time.Time
+	points    int
+}
+
+// rangeBuilder merges time sorted user_id records into delete ranges per series (tag set).
+// A point of another user in the same series closes the current range.
+type rangeBuilder struct {
+	userID  string
+	open    map[string]*deleteRange // Current range per series predicate (records must be time sorted per series)
+	settled []deleteRange
+}
+
+func newRangeBuilder(userID string) *rangeBuilder {
+	return &rangeBuilder{
+		userID: userID,
+		open:   make(map[string]*deleteRange),
+	}
+}
+
+// add consumes single user_id record
+func (b *rangeBuilder) add(record map[string]interface{}) {
+	pointTime, ok := record["_time"].(time.Time)
+	if !ok {
+		return
+	}
+	predicate := tagPredicate(record)
+
+	if value, _ := record["_value"].(string); value != b.userID {
+		b.close(predicate)
+		return
+	}
+
+	if current, exists := b.open[predicate]; exists {
+		current.stop = pointTime
+		current.points++
+		return
+	}
+	b.open[predicate] = &deleteRange{predicate: predicate, start: pointTime, stop: pointTime, points: 1}
+}
+
+// close settles open range of series
+func (b *rangeBuilder) close(predicate string) {
+	if current, exists := b.open[predicate]; exists {
+		b.settled = append(b.settled, *current)
+		delete(b.open, predicate)
+	}
+}
+
+// finish settles remaining ranges, ordered by series & start time
+func (b *rangeBuilder) finish() []deleteRange {
+	for predicate := range b.open {
+		b.close(predicate)
+	}
+	sort.Slice(b.settled, func(i, j int) bool {
+		if b.settled[i].predicate != b.settled[j].predicate {
+			return b.settled[i].predicate < b.settled[j].predicate
+		}
+		return b.settled[i].start.Before(b.settled[j].start)
+	})
+	return b.settled
+}
+
+// tagPredicate builds delete predicate matching record tag set (columns without "_" prefix)
+func tagPredicate(record map[string]interface{}) string {
+	keys := make([]string, 0, len(record))
+	for key, value := range record {
+		if strings.HasPrefix(key, "_") || key == "result" || key == "table" {
+			continue
+		}
+		if _, ok := value.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		conditions = append(conditions, fmt.Sprintf(`%s="%s"`, key, escapeString(record[key].(string))))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// escapeString escapes backslash and double quote for Flux/delete predicate string literal
+func escapeString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
@@ -0,0 +1,71 @@
+package erase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeBuilderMergesConsecutiveUserPoints(t *testing.T) {
+	base := time.Date(2025, 8, 6, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+	record := func(seconds int, userID, eventType string) map[string]interface{} {
+		return map[string]interface{}{
+			"_time":        at(seconds),
+			"_field":       "user_id",
+			"_value":       userID,
+			"_measurement": "security_events",
+			"event_type":   eventType,
+			"result":       "_result",
+		}
+	}
+
+	builder := newRangeBuilder("user-1")
+	for _, r := range []map[string]interface{}{
+		// Series event_type=login: user-2 point splits user-1 points into two ranges
+		record(1, "user-1", "login"),
+		record(2, "user-1", "login"),
+		record(3, "user-2", "login"),
+		record(4, "user-1", "login"),
+		// Series event_type=logout: single range of three points
+		record(1, "user-1", "logout"),
+		record(5, "user-1", "logout"),
+		record(9, "user-1", "logout"),
+		// Series without user-1 points
+		record(2, "user-3", "mfa"),
+		// Missing time is skipped
+		{"_value": "user-1", "event_type": "login"},
+	} {
+		builder.add(r)
+	}
+
+	expected := []deleteRange{
+		{predicate: `event_type="login"`, start: at(1), stop: at(2), points: 2},
+		{predicate: `event_type="login"`, start: at(4), stop: at(4), points: 1},
+		{predicate: `event_type="logout"`, start: at(1), stop: at(9), points: 3},
+	}
+
+	got := builder.finish()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d ranges, got %d: %+v", len(expected), len(got), got)
+	}
+	for i, want := range expected {
+		if got[i].predicate != want.predicate || !got[i].start.Equal(want.start) || !got[i].stop.Equal(want.stop) || got[i].points != want.points {
+			t.Errorf("range %d: expected %+v, got %+v", i, want, got[i])
+		}
+	}
+}
+
+func TestTagPredicateEscapesAndSortsTags(t *testing.T) {
+	record := map[string]interface{}{
+		"_time":      time.Now(),
+		"_value":     "user-1",
+		"table":      int64(0),
+		"severity":   `hi"gh`,
+		"event_type": `a\b`,
+	}
+
+	want := `event_type="a\\b" AND severity="hi\"gh"`
+	if got := tagPredicate(record); got != want {
+		t.Errorf("tagPredicate = %s, want %s", got, want)
+	}
+}
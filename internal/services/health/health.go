@@ -2,6 +2,7 @@ package health
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -78,38 +79,33 @@ func CheckHealth() (*HealthStatus, error) {
 		Timestamp: time.Now(),
 		Version:   cfg.App.Version,
 		Uptime:    time.Since(startTime).String(),
-		Services:  make(map[string]ServiceHealth),
 		System:    getSystemMetrics(),
 	}
 
-	overallHealthy := true
-
-	// Check InfluxDB
-	influxHealth := checkInfluxDB()
-	status.Services["influxdb"] = influxHealth
-	if influxHealth.Status != "healthy" {
-		overallHealthy = false
-	}
-
-	// Check Redis
-	redisHealth := checkRedis()
-	status.Services["redis"] = redisHealth
-	if redisHealth.Status != "healthy" {
-		overallHealthy = false
+	// Run service checks concurrently, each bounded by its timeout
+	checks := []serviceCheck{
+		{name: "influxdb", check: checkInfluxDB},
+		{name: "redis", check: checkRedis},
+		{name: "asynq", check: checkAsynq},
+		{name: "maxmind", check: checkMaxMind},
 	}
+	critical := []string{"influxdb", "redis", "asynq"}
 
-	// Check Asynq
-	asynqHealth := checkAsynq()
-	status.Services["asynq"] = asynqHealth
-	if asynqHealth.Status != "healthy" {
-		overallHealthy = false
+	// Record lag per measurement (health only, stale data must not take instance out of readiness)
+	if cfg.Health.InfluxDBData.Enabled {
+		checks = append(checks, serviceCheck{name: "influxdb_data", check: checkInfluxDBData})
+		critical = append(critical, "influxdb_data")
 	}
+	status.Services = runChecks(checks)
 
-	// Check MaxMind
-	maxmindHealth := checkMaxMind()
-	status.Services["maxmind"] = maxmindHealth
 	// Note: MaxMind degraded state doesn't affect overall health
 	// since it has fallback behavior
+	overallHealthy := true
+	for _, name := range critical {
+		if status.Services[name].Status != "healthy" {
+			overallHealthy = false
+		}
+	}
 
 	// Determine overall status
 	if overallHealthy {
@@ -127,7 +123,7 @@ func CheckHealth() (*HealthStatus, error) {
 	return status, nil
 }
 
-// checkInfluxDB performs InfluxDB connectivity and health check
+// checkInfluxDB performs InfluxDB connectivity and health check (plus write probe when enabled)
 func checkInfluxDB() ServiceHealth {
 	start := utils.Now()
 
@@ -154,6 +150,27 @@ func checkInfluxDB() ServiceHealth {
 		}
 	}
 
+	// Optional write-path probe (reads can work while writes fail, e.g. bucket permissions)
+	if config.Get().Health.InfluxWriteProbe.Enabled {
+		probe, err := probeInfluxDBWrite()
+		if err != nil {
+			return ServiceHealth{
+				Status:       "unhealthy",
+				ResponseTime: time.Since(start).String(),
+				LastCheck:    utils.Now(),
+				Error:        err.Error(),
+				Metadata:     map[string]interface{}{"write_probe": probe},
+			}
+		}
+
+		return ServiceHealth{
+			Status:       "healthy",
+			ResponseTime: time.Since(start).String(),
+			LastCheck:    utils.Now(),
+			Metadata:     map[string]interface{}{"write_probe": probe},
+		}
+	}
+
 	return ServiceHealth{
 		Status:       "healthy",
 		ResponseTime: responseTime.String(),
@@ -169,12 +186,19 @@ func checkRedis() ServiceHealth {
 	err := redis.Health()
 	responseTime := time.Since(start)
 
+	// Pool statistics help spotting exhausted pools (timeouts growing, idle_conns at 0)
+	var metadata map[string]interface{}
+	if poolStats, statsErr := redis.GetPoolStats(); statsErr == nil {
+		metadata = map[string]interface{}{"pool": poolStats}
+	}
+
 	if err != nil {
 		return ServiceHealth{
 			Status:       "unhealthy",
 			ResponseTime: responseTime.String(),
 			LastCheck:    utils.Now(),
 			Error:        err.Error(),
+			Metadata:     metadata,
 		}
 	}
 
@@ -182,6 +206,7 @@ func checkRedis() ServiceHealth {
 		Status:       "healthy",
 		ResponseTime: responseTime.String(),
 		LastCheck:    utils.Now(),
+		Metadata:     metadata,
 	}
 }
 
@@ -240,14 +265,27 @@ func checkMaxMind() ServiceHealth {
 		errorMsg = "GeoIP databases not loaded, using fallback"
 	} else {
 		// Service is working, populate metadata
-		metadata["database_version"] = dbInfo.CityDBModTime.Format("2006-01-02")
+		metadata["database_version"] = dbInfo.CityDBBuildTime.Format("2006-01-02")
 		metadata["last_reload"] = dbInfo.LoadedAt.Format("2006-01-02 15:04:05")
 		metadata["reload_count"] = dbInfo.ReloadCount
-		
-		// Check if database files are recent (within 2 weeks for weekly updates)
-		if time.Since(dbInfo.CityDBModTime) > 14*24*time.Hour {
+
+		// City providers in lookup order (MaxMind plus configured fallbacks)
+		providers := dbInfo.Providers
+		if len(providers) == 0 {
+			providers = []string{maxmind.PrimaryProviderName}
+		}
+		metadata["providers"] = providers
+
+		// Check database build time against per-database max age (maxmind.max_age)
+		var stale []string
+		for _, age := range maxmind.CheckDatabaseAges(dbInfo, maxmind.MaxAge, utils.Now()) {
+			if age.Stale {
+				stale = append(stale, age.Database)
+			}
+		}
+		if len(stale) > 0 {
 			status = "degraded"
-			errorMsg = "GeoIP database appears outdated"
+			errorMsg = fmt.Sprintf("GeoIP database appears outdated (%s)", strings.Join(stale, ", "))
 		}
 	}
 
@@ -288,30 +326,20 @@ func CheckReadiness() (*ReadinessStatus, error) {
 	// Cache miss - perform actual readiness check
 	status := &ReadinessStatus{
 		Timestamp: time.Now(),
-		Services:  make(map[string]ServiceHealth),
 	}
 
-	overallReady := true
+	// Check critical services concurrently: InfluxDB (logging), Redis (job queue), Asynq (background jobs)
+	status.Services = runChecks([]serviceCheck{
+		{name: "influxdb", check: checkInfluxDB},
+		{name: "redis", check: checkRedis},
+		{name: "asynq", check: checkAsynq},
+	})
 
-	// Check InfluxDB - critical for logging
-	influxHealth := checkInfluxDB()
-	status.Services["influxdb"] = influxHealth
-	if influxHealth.Status != "healthy" {
-		overallReady = false
-	}
-
-	// Check Redis - critical for job queue
-	redisHealth := checkRedis()
-	status.Services["redis"] = redisHealth
-	if redisHealth.Status != "healthy" {
-		overallReady = false
-	}
-
-	// Check Asynq - critical for background jobs
-	asynqHealth := checkAsynq()
-	status.Services["asynq"] = asynqHealth
-	if asynqHealth.Status != "healthy" {
-		overallReady = false
+	overallReady := true
+	for _, health := range status.Services {
+		if health.Status != "healthy" {
+			overallReady = false
+		}
 	}
 
 	// Determine overall readiness
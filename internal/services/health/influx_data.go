@@ -0,0 +1,277 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+const (
+	// defaultMaxDataLag flags measurement stale when newest record is older (health.influxdb_data.max_lag)
+	defaultMaxDataLag = 15 * time.Minute
+
+	// defaultDataCacheTTL caches record time range queries, they scan whole bucket range (health.influxdb_data.cache_ttl)
+	defaultDataCacheTTL = 5 * time.Minute
+)
+
+// dataMeasurements are ingest measurements checked when health.influxdb_data.measurements is empty
+var dataMeasurements = []string{"user_activities", "security_events", "transaction_events", "error_events", "session_events", "callback_logs"}
+
+// MeasurementData reports stored record time range of single measurement.
+// Lag is measured when records were queried (checked_at), so cached results do not grow lag.
+type MeasurementData struct {
+	Bucket       string     `json:"bucket"`
+	Retention    string     `json:"retention,omitempty"` // Bucket retention, "infinite" when data never expires, empty when unknown
+	OldestRecord *time.Time `json:"oldest_record"`
+	NewestRecord *time.Time `json:"newest_record"`
+	Lag          string     `json:"lag,omitempty"` // checked_at − newest_record
+	MaxLag       string     `json:"max_lag"`
+	Stale        bool       `json:"stale"`
+	CheckedAt    time.Time  `json:"checked_at"`
+	Error        string     `json:"error,omitempty"`
+}
+
+var (
+	dataCache      map[string]MeasurementData
+	dataCacheTime  time.Time
+	dataCacheMutex sync.Mutex
+)
+
+// checkInfluxDBData reports record time range & lag per measurement, degraded when any measurement lags
+// behind its max lag (ingestion may be broken) and unhealthy when no measurement could be queried
+func checkInfluxDBData() ServiceHealth {
+	start := utils.Now()
+
+	if !influxdb.IsHealthy() {
+		return ServiceHealth{
+			Status:       "unhealthy",
+			ResponseTime: "0ms",
+			LastCheck:    utils.Now(),
+			Error:        "InfluxDB client not initialized",
+		}
+	}
+
+	measurements := loadMeasurementData()
+	status, errorMsg := evaluateMeasurementData(measurements)
+
+	return ServiceHealth{
+		Status:       status,
+		ResponseTime: time.Since(start).String(),
+		LastCheck:    utils.Now(),
+		Error:        errorMsg,
+		Metadata:     map[string]interface{}{"measurements": measurements},
+	}
+}
+
+// evaluateMeasurementData returns overall status and error message of measurement results
+func evaluateMeasurementData(measurements map[string]MeasurementData) (string, string) {
+	var stale, failed []string
+	for name, data := range measurements {
+		switch {
+		case data.Error != "":
+			failed = append(failed, name)
+		case data.Stale:
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	sort.Strings(failed)
+
+	switch {
+	case len(measurements) > 0 && len(failed) == len(measurements):
+		return "unhealthy", fmt.Sprintf("Record time range query failed (%s)", strings.Join(failed, ", "))
+	case len(stale) > 0 || len(failed) > 0:
+		var reasons []string
+		if len(stale) > 0 {
+			reasons = append(reasons, fmt.Sprintf("no recent records, ingestion may be broken (%s)", strings.Join(stale, ", ")))
+		}
+		if len(failed) > 0 {
+			reasons = append(reasons, fmt.Sprintf("record time range query failed (%s)", strings.Join(failed, ", ")))
+		}
+		return "degraded", strings.Join(reasons, "; ")
+	}
+	return "healthy", ""
+}
+
+// loadMeasurementData returns cached measurement results, queried again after cache TTL
+func loadMeasurementData() map[string]MeasurementData {
+	dataCacheMutex.Lock()
+	defer dataCacheMutex.Unlock()
+
+	if dataCache != nil && time.Since(dataCacheTime) < dataCacheTTL() {
+		return dataCache
+	}
+
+	names := config.Get().Health.InfluxDBData.Measurements
+	if len(names) == 0 {
+		names = dataMeasurements
+	}
+
+	retentions := make(map[string]string) // Per bucket, measurements often share one
+	results := make(map[string]MeasurementData, len(names))
+	for _, name := range names {
+		results[name] = queryMeasurementData(name, retentions)
+	}
+
+	dataCache = results
+	dataCacheTime = time.Now()
+	return results
+}
+
+// queryMeasurementData queries oldest & newest record of measurement and retention of its bucket
+func queryMeasurementData(measurement string, retentions map[string]string) MeasurementData {
+	bucket := influxdb.MeasurementBucket(measurement)
+	if bucket == "" {
+		bucket = influxdb.GetConfig().Bucket
+	}
+
+	maxLag := maxDataLag(measurement)
+	data := MeasurementData{
+		Bucket:    bucket,
+		MaxLag:    maxLag.String(),
+		CheckedAt: utils.Now(),
+	}
+
+	retention, found := retentions[bucket]
+	if !found {
+		retention = bucketRetention(bucket)
+		retentions[bucket] = retention
+	}
+	data.Retention = retention
+
+	oldestQuery, newestQuery := recordTimeQueries(bucket, measurement)
+	oldest, err := queryRecordTime(oldestQuery)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+	newest, err := queryRecordTime(newestQuery)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+
+	data.OldestRecord, data.NewestRecord = oldest, newest
+	applyLag(&data, maxLag)
+	return data
+}
+
+// applyLag sets lag of newest record at check time, stale when lag exceeds maxLag.
+// Measurements without records are never stale (nothing ingested yet).
+func applyLag(data *MeasurementData, maxLag time.Duration) {
+	if data.NewestRecord == nil {
+		return
+	}
+
+	lag := data.CheckedAt.Sub(*data.NewestRecord)
+	if lag < 0 {
+		lag = 0 // Client clock ahead of server
+	}
+	data.Lag = lag.Round(time.Second).String()
+	data.Stale = lag > maxLag
+}
+
+// bucketRetention returns readable bucket retention, empty when client cannot report it
+func bucketRetention(bucket string) string {
+	retention, err := influxdb.BucketRetention(bucket)
+	if err != nil {
+		logger.WithScope("health").Debug().Err(err).Str("bucket", bucket).Msg("Bucket retention unavailable")
+		return ""
+	}
+	if retention == 0 {
+		return "infinite"
+	}
+	return retention.String()
+}
+
+// recordTimeQueries returns queries selecting oldest & newest record time as _time (Flux for v2-oss, SQL for v3-core).
+// Flux takes first/last per series (pushed down to storage) before grouping, fields with different types never collide.
+func recordTimeQueries(bucket, measurement string) (string, string) {
+	switch influxdb.GetConfig().Version {
+	case influxdb.VersionV3Core:
+		return fmt.Sprintf(`SELECT MIN(time) AS "_time" FROM "%s"`, measurement),
+			fmt.Sprintf(`SELECT MAX(time) AS "_time" FROM "%s"`, measurement)
+	default:
+		base := fmt.Sprintf(`from(bucket: "%s") |> range(start: 0) |> filter(fn: (r) => r._measurement == "%s")`, bucket, measurement)
+		return base + ` |> first() |> keep(columns: ["_time"]) |> group() |> min(column: "_time")`,
+			base + ` |> last() |> keep(columns: ["_time"]) |> group() |> max(column: "_time")`
+	}
+}
+
+// queryRecordTime returns _time of first result row, nil when measurement has no records
+func queryRecordTime(query string) (*time.Time, error) {
+	iterator, err := influxdb.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var recordTime *time.Time
+	if iterator.Next() {
+		recordTime = parseRecordTime(iterator.Record()["_time"])
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	return recordTime, nil
+}
+
+// parseRecordTime converts query time value (time.Time or Unix nanoseconds), nil for missing values
+func parseRecordTime(value interface{}) *time.Time {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case int64:
+		t = time.Unix(0, v)
+	default:
+		return nil
+	}
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// maxDataLag returns max lag of measurement (per-measurement override, global max_lag, then 15m)
+func maxDataLag(measurement string) time.Duration {
+	dataConfig := config.Get().Health.InfluxDBData
+	return parseHealthDuration("max_lag", []string{dataConfig.MaxLags[measurement], dataConfig.MaxLag}, defaultMaxDataLag)
+}
+
+// dataCacheTTL returns configured cache TTL of record time range queries
+func dataCacheTTL() time.Duration {
+	return parseHealthDuration("cache_ttl", []string{config.Get().Health.InfluxDBData.CacheTTL}, defaultDataCacheTTL)
+}
+
+// parseHealthDuration returns first valid positive duration of values, fallback when none is set
+func parseHealthDuration(setting string, values []string, fallback time.Duration) time.Duration {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			logger.WithScope("health").Warn().Str(setting, value).Msg("Invalid health data duration, ignoring")
+			continue
+		}
+		return duration
+	}
+	return fallback
+}
+
+// ClearDataCache clears cached record time ranges (useful for testing/debugging)
+func ClearDataCache() {
+	dataCacheMutex.Lock()
+	dataCache = nil
+	dataCacheTime = time.Time{}
+	dataCacheMutex.Unlock()
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+// useHealthConfig loads config with given health section
+func useHealthConfig(t *testing.T, health string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".config"), []byte(`{"health":`+health+`}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Chdir(dir)
+	if err := config.Init(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+}
+
+func TestMaxDataLagAndCacheTTL(t *testing.T) {
+	useHealthConfig(t, `{"influxdb_data":{"max_lag":"10m","max_lags":{"callback_logs":"24h","error_events":"bogus"},"cache_ttl":"-1s"}}`)
+
+	tests := map[string]time.Duration{
+		"callback_logs":   24 * time.Hour,
+		"error_events":    10 * time.Minute, // Invalid override falls back to global
+		"user_activities": 10 * time.Minute,
+	}
+	for measurement, want := range tests {
+		if got := maxDataLag(measurement); got != want {
+			t.Errorf("maxDataLag(%q) = %v, want %v", measurement, got, want)
+		}
+	}
+	if got := dataCacheTTL(); got != defaultDataCacheTTL {
+		t.Errorf("expected default cache TTL for invalid value, got %v", got)
+	}
+}
+
+func TestApplyLagAndEvaluate(t *testing.T) {
+	checkedAt := time.Date(2025, 8, 6, 12, 30, 0, 0, time.UTC)
+	recent, old := checkedAt.Add(-2*time.Minute), checkedAt.Add(-time.Hour)
+
+	fresh := MeasurementData{NewestRecord: &recent, CheckedAt: checkedAt}
+	applyLag(&fresh, 15*time.Minute)
+	if fresh.Lag != "2m0s" || fresh.Stale {
+		t.Errorf("expected fresh measurement with 2m lag, got %+v", fresh)
+	}
+
+	stale := MeasurementData{NewestRecord: &old, CheckedAt: checkedAt}
+	applyLag(&stale, 15*time.Minute)
+	if stale.Lag != "1h0m0s" || !stale.Stale {
+		t.Errorf("expected stale measurement with 1h lag, got %+v", stale)
+	}
+
+	empty := MeasurementData{CheckedAt: checkedAt}
+	applyLag(&empty, 15*time.Minute)
+	if empty.Lag != "" || empty.Stale {
+		t.Errorf("expected measurement without records never stale, got %+v", empty)
+	}
+
+	status, msg := evaluateMeasurementData(map[string]MeasurementData{"user_activities": fresh, "session_events": empty})
+	if status != "healthy" || msg != "" {
+		t.Errorf("expected healthy, got %s (%s)", status, msg)
+	}
+
+	status, msg = evaluateMeasurementData(map[string]MeasurementData{"user_activities": fresh, "error_events": stale, "callback_logs": {Error: "timeout"}})
+	if status != "degraded" || !strings.Contains(msg, "ingestion may be broken (error_events)") || !strings.Contains(msg, "query failed (callback_logs)") {
+		t.Errorf("expected degraded with stale & failed measurements, got %s (%s)", status, msg)
+	}
+
+	status, _ = evaluateMeasurementData(map[string]MeasurementData{"user_activities": {Error: "timeout"}})
+	if status != "unhealthy" {
+		t.Errorf("expected unhealthy when every query failed, got %s", status)
+	}
+}
+
+func TestParseRecordTime(t *testing.T) {
+	at := time.Date(2025, 8, 6, 12, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+	if got := parseRecordTime(at); got == nil || !got.Equal(at) || got.Location() != time.UTC {
+		t.Errorf("expected UTC time %v, got %v", at, got)
+	}
+	if got := parseRecordTime(at.UnixNano()); got == nil || !got.Equal(at) {
+		t.Errorf("expected time from nanoseconds %v, got %v", at, got)
+	}
+	if got := parseRecordTime(nil); got != nil {
+		t.Errorf("expected nil for missing value, got %v", got)
+	}
+}
@@ -0,0 +1,111 @@
+package health
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// probeMeasurement is dedicated measurement for write-path probe points
+const probeMeasurement = "_healthcheck"
+
+// probeLookback bounds read-back query range (Flux duration)
+const probeLookback = "5m"
+
+// probeInfluxDBWrite writes probe point and reads it back.
+// Metadata reports write/read stages separately so bucket permission issues are distinguishable from query issues.
+func probeInfluxDBWrite() (map[string]interface{}, error) {
+	bucket := config.Get().Health.InfluxWriteProbe.Bucket
+	if bucket == "" {
+		bucket = influxdb.GetConfig().Bucket
+	}
+
+	metadata := map[string]interface{}{
+		"bucket": bucket,
+		"write":  "skipped",
+		"read":   "skipped",
+	}
+
+	probeID, err := generateProbeID()
+	if err != nil {
+		return metadata, fmt.Errorf("failed to generate probe id: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	point := influxdb.NewPoint(
+		probeMeasurement,
+		map[string]string{"host": hostname},
+		map[string]interface{}{"probe_id": probeID},
+		utils.Now(),
+	)
+
+	// Write stage
+	start := utils.Now()
+	if err := influxdb.WritePointToBucket(bucket, point); err != nil {
+		metadata["write"] = "failed"
+		metadata["write_latency"] = time.Since(start).String()
+		return metadata, fmt.Errorf("write probe failed: %w", err)
+	}
+	metadata["write"] = "ok"
+	metadata["write_latency"] = time.Since(start).String()
+
+	// Read back stage
+	start = utils.Now()
+	found, err := readProbe(bucket, probeID)
+	metadata["read_latency"] = time.Since(start).String()
+	if err != nil {
+		metadata["read"] = "failed"
+		return metadata, fmt.Errorf("read probe failed: %w", err)
+	}
+	if !found {
+		metadata["read"] = "not_found"
+		return metadata, fmt.Errorf("read probe failed: probe point not found after write")
+	}
+	metadata["read"] = "ok"
+
+	return metadata, nil
+}
+
+// readProbe queries probe point by ID (Flux for v2-oss, SQL for v3-core)
+func readProbe(bucket, probeID string) (bool, error) {
+	var query string
+	switch influxdb.GetConfig().Version {
+	case influxdb.VersionV3Core:
+		query = fmt.Sprintf(
+			`SELECT probe_id FROM "%s" WHERE probe_id = '%s' AND time >= now() - INTERVAL '5 minutes' LIMIT 1`,
+			probeMeasurement, probeID,
+		)
+	default:
+		query = fmt.Sprintf(
+			`from(bucket: "%s") |> range(start: -%s) |> filter(fn: (r) => r._measurement == "%s" and r._field == "probe_id" and r._value == "%s") |> limit(n: 1)`,
+			bucket, probeLookback, probeMeasurement, probeID,
+		)
+	}
+
+	iterator, err := influxdb.Query(query)
+	if err != nil {
+		return false, err
+	}
+	defer iterator.Close()
+
+	found := iterator.Next()
+	if err := iterator.Err(); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// generateProbeID returns random hex identifier for probe point
+func generateProbeID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,89 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// defaultCheckTimeout bounds single service check when not configured
+const defaultCheckTimeout = 3 * time.Second
+
+// serviceCheck is named health check function
+type serviceCheck struct {
+	name  string
+	check func() ServiceHealth
+}
+
+// checkTimeout returns configured timeout for service (per-service override, global default, then 3s)
+func checkTimeout(service string) time.Duration {
+	healthConfig := config.Get().Health
+
+	for _, value := range []string{healthConfig.Timeouts[service], healthConfig.Timeout} {
+		if value == "" {
+			continue
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			logger.WithScope("health").Warn().Str("service", service).Str("timeout", value).Msg("Invalid health check timeout, ignoring")
+			continue
+		}
+		return timeout
+	}
+	return defaultCheckTimeout
+}
+
+// runWithTimeout runs check bounded by service timeout, hung check is reported unhealthy.
+// Underlying clients are not context aware, so timed out check finishes in background and its result is discarded.
+func runWithTimeout(service string, check func() ServiceHealth) ServiceHealth {
+	timeout := checkTimeout(service)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := utils.Now()
+	result := make(chan ServiceHealth, 1)
+	go func() {
+		result <- check()
+	}()
+
+	select {
+	case health := <-result:
+		return health
+	case <-ctx.Done():
+		return ServiceHealth{
+			Status:       "unhealthy",
+			ResponseTime: time.Since(start).String(),
+			LastCheck:    utils.Now(),
+			Error:        fmt.Sprintf("timeout: check exceeded %s", timeout),
+		}
+	}
+}
+
+// runChecks runs checks concurrently, total latency is bounded by slowest single check
+func runChecks(checks []serviceCheck) map[string]ServiceHealth {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]ServiceHealth, len(checks))
+	)
+
+	for _, sc := range checks {
+		wg.Add(1)
+		go func(sc serviceCheck) {
+			defer wg.Done()
+			health := runWithTimeout(sc.name, sc.check)
+
+			mu.Lock()
+			results[sc.name] = health
+			mu.Unlock()
+		}(sc)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,292 @@
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	clEntities "github.com/benedict-erwin/insight-collector/internal/entities/callback_logs"
+	eeEntities "github.com/benedict-erwin/insight-collector/internal/entities/error_events"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	sessEntities "github.com/benedict-erwin/insight-collector/internal/entities/session_events"
+	teEntities "github.com/benedict-erwin/insight-collector/internal/entities/transaction_events"
+	uaEntities "github.com/benedict-erwin/insight-collector/internal/entities/user_activities"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// DefaultRange is summary range when not requested
+const DefaultRange = "24h"
+
+// topLimit caps top statuses/countries lists
+const topLimit = 5
+
+// Tag/field names used for breakdowns (only queried for measurements that have them)
+const (
+	statusTag  = "status"
+	countryTag = "geo_country"
+	botField   = "is_bot"
+)
+
+// Query configs of summarized measurements
+var queryConfigs = []func() v2oss.QueryBuilderConfig{
+	uaEntities.GetQueryConfig,
+	seEntities.GetQueryConfig,
+	teEntities.GetQueryConfig,
+	eeEntities.GetQueryConfig,
+	sessEntities.GetQueryConfig,
+	clEntities.GetQueryConfig,
+}
+
+var (
+	// Cache per range (same 10s as health)
+	summaryCache      = make(map[string]cachedSummary)
+	summaryCacheMutex sync.RWMutex
+
+	cacheValidDuration = 10 * time.Second
+
+	// executeAggregate runs aggregate query (replaced in tests)
+	executeAggregate = func(qb *v2oss.QueryBuilder, req *v2oss.PaginationRequest, groupBy []string, client *v2oss.Client) ([]map[string]interface{}, error) {
+		return qb.ExecuteAggregateQuery(req, groupBy, client)
+	}
+)
+
+type cachedSummary struct {
+	summary  *Summary
+	cachedAt time.Time
+}
+
+// GroupCount is record count of single tag value
+type GroupCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// BotRatio holds bot vs human record counts over measurements storing is_bot
+type BotRatio struct {
+	Bots     int64   `json:"bots"`
+	Humans   int64   `json:"humans"`
+	BotRatio float64 `json:"bot_ratio"` // bots / (bots + humans), 0 without records
+}
+
+// Summary holds dashboard totals over range
+type Summary struct {
+	Range        string                  `json:"range"`
+	GeneratedAt  time.Time               `json:"generated_at"`
+	Counts       map[string]int64        `json:"counts"`        // Records per measurement
+	TopStatuses  map[string][]GroupCount `json:"top_statuses"`  // Per measurement with status tag
+	TopCountries []GroupCount            `json:"top_countries"` // Summed over measurements with geo_country tag
+	Bots         BotRatio                `json:"bots"`
+	Partial      bool                    `json:"partial"`          // True when some measurement queries failed
+	Errors       map[string]string       `json:"errors,omitempty"` // Failed query per measurement
+}
+
+// measurementStats holds query results of single measurement
+type measurementStats struct {
+	measurement string
+	count       int64
+	statuses    []GroupCount
+	countries   []GroupCount
+	bots        int64
+	hasBots     bool
+	err         error
+}
+
+// ValidateRange validates range preset against presets accepted by list endpoints (empty means DefaultRange)
+func ValidateRange(rangePreset string) error {
+	if rangePreset == "" {
+		rangePreset = DefaultRange
+	}
+	req := &v2oss.PaginationRequest{Range: &v2oss.DateRangeFilter{Preset: rangePreset}}
+	return v2oss.NewQueryBuilder(queryConfigs[0]()).ValidateAggregate(req, nil)
+}
+
+// GetSummary returns per-measurement counts, top statuses & countries and bot ratio over range preset (10s cache).
+// Measurement query failures are reported in Errors with Partial set, remaining measurements are still summarized.
+func GetSummary(rangePreset string, client *v2oss.Client) (*Summary, error) {
+	if rangePreset == "" {
+		rangePreset = DefaultRange
+	}
+
+	summaryCacheMutex.RLock()
+	cached, found := summaryCache[rangePreset]
+	summaryCacheMutex.RUnlock()
+	if found && time.Since(cached.cachedAt) < cacheValidDuration {
+		summary := *cached.summary // Copy to avoid race conditions
+		return &summary, nil
+	}
+
+	if err := ValidateRange(rangePreset); err != nil {
+		return nil, err
+	}
+
+	req := &v2oss.PaginationRequest{Range: &v2oss.DateRangeFilter{Preset: rangePreset}}
+	summary := buildSummary(rangePreset, collect(req, client))
+
+	summaryCacheMutex.Lock()
+	summaryCache[rangePreset] = cachedSummary{summary: summary, cachedAt: time.Now()}
+	summaryCacheMutex.Unlock()
+
+	copied := *summary
+	return &copied, nil
+}
+
+// collect queries all measurements concurrently
+func collect(req *v2oss.PaginationRequest, client *v2oss.Client) []measurementStats {
+	results := make([]measurementStats, len(queryConfigs))
+
+	var wg sync.WaitGroup
+	for i, queryConfig := range queryConfigs {
+		wg.Add(1)
+		go func(i int, cfg v2oss.QueryBuilderConfig) {
+			defer wg.Done()
+			results[i] = queryMeasurement(cfg, req, client)
+		}(i, queryConfig())
+	}
+	wg.Wait()
+
+	return results
+}
+
+// queryMeasurement runs count, status, country & bot queries of single measurement, first error stops it
+func queryMeasurement(cfg v2oss.QueryBuilderConfig, req *v2oss.PaginationRequest, client *v2oss.Client) measurementStats {
+	stats := measurementStats{measurement: cfg.Measurement}
+	qb := v2oss.NewQueryBuilder(cfg)
+
+	rows, err := executeAggregate(qb, req, nil, client)
+	if err != nil {
+		stats.err = err
+		return stats
+	}
+	stats.count = sumCounts(rows)
+
+	if cfg.ValidTags[statusTag] {
+		if rows, err = executeAggregate(qb, req, []string{statusTag}, client); err != nil {
+			stats.err = err
+			return stats
+		}
+		stats.statuses = groupCounts(rows, statusTag)
+	}
+
+	if cfg.ValidTags[countryTag] {
+		if rows, err = executeAggregate(qb, req, []string{countryTag}, client); err != nil {
+			stats.err = err
+			return stats
+		}
+		stats.countries = groupCounts(rows, countryTag)
+	}
+
+	if cfg.BoolFields[botField] {
+		botReq := *req
+		botReq.Filters = []v2oss.FilterItem{{Key: botField, Value: "true"}}
+		if rows, err = executeAggregate(qb, &botReq, nil, client); err != nil {
+			stats.err = err
+			return stats
+		}
+		stats.bots = sumCounts(rows)
+		stats.hasBots = true
+	}
+
+	return stats
+}
+
+// buildSummary merges measurement results into summary
+func buildSummary(rangePreset string, results []measurementStats) *Summary {
+	summary := &Summary{
+		Range:       rangePreset,
+		GeneratedAt: utils.Now(),
+		Counts:      make(map[string]int64),
+		TopStatuses: make(map[string][]GroupCount),
+	}
+
+	countries := make(map[string]int64)
+	for _, result := range results {
+		if result.err != nil {
+			logger.WithScope("StatsSummary").Warn().
+				Err(result.err).
+				Str("measurement", result.measurement).
+				Msg("Measurement stats query failed, returning partial summary")
+			if summary.Errors == nil {
+				summary.Errors = make(map[string]string)
+			}
+			summary.Errors[result.measurement] = result.err.Error()
+			summary.Partial = true
+			continue
+		}
+
+		summary.Counts[result.measurement] = result.count
+		if len(result.statuses) > 0 {
+			summary.TopStatuses[result.measurement] = topCounts(result.statuses)
+		}
+		for _, country := range result.countries {
+			countries[country.Value] += country.Count
+		}
+		if result.hasBots {
+			summary.Bots.Bots += result.bots
+			summary.Bots.Humans += result.count - result.bots
+		}
+	}
+
+	summary.TopCountries = make([]GroupCount, 0, len(countries))
+	for value, count := range countries {
+		summary.TopCountries = append(summary.TopCountries, GroupCount{Value: value, Count: count})
+	}
+	summary.TopCountries = topCounts(summary.TopCountries)
+
+	if total := summary.Bots.Bots + summary.Bots.Humans; total > 0 {
+		summary.Bots.BotRatio = float64(summary.Bots.Bots) / float64(total)
+	}
+	return summary
+}
+
+// groupCounts converts aggregate rows into counts per tag value (empty placeholder skipped)
+func groupCounts(rows []map[string]interface{}, tag string) []GroupCount {
+	counts := make([]GroupCount, 0, len(rows))
+	for _, row := range rows {
+		value, _ := row[tag].(string)
+		if value == "" || value == "-" {
+			continue
+		}
+		counts = append(counts, GroupCount{Value: value, Count: toInt64(row["count"])})
+	}
+	return counts
+}
+
+// topCounts sorts by count descending (value ascending on ties) and keeps topLimit entries
+func topCounts(counts []GroupCount) []GroupCount {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	if len(counts) > topLimit {
+		counts = counts[:topLimit]
+	}
+	return counts
+}
+
+// sumCounts sums count column of aggregate rows
+func sumCounts(rows []map[string]interface{}) int64 {
+	var total int64
+	for _, row := range rows {
+		total += toInt64(row["count"])
+	}
+	return total
+}
+
+// toInt64 converts InfluxDB count value to int64
+func toInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case int:
+		return int64(v)
+	}
+	return 0
+}
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+)
+
+func TestCollectBuildsPartialSummary(t *testing.T) {
+	originalExecute, originalConfigs := executeAggregate, queryConfigs
+	defer func() { executeAggregate, queryConfigs = originalExecute, originalConfigs }()
+
+	queryConfigs = []func() v2oss.QueryBuilderConfig{
+		func() v2oss.QueryBuilderConfig {
+			return v2oss.QueryBuilderConfig{
+				Measurement: "user_activities",
+				ValidTags:   map[string]bool{"status": true, "geo_country": true},
+				ValidFields: map[string]bool{"is_bot": true},
+				BoolFields:  map[string]bool{"is_bot": true},
+			}
+		},
+		func() v2oss.QueryBuilderConfig {
+			return v2oss.QueryBuilderConfig{
+				Measurement: "session_events",
+				ValidTags:   map[string]bool{"geo_country": true},
+			}
+		},
+		func() v2oss.QueryBuilderConfig {
+			return v2oss.QueryBuilderConfig{Measurement: "error_events"}
+		},
+	}
+
+	executeAggregate = func(qb *v2oss.QueryBuilder, req *v2oss.PaginationRequest, groupBy []string, client *v2oss.Client) ([]map[string]interface{}, error) {
+		query, _ := qb.BuildAggregateQuery(req, groupBy, "bucket")
+		switch {
+		case strings.Contains(query, `"error_events"`):
+			return nil, errors.New("query timeout")
+		case len(groupBy) == 1 && groupBy[0] == "status":
+			return []map[string]interface{}{
+				{"status": "success", "count": int64(90)},
+				{"status": "failed", "count": int64(10)},
+			}, nil
+		case len(groupBy) == 1 && groupBy[0] == "geo_country" && strings.Contains(query, `"user_activities"`):
+			return []map[string]interface{}{
+				{"geo_country": "ID", "count": int64(70)},
+				{"geo_country": "SG", "count": int64(20)},
+				{"geo_country": "-", "count": int64(10)},
+			}, nil
+		case len(groupBy) == 1 && groupBy[0] == "geo_country":
+			return []map[string]interface{}{{"geo_country": "SG", "count": int64(60)}}, nil
+		case len(req.Filters) == 1 && req.Filters[0].Key == "is_bot":
+			return []map[string]interface{}{{"count": int64(25)}}, nil
+		case strings.Contains(query, `"session_events"`):
+			return []map[string]interface{}{{"count": int64(60)}}, nil
+		default:
+			return []map[string]interface{}{{"count": int64(100)}}, nil
+		}
+	}
+
+	req := &v2oss.PaginationRequest{Range: &v2oss.DateRangeFilter{Preset: "24h"}}
+	summary := buildSummary("24h", collect(req, nil))
+
+	if summary.Counts["user_activities"] != 100 || summary.Counts["session_events"] != 60 {
+		t.Errorf("unexpected counts %v", summary.Counts)
+	}
+	if _, found := summary.Counts["error_events"]; found {
+		t.Error("failed measurement must not be counted")
+	}
+	if !summary.Partial || summary.Errors["error_events"] != "query timeout" {
+		t.Errorf("expected partial summary with error_events error, got partial=%v errors=%v", summary.Partial, summary.Errors)
+	}
+
+	statuses := summary.TopStatuses["user_activities"]
+	if len(statuses) != 2 || statuses[0] != (GroupCount{Value: "success", Count: 90}) {
+		t.Errorf("unexpected top statuses %v", statuses)
+	}
+
+	// Countries summed across measurements, placeholder skipped
+	expectedCountries := []GroupCount{{Value: "SG", Count: 80}, {Value: "ID", Count: 70}}
+	if len(summary.TopCountries) != len(expectedCountries) {
+		t.Fatalf("expected %v, got %v", expectedCountries, summary.TopCountries)
+	}
+	for i, want := range expectedCountries {
+		if summary.TopCountries[i] != want {
+			t.Errorf("country %d: expected %v, got %v", i, want, summary.TopCountries[i])
+		}
+	}
+
+	if summary.Bots.Bots != 25 || summary.Bots.Humans != 75 || summary.Bots.BotRatio != 0.25 {
+		t.Errorf("unexpected bot ratio %+v", summary.Bots)
+	}
+}
+
+func TestTopCountsLimitsAndOrders(t *testing.T) {
+	counts := []GroupCount{
+		{"a", 1}, {"b", 5}, {"c", 5}, {"d", 3}, {"e", 2}, {"f", 4}, {"g", 0},
+	}
+	got := topCounts(counts)
+	expected := []GroupCount{{"b", 5}, {"c", 5}, {"f", 4}, {"d", 3}, {"e", 2}}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("position %d: expected %v, got %v", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestValidateRange(t *testing.T) {
+	if err := ValidateRange(""); err != nil {
+		t.Errorf("default range must be valid, got %v", err)
+	}
+	if err := ValidateRange("13x"); err == nil {
+		t.Error("expected error for unknown range preset")
+	}
+}
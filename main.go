@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/jpillora/overseer"
 	"github.com/benedict-erwin/insight-collector/cmd"
@@ -21,7 +22,7 @@ func main() {
 				},
 				Address:          ":3000",
 				RestartSignal:    overseer.SIGUSR2,
-				TerminateTimeout: 30,
+				TerminateTimeout: 30 * time.Second,
 			})
 		case "dev":
 			// Development mode without overseer (for air hot reload)
@@ -35,7 +36,7 @@ func main() {
 					},
 					Address:          ":3001",
 					RestartSignal:    overseer.SIGUSR2,
-					TerminateTimeout: 30,
+					TerminateTimeout: 30 * time.Second,
 				})
 			} else {
 				// Worker CLI commands without overseer
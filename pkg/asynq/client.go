@@ -8,16 +8,32 @@ import (
 	"time"
 
 	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/latency"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
 	"github.com/hibiken/asynq"
 	"github.com/redis/go-redis/v9"
 )
 
+// defaultDedupWindow is how long job id is rejected when asynq.dedup_window is not configured
+const defaultDedupWindow = 10 * time.Minute
+
+// ErrDuplicateJob is returned when same job id was dispatched within dedup window (queued or already processed)
+var ErrDuplicateJob = errors.New("duplicate job")
+
 var (
 	client      *asynq.Client
 	redisClient *redis.Client
 )
 
+// enqueueContext enqueues task with asynq client (replaced in tests)
+var enqueueContext = func(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if client == nil {
+		return nil, fmt.Errorf("asynq client not initialized")
+	}
+	return client.EnqueueContext(ctx, task, opts...)
+}
+
 // InitClient initializes the Asynq Redis client with advanced pool optimization
 func InitClient() error {
 	cfg := config.Get()
@@ -96,7 +112,9 @@ func GetClient() *asynq.Client {
 	return client
 }
 
-// DispathJob enqueue helper function
+// DispathJob enqueue helper function.
+// Enqueue is synchronous (bounded by 5s timeout) so ingestion handlers can report retried requests:
+// ErrDuplicateJob means job already dispatched. Callers that don't need the result use DispatchJobAsync.
 func DispatchJob(payload *Payload) error {
 	// Validate payload first
 	if payload == nil {
@@ -113,65 +131,122 @@ func DispatchJob(payload *Payload) error {
 		return err
 	}
 
+	return enqueueTask(payload, data)
+}
+
+// DispatchJobAsync enqueues job in background without blocking caller (best effort, e.g. audit events).
+// Enqueue errors, including duplicates, are logged only.
+func DispatchJobAsync(payload *Payload) {
+	if payload == nil {
+		logger.WithScope("DispathJob").Error().Msg("Payload cannot be nil")
+		return
+	}
+
+	data, err := json.Marshal(payload.Data)
+	if err != nil {
+		logger.WithScope("DispathJob").Error().Err(err).Str("taskId", payload.TaskId).Msg("Failed to marshal payload")
+		return
+	}
+
 	// Enqueue in timeout-protected goroutine
 	go func() {
-		// Create new task
-		task := asynq.NewTask(payload.TaskType, data)
-		client := GetClient()
+		_ = enqueueTask(payload, data)
+	}()
+}
 
-		if client == nil {
-			log.Error().Msg("Asynq client not initialized")
-		}
+// DispatchJobSync enqueues job and waits for result (CLI tools that exit after dispatching, e.g. replay)
+func DispatchJobSync(payload *Payload) error {
+	if payload == nil {
+		return fmt.Errorf("payload cannot be nil")
+	}
 
-		// Route to appropriate queue
-		queue := GetQueueForTaskType(payload.TaskType)
-
-		// Add timeout and reduced uniqueness check
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) // 5s timeout for enqueue
-		defer cancel()
-
-		// Enqueue process
-		_, err = client.EnqueueContext(
-			ctx,
-			task,
-			asynq.Queue(queue),
-			asynq.Unique(1*time.Minute), // Reduced uniqueness window from 5min to 1min
-			asynq.TaskID(payload.TaskId),
-			asynq.Retention(10*time.Minute), // Retain completed tasks for 10min only (reduce memory)
-		)
-		if err != nil {
-			// Duplicate task
-			if errors.Is(err, asynq.ErrDuplicateTask) {
-				log.Warn().
-					Str("taskId", payload.TaskId).
-					Str("taskType", payload.TaskType).
-					Msg("Duplicate task ignored - already in queue")
-			}
+	data, err := json.Marshal(payload.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
 
-			// Conflict task
-			if errors.Is(err, asynq.ErrTaskIDConflict) {
-				log.Warn().
-					Str("taskId", payload.TaskId).
-					Str("taskType", payload.TaskType).
-					Msg("Task ID conflict - duplicate task")
-			}
+	// Replayed events already in queue are not an error
+	if err := enqueueTask(payload, data); err != nil && !errors.Is(err, ErrDuplicateJob) {
+		return err
+	}
+	return nil
+}
+
+// dedupWindow returns how long same job id is rejected (asynq.dedup_window), also retention of completed tasks
+func dedupWindow() time.Duration {
+	cfg := config.Get()
+	if cfg == nil || cfg.Asynq.DedupWindow == "" {
+		return defaultDedupWindow
+	}
+
+	window, err := time.ParseDuration(cfg.Asynq.DedupWindow)
+	if err != nil || window <= 0 {
+		logger.Warn().Str("dedup_window", cfg.Asynq.DedupWindow).Msg("Invalid dedup window, using default")
+		return defaultDedupWindow
+	}
+	return window
+}
+
+// enqueueTask enqueues marshalled payload to queue resolved from task type.
+// Same TaskId within dedup window returns ErrDuplicateJob.
+func enqueueTask(payload *Payload, data []byte) error {
+	// Setup logger scope
+	log := logger.WithScope("DispathJob")
+
+	// Stamp enqueue time for enqueue → write latency
+	enqueuedAt := payload.EnqueuedAt
+	if enqueuedAt.IsZero() {
+		enqueuedAt = utils.Now()
+	}
+	data = latency.Stamp(data, enqueuedAt)
+
+	// Create new task
+	task := asynq.NewTask(payload.TaskType, data)
+
+	// Route to appropriate queue
+	queue := GetQueueForTaskType(payload.TaskType)
 
-			// Other errors
-			log.Error().
-				Err(err).
+	// Add timeout and reduced uniqueness check
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second) // 5s timeout for enqueue
+	defer cancel()
+
+	// Enqueue options (retry policy from job registry).
+	// Dedup relies on TaskID only: it stays taken while task is queued and, through retention, for dedup window
+	// after processing. asynq.Unique is not used, its lock key hashes payload which differs per enqueued_at stamp.
+	opts := []asynq.Option{
+		asynq.Queue(queue),
+		asynq.TaskID(payload.TaskId),
+		asynq.Retention(dedupWindow()),
+	}
+	opts = append(opts, retryOptions(payload.TaskType)...)
+
+	// Enqueue process
+	_, err := enqueueContext(ctx, task, opts...)
+	if err != nil {
+		// Conflict task (same TaskId still queued or retained)
+		if errors.Is(err, asynq.ErrTaskIDConflict) {
+			log.Warn().
 				Str("taskId", payload.TaskId).
 				Str("taskType", payload.TaskType).
-				Msg("Failed to enqueue task")
+				Msg("Duplicate task ignored - already dispatched within dedup window")
+			return ErrDuplicateJob
 		}
 
-		// Success
-		log.Info().
+		// Other errors
+		log.Error().
+			Err(err).
 			Str("taskId", payload.TaskId).
 			Str("taskType", payload.TaskType).
-			Str("queue", queue).
-			Msg("Task enqueued successfully")
-	}()
+			Msg("Failed to enqueue task")
+		return fmt.Errorf("failed to enqueue task %s: %w", payload.TaskId, err)
+	}
 
+	// Success
+	log.Info().
+		Str("taskId", payload.TaskId).
+		Str("taskType", payload.TaskType).
+		Str("queue", queue).
+		Msg("Task enqueued successfully")
 	return nil
 }
 
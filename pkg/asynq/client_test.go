@@ -0,0 +1,163 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// fakeQueue rejects task like asynq with TaskID + Retention options: TaskID is taken while task
+// is queued and for retention after it is processed
+type fakeQueue struct {
+	mu       sync.Mutex
+	now      time.Time
+	tasks    map[string]*fakeTask
+	enqueued int
+	options  []asynq.OptionType
+}
+
+// fakeTask is stored task of fakeQueue
+type fakeTask struct {
+	retention   time.Duration
+	processed   bool
+	processedAt time.Time
+}
+
+func (f *fakeQueue) enqueue(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var taskID string
+	var retention time.Duration
+	for _, opt := range opts {
+		f.options = append(f.options, opt.Type())
+		switch opt.Type() {
+		case asynq.TaskIDOpt:
+			taskID = opt.Value().(string)
+		case asynq.RetentionOpt:
+			retention = opt.Value().(time.Duration)
+		}
+	}
+
+	if stored, exists := f.tasks[taskID]; exists {
+		if !stored.processed || f.now.Before(stored.processedAt.Add(stored.retention)) {
+			return nil, asynq.ErrTaskIDConflict
+		}
+	}
+	f.tasks[taskID] = &fakeTask{retention: retention}
+	f.enqueued++
+	return &asynq.TaskInfo{ID: taskID, Type: task.Type()}, nil
+}
+
+// process simulates successful processing (task kept for retention)
+func (f *fakeQueue) process(taskID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if stored, exists := f.tasks[taskID]; exists {
+		stored.processed = true
+		stored.processedAt = f.now
+	}
+}
+
+func (f *fakeQueue) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enqueued
+}
+
+// stubEnqueue replaces asynq enqueue for single test
+func stubEnqueue(t *testing.T) *fakeQueue {
+	queue := &fakeQueue{now: time.Now(), tasks: map[string]*fakeTask{}}
+	orig := enqueueContext
+	enqueueContext = queue.enqueue
+	t.Cleanup(func() {
+		enqueueContext = orig
+	})
+	return queue
+}
+
+func TestDispatchJobDeduplication(t *testing.T) {
+	queue := stubEnqueue(t)
+	payload := &Payload{
+		TaskId:   "se_0123456789abcdef",
+		TaskType: "security_events:logging",
+		Data:     map[string]interface{}{"user_id": "user-1"},
+	}
+
+	if err := DispatchJob(payload); err != nil {
+		t.Fatalf("unexpected error on first dispatch: %v", err)
+	}
+
+	// Retried request with same payload while task still queued
+	if err := DispatchJob(payload); !errors.Is(err, ErrDuplicateJob) {
+		t.Fatalf("expected ErrDuplicateJob on second dispatch, got %v", err)
+	}
+	if queue.count() != 1 {
+		t.Errorf("expected 1 enqueued task, got %d", queue.count())
+	}
+
+	// Replay tools treat duplicate as success
+	if err := DispatchJobSync(payload); err != nil {
+		t.Errorf("expected nil from DispatchJobSync on duplicate, got %v", err)
+	}
+
+	// Client retry arriving after task was processed (usually milliseconds later) is still a duplicate
+	queue.process(payload.TaskId)
+	if err := DispatchJob(payload); !errors.Is(err, ErrDuplicateJob) {
+		t.Fatalf("expected ErrDuplicateJob after task processed within dedup window, got %v", err)
+	}
+	if queue.count() != 1 {
+		t.Errorf("expected 1 enqueued task, got %d", queue.count())
+	}
+
+	// Same id accepted again once dedup window (retention) passed
+	queue.now = queue.now.Add(defaultDedupWindow + time.Second)
+	if err := DispatchJob(payload); err != nil {
+		t.Errorf("unexpected error after dedup window: %v", err)
+	}
+
+	// Different id is never a duplicate
+	other := *payload
+	other.TaskId = "se_fedcba9876543210"
+	if err := DispatchJob(&other); err != nil {
+		t.Errorf("unexpected error for different job id: %v", err)
+	}
+	if queue.count() != 3 {
+		t.Errorf("expected 3 enqueued tasks, got %d", queue.count())
+	}
+
+	// Completed tasks are retained for dedup window, payload hash lock is not used (enqueued_at differs per dispatch)
+	retained := false
+	for _, opt := range queue.options {
+		retained = retained || opt == asynq.RetentionOpt
+		if opt == asynq.UniqueOpt {
+			t.Fatal("expected no Unique option on enqueue")
+		}
+	}
+	if !retained {
+		t.Error("expected Retention option on enqueue")
+	}
+}
+
+func TestDispatchJobAsyncEnqueuesInBackground(t *testing.T) {
+	queue := stubEnqueue(t)
+
+	DispatchJobAsync(nil) // logged, never panics
+	DispatchJobAsync(&Payload{
+		TaskId:   "se_async",
+		TaskType: "security_events:logging",
+		Data:     map[string]interface{}{"user_id": "user-1"},
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for queue.count() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 1 task enqueued in background, got %d", queue.count())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,296 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/hibiken/asynq"
+)
+
+// deadLetterPageSize is page size used when walking dead queue
+const deadLetterPageSize = 100
+
+// DeadTask represents task moved to dead queue after exhausting its retries
+type DeadTask struct {
+	ID           string    `json:"id"`
+	Queue        string    `json:"queue"` // Original queue, retried tasks go back there
+	Type         string    `json:"type"`
+	Retried      int       `json:"retried"`
+	MaxRetry     int       `json:"max_retry"`
+	LastErr      string    `json:"last_error"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+	Payload      string    `json:"payload"`
+}
+
+// deadLetterEnvelope wraps original payload with failure context as dead queue task payload
+type deadLetterEnvelope struct {
+	Queue    string    `json:"queue"`
+	Retried  int       `json:"retried"`
+	MaxRetry int       `json:"max_retry"`
+	LastErr  string    `json:"last_error"`
+	FailedAt time.Time `json:"failed_at"`
+	Payload  []byte    `json:"payload"`
+}
+
+// redisClientOpt returns asynq connection options for Asynq Redis DB
+func redisClientOpt() asynq.RedisClientOpt {
+	cfg := config.Get()
+	return asynq.RedisClientOpt{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Asynq.DB,
+	}
+}
+
+// newInspector creates asynq inspector using Asynq Redis DB
+func newInspector() *asynq.Inspector {
+	return asynq.NewInspector(redisClientOpt())
+}
+
+// inspectQueues returns queues to inspect (all known queues when queue is empty)
+func inspectQueues(queue string) ([]string, error) {
+	if queue == "" {
+		return constants.GetAllQueues(), nil
+	}
+	if queue == constants.QueueDead {
+		return []string{queue}, nil
+	}
+	if !constants.IsValidQueue(queue) {
+		return nil, fmt.Errorf("invalid queue '%s'. Valid queues: %v", queue, constants.GetAllQueues())
+	}
+	return []string{queue}, nil
+}
+
+// deadLetterQueues resolves original queue filter of dead-letter commands, nil means every dead task.
+// Dead tasks keep their original queue, so "dead" itself (or empty) selects all of them.
+func deadLetterQueues(queue string) ([]string, error) {
+	if queue == "" || queue == constants.QueueDead {
+		return nil, nil
+	}
+	return inspectQueues(queue)
+}
+
+// newDeadLetterTask wraps failed task with failure context for dead queue
+func newDeadLetterTask(task *asynq.Task, queue string, retried, maxRetry int, cause error, failedAt time.Time) (*asynq.Task, error) {
+	envelope, err := json.Marshal(deadLetterEnvelope{
+		Queue:    queue,
+		Retried:  retried,
+		MaxRetry: maxRetry,
+		LastErr:  cause.Error(),
+		FailedAt: failedAt,
+		Payload:  task.Payload(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dead-letter envelope: %w", err)
+	}
+	return asynq.NewTask(task.Type(), envelope), nil
+}
+
+// decodeDeadTask unwraps dead queue task with original queue and payload
+func decodeDeadTask(info *asynq.TaskInfo) (DeadTask, error) {
+	var envelope deadLetterEnvelope
+	if err := json.Unmarshal(info.Payload, &envelope); err != nil {
+		return DeadTask{}, fmt.Errorf("invalid dead-letter task '%s': %w", info.ID, err)
+	}
+
+	return DeadTask{
+		ID:           info.ID,
+		Queue:        envelope.Queue,
+		Type:         info.Type,
+		Retried:      envelope.Retried,
+		MaxRetry:     envelope.MaxRetry,
+		LastErr:      envelope.LastErr,
+		LastFailedAt: envelope.FailedAt,
+		Payload:      string(envelope.Payload),
+	}, nil
+}
+
+// DeadLetterMiddleware moves tasks failing their last attempt to dead queue instead of asynq archive.
+// When move fails original error is returned so asynq archives task as fallback.
+func DeadLetterMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		err := next.ProcessTask(ctx, task)
+
+		// Success, or task interrupted by shutdown (re-queued by asynq)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+		if retried < maxRetry && !errors.Is(err, asynq.SkipRetry) {
+			return err
+		}
+
+		// Setup logger scope
+		log := logger.WithScope("DeadLetterMiddleware")
+
+		taskID, _ := asynq.GetTaskID(ctx)
+		queue, _ := asynq.GetQueueName(ctx)
+		deadTask, moveErr := newDeadLetterTask(task, queue, retried, maxRetry, err, time.Now())
+		if moveErr == nil {
+			enqueueCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			_, moveErr = enqueueContext(enqueueCtx, deadTask, asynq.Queue(constants.QueueDead), asynq.TaskID(taskID), asynq.MaxRetry(0))
+			cancel()
+		}
+		if moveErr != nil && !errors.Is(moveErr, asynq.ErrTaskIDConflict) {
+			log.Error().
+				Err(moveErr).
+				Str("task_id", taskID).
+				Str("task_type", task.Type()).
+				Msg("Failed to move task to dead queue, leaving it to asynq archive")
+			return err
+		}
+
+		log.Warn().
+			Err(err).
+			Str("task_id", taskID).
+			Str("task_type", task.Type()).
+			Str("queue", queue).
+			Int("retried", retried).
+			Msg("Task exhausted retries, moved to dead queue")
+		return nil
+	})
+}
+
+// collectDeadTasks walks dead queue and returns tasks whose original queue is in queues (nil = all), up to limit (0 = no limit)
+func collectDeadTasks(inspector *asynq.Inspector, queues []string, limit int) ([]DeadTask, error) {
+	wanted := make(map[string]bool, len(queues))
+	for _, q := range queues {
+		wanted[q] = true
+	}
+
+	deadTasks := []DeadTask{}
+	for page := 1; ; page++ {
+		tasks, err := inspector.ListPendingTasks(constants.QueueDead, asynq.PageSize(deadLetterPageSize), asynq.Page(page))
+		if err != nil {
+			// Dead queue never used yet
+			if errors.Is(err, asynq.ErrQueueNotFound) {
+				return deadTasks, nil
+			}
+			return nil, fmt.Errorf("failed to list dead-letter tasks: %w", err)
+		}
+
+		for _, task := range tasks {
+			deadTask, err := decodeDeadTask(task)
+			if err != nil {
+				logger.Warn().Err(err).Msg("Skipping malformed dead-letter task")
+				continue
+			}
+			if queues != nil && !wanted[deadTask.Queue] {
+				continue
+			}
+			deadTasks = append(deadTasks, deadTask)
+			if limit > 0 && len(deadTasks) >= limit {
+				return deadTasks, nil
+			}
+		}
+
+		if len(tasks) < deadLetterPageSize {
+			return deadTasks, nil
+		}
+	}
+}
+
+// ListDeadTasks returns dead-letter tasks originating from queue or all queues, up to limit
+func ListDeadTasks(queue string, limit int) ([]DeadTask, error) {
+	queues, err := deadLetterQueues(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	return collectDeadTasks(inspector, queues, limit)
+}
+
+// RetryDeadTasks re-enqueues dead-letter tasks to their original queue (single task when taskID is set), returns retried count
+func RetryDeadTasks(queue, taskID string) (int, error) {
+	queues, err := deadLetterQueues(queue)
+	if err != nil {
+		return 0, err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	client := asynq.NewClient(redisClientOpt())
+	defer client.Close()
+
+	// Single task or every task of given queue(s)
+	var deadTasks []DeadTask
+	if taskID != "" {
+		info, err := inspector.GetTaskInfo(constants.QueueDead, taskID)
+		if err != nil {
+			if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+				return 0, fmt.Errorf("dead-letter task '%s' not found", taskID)
+			}
+			return 0, fmt.Errorf("failed to get dead-letter task '%s': %w", taskID, err)
+		}
+		deadTask, err := decodeDeadTask(info)
+		if err != nil {
+			return 0, err
+		}
+		deadTasks = append(deadTasks, deadTask)
+	} else {
+		if deadTasks, err = collectDeadTasks(inspector, queues, 0); err != nil {
+			return 0, err
+		}
+	}
+
+	total := 0
+	for _, deadTask := range deadTasks {
+		opts := append([]asynq.Option{asynq.Queue(deadTask.Queue)}, retryOptions(deadTask.Type)...)
+		if _, err := client.Enqueue(asynq.NewTask(deadTask.Type, []byte(deadTask.Payload)), opts...); err != nil {
+			return total, fmt.Errorf("failed to re-enqueue task '%s' to queue '%s': %w", deadTask.ID, deadTask.Queue, err)
+		}
+		if err := inspector.DeleteTask(constants.QueueDead, deadTask.ID); err != nil {
+			return total, fmt.Errorf("task '%s' re-enqueued but not removed from dead queue: %w", deadTask.ID, err)
+		}
+		total++
+	}
+
+	return total, nil
+}
+
+// PurgeDeadTasks permanently deletes dead-letter tasks originating from queue or all queues, returns deleted count
+func PurgeDeadTasks(queue string) (int, error) {
+	queues, err := deadLetterQueues(queue)
+	if err != nil {
+		return 0, err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	// Whole dead queue
+	if queues == nil {
+		count, err := inspector.DeleteAllPendingTasks(constants.QueueDead)
+		if err != nil && !errors.Is(err, asynq.ErrQueueNotFound) {
+			return 0, fmt.Errorf("failed to purge dead-letter tasks: %w", err)
+		}
+		return count, nil
+	}
+
+	deadTasks, err := collectDeadTasks(inspector, queues, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, deadTask := range deadTasks {
+		if err := inspector.DeleteTask(constants.QueueDead, deadTask.ID); err != nil {
+			return total, fmt.Errorf("failed to delete dead-letter task '%s': %w", deadTask.ID, err)
+		}
+		total++
+	}
+
+	return total, nil
+}
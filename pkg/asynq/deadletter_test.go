@@ -0,0 +1,75 @@
+package asynq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+func TestDeadLetterEnvelopeRoundTrip(t *testing.T) {
+	original := asynq.NewTask("security_events:logging", []byte(`{"user_id":"user-1"}`))
+	failedAt := time.Date(2025, 8, 6, 12, 0, 0, 0, time.UTC)
+
+	deadTask, err := newDeadLetterTask(original, "critical", 5, 5, errors.New("influxdb unavailable"), failedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deadTask.Type() != original.Type() {
+		t.Errorf("dead task type = %s, want %s", deadTask.Type(), original.Type())
+	}
+
+	decoded, err := decodeDeadTask(&asynq.TaskInfo{ID: "se_1", Type: deadTask.Type(), Payload: deadTask.Payload()})
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+
+	want := DeadTask{
+		ID:           "se_1",
+		Queue:        "critical",
+		Type:         "security_events:logging",
+		Retried:      5,
+		MaxRetry:     5,
+		LastErr:      "influxdb unavailable",
+		LastFailedAt: failedAt,
+		Payload:      `{"user_id":"user-1"}`,
+	}
+	if decoded != want {
+		t.Errorf("decoded = %+v, want %+v", decoded, want)
+	}
+}
+
+func TestDecodeDeadTaskMalformed(t *testing.T) {
+	if _, err := decodeDeadTask(&asynq.TaskInfo{ID: "x", Payload: []byte("not json")}); err == nil {
+		t.Error("expected error for malformed dead-letter payload")
+	}
+}
+
+func TestInspectQueuesAcceptsDeadQueue(t *testing.T) {
+	queues, err := inspectQueues("dead")
+	if err != nil || len(queues) != 1 || queues[0] != "dead" {
+		t.Errorf("inspectQueues(dead) = %v, %v", queues, err)
+	}
+	if _, err := inspectQueues("unknown"); err == nil {
+		t.Error("expected error for unknown queue")
+	}
+}
+
+func TestDeadLetterQueues(t *testing.T) {
+	// Dead tasks are filtered by original queue, "dead" selects all of them
+	for _, queue := range []string{"", "dead"} {
+		queues, err := deadLetterQueues(queue)
+		if err != nil || queues != nil {
+			t.Errorf("deadLetterQueues(%q) = %v, %v, want all dead tasks", queue, queues, err)
+		}
+	}
+
+	queues, err := deadLetterQueues("critical")
+	if err != nil || len(queues) != 1 || queues[0] != "critical" {
+		t.Errorf("deadLetterQueues(critical) = %v, %v", queues, err)
+	}
+	if _, err := deadLetterQueues("unknown"); err == nil {
+		t.Error("expected error for unknown queue")
+	}
+}
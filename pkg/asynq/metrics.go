@@ -0,0 +1,72 @@
+package asynq
+
+import (
+	"fmt"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+)
+
+// QueueMetric holds live runtime counters of single queue
+type QueueMetric struct {
+	Queue          string `json:"queue"`
+	Pending        int    `json:"pending"`
+	Active         int    `json:"active"` // In progress
+	Scheduled      int    `json:"scheduled"`
+	Retry          int    `json:"retry"`
+	Archived       int    `json:"archived"` // Archived by asynq (dead queue move failed)
+	ProcessedToday int    `json:"processed_today"`
+	FailedToday    int    `json:"failed_today"`
+	ProcessedTotal int    `json:"processed_total"`
+	FailedTotal    int    `json:"failed_total"`
+	LatencyMs      int64  `json:"latency_ms"` // Age of oldest pending task
+	MemoryBytes    int64  `json:"memory_bytes"`
+	Paused         bool   `json:"paused"`
+}
+
+// GetQueueMetrics returns live metrics of all known queues from asynq inspector (zero values for unused queues)
+func GetQueueMetrics() (map[string]QueueMetric, error) {
+	inspector := newInspector()
+	defer inspector.Close()
+
+	// Queues known to Redis (created on first enqueue)
+	existingQueues, err := inspector.Queues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queues: %w", err)
+	}
+	existing := make(map[string]bool, len(existingQueues))
+	for _, queue := range existingQueues {
+		existing[queue] = true
+	}
+
+	metrics := make(map[string]QueueMetric, len(constants.GetAllQueues()))
+	for _, queue := range constants.GetAllQueues() {
+		// Queue never used yet
+		if !existing[queue] {
+			metrics[queue] = QueueMetric{Queue: queue}
+			continue
+		}
+
+		info, err := inspector.GetQueueInfo(queue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get info of queue '%s': %w", queue, err)
+		}
+
+		metrics[queue] = QueueMetric{
+			Queue:          queue,
+			Pending:        info.Pending,
+			Active:         info.Active,
+			Scheduled:      info.Scheduled,
+			Retry:          info.Retry,
+			Archived:       info.Archived,
+			ProcessedToday: info.Processed,
+			FailedToday:    info.Failed,
+			ProcessedTotal: info.ProcessedTotal,
+			FailedTotal:    info.FailedTotal,
+			LatencyMs:      info.Latency.Milliseconds(),
+			MemoryBytes:    info.MemoryUsage,
+			Paused:         info.Paused,
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,225 @@
+package asynq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/hibiken/asynq"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// pausedQueuesKey persists paused queues next to worker config (asynq:worker:config), restored on worker start
+const pausedQueuesKey = "asynq:worker:paused"
+
+// queuePauser is subset of asynq.Inspector used to pause queues (faked in tests)
+type queuePauser interface {
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+	PauseQueue(queue string) error
+	UnpauseQueue(queue string) error
+}
+
+// PauseQueue stops processing of queue on all workers, pending tasks stay in queue until resumed
+func PauseQueue(queue string) error {
+	if err := validatePauseQueue(queue); err != nil {
+		return err
+	}
+
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to create Redis client for queue pause: %w", err)
+	}
+	defer client.Close()
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	if err := pauseQueue(inspector, queue); err != nil {
+		return err
+	}
+	if err := updatePausedQueues(client, queue, true); err != nil {
+		return err
+	}
+
+	logger.Info().Str("queue", queue).Msg("Queue paused")
+	return nil
+}
+
+// ResumeQueue resumes processing of paused queue
+func ResumeQueue(queue string) error {
+	if err := validatePauseQueue(queue); err != nil {
+		return err
+	}
+
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to create Redis client for queue resume: %w", err)
+	}
+	defer client.Close()
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	if err := resumeQueue(inspector, queue); err != nil {
+		return err
+	}
+	if err := updatePausedQueues(client, queue, false); err != nil {
+		return err
+	}
+
+	logger.Info().Str("queue", queue).Msg("Queue resumed")
+	return nil
+}
+
+// RestorePausedQueues pauses persisted queues again (called on worker start, asynq pause keys may be lost on Redis flush)
+func RestorePausedQueues() {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to create Redis client for paused queues")
+		return
+	}
+	defer client.Close()
+
+	paused, err := loadPausedQueues(client)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load paused queues")
+		return
+	}
+	if len(paused) == 0 {
+		return
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	for _, queue := range paused {
+		if err := pauseQueue(inspector, queue); err != nil {
+			logger.Error().Err(err).Str("queue", queue).Msg("Failed to restore paused queue")
+		}
+	}
+	logger.Info().Strs("queues", paused).Msg("Paused queues restored, tasks are not processed until resumed")
+}
+
+// GetQueuePauseStates returns paused state of all known queues (persisted pause or paused in asynq)
+func GetQueuePauseStates() (map[string]bool, error) {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redis client for paused queues: %w", err)
+	}
+	defer client.Close()
+
+	paused, err := loadPausedQueues(client)
+	if err != nil {
+		return nil, err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	states := make(map[string]bool, len(constants.GetAllQueues()))
+	for _, queue := range constants.GetAllQueues() {
+		asynqPaused, err := isQueuePaused(inspector, queue)
+		if err != nil {
+			return nil, err
+		}
+		states[queue] = asynqPaused || slices.Contains(paused, queue)
+	}
+	return states, nil
+}
+
+// validatePauseQueue rejects queues workers never consume (dead queue included)
+func validatePauseQueue(queue string) error {
+	if !constants.IsValidQueue(queue) {
+		return fmt.Errorf("queue '%s' does not exist. Valid queues: %v", queue, constants.GetAllQueues())
+	}
+	return nil
+}
+
+// isQueuePaused returns asynq pause state, queues never used yet are not paused
+func isQueuePaused(inspector queuePauser, queue string) (bool, error) {
+	info, err := inspector.GetQueueInfo(queue)
+	if errors.Is(err, asynq.ErrQueueNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get info of queue '%s': %w", queue, err)
+	}
+	return info.Paused, nil
+}
+
+// pauseQueue pauses queue in asynq, already paused queue is not an error
+func pauseQueue(inspector queuePauser, queue string) error {
+	paused, err := isQueuePaused(inspector, queue)
+	if err != nil || paused {
+		return err
+	}
+	if err := inspector.PauseQueue(queue); err != nil {
+		return fmt.Errorf("failed to pause queue '%s': %w", queue, err)
+	}
+	return nil
+}
+
+// resumeQueue unpauses queue in asynq, queue that is not paused is not an error
+func resumeQueue(inspector queuePauser, queue string) error {
+	paused, err := isQueuePaused(inspector, queue)
+	if err != nil || !paused {
+		return err
+	}
+	if err := inspector.UnpauseQueue(queue); err != nil {
+		return fmt.Errorf("failed to resume queue '%s': %w", queue, err)
+	}
+	return nil
+}
+
+// loadPausedQueues returns persisted paused queues, empty when nothing was paused
+func loadPausedQueues(client redis.Client) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var paused []string
+	if err := client.GetJSON(ctx, pausedQueuesKey, &paused); err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load paused queues from Redis: %w", err)
+	}
+	return paused, nil
+}
+
+// updatePausedQueues adds or removes queue from persisted paused queues
+func updatePausedQueues(client redis.Client, queue string, paused bool) error {
+	queues, err := loadPausedQueues(client)
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(queues, queue)
+	switch {
+	case paused && index < 0:
+		queues = append(queues, queue)
+		slices.Sort(queues)
+	case !paused && index >= 0:
+		queues = slices.Delete(queues, index, index+1)
+	default:
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if len(queues) == 0 {
+		if err := client.Delete(ctx, pausedQueuesKey); err != nil {
+			return fmt.Errorf("failed to clear paused queues in Redis: %w", err)
+		}
+		return nil
+	}
+	if err := client.SetJSON(ctx, pausedQueuesKey, queues, 0); err != nil {
+		return fmt.Errorf("failed to save paused queues to Redis: %w", err)
+	}
+	return nil
+}
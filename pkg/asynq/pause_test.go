@@ -0,0 +1,121 @@
+package asynq
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hibiken/asynq"
+)
+
+// fakePauser tracks paused queues, queues missing from known return ErrQueueNotFound
+type fakePauser struct {
+	known   map[string]bool // Queue -> paused
+	pauses  int
+	infoErr error
+}
+
+func (f *fakePauser) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	if f.infoErr != nil {
+		return nil, f.infoErr
+	}
+	paused, ok := f.known[queue]
+	if !ok {
+		return nil, asynq.ErrQueueNotFound
+	}
+	return &asynq.QueueInfo{Queue: queue, Paused: paused}, nil
+}
+
+func (f *fakePauser) PauseQueue(queue string) error {
+	if f.known[queue] {
+		return errors.New("queue is already paused")
+	}
+	f.pauses++
+	f.known[queue] = true
+	return nil
+}
+
+func (f *fakePauser) UnpauseQueue(queue string) error {
+	if !f.known[queue] {
+		return errors.New("queue is not paused")
+	}
+	f.known[queue] = false
+	return nil
+}
+
+func TestPauseResumeQueueIdempotent(t *testing.T) {
+	pauser := &fakePauser{known: map[string]bool{"critical": false}}
+
+	// Queue never used yet is paused too, so first enqueued tasks wait
+	for _, queue := range []string{"critical", "critical", "low"} {
+		if err := pauseQueue(pauser, queue); err != nil {
+			t.Fatalf("pauseQueue(%s): %v", queue, err)
+		}
+	}
+	if pauser.pauses != 2 || !pauser.known["critical"] || !pauser.known["low"] {
+		t.Fatalf("paused = %v after %d pauses, want critical & low paused once", pauser.known, pauser.pauses)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := resumeQueue(pauser, "critical"); err != nil {
+			t.Fatalf("resumeQueue: %v", err)
+		}
+	}
+	if err := resumeQueue(pauser, "default"); err != nil {
+		t.Fatalf("resumeQueue of unused queue: %v", err)
+	}
+	if pauser.known["critical"] {
+		t.Error("critical still paused after resume")
+	}
+}
+
+func TestPauseQueueReturnsInspectorErrors(t *testing.T) {
+	pauser := &fakePauser{known: map[string]bool{}, infoErr: errors.New("connection refused")}
+
+	if err := pauseQueue(pauser, "critical"); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("pauseQueue error = %v, want connection refused", err)
+	}
+}
+
+func TestValidatePauseQueue(t *testing.T) {
+	for _, queue := range []string{"critical", "default", "low"} {
+		if err := validatePauseQueue(queue); err != nil {
+			t.Errorf("validatePauseQueue(%s): %v", queue, err)
+		}
+	}
+	for _, queue := range []string{"missing", "dead", ""} {
+		err := validatePauseQueue(queue)
+		if err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Errorf("validatePauseQueue(%q) = %v, want does not exist error", queue, err)
+		}
+	}
+}
+
+func TestUpdatePausedQueues(t *testing.T) {
+	client := newFakeControlClient()
+
+	paused, err := loadPausedQueues(client)
+	if err != nil || len(paused) != 0 {
+		t.Fatalf("loadPausedQueues on empty Redis = %v, %v", paused, err)
+	}
+
+	for _, queue := range []string{"low", "critical", "low"} {
+		if err := updatePausedQueues(client, queue, true); err != nil {
+			t.Fatalf("updatePausedQueues(%s, true): %v", queue, err)
+		}
+	}
+	paused, _ = loadPausedQueues(client)
+	if want := []string{"critical", "low"}; !reflect.DeepEqual(paused, want) {
+		t.Errorf("paused = %v, want %v", paused, want)
+	}
+
+	for _, queue := range []string{"critical", "low", "default"} {
+		if err := updatePausedQueues(client, queue, false); err != nil {
+			t.Fatalf("updatePausedQueues(%s, false): %v", queue, err)
+		}
+	}
+	if _, ok := client.data[pausedQueuesKey]; ok {
+		t.Error("paused queues key kept after resuming every queue")
+	}
+}
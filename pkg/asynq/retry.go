@@ -0,0 +1,66 @@
+package asynq
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/internal/jobs"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/hibiken/asynq"
+)
+
+// maxRetryDelay caps exponential backoff between retries
+const maxRetryDelay = 1 * time.Hour
+
+var (
+	retryOnce    sync.Once
+	retryConfigs map[string]jobs.RetryConfig
+)
+
+// GetRetryConfig returns retry configuration registered for task type
+func GetRetryConfig(taskType string) (jobs.RetryConfig, bool) {
+	retryOnce.Do(func() {
+		retryConfigs = make(map[string]jobs.RetryConfig)
+
+		registeredJobs, err := jobs.GetRegisteredJobs()
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to load job registry for retry config")
+			return
+		}
+
+		for _, job := range registeredJobs {
+			if job.Retry != nil {
+				retryConfigs[job.TaskType] = *job.Retry
+			}
+		}
+	})
+
+	retryConfig, exists := retryConfigs[taskType]
+	return retryConfig, exists
+}
+
+// retryOptions builds enqueue options from task type retry configuration
+func retryOptions(taskType string) []asynq.Option {
+	retryConfig, exists := GetRetryConfig(taskType)
+	if !exists {
+		return nil
+	}
+	return []asynq.Option{asynq.MaxRetry(retryConfig.MaxRetries)}
+}
+
+// RetryDelay computes delay before next retry: task type backoff doubled per retry, asynq default otherwise
+func RetryDelay(n int, err error, task *asynq.Task) time.Duration {
+	retryConfig, exists := GetRetryConfig(task.Type())
+	if !exists || retryConfig.Backoff <= 0 {
+		return asynq.DefaultRetryDelayFunc(n, err, task)
+	}
+
+	delay := retryConfig.Backoff
+	for i := 0; i < n && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
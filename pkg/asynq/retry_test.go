@@ -0,0 +1,73 @@
+package asynq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ua "github.com/benedict-erwin/insight-collector/internal/jobs/user_activities"
+	"github.com/hibiken/asynq"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		taskType string
+		retried  int
+		want     time.Duration
+	}{
+		{"logging first retry", ua.TypeUserActivitiesLogging, 0, 5 * time.Second},
+		{"logging doubled", ua.TypeUserActivitiesLogging, 1, 10 * time.Second},
+		{"logging fourth retry", ua.TypeUserActivitiesLogging, 3, 40 * time.Second},
+		{"background first retry", "example:processing", 0, 30 * time.Second},
+		{"background doubled twice", "example:processing", 2, 2 * time.Minute},
+		{"capped at max delay", "example:processing", 20, maxRetryDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RetryDelay(tt.retried, errors.New("write failed"), asynq.NewTask(tt.taskType, nil))
+			if got != tt.want {
+				t.Errorf("RetryDelay(%d) = %s, want %s", tt.retried, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayUnregisteredUsesAsynqDefault(t *testing.T) {
+	// asynq default: n^4 + 15s + random jitter up to 30s*(n+1)
+	got := RetryDelay(0, errors.New("failed"), asynq.NewTask("unknown:type", nil))
+	if got < 15*time.Second || got > 45*time.Second {
+		t.Errorf("expected asynq default delay between 15s and 45s, got %s", got)
+	}
+}
+
+func TestRetryOptions(t *testing.T) {
+	tests := []struct {
+		taskType     string
+		wantMaxRetry int // -1 = no options
+	}{
+		{ua.TypeUserActivitiesLogging, 5},
+		{"example:processing", 3},
+		{"unknown:type", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.taskType, func(t *testing.T) {
+			opts := retryOptions(tt.taskType)
+			if tt.wantMaxRetry < 0 {
+				if len(opts) != 0 {
+					t.Errorf("expected no options, got %v", opts)
+				}
+				return
+			}
+
+			if len(opts) != 1 || opts[0].Type() != asynq.MaxRetryOpt {
+				t.Fatalf("expected single MaxRetry option, got %v", opts)
+			}
+			if got := opts[0].Value().(int); got != tt.wantMaxRetry {
+				t.Errorf("MaxRetry = %d, want %d", got, tt.wantMaxRetry)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/internal/jobs"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+)
+
+// scheduleConfigKey is Redis key holding persisted cron schedules
+const scheduleConfigKey = "asynq:scheduler:config"
+
+// ScheduleEntry represents recurring task persisted in Redis
+type ScheduleEntry struct {
+	Cronspec string          `json:"cronspec"`
+	TaskType string          `json:"task_type"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+}
+
+// ScheduleInfo represents schedule entry with its next run time
+type ScheduleInfo struct {
+	ScheduleEntry
+	Queue   string    `json:"queue"`
+	NextRun time.Time `json:"next_run"`
+}
+
+// Scheduler wraps asynq.Scheduler with cron schedules persisted in Redis
+type Scheduler struct {
+	mu        sync.Mutex
+	scheduler *asynq.Scheduler
+	entries   []ScheduleEntry
+	entryIDs  map[string]string // schedule key => asynq entry ID
+}
+
+// NewScheduler creates scheduler and loads persisted schedules from Redis
+func NewScheduler() (*Scheduler, error) {
+	cfg := config.Get()
+
+	entries, err := loadSchedulesFromRedis()
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Asynq.DB, // Use Asynq-specific DB from config
+		},
+		&asynq.SchedulerOpts{
+			Location: scheduleLocation(),
+			PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+				if err != nil {
+					logger.Error().Err(err).Msg("Failed to enqueue scheduled task")
+					return
+				}
+				logger.Info().
+					Str("task_id", info.ID).
+					Str("task_type", info.Type).
+					Str("queue", info.Queue).
+					Msg("Scheduled task enqueued")
+			},
+		},
+	)
+
+	return &Scheduler{
+		scheduler: scheduler,
+		entries:   entries,
+		entryIDs:  make(map[string]string),
+	}, nil
+}
+
+// RegisterCron validates, registers and persists recurring task (existing cronspec + task type is replaced)
+func (s *Scheduler) RegisterCron(cronspec, taskType string, payload interface{}) error {
+	entry, err := newScheduleEntry(cronspec, taskType, payload)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Replace running registration of same schedule
+	key := entry.key()
+	if entryID, exists := s.entryIDs[key]; exists {
+		if err := s.scheduler.Unregister(entryID); err != nil {
+			return fmt.Errorf("failed to unregister previous schedule: %w", err)
+		}
+		delete(s.entryIDs, key)
+	}
+	if err := s.register(entry); err != nil {
+		return err
+	}
+
+	s.entries = upsertScheduleEntry(s.entries, entry)
+	return saveSchedulesToRedis(s.entries)
+}
+
+// Start registers persisted schedules and starts scheduler (non-blocking)
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Register persisted schedules (skip entries already registered via RegisterCron)
+	for _, entry := range s.entries {
+		if _, registered := s.entryIDs[entry.key()]; registered {
+			continue
+		}
+		if err := s.register(entry); err != nil {
+			return err
+		}
+	}
+
+	if err := s.scheduler.Start(); err != nil {
+		return fmt.Errorf("failed to start scheduler: %w", err)
+	}
+
+	logger.Info().Int("schedules", len(s.entries)).Msg("Asynq scheduler started")
+	return nil
+}
+
+// Shutdown stops scheduler
+func (s *Scheduler) Shutdown() {
+	s.scheduler.Shutdown()
+	logger.Info().Msg("Asynq scheduler shut down")
+}
+
+// register registers entry with asynq scheduler using task type queue & retry policy
+func (s *Scheduler) register(entry ScheduleEntry) error {
+	task := asynq.NewTask(entry.TaskType, entry.Payload)
+	opts := append([]asynq.Option{asynq.Queue(GetQueueForTaskType(entry.TaskType))}, retryOptions(entry.TaskType)...)
+
+	entryID, err := s.scheduler.Register(entry.Cronspec, task, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to register schedule '%s' for '%s': %w", entry.Cronspec, entry.TaskType, err)
+	}
+	s.entryIDs[entry.key()] = entryID
+	return nil
+}
+
+// AddSchedule validates and persists recurring task without running scheduler (picked up on next scheduler start)
+func AddSchedule(cronspec, taskType string, payload interface{}) error {
+	entry, err := newScheduleEntry(cronspec, taskType, payload)
+	if err != nil {
+		return err
+	}
+
+	entries, err := loadSchedulesFromRedis()
+	if err != nil {
+		return err
+	}
+	return saveSchedulesToRedis(upsertScheduleEntry(entries, entry))
+}
+
+// RemoveSchedule deletes persisted schedule matching cronspec and task type
+func RemoveSchedule(cronspec, taskType string) error {
+	entries, err := loadSchedulesFromRedis()
+	if err != nil {
+		return err
+	}
+
+	key := ScheduleEntry{Cronspec: cronspec, TaskType: taskType}.key()
+	remaining := make([]ScheduleEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.key() != key {
+			remaining = append(remaining, entry)
+		}
+	}
+	if len(remaining) == len(entries) {
+		return fmt.Errorf("schedule '%s' for '%s' not found", cronspec, taskType)
+	}
+
+	return saveSchedulesToRedis(remaining)
+}
+
+// ListSchedules returns persisted schedules with their next run time
+func ListSchedules() ([]ScheduleInfo, error) {
+	entries, err := loadSchedulesFromRedis()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(scheduleLocation())
+	schedules := make([]ScheduleInfo, 0, len(entries))
+	for _, entry := range entries {
+		info := ScheduleInfo{
+			ScheduleEntry: entry,
+			Queue:         GetQueueForTaskType(entry.TaskType),
+		}
+		if schedule, err := cron.ParseStandard(entry.Cronspec); err == nil {
+			info.NextRun = schedule.Next(now)
+		}
+		schedules = append(schedules, info)
+	}
+
+	return schedules, nil
+}
+
+// ValidateCronspec checks cron expression (standard 5 fields or descriptors like @daily, @every 1h)
+func ValidateCronspec(cronspec string) error {
+	if _, err := cron.ParseStandard(cronspec); err != nil {
+		return fmt.Errorf("invalid cron expression '%s': %w", cronspec, err)
+	}
+	return nil
+}
+
+// newScheduleEntry validates cronspec & task type and encodes payload
+func newScheduleEntry(cronspec, taskType string, payload interface{}) (ScheduleEntry, error) {
+	if err := ValidateCronspec(cronspec); err != nil {
+		return ScheduleEntry{}, err
+	}
+
+	// Task type must have registered handler
+	registeredJobs, err := jobs.GetRegisteredJobs()
+	if err != nil {
+		return ScheduleEntry{}, fmt.Errorf("failed to load job registry: %w", err)
+	}
+	found := false
+	for _, job := range registeredJobs {
+		if job.TaskType == taskType {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ScheduleEntry{}, fmt.Errorf("unknown task type '%s'", taskType)
+	}
+
+	entry := ScheduleEntry{Cronspec: cronspec, TaskType: taskType}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return ScheduleEntry{}, fmt.Errorf("failed to marshal schedule payload: %w", err)
+		}
+		entry.Payload = data
+	}
+
+	return entry, nil
+}
+
+// key identifies schedule by cronspec and task type
+func (e ScheduleEntry) key() string {
+	return e.Cronspec + "|" + e.TaskType
+}
+
+// upsertScheduleEntry replaces entry with same key or appends new one
+func upsertScheduleEntry(entries []ScheduleEntry, entry ScheduleEntry) []ScheduleEntry {
+	for i := range entries {
+		if entries[i].key() == entry.key() {
+			entries[i] = entry
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
+
+// scheduleLocation returns timezone used to evaluate cron expressions
+func scheduleLocation() *time.Location {
+	if location := utils.GetLocation(); location != nil {
+		return location
+	}
+	return time.UTC
+}
+
+// saveSchedulesToRedis persists schedules to Redis
+func saveSchedulesToRedis(entries []ScheduleEntry) error {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to create Redis client for scheduler config: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.SetJSON(ctx, scheduleConfigKey, entries, 0); err != nil {
+		return fmt.Errorf("failed to save scheduler config to Redis: %w", err)
+	}
+
+	logger.Debug().Int("schedules", len(entries)).Msg("Scheduler configuration saved to Redis")
+	return nil
+}
+
+// loadSchedulesFromRedis loads schedules from Redis (empty when not configured yet)
+func loadSchedulesFromRedis() ([]ScheduleEntry, error) {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redis client for scheduler config: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entries := []ScheduleEntry{}
+	if err := client.GetJSON(ctx, scheduleConfigKey, &entries); err != nil {
+		// Check if key doesn't exist
+		exists, existsErr := client.Exists(ctx, scheduleConfigKey)
+		if existsErr == nil && !exists {
+			return []ScheduleEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to load scheduler config from Redis: %w", err)
+	}
+
+	return entries, nil
+}
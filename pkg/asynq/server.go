@@ -3,6 +3,7 @@ package asynq
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/benedict-erwin/insight-collector/config"
@@ -12,12 +13,20 @@ import (
 )
 
 var (
+	serverMu          sync.Mutex // Guards server & serverRedisClient across init, live apply and close
 	server            *asynq.Server
 	serverRedisClient *redis.Client
 )
 
 // InitServer initializes and configures the Asynq server with advanced Redis pool optimization
 func InitServer() *asynq.Server {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+	return initServerLocked()
+}
+
+// initServerLocked creates server and its Redis client (caller must hold serverMu)
+func initServerLocked() *asynq.Server {
 	cfg := config.Get()
 
 	// Setup logger scope
@@ -84,12 +93,25 @@ func InitServer() *asynq.Server {
 			Concurrency:     GetConcurrency(),
 			Queues:          GenerateQueues(),
 			ShutdownTimeout: 30 * time.Second, // Wait 30s for running tasks
+			RetryDelayFunc:  RetryDelay,       // Per task type backoff from job registry
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, task *asynq.Task, err error) {
 				log.Error().
 					Err(err).
 					Str("task_type", task.Type()).
 					Bytes("payload", task.Payload()).
 					Msg("Task processing failed")
+
+				// Retries exhausted and DeadLetterMiddleware could not move task, archived by asynq
+				retried, _ := asynq.GetRetryCount(ctx)
+				maxRetry, _ := asynq.GetMaxRetry(ctx)
+				if retried >= maxRetry {
+					taskID, _ := asynq.GetTaskID(ctx)
+					log.Warn().
+						Str("task_id", taskID).
+						Str("task_type", task.Type()).
+						Int("retried", retried).
+						Msg("Task exhausted retries, archived (see `worker tasks list --state archived`)")
+				}
 			}),
 		},
 	)
@@ -128,11 +150,61 @@ func InitServer() *asynq.Server {
 
 // GetServer returns the current Asynq server instance
 func GetServer() *asynq.Server {
+	serverMu.Lock()
+	defer serverMu.Unlock()
 	return server
 }
 
+// ApplyConcurrency gracefully restarts current server with updated concurrency and regenerated queues.
+// Running tasks get ShutdownTimeout to finish, unfinished tasks are re-queued and picked up by new server.
+// Blocks up to ShutdownTimeout, callers with heartbeat duties should run it in goroutine.
+func ApplyConcurrency(mux *asynq.ServeMux) (*asynq.Server, error) {
+	// Setup logger scope
+	log := logger.WithScope("ApplyConcurrency")
+
+	// Held for whole restart so CloseServer never sees half replaced server
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	mu.RLock()
+	oldServer := currentServer
+	mu.RUnlock()
+
+	if oldServer == nil {
+		return nil, fmt.Errorf("no running server in current process")
+	}
+
+	// Stop old server, waits for running tasks (max 30s)
+	log.Info().Msg("Stopping current server to apply new concurrency...")
+	oldServer.Shutdown()
+
+	// Close old server Redis client, new one is created by InitServer
+	if serverRedisClient != nil {
+		if err := serverRedisClient.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close previous server Redis client")
+		}
+		serverRedisClient = nil
+	}
+
+	// Start new server with updated configuration (InitServer reloads worker config to regenerate queues)
+	newServer := initServerLocked()
+	if err := newServer.Start(mux); err != nil {
+		SetServerRunning(false)
+		return nil, fmt.Errorf("failed to start server with new concurrency: %w", err)
+	}
+
+	log.Info().
+		Int("concurrency", GetConcurrency()).
+		Interface("queues", GenerateQueues()).
+		Msg("Server restarted with new concurrency")
+	return newServer, nil
+}
+
 // CloseServer closes the Asynq server and underlying Redis client connections
 func CloseServer() {
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
 	if server != nil {
 		server.Shutdown()
 		logger.Info().Msg("Asynq server shut down")
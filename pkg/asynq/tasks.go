@@ -0,0 +1,219 @@
+package asynq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/hibiken/asynq"
+)
+
+// taskPayloadSummaryLen truncates payload in task listing
+const taskPayloadSummaryLen = 120
+
+// TaskStates lists task states supported by task inspection
+var TaskStates = []string{"pending", "active", "scheduled", "retry", "archived"}
+
+// TaskSummary represents queued task in task listing
+type TaskSummary struct {
+	ID            string     `json:"id"`
+	Queue         string     `json:"queue"`
+	Type          string     `json:"type"`
+	State         string     `json:"state"`
+	Retried       int        `json:"retried"`
+	MaxRetry      int        `json:"max_retry"`
+	LastErr       string     `json:"last_error,omitempty"`
+	NextProcessAt *time.Time `json:"next_process_at,omitempty"` // Scheduled & retry tasks only
+	Payload       string     `json:"payload"`                   // Truncated payload
+}
+
+// taskInspector is subset of asynq.Inspector used by task lookups (faked in tests)
+type taskInspector interface {
+	ListPendingTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListActiveTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListScheduledTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListRetryTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListArchivedTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	GetTaskInfo(queue, id string) (*asynq.TaskInfo, error)
+}
+
+// ensureAsynqRedis checks Asynq Redis DB is reachable, inspection works without running worker
+func ensureAsynqRedis() error {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Asynq Redis: %w", err)
+	}
+	return client.Close()
+}
+
+// IsWorkerRunning reports whether worker heartbeat is present in Redis
+func IsWorkerRunning() bool {
+	return checkWorkerHeartbeat()
+}
+
+// ListTasks returns tasks in state of queue or all queues, up to limit per queue
+func ListTasks(queue, state string, limit int) ([]TaskSummary, error) {
+	queues, err := inspectQueues(queue)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureAsynqRedis(); err != nil {
+		return nil, err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	summaries := []TaskSummary{}
+	for _, q := range queues {
+		tasks, err := listTasksByState(inspector, q, state, limit)
+		if err != nil {
+			// Queue never used yet
+			if errors.Is(err, asynq.ErrQueueNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s tasks of queue '%s': %w", state, q, err)
+		}
+
+		for _, task := range tasks {
+			summaries = append(summaries, newTaskSummary(task))
+		}
+	}
+
+	return summaries, nil
+}
+
+// listTasksByState calls inspector list method of task state
+func listTasksByState(inspector taskInspector, queue, state string, limit int) ([]*asynq.TaskInfo, error) {
+	switch state {
+	case "pending":
+		return inspector.ListPendingTasks(queue, asynq.PageSize(limit))
+	case "active":
+		return inspector.ListActiveTasks(queue, asynq.PageSize(limit))
+	case "scheduled":
+		return inspector.ListScheduledTasks(queue, asynq.PageSize(limit))
+	case "retry":
+		return inspector.ListRetryTasks(queue, asynq.PageSize(limit))
+	case "archived":
+		return inspector.ListArchivedTasks(queue, asynq.PageSize(limit))
+	default:
+		return nil, fmt.Errorf("invalid task state '%s'. Valid states: %v", state, TaskStates)
+	}
+}
+
+// newTaskSummary maps asynq task info to summary with truncated payload
+func newTaskSummary(task *asynq.TaskInfo) TaskSummary {
+	payload := string(task.Payload)
+	if len(payload) > taskPayloadSummaryLen {
+		payload = payload[:taskPayloadSummaryLen] + "..."
+	}
+
+	summary := TaskSummary{
+		ID:       task.ID,
+		Queue:    task.Queue,
+		Type:     task.Type,
+		State:    task.State.String(),
+		Retried:  task.Retried,
+		MaxRetry: task.MaxRetry,
+		LastErr:  task.LastErr,
+		Payload:  payload,
+	}
+	if !task.NextProcessAt.IsZero() {
+		nextProcessAt := task.NextProcessAt
+		summary.NextProcessAt = &nextProcessAt
+	}
+	return summary
+}
+
+// findTask searches queue(s) for task ID
+func findTask(inspector taskInspector, queues []string, taskID string) (*asynq.TaskInfo, error) {
+	for _, q := range queues {
+		task, err := inspector.GetTaskInfo(q, taskID)
+		if err == nil {
+			return task, nil
+		}
+		if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+			continue
+		}
+		return nil, fmt.Errorf("failed to get task '%s' in queue '%s': %w", taskID, q, err)
+	}
+	return nil, fmt.Errorf("task '%s' not found", taskID)
+}
+
+// CancelTask sends cancel signal to running worker processing active task
+func CancelTask(queue, taskID string) error {
+	queues, err := inspectQueues(queue)
+	if err != nil {
+		return err
+	}
+	if err := ensureAsynqRedis(); err != nil {
+		return err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	task, err := findTask(inspector, queues, taskID)
+	if err != nil {
+		return err
+	}
+	if task.State != asynq.TaskStateActive {
+		return fmt.Errorf("task '%s' is %s, only active tasks can be cancelled", taskID, task.State)
+	}
+
+	// Cancel signal is delivered via pub/sub, only running worker can act on it
+	if !IsWorkerRunning() {
+		return fmt.Errorf("no running worker, task '%s' is orphaned and will be recovered when worker starts", taskID)
+	}
+	if err := inspector.CancelProcessing(taskID); err != nil {
+		return fmt.Errorf("failed to cancel task '%s': %w", taskID, err)
+	}
+	return nil
+}
+
+// ArchiveTask moves pending, scheduled or retry task to archived state
+func ArchiveTask(queue, taskID string) error {
+	queues, err := inspectQueues(queue)
+	if err != nil {
+		return err
+	}
+	if err := ensureAsynqRedis(); err != nil {
+		return err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	task, err := findTask(inspector, queues, taskID)
+	if err != nil {
+		return err
+	}
+	if err := inspector.ArchiveTask(task.Queue, taskID); err != nil {
+		return fmt.Errorf("failed to archive task '%s' (%s): %w", taskID, task.State, err)
+	}
+	return nil
+}
+
+// RetryTask moves scheduled, retry or archived task to pending state (processed immediately)
+func RetryTask(queue, taskID string) error {
+	queues, err := inspectQueues(queue)
+	if err != nil {
+		return err
+	}
+	if err := ensureAsynqRedis(); err != nil {
+		return err
+	}
+
+	inspector := newInspector()
+	defer inspector.Close()
+
+	task, err := findTask(inspector, queues, taskID)
+	if err != nil {
+		return err
+	}
+	if err := inspector.RunTask(task.Queue, taskID); err != nil {
+		return fmt.Errorf("failed to retry task '%s' (%s): %w", taskID, task.State, err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package asynq
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hibiken/asynq"
+)
+
+// fakeInspector serves tasks per queue and state, queues without entry return ErrQueueNotFound
+type fakeInspector struct {
+	tasks  map[string][]*asynq.TaskInfo // Keyed by queue
+	errs   map[string]error             // Forced GetTaskInfo error by queue
+	called string                       // Last list method state
+}
+
+func (f *fakeInspector) list(state, queue string) ([]*asynq.TaskInfo, error) {
+	f.called = state
+	tasks, ok := f.tasks[queue]
+	if !ok {
+		return nil, asynq.ErrQueueNotFound
+	}
+	var out []*asynq.TaskInfo
+	for _, task := range tasks {
+		if task.State.String() == state {
+			out = append(out, task)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeInspector) ListPendingTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	return f.list("pending", queue)
+}
+
+func (f *fakeInspector) ListActiveTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	return f.list("active", queue)
+}
+
+func (f *fakeInspector) ListScheduledTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	return f.list("scheduled", queue)
+}
+
+func (f *fakeInspector) ListRetryTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	return f.list("retry", queue)
+}
+
+func (f *fakeInspector) ListArchivedTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	return f.list("archived", queue)
+}
+
+func (f *fakeInspector) GetTaskInfo(queue, id string) (*asynq.TaskInfo, error) {
+	if err, ok := f.errs[queue]; ok {
+		return nil, err
+	}
+	tasks, ok := f.tasks[queue]
+	if !ok {
+		return nil, asynq.ErrQueueNotFound
+	}
+	for _, task := range tasks {
+		if task.ID == id {
+			return task, nil
+		}
+	}
+	return nil, asynq.ErrTaskNotFound
+}
+
+func newFakeInspector() *fakeInspector {
+	return &fakeInspector{
+		tasks: map[string][]*asynq.TaskInfo{
+			"critical": {
+				{ID: "t1", Queue: "critical", Type: "security_events:logging", State: asynq.TaskStatePending},
+				{ID: "t2", Queue: "critical", Type: "security_events:logging", State: asynq.TaskStateActive},
+			},
+			"low": {
+				{ID: "t3", Queue: "low", Type: "example:processing", State: asynq.TaskStateScheduled},
+			},
+		},
+		errs: map[string]error{},
+	}
+}
+
+func TestListTasksByState(t *testing.T) {
+	tests := []struct {
+		queue string
+		state string
+		want  int
+	}{
+		{"critical", "pending", 1},
+		{"critical", "active", 1},
+		{"critical", "scheduled", 0},
+		{"low", "scheduled", 1},
+		{"low", "retry", 0},
+		{"low", "archived", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.queue+"/"+tt.state, func(t *testing.T) {
+			inspector := newFakeInspector()
+			tasks, err := listTasksByState(inspector, tt.queue, tt.state, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if inspector.called != tt.state {
+				t.Errorf("expected %s list method, got %s", tt.state, inspector.called)
+			}
+			if len(tasks) != tt.want {
+				t.Errorf("expected %d tasks, got %d", tt.want, len(tasks))
+			}
+		})
+	}
+}
+
+func TestListTasksByStateInvalidState(t *testing.T) {
+	inspector := newFakeInspector()
+	_, err := listTasksByState(inspector, "critical", "completed", 10)
+	if err == nil || !strings.Contains(err.Error(), "invalid task state 'completed'") {
+		t.Fatalf("expected invalid task state error, got %v", err)
+	}
+	if inspector.called != "" {
+		t.Errorf("expected no list call for invalid state, got %s", inspector.called)
+	}
+}
+
+func TestFindTask(t *testing.T) {
+	queues := []string{"critical", "default", "low"}
+
+	// "default" is missing (ErrQueueNotFound) and "critical" lacks t3 (ErrTaskNotFound), both skipped
+	task, err := findTask(newFakeInspector(), queues, "t3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Queue != "low" {
+		t.Errorf("expected task in queue low, got %s", task.Queue)
+	}
+
+	if _, err := findTask(newFakeInspector(), queues, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFindTaskReturnsRealErrors(t *testing.T) {
+	redisErr := errors.New("connection refused")
+	inspector := newFakeInspector()
+	inspector.errs["critical"] = redisErr
+
+	_, err := findTask(inspector, []string{"critical", "low"}, "t3")
+	if !errors.Is(err, redisErr) {
+		t.Fatalf("expected wrapped Redis error, got %v", err)
+	}
+}
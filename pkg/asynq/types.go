@@ -1,8 +1,11 @@
 package asynq
 
+import "time"
+
 // Payload
 type Payload struct {
-	TaskId   string      // Asynq TaskID metadata
-	TaskType string      // Asynq TaskType metadata
-	Data     interface{} // The Task Payload (JSON)
+	TaskId     string      // Asynq TaskID metadata
+	TaskType   string      // Asynq TaskType metadata
+	Data       interface{} // The Task Payload (JSON)
+	EnqueuedAt time.Time   // Stamped on payload as enqueued_at for ingest latency (utils.Now() when zero)
 }
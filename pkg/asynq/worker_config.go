@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -22,12 +23,25 @@ type WorkerConfig struct {
 	TaskTypes  []string `json:"task_types"`
 }
 
+// Redis keys for live concurrency apply between CLI and running worker
+const (
+	concurrencyApplyKey   = "asynq:worker:concurrency:apply"
+	concurrencyAppliedKey = "asynq:worker:concurrency:applied"
+)
+
+// Redis keys for worker drain between CLI and running workers
+const (
+	drainRequestKey = "asynq:worker:drain"   // Draining flag, persisted until next worker start
+	drainedKey      = "asynq:worker:drained" // Set by workers that stopped pulling new tasks
+)
+
 var (
 	mu                 sync.RWMutex
 	currentConcurrency int
 	workers            = []WorkerConfig{} // Start with empty workers
 	currentServer      *asynq.Server
 	serverRunning      bool
+	draining           bool // Server in current process stopped pulling new tasks
 )
 
 // InitConcurrency initializes concurrency from config if not set via command
@@ -68,7 +82,7 @@ func GetConcurrency() int {
 	return currentConcurrency
 }
 
-// SetConcurrency updates concurrency setting and persists to config file (requires manual restart)
+// SetConcurrency updates concurrency setting and persists to config file (use RequestConcurrencyApply for running worker)
 func SetConcurrency(concurrency int) error {
 	mu.Lock()
 	currentConcurrency = concurrency
@@ -84,8 +98,214 @@ func SetConcurrency(concurrency int) error {
 	return nil
 }
 
-// SetWorker updates worker configuration and persists to Redis
-func SetWorker(name string, percentage int, taskTypes []string) {
+// RequestConcurrencyApply asks running worker process to apply concurrency without restart (picked up on next check)
+func RequestConcurrencyApply(concurrency int) error {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to create Redis client for concurrency apply: %w", err)
+	}
+	defer client.Close()
+
+	if err := requestConcurrencyApply(client, concurrency); err != nil {
+		return err
+	}
+
+	logger.Info().Int("concurrency", concurrency).Msg("Concurrency apply requested")
+	return nil
+}
+
+// requestConcurrencyApply clears previous acknowledgement and stores apply request
+func requestConcurrencyApply(client redis.Client, concurrency int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Delete(ctx, concurrencyAppliedKey); err != nil {
+		return fmt.Errorf("failed to clear concurrency apply status: %w", err)
+	}
+	if err := client.Set(ctx, concurrencyApplyKey, concurrency, 5*time.Minute); err != nil {
+		return fmt.Errorf("failed to request concurrency apply: %w", err)
+	}
+	return nil
+}
+
+// PopConcurrencyApply returns pending concurrency apply request (if any) and removes it from Redis atomically.
+// Client is owned by caller so worker loop reuses one connection across checks.
+func PopConcurrencyApply(client redis.Client) (int, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// GETDEL so request is consumed once even with several worker processes; missing key means no pending request
+	value, err := client.GetDel(ctx, concurrencyApplyKey)
+	if err != nil || value == "" {
+		return 0, false
+	}
+
+	concurrency, err := strconv.Atoi(value)
+	if err != nil || concurrency < 1 {
+		logger.Warn().Str("value", value).Msg("Ignoring invalid concurrency apply request")
+		return 0, false
+	}
+
+	// Update in-memory concurrency for new server
+	mu.Lock()
+	currentConcurrency = concurrency
+	mu.Unlock()
+
+	return concurrency, true
+}
+
+// AckConcurrencyApply marks concurrency as applied by running worker
+func AckConcurrencyApply(client redis.Client, concurrency int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Set(ctx, concurrencyAppliedKey, concurrency, 5*time.Minute); err != nil {
+		logger.Error().Err(err).Msg("Failed to set concurrency apply status")
+	}
+}
+
+// WaitConcurrencyApplied waits until running worker acknowledges concurrency apply or timeout expires
+func WaitConcurrencyApplied(concurrency int, timeout time.Duration) bool {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to create Redis client for concurrency apply status")
+		return false
+	}
+	defer client.Close()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		value, err := client.Get(ctx, concurrencyAppliedKey)
+		cancel()
+
+		if err == nil && value == strconv.Itoa(concurrency) {
+			return true
+		}
+		time.Sleep(1 * time.Second)
+	}
+	return false
+}
+
+// RequestDrain sets persisted draining flag, running workers stop pulling new tasks on next check
+func RequestDrain() error {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return fmt.Errorf("failed to create Redis client for worker drain: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Clear previous acknowledgement and request drain in one round trip
+	if err := client.Pipeline(ctx, func(pipe redis.Pipe) error {
+		pipe.Delete(ctx, drainedKey)
+		pipe.Set(ctx, drainRequestKey, time.Now().Unix(), 0)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to request worker drain: %w", err)
+	}
+
+	logger.Info().Msg("Worker drain requested")
+	return nil
+}
+
+// ClearDrain removes draining flag (called on worker start so new deployment consumes tasks again)
+func ClearDrain() {
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to create Redis client for worker drain")
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Delete(ctx, drainRequestKey, drainedKey); err != nil {
+		logger.Error().Err(err).Msg("Failed to clear worker drain flag")
+	}
+}
+
+// IsDrainRequested checks if draining flag is set in Redis (client owned by caller)
+func IsDrainRequested(client redis.Client) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	exists, err := client.Exists(ctx, drainRequestKey)
+	return err == nil && exists
+}
+
+// SetDraining marks server in current process as drained and acknowledges drain request
+func SetDraining() {
+	mu.Lock()
+	draining = true
+	mu.Unlock()
+
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to create Redis client for worker drain status")
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Set(ctx, drainedKey, time.Now().Unix(), 0); err != nil {
+		logger.Error().Err(err).Msg("Failed to set worker drain status")
+	}
+}
+
+// IsDraining returns whether server in current process stopped pulling new tasks
+func IsDraining() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return draining
+}
+
+// DrainStatus holds worker drain progress reported to CLI
+type DrainStatus struct {
+	Acknowledged bool // At least one running worker stopped pulling new tasks
+	Active       int  // Tasks still in progress across all queues
+	Pending      int  // Tasks left in queues for next worker start
+}
+
+// GetDrainStatus returns drain acknowledgement and task counts across all queues
+func GetDrainStatus() (DrainStatus, error) {
+	var status DrainStatus
+
+	client, err := redis.NewClientForAsynq()
+	if err != nil {
+		return status, fmt.Errorf("failed to create Redis client for worker drain status: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	acknowledged, err := client.Exists(ctx, drainedKey)
+	if err != nil {
+		return status, fmt.Errorf("failed to get worker drain status: %w", err)
+	}
+	status.Acknowledged = acknowledged
+
+	metrics, err := GetQueueMetrics()
+	if err != nil {
+		return status, err
+	}
+	for _, metric := range metrics {
+		status.Active += metric.Active
+		status.Pending += metric.Pending
+	}
+
+	return status, nil
+}
+
+// SetWorker updates worker configuration and persists to Redis.
+// When normalize is true remaining workers are rescaled so total percentage stays 100.
+func SetWorker(name string, percentage int, taskTypes []string, normalize bool) {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -108,6 +328,11 @@ func SetWorker(name string, percentage int, taskTypes []string) {
 		})
 	}
 
+	// Keep total at 100% by rescaling other workers
+	if normalize {
+		workers = normalizeRemaining(workers, name)
+	}
+
 	// Persist to Redis
 	if err := saveWorkersToRedis(); err != nil {
 		logger.Error().Err(err).Msg("Failed to save worker config to Redis")
@@ -140,21 +365,46 @@ func GetWorker(name string) (WorkerConfig, bool) {
 	return WorkerConfig{}, false
 }
 
-// GenerateQueues creates queue config from worker percentages
+// GenerateQueues creates queue config from worker percentages (weights proportional to percentages)
 func GenerateQueues() map[string]int {
 	mu.RLock()
 	defer mu.RUnlock()
 
-	queues := make(map[string]int)
-	for _, worker := range workers {
-		queues[worker.Name] = worker.Percentage / 10
-		if queues[worker.Name] == 0 {
-			queues[worker.Name] = 1 // minimum 1
+	return queueWeights(workers)
+}
+
+// queueWeights converts percentages into asynq queue weights reduced by their GCD (60/30/10 -> 6/3/1,
+// 55/30/15 -> 11/6/3), so ratios stay exact without collapsing close percentages. Queues at 0% get minimum
+// weight 1; reduction is skipped when such queue exists so it stays small next to others (50/50/0 -> 50/50/1).
+func queueWeights(configs []WorkerConfig) map[string]int {
+	divisor := 0
+	for _, worker := range configs {
+		if worker.Percentage <= 0 {
+			divisor = 1
+			break
+		}
+		divisor = gcd(divisor, worker.Percentage)
+	}
+
+	queues := make(map[string]int, len(configs))
+	for _, worker := range configs {
+		weight := 1 // minimum 1
+		if worker.Percentage > 0 {
+			weight = worker.Percentage / divisor
 		}
+		queues[worker.Name] = weight
 	}
 	return queues
 }
 
+// gcd returns greatest common divisor of a and b (gcd(0, b) = b)
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // GetQueueForTaskType returns appropriate queue for task type
 func GetQueueForTaskType(taskType string) string {
 	mu.RLock()
@@ -194,8 +444,9 @@ func ValidateWorkerConfig() error {
 		}
 	}
 
-	if totalPercentage != 100 {
+	if len(workers) > 0 && totalPercentage != 100 {
 		logger.Warn().Int("total_percentage", totalPercentage).Msg("Worker percentages do not sum to 100%")
+		return fmt.Errorf("worker percentages sum to %d%%, expected 100%%", totalPercentage)
 	}
 
 	return nil
@@ -474,6 +725,43 @@ func normalizePercentages(workers []WorkerConfig) []WorkerConfig {
 	return workers
 }
 
+// normalizeRemaining keeps percentage of fixed worker and rescales other workers to fill remaining percentage
+func normalizeRemaining(workers []WorkerConfig, fixed string) []WorkerConfig {
+	var fixedPercentage int
+	others := []int{}
+	for i := range workers {
+		if workers[i].Name == fixed {
+			fixedPercentage = workers[i].Percentage
+		} else {
+			others = append(others, i)
+		}
+	}
+
+	// Single worker must take everything
+	if len(others) == 0 {
+		return normalizePercentages(workers)
+	}
+
+	// Normalize other workers to 100% first, then scale to remaining percentage
+	rest := make([]WorkerConfig, len(others))
+	for i, idx := range others {
+		rest[i] = workers[idx]
+	}
+	rest = normalizePercentages(rest)
+
+	remaining := 100 - fixedPercentage
+	assigned := 0
+	for i, idx := range others {
+		workers[idx].Percentage = rest[i].Percentage * remaining / 100
+		assigned += workers[idx].Percentage
+	}
+
+	// Handle rounding errors - add difference to first other worker
+	workers[others[0]].Percentage += remaining - assigned
+
+	return workers
+}
+
 // updateConfigFileConcurrency updates the concurrency value in the config file
 func updateConfigFileConcurrency(concurrency int) error {
 	const configFile = ".config.json"
@@ -0,0 +1,173 @@
+package asynq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// fakeControlClient keeps string keys in memory, methods not used by control keys panic via nil embedded Client
+type fakeControlClient struct {
+	redis.Client
+	data    map[string]string
+	getDels int
+}
+
+func newFakeControlClient() *fakeControlClient {
+	return &fakeControlClient{data: map[string]string{}}
+}
+
+func (f *fakeControlClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	f.data[key] = fmt.Sprint(value)
+	return nil
+}
+
+func (f *fakeControlClient) Get(ctx context.Context, key string) (string, error) {
+	value, ok := f.data[key]
+	if !ok {
+		return "", goredis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeControlClient) GetDel(ctx context.Context, key string) (string, error) {
+	f.getDels++
+	value, err := f.Get(ctx, key)
+	delete(f.data, key)
+	return value, err
+}
+
+func (f *fakeControlClient) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		delete(f.data, key)
+	}
+	return nil
+}
+
+func (f *fakeControlClient) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	f.data[key] = string(data)
+	return nil
+}
+
+func (f *fakeControlClient) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	value, err := f.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(value), dest)
+}
+
+func (f *fakeControlClient) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := f.data[key]
+	return ok, nil
+}
+
+func TestConcurrencyApplyRequestPopAck(t *testing.T) {
+	client := newFakeControlClient()
+	client.data[concurrencyAppliedKey] = "8" // Stale acknowledgement of previous apply
+
+	if err := requestConcurrencyApply(client, 20); err != nil {
+		t.Fatalf("unexpected request error: %v", err)
+	}
+	if _, ok := client.data[concurrencyAppliedKey]; ok {
+		t.Error("request should clear previous acknowledgement")
+	}
+	if client.data[concurrencyApplyKey] != "20" {
+		t.Errorf("apply key = %q, want 20", client.data[concurrencyApplyKey])
+	}
+
+	// Pop consumes request once with single GETDEL
+	concurrency, ok := PopConcurrencyApply(client)
+	if !ok || concurrency != 20 {
+		t.Fatalf("PopConcurrencyApply = (%d, %v), want (20, true)", concurrency, ok)
+	}
+	if client.getDels != 1 {
+		t.Errorf("expected 1 GETDEL, got %d", client.getDels)
+	}
+	if GetConcurrency() != 20 {
+		t.Errorf("in-memory concurrency = %d, want 20", GetConcurrency())
+	}
+	if _, ok := PopConcurrencyApply(client); ok {
+		t.Error("request must not be popped twice")
+	}
+
+	AckConcurrencyApply(client, concurrency)
+	if client.data[concurrencyAppliedKey] != "20" {
+		t.Errorf("applied key = %q, want 20", client.data[concurrencyAppliedKey])
+	}
+}
+
+func TestPopConcurrencyApplyInvalid(t *testing.T) {
+	for _, value := range []string{"abc", "0", "-5"} {
+		client := newFakeControlClient()
+		client.data[concurrencyApplyKey] = value
+
+		if _, ok := PopConcurrencyApply(client); ok {
+			t.Errorf("value %q: expected invalid request to be ignored", value)
+		}
+		if _, exists := client.data[concurrencyApplyKey]; exists {
+			t.Errorf("value %q: invalid request should still be consumed", value)
+		}
+	}
+}
+
+func TestIsDrainRequested(t *testing.T) {
+	client := newFakeControlClient()
+	if IsDrainRequested(client) {
+		t.Error("expected no drain request")
+	}
+	client.data[drainRequestKey] = "1"
+	if !IsDrainRequested(client) {
+		t.Error("expected drain request")
+	}
+}
+
+func TestQueueWeightsProportional(t *testing.T) {
+	tests := []struct {
+		name        string
+		percentages []int
+		expected    []int
+	}{
+		{"60/30/10", []int{60, 30, 10}, []int{6, 3, 1}},
+		{"55/30/15", []int{55, 30, 15}, []int{11, 6, 3}},
+		{"10/19/71 keeps resolution", []int{10, 19, 71}, []int{10, 19, 71}},
+		{"single queue", []int{100}, []int{1}},
+		{"zero percentage keeps minimum", []int{50, 50, 0}, []int{50, 50, 1}},
+	}
+
+	for _, tt := range tests {
+		configs := make([]WorkerConfig, len(tt.percentages))
+		for i, percentage := range tt.percentages {
+			configs[i] = WorkerConfig{Name: fmt.Sprintf("queue_%d", i), Percentage: percentage}
+		}
+
+		queues := queueWeights(configs)
+		for i, want := range tt.expected {
+			if got := queues[configs[i].Name]; got != want {
+				t.Errorf("%s: %s weight = %d, want %d", tt.name, configs[i].Name, got, want)
+			}
+		}
+
+		// Weights are non-zero and keep percentage ratios of non-zero queues
+		for i := range configs {
+			for j := range configs {
+				a, b := configs[i].Percentage, configs[j].Percentage
+				if queues[configs[i].Name] <= 0 {
+					t.Errorf("%s: %s has non-positive weight", tt.name, configs[i].Name)
+				}
+				if a > 0 && b > 0 && queues[configs[i].Name]*b != queues[configs[j].Name]*a {
+					t.Errorf("%s: weights %v not proportional to %v", tt.name, queues, tt.percentages)
+				}
+			}
+		}
+	}
+}
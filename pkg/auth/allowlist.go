@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// clientAllowedNets holds parsed IP allowlist per client (missing or empty = allow all)
+var clientAllowedNets = make(map[string][]*net.IPNet)
+
+// ParseAllowedIPs parses IP/CIDR allowlist, single IPs are converted to /32 (IPv4) or /128 (IPv6)
+func ParseAllowedIPs(allowedIPs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(allowedIPs))
+	for _, entry := range allowedIPs {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// Single IP address
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address '%s'", entry)
+			}
+			bits := 128
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		// CIDR range
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR '%s': %v", entry, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// IsIPAllowed checks if source IP is in client allowlist (empty allowlist allows all)
+func IsIPAllowed(clientID string, ip net.IP) bool {
+	authMutex.RLock()
+	defer authMutex.RUnlock()
+
+	nets := clientAllowedNets[clientID]
+	if len(nets) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// setClientAllowedNets parses and caches client allowlist (caller must hold authMutex)
+func setClientAllowedNets(clientID string, allowedIPs []string) error {
+	nets, err := ParseAllowedIPs(allowedIPs)
+	if err != nil {
+		return fmt.Errorf("invalid allowed_ips for client %s: %w", clientID, err)
+	}
+
+	if len(nets) == 0 {
+		delete(clientAllowedNets, clientID)
+		return nil
+	}
+	clientAllowedNets[clientID] = nets
+	return nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// apiKeyBytes is random length of generated API keys (256 bits)
+const apiKeyBytes = 32
+
+// GenerateAPIKey generates random API key and returns plaintext key with its SHA256 hash (only hash is stored)
+func GenerateAPIKey() (string, string, error) {
+	bytes := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate API key: %v", err)
+	}
+
+	key := hex.EncodeToString(bytes)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns SHA256 hex digest of API key
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAPIKey verifies API key against stored hashes and returns client config, failures are reported to audit hook
+func VerifyAPIKey(key string, source RequestSource) (*config.ClientConfig, error) {
+	clientConfig, reason, err := verifyAPIKey(key)
+	if err != nil {
+		failure := Failure{
+			AuthMethod: AuthMethodAPIKey,
+			Reason:     reason,
+			Error:      err.Error(),
+			Source:     source,
+		}
+		// Client known only when key matched (inactive client)
+		if clientConfig != nil {
+			failure.ClientID = clientConfig.ClientID
+		}
+		auditFailure(failure)
+		return nil, err
+	}
+	return clientConfig, nil
+}
+
+// verifyAPIKey verifies API key, returns matched client config (also on inactive client) and failure reason on error
+func verifyAPIKey(key string) (*config.ClientConfig, string, error) {
+	if key == "" {
+		return nil, FailureInvalidAPIKey, fmt.Errorf("empty API key")
+	}
+
+	hashed := []byte(HashAPIKey(key))
+
+	authMutex.RLock()
+	// Compare against every client (constant-time, no early exit)
+	matchedID := ""
+	for clientID, storedHash := range clientAPIKeys {
+		if subtle.ConstantTimeCompare(hashed, []byte(storedHash)) == 1 {
+			matchedID = clientID
+		}
+	}
+	clientConfig, exists := clientConfigs[matchedID]
+	authMutex.RUnlock()
+
+	if matchedID == "" || !exists {
+		logger.Warn().Msg("Unknown API key in API key verification")
+		return nil, FailureInvalidAPIKey, fmt.Errorf("invalid API key")
+	}
+
+	if !clientConfig.Active {
+		logger.Warn().
+			Str("client_id", clientConfig.ClientID).
+			Str("client_name", clientConfig.ClientName).
+			Msg("Inactive client attempted API key verification")
+		return &clientConfig, FailureInactiveClient, fmt.Errorf("client %s (%s) is inactive", clientConfig.ClientID, clientConfig.ClientName)
+	}
+
+	logger.Info().
+		Str("client_id", clientConfig.ClientID).
+		Str("client_name", clientConfig.ClientName).
+		Msg("API key verification successful")
+
+	return &clientConfig, "", nil
+}
+
+// isValidAPIKeyHash checks stored hash is SHA256 hex digest
+func isValidAPIKeyHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
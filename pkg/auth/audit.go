@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// Authentication methods reported in audit
+const (
+	AuthMethodSignature = "signature"
+	AuthMethodJWT       = "jwt"
+	AuthMethodAPIKey    = "api_key"
+)
+
+// Audit worker pool sizing: failures beyond queue capacity are dropped (and counted) instead of piling up goroutines
+const (
+	auditWorkers   = 4
+	auditQueueSize = 1024
+)
+
+// Auth failure reasons reported in audit
+const (
+	FailureUnknownClient    = "unknown_client"
+	FailureInactiveClient   = "inactive_client"
+	FailureInvalidTimestamp = "invalid_timestamp"
+	FailureExpiredTimestamp = "expired_timestamp"
+	FailureReplayedNonce    = "replayed_nonce"
+	FailureInvalidSignature = "invalid_signature"
+	FailureInvalidAuthType  = "invalid_auth_type"
+	FailureMalformedToken   = "malformed_token"
+	FailureExpiredToken     = "expired_token"
+	FailureInvalidToken     = "invalid_token"
+	FailureInvalidAPIKey    = "invalid_api_key"
+)
+
+// failureSeverity maps failure reason to security event severity (forged credentials rank highest)
+var failureSeverity = map[string]string{
+	FailureReplayedNonce:    "high",
+	FailureInvalidSignature: "high",
+	FailureInvalidToken:     "high",
+	FailureInvalidAPIKey:    "high",
+	FailureUnknownClient:    "medium",
+	FailureInactiveClient:   "medium",
+	FailureInvalidAuthType:  "medium",
+	FailureMalformedToken:   "low",
+	FailureInvalidTimestamp: "low",
+	FailureExpiredTimestamp: "low",
+	FailureExpiredToken:     "low",
+}
+
+// RequestSource describes HTTP request being authenticated
+type RequestSource struct {
+	IP        string
+	UserAgent string
+	Method    string
+	Endpoint  string
+	RequestID string
+}
+
+// Failure describes failed authentication attempt
+type Failure struct {
+	ClientID   string // Claimed client_id, empty when token could not be parsed
+	AuthMethod string
+	Reason     string
+	Error      string
+	Source     RequestSource
+}
+
+// Severity returns security event severity of failure reason
+func (f Failure) Severity() string {
+	if severity, exists := failureSeverity[f.Reason]; exists {
+		return severity
+	}
+	return "medium"
+}
+
+var (
+	failureAuditor   func(Failure)
+	failureAuditorMu sync.RWMutex
+
+	auditPoolOnce sync.Once
+	failurePool   *auditPool
+)
+
+// auditEnabled reports whether auth.audit_failures is on (replaced in tests)
+var auditEnabled = func() bool {
+	cfg := config.Get()
+	return cfg != nil && cfg.Auth.AuditFailures
+}
+
+// SetFailureAuditor registers audit hook receiving auth failures (nil disables)
+func SetFailureAuditor(auditor func(Failure)) {
+	failureAuditorMu.Lock()
+	defer failureAuditorMu.Unlock()
+	failureAuditor = auditor
+}
+
+// DroppedAuditFailures returns number of auth failures not audited because audit queue was full
+func DroppedAuditFailures() int64 {
+	return getFailurePool().Dropped()
+}
+
+// getFailurePool lazily starts audit worker pool
+func getFailurePool() *auditPool {
+	auditPoolOnce.Do(func() {
+		failurePool = newAuditPool(auditWorkers, auditQueueSize, runFailureAuditor)
+	})
+	return failurePool
+}
+
+// auditFailure passes failure to audit hook without blocking authentication (auth.audit_failures).
+// Hook runs on fixed worker pool, failures are dropped when queue is full (e.g. credential stuffing burst).
+func auditFailure(failure Failure) {
+	if !auditEnabled() {
+		return
+	}
+
+	failureAuditorMu.RLock()
+	auditor := failureAuditor
+	failureAuditorMu.RUnlock()
+	if auditor == nil {
+		return
+	}
+
+	pool := getFailurePool()
+	if !pool.Submit(failure) {
+		// Log first drop and every 100th after, so a flood doesn't flood logs too
+		if dropped := pool.Dropped(); dropped == 1 || dropped%100 == 0 {
+			logger.WithScope("AuthAudit").Warn().
+				Int64("dropped", dropped).
+				Int("queue_size", auditQueueSize).
+				Msg("Auth failure audit queue full, dropping events")
+		}
+	}
+}
+
+// runFailureAuditor calls currently registered audit hook, panics are logged
+func runFailureAuditor(failure Failure) {
+	failureAuditorMu.RLock()
+	auditor := failureAuditor
+	failureAuditorMu.RUnlock()
+	if auditor == nil {
+		return
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.WithScope("AuthAudit").Error().
+				Str("client_id", failure.ClientID).
+				Str("reason", failure.Reason).
+				Str("panic", fmt.Sprint(rec)).
+				Msg("Auth failure audit failed")
+		}
+	}()
+	auditor(failure)
+}
+
+// auditPool is bounded queue drained by fixed number of workers
+type auditPool struct {
+	queue   chan Failure
+	dropped atomic.Int64
+}
+
+// newAuditPool starts workers handling queued failures (workers live for process lifetime)
+func newAuditPool(workers, size int, handle func(Failure)) *auditPool {
+	p := &auditPool{queue: make(chan Failure, size)}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for failure := range p.queue {
+				handle(failure)
+			}
+		}()
+	}
+	return p
+}
+
+// Submit queues failure without blocking, returns false (and counts drop) when queue is full
+func (p *auditPool) Submit(failure Failure) bool {
+	select {
+	case p.queue <- failure:
+		return true
+	default:
+		p.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped returns number of failures dropped because queue was full
+func (p *auditPool) Dropped() int64 {
+	return p.dropped.Load()
+}
+
+// tokenFailureReason classifies JWT verification error
+func tokenFailureReason(err error) string {
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return FailureExpiredToken
+	}
+	return FailureInvalidToken
+}
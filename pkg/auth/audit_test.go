@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestFailureSeverity(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected string
+	}{
+		{FailureReplayedNonce, "high"},
+		{FailureInvalidSignature, "high"},
+		{FailureInvalidToken, "high"},
+		{FailureUnknownClient, "medium"},
+		{FailureInactiveClient, "medium"},
+		{FailureExpiredTimestamp, "low"},
+		{FailureExpiredToken, "low"},
+		{FailureMalformedToken, "low"},
+		{"something_new", "medium"},
+	}
+
+	for _, tt := range tests {
+		if got := (Failure{Reason: tt.reason}).Severity(); got != tt.expected {
+			t.Errorf("Severity(%s) = %s, want %s", tt.reason, got, tt.expected)
+		}
+	}
+}
+
+func TestTokenFailureReason(t *testing.T) {
+	expired := fmt.Errorf("%w: %w", jwt.ErrTokenInvalidClaims, jwt.ErrTokenExpired)
+	if got := tokenFailureReason(expired); got != FailureExpiredToken {
+		t.Errorf("expected %s, got %s", FailureExpiredToken, got)
+	}
+	if got := tokenFailureReason(jwt.ErrTokenSignatureInvalid); got != FailureInvalidToken {
+		t.Errorf("expected %s, got %s", FailureInvalidToken, got)
+	}
+}
+
+func TestAuditFailureDisabledWithoutConfig(t *testing.T) {
+	called := make(chan Failure, 1)
+	SetFailureAuditor(func(f Failure) { called <- f })
+	t.Cleanup(func() { SetFailureAuditor(nil) })
+
+	// auth.audit_failures is off when config is not loaded
+	auditFailure(Failure{ClientID: "client-1", Reason: FailureUnknownClient})
+
+	select {
+	case f := <-called:
+		t.Errorf("audit hook must not run when disabled, got %+v", f)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAuditPoolDropsWhenQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	handled := make(chan Failure, 10)
+	pool := newAuditPool(1, 2, func(f Failure) {
+		<-release
+		handled <- f
+	})
+
+	// First failure occupies the single worker, next two fill the queue
+	if !pool.Submit(Failure{Reason: "1"}) {
+		t.Fatal("expected first failure accepted")
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(pool.queue) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("worker did not pick up first failure")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for _, reason := range []string{"2", "3"} {
+		if !pool.Submit(Failure{Reason: reason}) {
+			t.Fatalf("expected failure %s queued", reason)
+		}
+	}
+
+	// Queue full: dropped without blocking and counted
+	for i := 0; i < 3; i++ {
+		if pool.Submit(Failure{Reason: "dropped"}) {
+			t.Fatal("expected failure dropped when queue is full")
+		}
+	}
+	if pool.Dropped() != 3 {
+		t.Errorf("expected 3 dropped, got %d", pool.Dropped())
+	}
+
+	close(release)
+	for _, want := range []string{"1", "2", "3"} {
+		select {
+		case f := <-handled:
+			if f.Reason != want {
+				t.Errorf("expected failure %s handled, got %s", want, f.Reason)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("failure %s not handled", want)
+		}
+	}
+}
+
+// enableAudit turns on auth.audit_failures and registers hook for single test
+func enableAudit(t *testing.T) chan Failure {
+	called := make(chan Failure, 10)
+	orig := auditEnabled
+	auditEnabled = func() bool { return true }
+	SetFailureAuditor(func(f Failure) { called <- f })
+	t.Cleanup(func() {
+		auditEnabled = orig
+		SetFailureAuditor(nil)
+	})
+	return called
+}
+
+func TestVerifyAPIKeyAuditsFailures(t *testing.T) {
+	called := enableAudit(t)
+
+	key, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("failed to generate API key: %v", err)
+	}
+	if err := AddClient(config.ClientConfig{
+		ClientID:   "apikey-audit-client",
+		AuthType:   "apikey",
+		APIKeyHash: hash,
+		Active:     false,
+	}); err != nil {
+		t.Fatalf("failed to add client: %v", err)
+	}
+	t.Cleanup(func() { _ = RemoveClient("apikey-audit-client") })
+
+	source := RequestSource{IP: "203.0.113.10", Endpoint: "/v1/export", RequestID: "req-1"}
+	tests := []struct {
+		name     string
+		key      string
+		clientID string
+		reason   string
+	}{
+		{"unknown key", "not-a-valid-key", "", FailureInvalidAPIKey},
+		{"inactive client", key, "apikey-audit-client", FailureInactiveClient},
+	}
+
+	for _, tt := range tests {
+		if _, err := VerifyAPIKey(tt.key, source); err == nil {
+			t.Fatalf("%s: expected verification error", tt.name)
+		}
+
+		select {
+		case f := <-called:
+			if f.AuthMethod != AuthMethodAPIKey || f.Reason != tt.reason || f.ClientID != tt.clientID {
+				t.Errorf("%s: unexpected audited failure %+v", tt.name, f)
+			}
+			if f.Source != source {
+				t.Errorf("%s: expected request source %+v, got %+v", tt.name, source, f.Source)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: expected audit hook call", tt.name)
+		}
+	}
+}
@@ -2,8 +2,10 @@ package auth
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"fmt"
+	"net"
 	"os"
 	"sync"
 	"time"
@@ -16,18 +18,20 @@ import (
 
 var (
 	clientPublicKeys = make(map[string]*rsa.PublicKey)
+	clientEdKeys     = make(map[string]ed25519.PublicKey)
 	clientSecretKeys = make(map[string]string)
+	clientAPIKeys    = make(map[string]string) // client_id -> SHA256 hex of API key
 	clientConfigs    = make(map[string]config.ClientConfig)
 	authMutex        sync.RWMutex
 	
 	// Nonce tracking for replay attack prevention
-	usedNonces    = make(map[string]int64) // nonce -> timestamp
+	usedNonces    = make(map[string]int64) // client_id:nonce -> timestamp (fallback when Redis unavailable)
 	nonceMutex    sync.RWMutex
 	cleanupCtx    context.Context
 	cleanupCancel context.CancelFunc
 )
 
-// InitAuth loads all client keys (RSA public keys or HMAC secrets) into memory
+// InitAuth loads all client keys (RSA/Ed25519 public keys, HMAC secrets or API key hashes) into memory
 func InitAuth() error {
 	authConfig := config.Get().Auth
 	if !authConfig.Enabled {
@@ -48,6 +52,16 @@ func InitAuth() error {
 			continue
 		}
 
+		// Validate per-client signature window
+		if err := ValidateSignatureWindow(clientConfig); err != nil {
+			logger.Error().
+				Str("client_id", clientConfig.ClientID).
+				Str("client_name", clientConfig.ClientName).
+				Int("signature_window_seconds", clientConfig.SignatureWindowSeconds).
+				Msg("Invalid signature window")
+			return err
+		}
+
 		// Load keys based on auth type
 		switch clientConfig.AuthType {
 		case "rsa":
@@ -86,6 +100,30 @@ func InitAuth() error {
 
 			clientPublicKeys[clientConfig.ClientID] = publicKey
 
+		case "ed25519":
+			if clientConfig.KeyPath == "" {
+				logger.Error().
+					Str("client_id", clientConfig.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Msg("Ed25519 auth type requires key_path")
+				return fmt.Errorf("Ed25519 client %s (%s) missing key_path",
+					clientConfig.ClientID, clientConfig.ClientName)
+			}
+
+			publicKey, err := loadEd25519PublicKey(clientConfig.KeyPath)
+			if err != nil {
+				logger.Error().
+					Err(err).
+					Str("client_id", clientConfig.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Str("key_path", clientConfig.KeyPath).
+					Msg("Failed to load Ed25519 public key")
+				return fmt.Errorf("failed to load Ed25519 key for client %s (%s): %v",
+					clientConfig.ClientID, clientConfig.ClientName, err)
+			}
+
+			clientEdKeys[clientConfig.ClientID] = publicKey
+
 		case "hmac":
 			if clientConfig.SecretKey == "" {
 				logger.Error().
@@ -98,6 +136,18 @@ func InitAuth() error {
 
 			clientSecretKeys[clientConfig.ClientID] = clientConfig.SecretKey
 
+		case "apikey":
+			if !isValidAPIKeyHash(clientConfig.APIKeyHash) {
+				logger.Error().
+					Str("client_id", clientConfig.ClientID).
+					Str("client_name", clientConfig.ClientName).
+					Msg("API key auth type requires valid api_key_hash")
+				return fmt.Errorf("API key client %s (%s) missing or invalid api_key_hash",
+					clientConfig.ClientID, clientConfig.ClientName)
+			}
+
+			clientAPIKeys[clientConfig.ClientID] = clientConfig.APIKeyHash
+
 		default:
 			logger.Error().
 				Str("client_id", clientConfig.ClientID).
@@ -108,6 +158,17 @@ func InitAuth() error {
 				clientConfig.AuthType, clientConfig.ClientID, clientConfig.ClientName)
 		}
 
+		// Parse source IP allowlist
+		if err := setClientAllowedNets(clientConfig.ClientID, clientConfig.AllowedIPs); err != nil {
+			logger.Error().
+				Err(err).
+				Str("client_id", clientConfig.ClientID).
+				Str("client_name", clientConfig.ClientName).
+				Strs("allowed_ips", clientConfig.AllowedIPs).
+				Msg("Invalid allowed IPs")
+			return err
+		}
+
 		// Cache client config
 		clientConfigs[clientConfig.ClientID] = clientConfig
 		loadedCount++
@@ -120,40 +181,61 @@ func InitAuth() error {
 			Msg("Client auth loaded successfully")
 	}
 
+	// Rebuild JWKS on next request
+	invalidateJWKS()
+
 	logger.Info().
 		Int("loaded_clients", loadedCount).
 		Int("total_clients", len(authConfig.Clients)).
 		Msg("Auth system initialized")
 
-	// Start nonce cleanup worker
+	// Shared nonce store for multi-instance replay protection
+	initNonceStore()
+
+	// Start nonce cleanup worker (local fallback map)
 	cleanupCtx, cleanupCancel = context.WithCancel(context.Background())
 	go cleanupWorker(cleanupCtx)
 
 	return nil
 }
 
-// VerifyJWT verifies JWT token and returns claims
-func VerifyJWT(tokenString string) (*Claims, error) {
+// VerifyJWT verifies JWT token and returns claims, failures are reported to audit hook
+func VerifyJWT(tokenString string, source RequestSource) (*Claims, error) {
+	claims, clientID, reason, err := verifyJWT(tokenString)
+	if err != nil {
+		auditFailure(Failure{
+			ClientID:   clientID,
+			AuthMethod: AuthMethodJWT,
+			Reason:     reason,
+			Error:      err.Error(),
+			Source:     source,
+		})
+	}
+	return claims, err
+}
+
+// verifyJWT verifies JWT token, returns client_id from unverified claims and failure reason on error
+func verifyJWT(tokenString string) (*Claims, string, string, error) {
 	// Parse token without verification first to get client_id
 	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, &Claims{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %v", err)
+		return nil, "", FailureMalformedToken, fmt.Errorf("failed to parse token: %v", err)
 	}
 
 	// Extract client_id
 	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		return nil, fmt.Errorf("invalid token claims")
+		return nil, "", FailureMalformedToken, fmt.Errorf("invalid token claims")
 	}
 
 	// Get public key for this client
 	publicKey, clientConfig, exists := GetClientInfo(claims.ClientID)
 	if !exists {
-		return nil, fmt.Errorf("unknown client_id: %s", claims.ClientID)
+		return nil, claims.ClientID, FailureUnknownClient, fmt.Errorf("unknown client_id: %s", claims.ClientID)
 	}
 
 	if !clientConfig.Active {
-		return nil, fmt.Errorf("client %s (%s) is inactive",
+		return nil, claims.ClientID, FailureInactiveClient, fmt.Errorf("client %s (%s) is inactive",
 			claims.ClientID, clientConfig.ClientName)
 	}
 
@@ -169,15 +251,15 @@ func VerifyJWT(tokenString string) (*Claims, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("token verification failed: %v", err)
+		return nil, claims.ClientID, tokenFailureReason(err), fmt.Errorf("token verification failed: %v", err)
 	}
 
 	verifiedClaims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
-		return nil, fmt.Errorf("invalid token")
+		return nil, claims.ClientID, FailureInvalidToken, fmt.Errorf("invalid token")
 	}
 
-	return verifiedClaims, nil
+	return verifiedClaims, claims.ClientID, "", nil
 }
 
 // GetClientInfo returns public key and config for client
@@ -198,14 +280,50 @@ func GetClientInfo(clientID string) (*rsa.PublicKey, config.ClientConfig, bool)
 		}
 	}
 
-	// For HMAC clients, public key is not needed (will be nil)
-	if clientConfig.AuthType == "hmac" {
+	// For HMAC and API key clients, public key is not needed (will be nil)
+	if clientConfig.AuthType == "hmac" || clientConfig.AuthType == "apikey" {
 		return nil, clientConfig, true
 	}
 
+	// For Ed25519 clients, key is fetched with GetClientEd25519Key
+	if clientConfig.AuthType == "ed25519" {
+		if _, keyExists := clientEdKeys[clientID]; keyExists {
+			return nil, clientConfig, true
+		}
+	}
+
 	return nil, config.ClientConfig{}, false
 }
 
+// GetClientEd25519Key returns Ed25519 public key for client
+func GetClientEd25519Key(clientID string) (ed25519.PublicKey, bool) {
+	authMutex.RLock()
+	defer authMutex.RUnlock()
+
+	publicKey, exists := clientEdKeys[clientID]
+	return publicKey, exists
+}
+
+// loadEd25519PublicKey reads PEM encoded (PKIX) Ed25519 public key from file
+func loadEd25519PublicKey(keyPath string) (ed25519.PublicKey, error) {
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := jwt.ParseEdPublicKeyFromPEM(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Ed25519 key: %v", err)
+	}
+
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key is not a valid Ed25519 public key")
+	}
+
+	return publicKey, nil
+}
+
 // GetClientSecretKey returns HMAC secret key for client
 func GetClientSecretKey(clientID string) (string, bool) {
 	authMutex.RLock()
@@ -217,6 +335,11 @@ func GetClientSecretKey(clientID string) (string, bool) {
 
 // AddClient adds new client to memory cache (called by CLI)
 func AddClient(clientConfig config.ClientConfig) error {
+	// Validate per-client signature window
+	if err := ValidateSignatureWindow(clientConfig); err != nil {
+		return err
+	}
+
 	authMutex.Lock()
 	defer authMutex.Unlock()
 
@@ -249,6 +372,23 @@ func AddClient(clientConfig config.ClientConfig) error {
 
 		clientPublicKeys[clientConfig.ClientID] = publicKey
 
+	case "ed25519":
+		if clientConfig.KeyPath == "" {
+			return fmt.Errorf("Ed25519 client %s missing key_path", clientConfig.ClientID)
+		}
+
+		publicKey, err := loadEd25519PublicKey(clientConfig.KeyPath)
+		if err != nil {
+			logger.Error().
+				Err(err).
+				Str("client_id", clientConfig.ClientID).
+				Str("key_path", clientConfig.KeyPath).
+				Msg("Failed to load Ed25519 public key for new client")
+			return fmt.Errorf("failed to load Ed25519 key: %v", err)
+		}
+
+		clientEdKeys[clientConfig.ClientID] = publicKey
+
 	case "hmac":
 		if clientConfig.SecretKey == "" {
 			return fmt.Errorf("HMAC client %s missing secret_key", clientConfig.ClientID)
@@ -256,12 +396,25 @@ func AddClient(clientConfig config.ClientConfig) error {
 
 		clientSecretKeys[clientConfig.ClientID] = clientConfig.SecretKey
 
+	case "apikey":
+		if !isValidAPIKeyHash(clientConfig.APIKeyHash) {
+			return fmt.Errorf("API key client %s missing or invalid api_key_hash", clientConfig.ClientID)
+		}
+
+		clientAPIKeys[clientConfig.ClientID] = clientConfig.APIKeyHash
+
 	default:
 		return fmt.Errorf("invalid auth_type '%s' for client %s", clientConfig.AuthType, clientConfig.ClientID)
 	}
 
+	// Parse source IP allowlist
+	if err := setClientAllowedNets(clientConfig.ClientID, clientConfig.AllowedIPs); err != nil {
+		return err
+	}
+
 	// Cache client config
 	clientConfigs[clientConfig.ClientID] = clientConfig
+	invalidateJWKS()
 
 	logger.Info().
 		Str("client_id", clientConfig.ClientID).
@@ -275,6 +428,11 @@ func AddClient(clientConfig config.ClientConfig) error {
 
 // UpdateClient updates existing client in memory cache (called by CLI)
 func UpdateClient(clientConfig config.ClientConfig) error {
+	// Validate per-client signature window
+	if err := ValidateSignatureWindow(clientConfig); err != nil {
+		return err
+	}
+
 	authMutex.Lock()
 	defer authMutex.Unlock()
 
@@ -302,15 +460,38 @@ func UpdateClient(clientConfig config.ClientConfig) error {
 			clientPublicKeys[clientConfig.ClientID] = publicKey
 		}
 
+	case "ed25519":
+		// Update Ed25519 public key if key path changed
+		if clientConfig.KeyPath != "" {
+			publicKey, err := loadEd25519PublicKey(clientConfig.KeyPath)
+			if err != nil {
+				return fmt.Errorf("failed to load Ed25519 key: %v", err)
+			}
+
+			clientEdKeys[clientConfig.ClientID] = publicKey
+		}
+
 	case "hmac":
 		// Update HMAC secret key
 		if clientConfig.SecretKey != "" {
 			clientSecretKeys[clientConfig.ClientID] = clientConfig.SecretKey
 		}
+
+	case "apikey":
+		// Update API key hash
+		if isValidAPIKeyHash(clientConfig.APIKeyHash) {
+			clientAPIKeys[clientConfig.ClientID] = clientConfig.APIKeyHash
+		}
+	}
+
+	// Update source IP allowlist
+	if err := setClientAllowedNets(clientConfig.ClientID, clientConfig.AllowedIPs); err != nil {
+		return err
 	}
 
 	// Update client config
 	clientConfigs[clientConfig.ClientID] = clientConfig
+	invalidateJWKS()
 
 	logger.Info().
 		Str("client_id", clientConfig.ClientID).
@@ -337,12 +518,20 @@ func RemoveClient(clientID string) error {
 	switch clientConfig.AuthType {
 	case "rsa":
 		delete(clientPublicKeys, clientID)
+	case "ed25519":
+		delete(clientEdKeys, clientID)
 	case "hmac":
 		delete(clientSecretKeys, clientID)
+	case "apikey":
+		delete(clientAPIKeys, clientID)
 	}
 
+	// Remove IP allowlist
+	delete(clientAllowedNets, clientID)
+
 	// Remove from client configs
 	delete(clientConfigs, clientID)
+	invalidateJWKS()
 
 	logger.Info().
 		Str("client_id", clientID).
@@ -352,7 +541,7 @@ func RemoveClient(clientID string) error {
 	return nil
 }
 
-// cleanExpiredNonces removes expired nonces from memory
+// cleanExpiredNonces removes expired nonces from local fallback map
 func cleanExpiredNonces() {
 	nonceMutex.Lock()
 	defer nonceMutex.Unlock()
@@ -361,8 +550,8 @@ func cleanExpiredNonces() {
 	count := 0
 	
 	for nonce, timestamp := range usedNonces {
-		// Remove nonces older than 5 minutes (300 seconds)
-		if now-timestamp > 300 {
+		// Remove nonces older than nonce TTL (same lifetime as Redis store)
+		if now-timestamp > int64(nonceTTL/time.Second) {
 			delete(usedNonces, nonce)
 			count++
 		}
@@ -396,6 +585,8 @@ func cleanupWorker(ctx context.Context) {
 
 // StopAuth stops the cleanup worker gracefully
 func StopAuth() {
+	closeNonceStore()
+
 	if cleanupCancel != nil {
 		cleanupCancel()
 		logger.Info().Msg("Auth system stopped")
@@ -409,7 +600,11 @@ func ReloadAuth() error {
 	// Clear existing cache
 	authMutex.Lock()
 	clientPublicKeys = make(map[string]*rsa.PublicKey)
+	clientEdKeys = make(map[string]ed25519.PublicKey)
 	clientSecretKeys = make(map[string]string)
+	clientAPIKeys = make(map[string]string)
+	clientAllowedNets = make(map[string][]*net.IPNet)
+	invalidateJWKS()
 	clientConfigs = make(map[string]config.ClientConfig)
 	authMutex.Unlock()
 
@@ -1,9 +1,67 @@
 package auth
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
 
 // Claims represents JWT token claims
 type Claims struct {
-	ClientID string `json:"client_id"` // Random string identifier (only required field)
+	ClientID    string   `json:"client_id"`             // Random string identifier (only required field)
+	Permissions []string `json:"permissions,omitempty"` // Informational, server authorizes with configured client permissions
 	jwt.RegisteredClaims
-}
\ No newline at end of file
+}
+
+// GenerateJWT mints RSA signed JWT for client using configured algorithm (RS256/RS512)
+func GenerateJWT(client config.ClientConfig, privateKey *rsa.PrivateKey, ttl time.Duration) (string, *Claims, error) {
+	if client.AuthType != "rsa" {
+		return "", nil, fmt.Errorf("JWT minting requires rsa client, client %s uses %s", client.ClientID, client.AuthType)
+	}
+	if ttl <= 0 {
+		return "", nil, fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+
+	// Token is verified against configured algorithm, so it must be RSA based
+	algorithm := config.Get().Auth.Algorithm
+	method, ok := jwt.GetSigningMethod(algorithm).(*jwt.SigningMethodRSA)
+	if !ok {
+		return "", nil, fmt.Errorf("configured algorithm %s is not an RSA algorithm (RS256, RS512)", algorithm)
+	}
+
+	now := utils.Now()
+	claims := &Claims{
+		ClientID:    client.ClientID,
+		Permissions: client.Permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(privateKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign token: %v", err)
+	}
+	return token, claims, nil
+}
+
+// LoadRSAPrivateKey reads PEM encoded RSA private key (PKCS#1 or PKCS#8)
+func LoadRSAPrivateKey(keyPath string) (*rsa.PrivateKey, error) {
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %v", err)
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSA private key: %v", err)
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+// JWK represents single RSA public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS represents JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwksCache holds marshaled JWKS (guarded by authMutex, nil = rebuild on next request)
+var jwksCache []byte
+
+// GetJWKS returns marshaled JWKS of all active RSA clients (cached until clients change)
+func GetJWKS() ([]byte, error) {
+	authMutex.RLock()
+	cached := jwksCache
+	authMutex.RUnlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	authMutex.Lock()
+	defer authMutex.Unlock()
+
+	// Rebuilt by concurrent request while waiting for lock
+	if jwksCache != nil {
+		return jwksCache, nil
+	}
+
+	data, err := json.Marshal(buildJWKS())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JWKS: %w", err)
+	}
+	jwksCache = data
+
+	return jwksCache, nil
+}
+
+// buildJWKS converts active RSA client public keys to JWKS, kid = client ID (caller must hold authMutex)
+func buildJWKS() JWKS {
+	alg := config.Get().Auth.Algorithm
+	if !strings.HasPrefix(alg, "RS") {
+		alg = "RS256"
+	}
+
+	jwks := JWKS{Keys: make([]JWK, 0, len(clientPublicKeys))}
+	for clientID, publicKey := range clientPublicKeys {
+		clientConfig, exists := clientConfigs[clientID]
+		if !exists || !clientConfig.Active || clientConfig.AuthType != "rsa" || publicKey == nil {
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, JWK{
+			Kty: "RSA",
+			Kid: clientID,
+			Use: "sig",
+			Alg: alg,
+			N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		})
+	}
+
+	// Stable order for consistent output
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+
+	return jwks
+}
+
+// invalidateJWKS clears cached JWKS (caller must hold authMutex)
+func invalidateJWKS() {
+	jwksCache = nil
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// nonceTTL keeps nonce long enough to cover whole timestamp window (past and future), bounds MaxSignatureWindowSeconds
+const nonceTTL = 5 * time.Minute
+
+var (
+	nonceStore      redis.Client // Shared nonce store, nil when Redis unavailable
+	nonceStoreMutex sync.RWMutex
+)
+
+// initNonceStore connects to Redis nonce store, local map is used as fallback on failure
+func initNonceStore() {
+	client, err := redis.NewClientForNonceStore()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Redis nonce store unavailable, falling back to in-process nonce tracking")
+		return
+	}
+
+	nonceStoreMutex.Lock()
+	if nonceStore != nil {
+		_ = nonceStore.Close()
+	}
+	nonceStore = client
+	nonceStoreMutex.Unlock()
+
+	logger.Info().Msg("Redis nonce store initialized")
+}
+
+// closeNonceStore closes Redis nonce store connection
+func closeNonceStore() {
+	nonceStoreMutex.Lock()
+	defer nonceStoreMutex.Unlock()
+
+	if nonceStore != nil {
+		_ = nonceStore.Close()
+		nonceStore = nil
+	}
+}
+
+// CheckAndStoreNonce atomically stores nonce for client, returns false when nonce was already used (cluster-wide via Redis SET NX).
+// Error reports Redis failure, result then comes from in-process fallback map.
+func CheckAndStoreNonce(clientID, nonce string) (bool, error) {
+	key := fmt.Sprintf("%s:%s", clientID, nonce)
+
+	nonceStoreMutex.RLock()
+	store := nonceStore
+	nonceStoreMutex.RUnlock()
+
+	if store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		stored, err := store.SetNX(ctx, key, utils.Now().Unix(), nonceTTL)
+		if err == nil {
+			return stored, nil
+		}
+
+		// Redis error, fall back to local map
+		return checkAndStoreLocalNonce(key), fmt.Errorf("nonce store unavailable: %w", err)
+	}
+
+	return checkAndStoreLocalNonce(key), nil
+}
+
+// checkAndStoreLocalNonce tracks nonce in process memory (fallback, cleaned by cleanupWorker)
+func checkAndStoreLocalNonce(key string) bool {
+	nonceMutex.Lock()
+	defer nonceMutex.Unlock()
+
+	if _, exists := usedNonces[key]; exists {
+		return false
+	}
+	usedNonces[key] = utils.Now().Unix()
+	return true
+}
@@ -1,6 +1,10 @@
 package auth
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
 
 // Action constants for permissions
 const (
@@ -11,11 +15,12 @@ const (
 	ActionAdmin  = "admin"
 	ActionBulk   = "bulk"
 	ActionExport = "export"
+	ActionDebug  = "debug"
 	ActionAll    = "*"
 )
 
-// HasPermission checks if user has required permission with wildcard support
-func HasPermission(userPermissions []string, required string) bool {
+// HasPermissionIn checks if permission list grants required permission with wildcard support
+func HasPermissionIn(userPermissions []string, required string) bool {
 	for _, perm := range userPermissions {
 		if matchesPermission(perm, required) {
 			return true
@@ -24,6 +29,11 @@ func HasPermission(userPermissions []string, required string) bool {
 	return false
 }
 
+// HasPermission checks if client has required permission with wildcard support
+func HasPermission(client config.ClientConfig, required string) bool {
+	return HasPermissionIn(client.Permissions, required)
+}
+
 // matchesPermission handles exact match and wildcard patterns
 func matchesPermission(userPerm, required string) bool {
 	// Exact match
@@ -31,8 +41,8 @@ func matchesPermission(userPerm, required string) bool {
 		return true
 	}
 
-	// Super admin access
-	if userPerm == "*:*" {
+	// Super admin access: "*" alone or "*:*"
+	if userPerm == ActionAll || userPerm == "*:*" {
 		return true
 	}
 
@@ -48,19 +58,25 @@ func matchesPermission(userPerm, required string) bool {
 	reqAction, reqResource := requiredParts[0], requiredParts[1]
 
 	// Admin covers all actions for that resource: "admin:logs" covers "create:logs", "read:logs", etc.
-	if userAction == ActionAdmin && userResource == reqResource {
+	if userAction == ActionAdmin && matchesSegment(userResource, reqResource) {
 		return true
 	}
 
-	// Resource wildcard: "read:*" covers "read:logs", "read:health", etc.
-	if userAction == reqAction && userResource == "*" {
-		return true
-	}
+	// Wildcard on either segment: "read:*" covers "read:logs", "*:logs" covers "create:logs",
+	// trailing wildcard "read:user_*" covers "read:user_activities"
+	return matchesSegment(userAction, reqAction) && matchesSegment(userResource, reqResource)
+}
 
-	// Action wildcard: "*:logs" covers "create:logs", "read:logs", etc.
-	if userAction == "*" && userResource == reqResource {
+// matchesSegment matches single permission segment, supports "*" and trailing wildcard ("user_*")
+func matchesSegment(pattern, value string) bool {
+	if pattern == "" || value == "" {
+		return false
+	}
+	if pattern == "*" {
 		return true
 	}
-
-	return false
-}
\ No newline at end of file
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(value, prefix)
+	}
+	return pattern == value
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+func TestHasPermissionIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		required    string
+		expected    bool
+	}{
+		// Exact scopes
+		{"exact match", []string{"read:health"}, "read:health", true},
+		{"exact other resource", []string{"read:health"}, "read:logs", false},
+		{"exact other action", []string{"read:health"}, "create:health", false},
+		{"no permissions", []string{}, "read:health", false},
+		{"non-wildcard does not match wildcard scope", []string{"read:health"}, "read:*", false},
+
+		// Super admin
+		{"star alone", []string{"*"}, "delete:logs", true},
+		{"star star", []string{"*:*"}, "read:health", true},
+
+		// Segment wildcards
+		{"resource wildcard", []string{"read:*"}, "read:user_activities", true},
+		{"resource wildcard other action", []string{"read:*"}, "create:user_activities", false},
+		{"action wildcard", []string{"*:logs"}, "delete:logs", true},
+		{"action wildcard other resource", []string{"*:logs"}, "delete:health", false},
+
+		// Trailing wildcards
+		{"trailing wildcard", []string{"read:user_*"}, "read:user_activities", true},
+		{"trailing wildcard mismatch", []string{"read:user_*"}, "read:security_events", false},
+		{"trailing wildcard action", []string{"re*:health"}, "read:health", true},
+
+		// Admin action
+		{"admin covers resource", []string{"admin:logs"}, "delete:logs", true},
+		{"admin other resource", []string{"admin:logs"}, "delete:health", false},
+		{"admin wildcard resource", []string{"admin:*"}, "export:transaction_events", true},
+
+		// Malformed
+		{"malformed permission", []string{"read"}, "read:health", false},
+		{"malformed required", []string{"read:*"}, "read", false},
+		{"empty segment", []string{"read:"}, "read:health", false},
+	}
+
+	for _, tt := range tests {
+		if got := HasPermissionIn(tt.permissions, tt.required); got != tt.expected {
+			t.Errorf("%s: HasPermissionIn(%v, %q) = %v, want %v", tt.name, tt.permissions, tt.required, got, tt.expected)
+		}
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	client := config.ClientConfig{ClientID: "client", Permissions: []string{"read:*", "admin:logs"}}
+
+	if !HasPermission(client, "read:health") {
+		t.Errorf("expected read:* to grant read:health")
+	}
+	if !HasPermission(client, "create:logs") {
+		t.Errorf("expected admin:logs to grant create:logs")
+	}
+	if HasPermission(client, "create:health") {
+		t.Errorf("expected create:health to be denied")
+	}
+}
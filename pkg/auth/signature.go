@@ -2,33 +2,72 @@ package auth
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"hash"
+	"io"
 	"strconv"
+	"time"
 
 	"github.com/benedict-erwin/insight-collector/config"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
 
-// Global variable for windowTime
+// Global variable for windowTime (default, overridable per client via signature_window_seconds)
 var windowTime int64 = 30
 
+// Signature scheme versions, selected by X-Signature-Version header (missing header = SignatureVersionRawBody)
+const (
+	SignatureVersionRawBody  = "1" // Legacy: raw body embedded in canonical payload
+	SignatureVersionBodyHash = "2" // Hex SHA256 digest of body in canonical payload (body_sha256)
+)
+
 // SignaturePayload represents the data structure for signature generation
 type SignaturePayload struct {
-	ClientID  string `json:"client_id"`
-	Timestamp int64  `json:"timestamp"`
-	Nonce     string `json:"nonce,omitempty"`
-	Method    string `json:"method"`
-	Path      string `json:"path"`
-	Body      string `json:"body,omitempty"`
+	ClientID   string `json:"client_id"`
+	Timestamp  int64  `json:"timestamp"`
+	Nonce      string `json:"nonce,omitempty"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Body       string `json:"body,omitempty"`        // Version 1 only
+	BodySHA256 string `json:"body_sha256,omitempty"` // Version 2 only, always set (digest of empty body for GET)
+}
+
+// SignedBody is request body as covered by signature of given version
+type SignedBody struct {
+	Version string // SignatureVersionRawBody or SignatureVersionBodyHash
+	Raw     string // Raw body (version 1)
+	SHA256  string // Hex SHA256 of raw body (version 2)
+}
+
+// IsSignatureVersion reports whether version is supported signature scheme
+func IsSignatureVersion(version string) bool {
+	return version == SignatureVersionRawBody || version == SignatureVersionBodyHash
+}
+
+// BodySHA256 streams body through SHA256 and returns hex digest, body is also copied to dst when not nil
+// (e.g. buffer restoring request body) so it is read only once.
+func BodySHA256(body io.Reader, dst io.Writer) (string, error) {
+	h := sha256.New()
+	w := io.Writer(h)
+	if dst != nil {
+		w = io.MultiWriter(h, dst)
+	}
+	if body != nil {
+		if _, err := io.Copy(w, body); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 // ToSignatureString converts payload to canonical JSON string for signing
@@ -44,7 +83,7 @@ func getHashFunction() (func() hash.Hash, crypto.Hash, error) {
 	switch algorithm {
 	case "RS256", "HS256":
 		return sha256.New, crypto.SHA256, nil
-	case "RS512", "HS512":
+	case "RS512", "HS512", "EdDSA":
 		return sha512.New, crypto.SHA512, nil
 	default:
 		return nil, 0, fmt.Errorf("unsupported algorithm: %s", algorithm)
@@ -84,28 +123,50 @@ func GenerateHMACSignature(payload SignaturePayload, secretKey string) (string,
 	return base64.StdEncoding.EncodeToString(signature), nil
 }
 
-// VerifySignature verifies the request signature and returns client config
-func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatureStr string) (*config.ClientConfig, error) {
-	// Parse timestamp
-	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+// GenerateEd25519Signature generates Ed25519 signature for the payload (signs canonical payload directly, no pre-hash)
+func GenerateEd25519Signature(payload SignaturePayload, privateKey ed25519.PrivateKey) (string, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("invalid Ed25519 private key size: %d", len(privateKey))
+	}
+
+	signature := ed25519.Sign(privateKey, []byte(payload.ToSignatureString()))
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifySignature verifies the request signature and returns client config, failures are reported to audit hook
+func VerifySignature(clientID, timestampStr, nonce, method, path string, body SignedBody, signatureStr string, source RequestSource) (*config.ClientConfig, error) {
+	clientConfig, reason, err := verifySignature(clientID, timestampStr, nonce, method, path, body, signatureStr)
 	if err != nil {
+		auditFailure(Failure{
+			ClientID:   clientID,
+			AuthMethod: AuthMethodSignature,
+			Reason:     reason,
+			Error:      err.Error(),
+			Source:     source,
+		})
+	}
+	return clientConfig, err
+}
+
+// verifySignature verifies the request signature, returns failure reason on error
+func verifySignature(clientID, timestampStr, nonce, method, path string, body SignedBody, signatureStr string) (*config.ClientConfig, string, error) {
+	// Reject unknown scheme before nonce is consumed
+	if !IsSignatureVersion(body.Version) {
 		logger.Warn().
 			Str("client_id", clientID).
-			Str("timestamp", timestampStr).
-			Msg("Invalid timestamp format")
-		return nil, fmt.Errorf("invalid timestamp format")
+			Str("signature_version", body.Version).
+			Msg("Unsupported signature version")
+		return nil, FailureInvalidSignature, fmt.Errorf("unsupported signature version: %s", body.Version)
 	}
 
-	// Check timestamp validity (5 minutes window)
-	now := utils.Now().Unix()
-	if now-timestamp > windowTime || timestamp-now > windowTime {
+	// Parse timestamp
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
 		logger.Warn().
 			Str("client_id", clientID).
-			Int64("timestamp", timestamp).
-			Int64("current_time", now).
-			Int64("diff", now-timestamp).
-			Msg("Request timestamp expired or too far in future")
-		return nil, fmt.Errorf("request timestamp expired")
+			Str("timestamp", timestampStr).
+			Msg("Invalid timestamp format")
+		return nil, FailureInvalidTimestamp, fmt.Errorf("invalid timestamp format")
 	}
 
 	// Get client info
@@ -114,7 +175,7 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 		logger.Warn().
 			Str("client_id", clientID).
 			Msg("Unknown client ID in signature verification")
-		return nil, fmt.Errorf("unknown client_id: %s", clientID)
+		return nil, FailureUnknownClient, fmt.Errorf("unknown client_id: %s", clientID)
 	}
 
 	if !clientConfig.Active {
@@ -122,40 +183,60 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 			Str("client_id", clientID).
 			Str("client_name", clientConfig.ClientName).
 			Msg("Inactive client attempted signature verification")
-		return nil, fmt.Errorf("client %s (%s) is inactive", clientID, clientConfig.ClientName)
+		return nil, FailureInactiveClient, fmt.Errorf("client %s (%s) is inactive", clientID, clientConfig.ClientName)
+	}
+
+	// Check timestamp validity (client-specific window, fallback to global default)
+	window := signatureWindow(clientConfig)
+	now := utils.Now().Unix()
+	if now-timestamp > window || timestamp-now > window {
+		logger.Warn().
+			Str("client_id", clientID).
+			Str("client_name", clientConfig.ClientName).
+			Int64("timestamp", timestamp).
+			Int64("current_time", now).
+			Int64("diff", now-timestamp).
+			Int64("window_seconds", window).
+			Msg("Request timestamp expired or too far in future")
+		return nil, FailureExpiredTimestamp, fmt.Errorf("request timestamp expired")
 	}
 
 	// Optional nonce checking for replay attack prevention
 	if nonce != "" {
-		nonceMutex.Lock()
-		if usedTimestamp, exists := usedNonces[nonce]; exists {
-			nonceMutex.Unlock()
+		stored, err := CheckAndStoreNonce(clientID, nonce)
+		if err != nil {
+			logger.Warn().
+				Err(err).
+				Str("client_id", clientID).
+				Msg("Nonce checked against local store only")
+		}
+		if !stored {
 			logger.Warn().
 				Str("client_id", clientID).
 				Str("nonce", nonce).
-				Int64("previous_timestamp", usedTimestamp).
 				Int64("current_timestamp", timestamp).
 				Msg("Nonce replay attack detected")
-			return nil, fmt.Errorf("nonce already used (replay attack)")
+			return nil, FailureReplayedNonce, fmt.Errorf("nonce already used (replay attack)")
 		}
-		// Store nonce with current timestamp
-		usedNonces[nonce] = timestamp
-		nonceMutex.Unlock()
-		
+
 		logger.Debug().
 			Str("client_id", clientID).
 			Str("nonce", nonce).
 			Msg("Nonce registered successfully")
 	}
 
-	// Build payload for verification
+	// Build payload for verification (raw body or its digest depending on version)
 	payload := SignaturePayload{
 		ClientID:  clientID,
 		Timestamp: timestamp,
 		Nonce:     nonce,
 		Method:    method,
 		Path:      path,
-		Body:      body,
+	}
+	if body.Version == SignatureVersionBodyHash {
+		payload.BodySHA256 = body.SHA256
+	} else {
+		payload.Body = body.Raw
 	}
 
 	// Decode signature
@@ -165,13 +246,15 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 			Str("client_id", clientID).
 			Err(err).
 			Msg("Failed to decode signature")
-		return nil, fmt.Errorf("invalid signature format")
+		return nil, FailureInvalidSignature, fmt.Errorf("invalid signature format")
 	}
 
 	// Verify signature based on client auth type
 	switch clientConfig.AuthType {
 	case "rsa":
 		err = verifyRSASignature(payload, signature, clientID)
+	case "ed25519":
+		err = verifyEd25519Signature(payload, signature, clientID)
 	case "hmac":
 		err = verifyHMACSignature(payload, signature, clientID)
 	default:
@@ -179,7 +262,7 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 			Str("client_id", clientID).
 			Str("auth_type", clientConfig.AuthType).
 			Msg("Invalid auth type for signature verification")
-		return nil, fmt.Errorf("invalid auth_type: %s", clientConfig.AuthType)
+		return nil, FailureInvalidAuthType, fmt.Errorf("invalid auth_type: %s", clientConfig.AuthType)
 	}
 
 	if err != nil {
@@ -190,7 +273,7 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 			Str("algorithm", config.Get().Auth.Algorithm).
 			Err(err).
 			Msg("Signature verification failed")
-		return nil, fmt.Errorf("signature verification failed: %v", err)
+		return nil, FailureInvalidSignature, fmt.Errorf("signature verification failed: %v", err)
 	}
 
 	logger.Info().
@@ -198,12 +281,37 @@ func VerifySignature(clientID, timestampStr, nonce, method, path, body, signatur
 		Str("client_name", clientConfig.ClientName).
 		Str("auth_type", clientConfig.AuthType).
 		Str("algorithm", config.Get().Auth.Algorithm).
+		Str("signature_version", body.Version).
 		Str("method", method).
 		Str("path", path).
 		Int64("timestamp", timestamp).
 		Msg("Signature verification successful")
 
-	return &clientConfig, nil
+	return &clientConfig, "", nil
+}
+
+// signatureWindow returns allowed timestamp skew in seconds for client
+func signatureWindow(clientConfig config.ClientConfig) int64 {
+	if clientConfig.SignatureWindowSeconds > 0 {
+		return int64(clientConfig.SignatureWindowSeconds)
+	}
+	return windowTime
+}
+
+// MaxSignatureWindowSeconds caps client signature window so accepted timestamps (±window) never outlive stored nonces
+const MaxSignatureWindowSeconds = int(nonceTTL / time.Second / 2)
+
+// ValidateSignatureWindow checks client signature window is positive and within MaxSignatureWindowSeconds when set
+func ValidateSignatureWindow(clientConfig config.ClientConfig) error {
+	if clientConfig.SignatureWindowSeconds < 0 {
+		return fmt.Errorf("signature_window_seconds must be positive for client %s, got %d",
+			clientConfig.ClientID, clientConfig.SignatureWindowSeconds)
+	}
+	if clientConfig.SignatureWindowSeconds > MaxSignatureWindowSeconds {
+		return fmt.Errorf("signature_window_seconds must be at most %d for client %s (nonce replay protection), got %d",
+			MaxSignatureWindowSeconds, clientConfig.ClientID, clientConfig.SignatureWindowSeconds)
+	}
+	return nil
 }
 
 // verifyRSASignature verifies RSA signature
@@ -226,6 +334,24 @@ func verifyRSASignature(payload SignaturePayload, signature []byte, clientID str
 	return rsa.VerifyPKCS1v15(publicKey, cryptoHash, hashed, signature)
 }
 
+// verifyEd25519Signature verifies Ed25519 signature
+func verifyEd25519Signature(payload SignaturePayload, signature []byte, clientID string) error {
+	publicKey, exists := GetClientEd25519Key(clientID)
+	if !exists || publicKey == nil {
+		return fmt.Errorf("Ed25519 public key not found for client: %s", clientID)
+	}
+
+	if len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid Ed25519 signature size: %d", len(signature))
+	}
+
+	if !ed25519.Verify(publicKey, []byte(payload.ToSignatureString()), signature) {
+		return fmt.Errorf("Ed25519 signature mismatch")
+	}
+
+	return nil
+}
+
 // verifyHMACSignature verifies HMAC signature
 func verifyHMACSignature(payload SignaturePayload, signature []byte, clientID string) error {
 	secretKey, exists := GetClientSecretKey(clientID)
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+func TestValidateSignatureWindow(t *testing.T) {
+	tests := []struct {
+		window  int
+		wantErr string
+	}{
+		{0, ""},
+		{60, ""},
+		{MaxSignatureWindowSeconds, ""},
+		{-1, "must be positive"},
+		{MaxSignatureWindowSeconds + 1, "at most"},
+		{600, "at most"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSignatureWindow(config.ClientConfig{ClientID: "client-1", SignatureWindowSeconds: tt.window})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("window %d: unexpected error: %v", tt.window, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("window %d: error = %v, want containing %q", tt.window, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMaxSignatureWindowCoveredByNonceTTL(t *testing.T) {
+	// Timestamp accepted from now-window to now+window, nonce must be kept for whole span
+	if span := 2 * time.Duration(MaxSignatureWindowSeconds) * time.Second; span > nonceTTL {
+		t.Errorf("accepted timestamp span %s exceeds nonce TTL %s", span, nonceTTL)
+	}
+}
+
+// useHMACClient loads HS256 config and registers active HMAC client in memory cache
+func useHMACClient(t *testing.T, clientID, secret string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".config"), []byte(`{"auth":{"enabled":true,"algorithm":"HS256"}}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Chdir(dir)
+	if err := config.Init(); err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	authMutex.Lock()
+	clientConfigs[clientID] = config.ClientConfig{ClientID: clientID, ClientName: "test", AuthType: "hmac", Active: true}
+	clientSecretKeys[clientID] = secret
+	authMutex.Unlock()
+	t.Cleanup(func() {
+		authMutex.Lock()
+		delete(clientConfigs, clientID)
+		delete(clientSecretKeys, clientID)
+		authMutex.Unlock()
+	})
+}
+
+func TestBodySHA256Streams(t *testing.T) {
+	body := `{"user_id":"user-1","details":{"plan":"pro"}}`
+	var restored bytes.Buffer
+	digest, err := BodySHA256(strings.NewReader(body), &restored)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.String() != body {
+		t.Errorf("expected body copied to dst, got %q", restored.String())
+	}
+	if len(digest) != 64 {
+		t.Errorf("expected hex SHA256 digest, got %q", digest)
+	}
+
+	// Nil body hashes as empty body
+	empty, _ := BodySHA256(nil, nil)
+	if empty != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected empty body digest %s", empty)
+	}
+}
+
+func TestVerifySignatureVersions(t *testing.T) {
+	const clientID, secret = "sig-client", "sig-secret"
+	useHMACClient(t, clientID, secret)
+
+	body := `{"user_id":"user-1"}`
+	digest, _ := BodySHA256(strings.NewReader(body), nil)
+	timestamp := time.Now().Unix()
+	timestampStr := strconv.FormatInt(timestamp, 10)
+
+	sign := func(payload SignaturePayload) string {
+		t.Helper()
+		signature, err := GenerateSignature(payload, secret)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		return signature
+	}
+	base := SignaturePayload{ClientID: clientID, Timestamp: timestamp, Method: "POST", Path: "/v1/user-activities/insert"}
+
+	legacy := base
+	legacy.Body = body
+	hashed := base
+	hashed.BodySHA256 = digest
+
+	tests := []struct {
+		name      string
+		signature string
+		body      SignedBody
+		wantErr   string
+	}{
+		{"legacy raw body", sign(legacy), SignedBody{Version: SignatureVersionRawBody, Raw: body}, ""},
+		{"body digest", sign(hashed), SignedBody{Version: SignatureVersionBodyHash, SHA256: digest}, ""},
+		{"digest signature sent as legacy", sign(hashed), SignedBody{Version: SignatureVersionRawBody, Raw: body}, "signature verification failed"},
+		{"legacy signature sent as digest", sign(legacy), SignedBody{Version: SignatureVersionBodyHash, SHA256: digest}, "signature verification failed"},
+		{"tampered body", sign(hashed), SignedBody{Version: SignatureVersionBodyHash, SHA256: strings.Repeat("0", 64)}, "signature verification failed"},
+		{"unknown version", sign(hashed), SignedBody{Version: "3", SHA256: digest}, "unsupported signature version"},
+	}
+	for _, tt := range tests {
+		_, _, err := verifySignature(clientID, timestampStr, "", base.Method, base.Path, tt.body, tt.signature)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
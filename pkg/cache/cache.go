@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	lru "github.com/hashicorp/golang-lru/v2"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces two-tier cache keys in Redis cache store
+const keyPrefix = "tt:"
+
+// Codec serializes values stored in Redis layer
+type Codec[T any] struct {
+	Marshal   func(value T) ([]byte, error)
+	Unmarshal func(data []byte) (T, error)
+}
+
+// JSONCodec returns codec storing values as JSON
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Marshal: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		Unmarshal: func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+}
+
+// Option configures TwoTier cache
+type Option[T any] func(*TwoTier[T])
+
+// WithCodec overrides default JSON codec for Redis layer
+func WithCodec[T any](codec Codec[T]) Option[T] {
+	return func(c *TwoTier[T]) {
+		if codec.Marshal != nil && codec.Unmarshal != nil {
+			c.codec = codec
+		}
+	}
+}
+
+// WithRedis sets Redis client used as second tier (nil = local only)
+func WithRedis[T any](client redis.Client) Option[T] {
+	return func(c *TwoTier[T]) {
+		c.remote = client
+	}
+}
+
+// localEntry wraps locally cached value with expiration time (zero = no expiration)
+type localEntry[T any] struct {
+	value     T
+	expiresAt time.Time
+}
+
+// TwoTier is in-process LRU cache fronting shared Redis cache.
+// Reads check LRU first, Redis hits are promoted into LRU for localTTL.
+// Redis failures are treated as misses so callers fall back to source.
+type TwoTier[T any] struct {
+	name     string
+	local    *lru.Cache[string, localEntry[T]]
+	localTTL time.Duration
+	remote   redis.Client
+	codec    Codec[T]
+}
+
+// New creates two-tier cache with LRU of size entries, localTTL caps how long values stay in-process
+func New[T any](name string, size int, localTTL time.Duration, opts ...Option[T]) (*TwoTier[T], error) {
+	if name == "" {
+		return nil, fmt.Errorf("cache name is required")
+	}
+
+	local, err := lru.New[string, localEntry[T]](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s local cache: %w", name, err)
+	}
+
+	c := &TwoTier[T]{
+		name:     name,
+		local:    local,
+		localTTL: localTTL,
+		codec:    JSONCodec[T](),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// NewWithCacheStore creates two-tier cache backed by Redis cache client (NewClientForCache).
+// Falls back to local only cache when Redis is unavailable.
+func NewWithCacheStore[T any](name string, size int, localTTL time.Duration, opts ...Option[T]) (*TwoTier[T], error) {
+	client, err := redis.NewClientForCache()
+	if err != nil {
+		logger.WithScope("cache").Warn().Err(err).Str("cache", name).Msg("Redis cache store unavailable, using local cache only")
+		client = nil
+	}
+
+	return New(name, size, localTTL, append([]Option[T]{WithRedis[T](client)}, opts...)...)
+}
+
+// Get returns cached value, checking local LRU then Redis.
+// Redis hit is promoted into LRU for min(remaining Redis ttl, localTTL), so local copy never outlives Redis entry.
+func (c *TwoTier[T]) Get(ctx context.Context, key string) (T, bool) {
+	var zero T
+
+	if entry, found := c.local.Get(key); found {
+		if entry.expiresAt.IsZero() || time.Now().Before(entry.expiresAt) {
+			return entry.value, true
+		}
+		c.local.Remove(key)
+	}
+
+	if c.remote == nil {
+		return zero, false
+	}
+
+	// Value and remaining ttl in one round trip
+	var getCmd *goredis.StringCmd
+	var ttlCmd *goredis.DurationCmd
+	err := c.remote.Pipeline(ctx, func(pipe redis.Pipe) error {
+		getCmd = pipe.Get(ctx, c.remoteKey(key))
+		ttlCmd = pipe.PTTL(ctx, c.remoteKey(key))
+		return nil
+	})
+	if err == nil {
+		err = getCmd.Err()
+	}
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			logger.WithScope("cache").Debug().Err(err).Str("cache", c.name).Msg("Redis cache read failed")
+		}
+		return zero, false
+	}
+
+	value, err := c.codec.Unmarshal([]byte(getCmd.Val()))
+	if err != nil {
+		logger.WithScope("cache").Debug().Err(err).Str("cache", c.name).Msg("Failed to decode cached value")
+		return zero, false
+	}
+
+	// Negative PTTL: no expiration on Redis entry
+	localTTL := c.localTTL
+	if remaining := ttlCmd.Val(); remaining > 0 && (localTTL <= 0 || remaining < localTTL) {
+		localTTL = remaining
+	}
+	c.setLocal(key, value, localTTL)
+	return value, true
+}
+
+// Set stores value in both tiers, ttl <= 0 means no expiration.
+// Local copy expires after min(ttl, localTTL); returned error is from Redis layer only.
+func (c *TwoTier[T]) Set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	localTTL := c.localTTL
+	if ttl > 0 && (localTTL <= 0 || ttl < localTTL) {
+		localTTL = ttl
+	}
+	c.setLocal(key, value, localTTL)
+
+	if c.remote == nil {
+		return nil
+	}
+
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s cache value: %w", c.name, err)
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	if err := c.remote.Set(ctx, c.remoteKey(key), data, ttl); err != nil {
+		return fmt.Errorf("failed to store %s cache value: %w", c.name, err)
+	}
+	return nil
+}
+
+// Delete removes key from both tiers
+func (c *TwoTier[T]) Delete(ctx context.Context, key string) error {
+	c.local.Remove(key)
+	if c.remote == nil {
+		return nil
+	}
+	return c.remote.Delete(ctx, c.remoteKey(key))
+}
+
+// Purge clears local LRU (Redis entries expire by TTL)
+func (c *TwoTier[T]) Purge() {
+	c.local.Purge()
+}
+
+// Len returns number of entries in local LRU
+func (c *TwoTier[T]) Len() int {
+	return c.local.Len()
+}
+
+// HasRemote reports whether Redis layer is attached
+func (c *TwoTier[T]) HasRemote() bool {
+	return c.remote != nil
+}
+
+// setLocal stores value in LRU, ttl <= 0 means no expiration
+func (c *TwoTier[T]) setLocal(key string, value T, ttl time.Duration) {
+	entry := localEntry[T]{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.local.Add(key, entry)
+}
+
+// remoteKey builds namespaced Redis key
+func (c *TwoTier[T]) remoteKey(key string) string {
+	return keyPrefix + c.name + ":" + key
+}
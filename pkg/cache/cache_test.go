@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// fakeRedis is in-memory redis.Client covering Get/Set/Delete/Pipeline used by TwoTier
+type fakeRedis struct {
+	redis.Client
+	mu    sync.Mutex
+	data  map[string]string
+	ttls  map[string]time.Duration
+	gets  int
+	fails bool
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{data: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if f.fails {
+		return "", errors.New("connection refused")
+	}
+	value, ok := f.data[key]
+	if !ok {
+		return "", goredis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fails {
+		return errors.New("connection refused")
+	}
+	switch v := value.(type) {
+	case []byte:
+		f.data[key] = string(v)
+	case string:
+		f.data[key] = v
+	}
+	f.ttls[key] = expiration
+	return nil
+}
+
+func (f *fakeRedis) Delete(ctx context.Context, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.data, key)
+	}
+	return nil
+}
+
+// Pipeline runs fn against fakePipe, queued GET and PTTL resolve immediately
+func (f *fakeRedis) Pipeline(ctx context.Context, fn func(redis.Pipe) error) error {
+	return fn(&fakePipe{redis: f})
+}
+
+// fakePipe covers Get/PTTL used by TwoTier.Get
+type fakePipe struct {
+	redis.Pipe
+	redis *fakeRedis
+}
+
+func (p *fakePipe) Get(ctx context.Context, key string) *goredis.StringCmd {
+	value, err := p.redis.Get(ctx, key)
+	return goredis.NewStringResult(value, err)
+}
+
+func (p *fakePipe) PTTL(ctx context.Context, key string) *goredis.DurationCmd {
+	p.redis.mu.Lock()
+	defer p.redis.mu.Unlock()
+	if _, ok := p.redis.data[key]; !ok {
+		return goredis.NewDurationResult(-2, nil)
+	}
+	if ttl := p.redis.ttls[key]; ttl > 0 {
+		return goredis.NewDurationResult(ttl, nil)
+	}
+	return goredis.NewDurationResult(-1, nil)
+}
+
+type testValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestTwoTierPromotion(t *testing.T) {
+	ctx := context.Background()
+	remote := newFakeRedis()
+
+	writer, err := New[testValue]("test", 10, time.Minute, WithRedis[testValue](remote))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := writer.Set(ctx, "a", testValue{Name: "a", Count: 1}, time.Hour); err != nil {
+		t.Fatalf("unexpected set error: %v", err)
+	}
+	if remote.data["tt:test:a"] != `{"name":"a","count":1}` {
+		t.Errorf("unexpected remote value %q", remote.data["tt:test:a"])
+	}
+	if remote.ttls["tt:test:a"] != time.Hour {
+		t.Errorf("expected remote ttl 1h, got %v", remote.ttls["tt:test:a"])
+	}
+
+	// Second process: local miss, Redis hit promoted into LRU
+	reader, _ := New[testValue]("test", 10, time.Minute, WithRedis[testValue](remote))
+	value, found := reader.Get(ctx, "a")
+	if !found || value.Count != 1 {
+		t.Fatalf("expected Redis hit, got %+v found=%v", value, found)
+	}
+	if reader.Len() != 1 {
+		t.Errorf("expected promoted entry in local cache, got %d", reader.Len())
+	}
+
+	gets := remote.gets
+	if _, found := reader.Get(ctx, "a"); !found {
+		t.Fatal("expected local hit")
+	}
+	if remote.gets != gets {
+		t.Error("local hit must not query Redis")
+	}
+
+	// Miss in both tiers
+	if _, found := reader.Get(ctx, "missing"); found {
+		t.Error("expected miss")
+	}
+
+	// Delete removes both tiers
+	if err := reader.Delete(ctx, "a"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, found := reader.Get(ctx, "a"); found {
+		t.Error("expected miss after delete")
+	}
+}
+
+func TestTwoTierLocalExpiration(t *testing.T) {
+	ctx := context.Background()
+	remote := newFakeRedis()
+
+	c, _ := New[string]("exp", 10, time.Hour, WithRedis[string](remote))
+	if err := c.Set(ctx, "k", "v", 20*time.Millisecond); err != nil {
+		t.Fatalf("unexpected set error: %v", err)
+	}
+	if value, found := c.Get(ctx, "k"); !found || value != "v" {
+		t.Fatalf("expected local hit, got %q found=%v", value, found)
+	}
+
+	// Local copy expires with shorter ttl, value served again from Redis (fake never expires keys)
+	time.Sleep(30 * time.Millisecond)
+	gets := remote.gets
+	if value, found := c.Get(ctx, "k"); !found || value != "v" {
+		t.Fatalf("expected Redis hit, got %q found=%v", value, found)
+	}
+	if remote.gets != gets+1 {
+		t.Error("expired local entry must fall back to Redis")
+	}
+}
+
+func TestTwoTierPromotionKeepsRedisTTL(t *testing.T) {
+	ctx := context.Background()
+	remote := newFakeRedis()
+
+	writer, _ := New[string]("ttl", 10, time.Hour, WithRedis[string](remote))
+	if err := writer.Set(ctx, "short", "v", 50*time.Millisecond); err != nil {
+		t.Fatalf("unexpected set error: %v", err)
+	}
+	if err := writer.Set(ctx, "forever", "v", 0); err != nil {
+		t.Fatalf("unexpected set error: %v", err)
+	}
+
+	// Promoted entry expires with remaining Redis ttl instead of localTTL
+	reader, _ := New[string]("ttl", 10, time.Hour, WithRedis[string](remote))
+	if _, found := reader.Get(ctx, "short"); !found {
+		t.Fatal("expected Redis hit")
+	}
+	entry, _ := reader.local.Get("short")
+	if remaining := time.Until(entry.expiresAt); remaining <= 0 || remaining > 50*time.Millisecond {
+		t.Errorf("expected local expiry within Redis ttl, got %v", remaining)
+	}
+
+	// Redis entry without expiration uses localTTL
+	if _, found := reader.Get(ctx, "forever"); !found {
+		t.Fatal("expected Redis hit")
+	}
+	entry, _ = reader.local.Get("forever")
+	if remaining := time.Until(entry.expiresAt); remaining <= 50*time.Minute || remaining > time.Hour {
+		t.Errorf("expected local expiry of localTTL, got %v", remaining)
+	}
+}
+
+func TestTwoTierRedisFailure(t *testing.T) {
+	ctx := context.Background()
+	remote := newFakeRedis()
+	remote.fails = true
+
+	c, _ := New[int]("fail", 10, time.Minute, WithRedis[int](remote))
+	if err := c.Set(ctx, "k", 42, time.Minute); err == nil {
+		t.Error("expected Redis set error")
+	}
+
+	// Local tier still serves value
+	if value, found := c.Get(ctx, "k"); !found || value != 42 {
+		t.Errorf("expected local hit, got %d found=%v", value, found)
+	}
+
+	// Redis failure is a miss
+	if _, found := c.Get(ctx, "other"); found {
+		t.Error("expected miss on Redis failure")
+	}
+}
+
+func TestTwoTierLocalOnlyAndCodec(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := New[int]("local", 2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.HasRemote() {
+		t.Error("expected local only cache")
+	}
+	for i := 0; i < 3; i++ {
+		_ = c.Set(ctx, strconv.Itoa(i), i, 0)
+	}
+	if _, found := c.Get(ctx, "0"); found {
+		t.Error("expected oldest entry evicted")
+	}
+	if value, found := c.Get(ctx, "2"); !found || value != 2 {
+		t.Errorf("expected hit, got %d found=%v", value, found)
+	}
+
+	// Custom codec
+	remote := newFakeRedis()
+	codec := Codec[int]{
+		Marshal:   func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil },
+		Unmarshal: func(data []byte) (int, error) { return strconv.Atoi(string(data)) },
+	}
+	c, _ = New[int]("codec", 2, time.Minute, WithRedis[int](remote), WithCodec(codec))
+	_ = c.Set(ctx, "k", 7, time.Minute)
+	if remote.data["tt:codec:k"] != "7" {
+		t.Errorf("expected custom codec output, got %q", remote.data["tt:codec:k"])
+	}
+
+	if _, err := New[int]("", 2, 0); err == nil {
+		t.Error("expected error for empty name")
+	}
+}
@@ -0,0 +1,82 @@
+package influxdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// pointBuffer is implemented by clients supporting buffered writes (v2-oss)
+type pointBuffer interface {
+	StartWriteBuffer(opts v2oss.BufferOptions) error
+	StopWriteBuffer() error
+	EnqueuePoint(bucket string, point interface{}) error
+	WriteBufferStats() (v2oss.BufferStats, bool)
+}
+
+// StartWriteBuffer enables buffered writes from influxdb.write_buffer (no-op when disabled).
+// Only long running writers (worker) should start it; buffered points are flushed by StopWriteBuffer or Close.
+func StartWriteBuffer() error {
+	cfg := config.Get()
+	if cfg == nil || !cfg.InfluxDB.WriteBuffer.Enabled {
+		return nil
+	}
+	if currentClient == nil {
+		return fmt.Errorf("InfluxDB client not initialized")
+	}
+
+	buffer, ok := currentClient.(pointBuffer)
+	if !ok {
+		logger.Warn().Str("version", string(GetConfig().Version)).Msg("Write buffer not supported, writing points immediately")
+		return nil
+	}
+
+	bufferConfig := cfg.InfluxDB.WriteBuffer
+	opts := v2oss.BufferOptions{BatchSize: bufferConfig.BatchSize, MaxBuffered: bufferConfig.MaxBuffered}
+	if bufferConfig.FlushInterval != "" {
+		interval, err := time.ParseDuration(bufferConfig.FlushInterval)
+		if err != nil {
+			return fmt.Errorf("invalid influxdb.write_buffer.flush_interval: %w", err)
+		}
+		opts.FlushInterval = interval
+	}
+	return buffer.StartWriteBuffer(opts)
+}
+
+// StopWriteBuffer flushes buffered points and disables buffering
+func StopWriteBuffer() error {
+	if buffer, ok := currentClient.(pointBuffer); ok {
+		return buffer.StopWriteBuffer()
+	}
+	return nil
+}
+
+// EnqueuePoint buffers point for configured bucket (written immediately when buffer not started)
+func EnqueuePoint(point interface{}) error {
+	return EnqueuePointToBucket("", point)
+}
+
+// EnqueuePointToBucket buffers point for bucket (empty = configured bucket), written immediately when buffer
+// not started or not supported. Returns error when buffer is full so job can be retried.
+func EnqueuePointToBucket(bucket string, point interface{}) error {
+	if currentClient == nil {
+		logger.Error().Msg("InfluxDB client not initialized")
+		return fmt.Errorf("InfluxDB client not initialized")
+	}
+
+	if buffer, ok := currentClient.(pointBuffer); ok {
+		return buffer.EnqueuePoint(bucket, point)
+	}
+	return WritePointToBucket(bucket, point)
+}
+
+// WriteBufferStats returns write buffer activity, false when buffering is not active
+func WriteBufferStats() (v2oss.BufferStats, bool) {
+	if buffer, ok := currentClient.(pointBuffer); ok {
+		return buffer.WriteBufferStats()
+	}
+	return v2oss.BufferStats{}, false
+}
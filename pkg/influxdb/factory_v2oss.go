@@ -3,7 +3,9 @@ package influxdb
 import (
 	"time"
 	
+	"github.com/benedict-erwin/insight-collector/config"
 	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
 )
 
 // createV2OSSClient creates a new v2-oss client instance
@@ -11,9 +13,30 @@ func createV2OSSClient() Client {
 	client := &v2oss.Client{}
 	cfg := GetConfig()
 	client.SetConfig(cfg.URL, cfg.Token, cfg.Org, cfg.Bucket)
+	client.SetWriteRetry(writeRetryOptions())
 	return client
 }
 
+// writeRetryOptions returns write retry from influxdb.write_retry (zero values use client defaults)
+func writeRetryOptions() v2oss.RetryOptions {
+	cfg := config.Get()
+	if cfg == nil {
+		return v2oss.RetryOptions{}
+	}
+
+	retryConfig := cfg.InfluxDB.WriteRetry
+	opts := v2oss.RetryOptions{MaxAttempts: retryConfig.MaxAttempts}
+	if retryConfig.BaseDelay != "" {
+		delay, err := time.ParseDuration(retryConfig.BaseDelay)
+		if err != nil {
+			logger.Warn().Str("base_delay", retryConfig.BaseDelay).Msg("Invalid influxdb.write_retry.base_delay, using default")
+		} else {
+			opts.BaseDelay = delay
+		}
+	}
+	return opts
+}
+
 // createV2OSSPoint creates a new v2-oss Point
 func createV2OSSPoint(measurement string, tags map[string]string, fields map[string]interface{}, timestamp time.Time) interface{} {
 	return v2oss.NewPoint(measurement, tags, fields, timestamp)
@@ -124,6 +124,92 @@ func WritePoints(points []interface{}) error {
 	return currentClient.WritePoints(points)
 }
 
+// bucketWriter is implemented by clients able to write outside configured bucket
+type bucketWriter interface {
+	WritePointToBucket(bucket string, point interface{}) error
+	WritePointsToBucket(bucket string, points []interface{}) error
+}
+
+// WritePointToBucket writes single point to given bucket (empty or configured bucket uses WritePoint)
+func WritePointToBucket(bucket string, point interface{}) error {
+	if currentClient == nil {
+		logger.Error().Msg("InfluxDB client not initialized")
+		return fmt.Errorf("InfluxDB client not initialized")
+	}
+	if bucket == "" || bucket == GetConfig().Bucket {
+		return currentClient.WritePoint(point)
+	}
+
+	writer, ok := currentClient.(bucketWriter)
+	if !ok {
+		return fmt.Errorf("bucket override not supported by InfluxDB %s", GetConfig().Version)
+	}
+	return writer.WritePointToBucket(bucket, point)
+}
+
+// WritePointsToBucket writes points in batch to given bucket (empty or configured bucket uses WritePoints)
+func WritePointsToBucket(bucket string, points []interface{}) error {
+	if currentClient == nil {
+		logger.Error().Msg("InfluxDB client not initialized")
+		return fmt.Errorf("InfluxDB client not initialized")
+	}
+	if bucket == "" || bucket == GetConfig().Bucket {
+		return currentClient.WritePoints(points)
+	}
+
+	writer, ok := currentClient.(bucketWriter)
+	if !ok {
+		return fmt.Errorf("bucket override not supported by InfluxDB %s", GetConfig().Version)
+	}
+	return writer.WritePointsToBucket(bucket, points)
+}
+
+// MeasurementBucket returns bucket override of measurement from influxdb.buckets (empty = configured bucket)
+func MeasurementBucket(measurement string) string {
+	cfg := config.Get()
+	if cfg == nil {
+		return ""
+	}
+	return cfg.InfluxDB.Buckets[measurement]
+}
+
+// predicateDeleter is implemented by clients supporting delete API
+type predicateDeleter interface {
+	DeleteByPredicate(bucket, measurement string, start, stop time.Time, predicate string) error
+}
+
+// DeleteByPredicate deletes points of measurement within time range matching predicate (tags only), measurement bucket is resolved from config
+func DeleteByPredicate(measurement string, start, stop time.Time, predicate string) error {
+	if currentClient == nil {
+		logger.Error().Msg("InfluxDB client not initialized")
+		return fmt.Errorf("InfluxDB client not initialized")
+	}
+
+	deleter, ok := currentClient.(predicateDeleter)
+	if !ok {
+		return fmt.Errorf("delete by predicate not supported by InfluxDB %s", GetConfig().Version)
+	}
+	return deleter.DeleteByPredicate(MeasurementBucket(measurement), measurement, start, stop, predicate)
+}
+
+// retentionReporter is implemented by clients able to read bucket retention
+type retentionReporter interface {
+	BucketRetention(bucket string) (time.Duration, error)
+}
+
+// BucketRetention returns retention of bucket (configured bucket when empty), 0 means data never expires
+func BucketRetention(bucket string) (time.Duration, error) {
+	if currentClient == nil {
+		return 0, fmt.Errorf("InfluxDB client not initialized")
+	}
+
+	reporter, ok := currentClient.(retentionReporter)
+	if !ok {
+		return 0, fmt.Errorf("bucket retention not supported by InfluxDB %s", GetConfig().Version)
+	}
+	return reporter.BucketRetention(bucket)
+}
+
 // Query executes a query and returns results as an iterator
 func Query(query string) (QueryIterator, error) {
 	if currentClient == nil {
@@ -0,0 +1,181 @@
+package v2oss
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fluxDurationRegex matches Flux duration literals (e.g. 30m, 1h, 1h30m, 7d)
+var fluxDurationRegex = regexp.MustCompile(`^([0-9]+(ns|us|µs|ms|s|m|h|d|w|mo|y))+$`)
+
+// BuildAggregateQuery constructs Flux query counting records grouped by tag columns.
+// When req.Window is set counts are bucketed per window (time-series), otherwise one count per group.
+func (qb *QueryBuilder) BuildAggregateQuery(req *PaginationRequest, groupBy []string, bucket string) (string, error) {
+	if err := qb.validateAggregateRequest(req, groupBy); err != nil {
+		return "", err
+	}
+
+	// Validate bucket parameter
+	if bucket == "" {
+		return "", fmt.Errorf("bucket parameter is required")
+	}
+
+	// Build time range filter
+	timeRange, err := qb.buildTimeRange(req.Range)
+	if err != nil {
+		return "", fmt.Errorf("invalid time range: %w", err)
+	}
+
+	// Build dynamic filters (no cursor for aggregation)
+	tagFilters, fieldFilters := qb.buildFilters(req.Filters)
+
+	// Count CountField per record, pivot only when field filters are present
+	var source string
+	if fieldFilters != "" {
+		keepColumns := append([]string{"_time", qb.config.CountField}, groupBy...)
+		source = fmt.Sprintf(`%s
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")%s
+  |> keep(columns: [%s])
+  |> rename(columns: {"%s": "_value"})`,
+			tagFilters,
+			fieldFilters,
+			quoteColumns(keepColumns),
+			qb.config.CountField,
+		)
+	} else {
+		source = fmt.Sprintf(`
+  |> filter(fn: (r) => r["_field"] == "%s")%s`,
+			qb.config.CountField,
+			tagFilters,
+		)
+	}
+
+	// Aggregation: windowed time-series or single count per group
+	var aggregation string
+	if req.Window != "" {
+		aggregation = fmt.Sprintf(`
+  |> aggregateWindow(every: %s, fn: count, createEmpty: false)
+  |> group()
+  |> sort(columns: ["_time"])`, req.Window)
+	} else {
+		aggregation = `
+  |> count()
+  |> group()
+  |> sort(columns: ["_value"], desc: true)`
+	}
+
+	query := fmt.Sprintf(`from(bucket: "%s")
+  |> range(%s)
+  |> filter(fn: (r) => r["_measurement"] == "%s")%s
+  |> group(columns: [%s])%s`,
+		bucket, // Use provided bucket parameter
+		timeRange,
+		qb.config.Measurement,
+		source,
+		quoteColumns(groupBy),
+		aggregation,
+	)
+
+	return qb.fluxImports(req.Filters) + strings.TrimSpace(query), nil
+}
+
+// ExecuteAggregateQuery builds and executes aggregate query, returns group keys with "count" (and "time" when windowed)
+func (qb *QueryBuilder) ExecuteAggregateQuery(req *PaginationRequest, groupBy []string, client *Client) ([]map[string]interface{}, error) {
+	// Build query
+	bucket := qb.bucket(client)
+	query, err := qb.BuildAggregateQuery(req, groupBy, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute query
+	result, err := client.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, ok := result.(*QueryIterator)
+	if !ok || iterator == nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	defer func() { _ = iterator.Close() }()
+
+	// Parse results (group keys + count)
+	results := []map[string]interface{}{}
+	for iterator.Next() {
+		record := iterator.Record()
+		if record == nil {
+			continue
+		}
+
+		row := make(map[string]interface{}, len(groupBy)+2)
+		for _, key := range groupBy {
+			row[key] = record[key]
+		}
+		row["count"] = record["_value"]
+		if req.Window != "" {
+			row["time"] = record["_time"]
+		}
+		results = append(results, row)
+	}
+
+	// Check for iterator errors
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// validateAggregateRequest validates group by columns, window duration, filters and date range
+func (qb *QueryBuilder) validateAggregateRequest(req *PaginationRequest, groupBy []string) error {
+	// Group by tags only (prevents injection & high cardinality grouping)
+	seen := make(map[string]bool, len(groupBy))
+	for _, key := range groupBy {
+		if !qb.config.ValidTags[key] {
+			return fmt.Errorf("invalid group by column '%s', only tags are allowed", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate group by column '%s'", key)
+		}
+		seen[key] = true
+	}
+
+	// Validate window duration if provided
+	if req.Window != "" && !IsValidFluxDuration(req.Window) {
+		return fmt.Errorf("invalid window '%s', expected duration like 5m, 1h, 1d", req.Window)
+	}
+
+	// Validate filter operators
+	if err := qb.validateFilters(req.Filters); err != nil {
+		return err
+	}
+
+	// Validate date range format if provided
+	if _, err := qb.buildTimeRange(req.Range); err != nil {
+		return fmt.Errorf("invalid time range: %w", err)
+	}
+
+	return nil
+}
+
+// IsValidFluxDuration checks if value is valid non-zero Flux duration literal
+func IsValidFluxDuration(value string) bool {
+	return fluxDurationRegex.MatchString(value) && strings.ContainsAny(value, "123456789")
+}
+
+// quoteColumns builds quoted column list for Flux arrays
+func quoteColumns(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, col := range columns {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, col))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// ValidateAggregate validates group by tags, filters and date range of aggregate request
+func (qb *QueryBuilder) ValidateAggregate(req *PaginationRequest, groupBy []string) error {
+	return qb.validateAggregateRequest(req, groupBy)
+}
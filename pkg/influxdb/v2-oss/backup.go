@@ -0,0 +1,119 @@
+package v2oss
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BackupRecord is single point of JSON lines backup (influx export/import)
+type BackupRecord struct {
+	Measurement string                 `json:"measurement"`
+	Time        time.Time              `json:"time"` // RFC3339Nano, full precision kept
+	Tags        map[string]string      `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+	IntFields   []string               `json:"int_fields,omitempty"` // JSON numbers lose int/float type, restored on decode
+}
+
+// NewBackupRecord splits pivoted query record into tags & fields.
+// tagKeys lists measurement tag columns, other non-internal columns are fields (null fields are dropped).
+func NewBackupRecord(record map[string]interface{}, tagKeys map[string]bool) (*BackupRecord, error) {
+	measurement, _ := record["_measurement"].(string)
+	if measurement == "" {
+		return nil, fmt.Errorf("record has no _measurement")
+	}
+
+	var timestamp time.Time
+	switch v := record["_time"].(type) {
+	case time.Time:
+		timestamp = v
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid _time: %w", err)
+		}
+		timestamp = parsed
+	default:
+		return nil, fmt.Errorf("record has no _time")
+	}
+
+	backup := &BackupRecord{
+		Measurement: measurement,
+		Time:        timestamp,
+		Tags:        make(map[string]string),
+		Fields:      make(map[string]interface{}),
+	}
+
+	for key, value := range record {
+		if key == "_measurement" || key == "_time" || value == nil {
+			continue
+		}
+
+		if tagKeys[key] {
+			backup.Tags[key] = fmt.Sprint(value)
+			continue
+		}
+
+		switch v := value.(type) {
+		case int64, int, int32:
+			backup.IntFields = append(backup.IntFields, key)
+		case uint64:
+			// Stored back as signed integer field
+			backup.IntFields = append(backup.IntFields, key)
+			value = int64(v)
+		case float64, bool, string:
+		default:
+			return nil, fmt.Errorf("unsupported type %T for field %s", value, key)
+		}
+		backup.Fields[key] = value
+	}
+
+	sort.Strings(backup.IntFields)
+	return backup, nil
+}
+
+// DecodeBackupRecord parses JSON line of backup, restoring integer fields as int64 & others as float64
+func DecodeBackupRecord(line []byte) (*BackupRecord, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	var backup BackupRecord
+	if err := decoder.Decode(&backup); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if backup.Measurement == "" {
+		return nil, fmt.Errorf("measurement is required")
+	}
+	if backup.Time.IsZero() {
+		return nil, fmt.Errorf("time is required")
+	}
+	if len(backup.Fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+
+	intFields := make(map[string]bool, len(backup.IntFields))
+	for _, key := range backup.IntFields {
+		intFields[key] = true
+	}
+
+	for key, value := range backup.Fields {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+
+		var err error
+		if intFields[key] {
+			backup.Fields[key], err = number.Int64()
+		} else {
+			backup.Fields[key], err = number.Float64()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid number for field %s: %w", key, err)
+		}
+	}
+
+	return &backup, nil
+}
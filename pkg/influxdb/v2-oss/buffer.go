@@ -0,0 +1,216 @@
+package v2oss
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// Write buffer defaults (influxdb.write_buffer)
+const (
+	DefaultBufferBatchSize     = 500
+	DefaultBufferFlushInterval = time.Second
+)
+
+// ErrBufferFull is returned when buffer holds max_buffered points (e.g. InfluxDB down), caller should retry later
+var ErrBufferFull = errors.New("influxdb write buffer full")
+
+// ErrBufferClosed is returned when enqueueing after buffer was closed
+var ErrBufferClosed = errors.New("influxdb write buffer closed")
+
+// BufferOptions configures write buffer flush triggers
+type BufferOptions struct {
+	BatchSize     int           // Flush when this many points are buffered
+	FlushInterval time.Duration // Flush buffered points at least this often
+	MaxBuffered   int           // Reject new points above this (failed flushes are kept), default 10x batch size
+}
+
+// BufferStats reports write buffer activity
+type BufferStats struct {
+	Buffered      int       `json:"buffered"`       // Points waiting for flush (including flush in progress)
+	Flushes       int64     `json:"flushes"`        // Successful bucket writes
+	FlushedPoints int64     `json:"flushed_points"` // Points written by flushes
+	FailedFlushes int64     `json:"failed_flushes"` // Bucket writes failed (retryable failures keep points for next flush)
+	DroppedPoints int64     `json:"dropped_points"` // Points of batches rejected by InfluxDB with non-retryable error
+	Rejected      int64     `json:"rejected"`       // Points rejected because buffer was full
+	LastFlushAt   time.Time `json:"last_flush_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// WriteBuffer accumulates points per bucket and writes them in batches on size or interval.
+// Points have explicit timestamps, so flush order between batches doesn't matter.
+// Delivery is at-most-once: callers treat buffered point as stored, points still buffered at crash are lost.
+type WriteBuffer struct {
+	opts       BufferOptions
+	writeBatch func(bucket string, points []*write.Point) error
+
+	mu      sync.Mutex
+	pending map[string][]*write.Point // Points per bucket
+	count   int                       // Buffered points including flush in progress (bounded by MaxBuffered)
+	closed  bool
+	stats   BufferStats
+
+	flushMu sync.Mutex // Serializes flushes (interval, size trigger, manual)
+	trigger chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewWriteBuffer starts buffer flushing points with writeBatch (non-positive options use defaults)
+func NewWriteBuffer(opts BufferOptions, writeBatch func(bucket string, points []*write.Point) error) *WriteBuffer {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBufferBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultBufferFlushInterval
+	}
+	if opts.MaxBuffered < opts.BatchSize {
+		opts.MaxBuffered = opts.BatchSize * 10
+	}
+
+	b := &WriteBuffer{
+		opts:       opts,
+		writeBatch: writeBatch,
+		pending:    make(map[string][]*write.Point),
+		trigger:    make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Add buffers point for bucket, returns ErrBufferFull when max_buffered reached
+func (b *WriteBuffer) Add(bucket string, point *write.Point) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBufferClosed
+	}
+	if b.count >= b.opts.MaxBuffered {
+		b.stats.Rejected++
+		b.mu.Unlock()
+		return ErrBufferFull
+	}
+
+	b.pending[bucket] = append(b.pending[bucket], point)
+	b.count++
+	full := b.count >= b.opts.BatchSize
+	b.mu.Unlock()
+
+	// Size trigger (non-blocking, pending trigger already covers this point)
+	if full {
+		select {
+		case b.trigger <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush writes all buffered points. Batches failing with retryable error are kept for next flush,
+// batches rejected by InfluxDB (e.g. malformed point, field type conflict) are dropped so they can't block bucket.
+func (b *WriteBuffer) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	batches := b.pending
+	b.pending = make(map[string][]*write.Point)
+	b.mu.Unlock()
+
+	var errs []error
+	for bucket, points := range batches {
+		err := b.writeBatch(bucket, points)
+
+		retryable := err != nil && IsRetryableWriteError(err)
+
+		b.mu.Lock()
+		switch {
+		case retryable:
+			// Keep failed points ahead of newer ones
+			b.pending[bucket] = append(points, b.pending[bucket]...)
+			b.stats.FailedFlushes++
+			b.stats.LastError = err.Error()
+		case err != nil:
+			b.count -= len(points)
+			b.stats.FailedFlushes++
+			b.stats.DroppedPoints += int64(len(points))
+			b.stats.LastError = err.Error()
+		default:
+			b.count -= len(points)
+			b.stats.Flushes++
+			b.stats.FlushedPoints += int64(len(points))
+			b.stats.LastFlushAt = time.Now()
+		}
+		b.mu.Unlock()
+
+		if err != nil {
+			message := "Failed to flush buffered points, keeping them for next flush"
+			if !retryable {
+				message = "Buffered points rejected by InfluxDB, dropping batch"
+			}
+			logger.WithScope("InfluxWriteBuffer").Error().
+				Err(err).
+				Str("bucket", bucket).
+				Int("points", len(points)).
+				Msg(message)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops interval flushing and flushes remaining points (new points are rejected)
+func (b *WriteBuffer) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.stop)
+	<-b.done
+
+	if err := b.Flush(); err != nil {
+		stats := b.Stats()
+		logger.WithScope("InfluxWriteBuffer").Error().
+			Err(err).
+			Int("lost_points", stats.Buffered).
+			Msg("Final flush failed, buffered points lost")
+		return err
+	}
+	return nil
+}
+
+// Stats returns snapshot of buffer activity
+func (b *WriteBuffer) Stats() BufferStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := b.stats
+	stats.Buffered = b.count
+	return stats
+}
+
+// run flushes on interval or size trigger until closed
+func (b *WriteBuffer) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+		case <-b.trigger:
+		}
+		_ = b.Flush() // Errors logged, points kept
+	}
+}
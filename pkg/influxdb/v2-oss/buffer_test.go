@@ -0,0 +1,207 @@
+package v2oss
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	influxhttp "github.com/influxdata/influxdb-client-go/v2/api/http"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// fakeBatchWriter records flushed batches per bucket, failing while failErr is set
+type fakeBatchWriter struct {
+	mu      sync.Mutex
+	failErr error // Returned by write while set
+	batches map[string][]int
+	written chan struct{}
+}
+
+func newFakeBatchWriter() *fakeBatchWriter {
+	return &fakeBatchWriter{batches: map[string][]int{}, written: make(chan struct{}, 100)}
+}
+
+func (f *fakeBatchWriter) write(bucket string, points []*write.Point) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failErr != nil {
+		return f.failErr
+	}
+	f.batches[bucket] = append(f.batches[bucket], len(points))
+	f.written <- struct{}{}
+	return nil
+}
+
+// setFail makes writes fail with retryable error (InfluxDB unavailable)
+func (f *fakeBatchWriter) setFail(fail bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failErr = nil
+	if fail {
+		f.failErr = &influxhttp.Error{StatusCode: 503, Message: "influxdb unavailable"}
+	}
+}
+
+// setFailErr makes writes fail with err
+func (f *fakeBatchWriter) setFailErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failErr = err
+}
+
+func (f *fakeBatchWriter) batchSizes(bucket string) []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]int(nil), f.batches[bucket]...)
+}
+
+func testPoint() *write.Point {
+	return write.NewPoint("transaction_events", map[string]string{"status": "ok"}, map[string]interface{}{"amount": 1.0}, time.Now())
+}
+
+func waitWritten(t *testing.T, f *fakeBatchWriter) {
+	t.Helper()
+	select {
+	case <-f.written:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected buffered points to be flushed")
+	}
+}
+
+func TestWriteBufferFlushesOnBatchSize(t *testing.T) {
+	writer := newFakeBatchWriter()
+	buffer := NewWriteBuffer(BufferOptions{BatchSize: 3, FlushInterval: time.Hour}, writer.write)
+	defer buffer.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := buffer.Add("events", testPoint()); err != nil {
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+	waitWritten(t, writer)
+	_ = buffer.Flush() // Waits for triggered flush to finish
+
+	if got := writer.batchSizes("events"); len(got) != 1 || got[0] != 3 {
+		t.Errorf("expected single batch of 3 points, got %v", got)
+	}
+	if stats := buffer.Stats(); stats.FlushedPoints != 3 || stats.Buffered != 0 {
+		t.Errorf("unexpected stats after size flush: %+v", stats)
+	}
+}
+
+func TestWriteBufferFlushesOnInterval(t *testing.T) {
+	writer := newFakeBatchWriter()
+	buffer := NewWriteBuffer(BufferOptions{BatchSize: 100, FlushInterval: 20 * time.Millisecond}, writer.write)
+	defer buffer.Close()
+
+	if err := buffer.Add("events", testPoint()); err != nil {
+		t.Fatalf("unexpected add error: %v", err)
+	}
+	waitWritten(t, writer)
+
+	if got := writer.batchSizes("events"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected interval flush of 1 point, got %v", got)
+	}
+}
+
+func TestWriteBufferKeepsPointsOnFailedFlush(t *testing.T) {
+	writer := newFakeBatchWriter()
+	writer.setFail(true)
+	buffer := NewWriteBuffer(BufferOptions{BatchSize: 2, FlushInterval: time.Hour, MaxBuffered: 4}, writer.write)
+	defer buffer.Close()
+
+	for _, bucket := range []string{"events", "events", "audit"} {
+		if err := buffer.Add(bucket, testPoint()); err != nil {
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+	if err := buffer.Flush(); err == nil {
+		t.Fatal("expected flush error while InfluxDB is down")
+	}
+
+	stats := buffer.Stats()
+	if stats.Buffered != 3 || stats.FailedFlushes < 2 || stats.LastError == "" {
+		t.Errorf("expected failed points kept, got %+v", stats)
+	}
+
+	// Full buffer rejects new points so caller (job) can retry
+	if err := buffer.Add("events", testPoint()); err != nil {
+		t.Fatalf("unexpected add error below max: %v", err)
+	}
+	if err := buffer.Add("events", testPoint()); !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("expected ErrBufferFull, got %v", err)
+	}
+	if buffer.Stats().Rejected != 1 {
+		t.Errorf("expected 1 rejected point, got %d", buffer.Stats().Rejected)
+	}
+
+	// Recovered: kept points written with newer ones
+	writer.setFail(false)
+	if err := buffer.Flush(); err != nil {
+		t.Fatalf("unexpected flush error after recovery: %v", err)
+	}
+	if got := writer.batchSizes("events"); len(got) != 1 || got[0] != 3 {
+		t.Errorf("expected events batch of 3 points, got %v", got)
+	}
+	if got := writer.batchSizes("audit"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected audit batch of 1 point, got %v", got)
+	}
+}
+
+func TestWriteBufferDropsNonRetryableBatch(t *testing.T) {
+	writer := newFakeBatchWriter()
+	writer.setFailErr(&influxhttp.Error{StatusCode: 422, Code: "unprocessable entity", Message: "field type conflict"})
+	buffer := NewWriteBuffer(BufferOptions{BatchSize: 2, FlushInterval: time.Hour, MaxBuffered: 2}, writer.write)
+	defer buffer.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := buffer.Add("events", testPoint()); err != nil {
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+	if err := buffer.Flush(); err == nil {
+		t.Fatal("expected flush error for rejected batch")
+	}
+
+	stats := buffer.Stats()
+	if stats.Buffered != 0 || stats.DroppedPoints != 2 || stats.FailedFlushes != 1 {
+		t.Errorf("expected rejected batch dropped, got %+v", stats)
+	}
+
+	// Dropped batch must not block bucket or fill buffer
+	writer.setFailErr(nil)
+	if err := buffer.Add("events", testPoint()); err != nil {
+		t.Fatalf("unexpected add error after dropped batch: %v", err)
+	}
+	if err := buffer.Flush(); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+	if got := writer.batchSizes("events"); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected only new point written, got %v", got)
+	}
+}
+
+func TestWriteBufferCloseFlushesRemainingPoints(t *testing.T) {
+	writer := newFakeBatchWriter()
+	buffer := NewWriteBuffer(BufferOptions{BatchSize: 100, FlushInterval: time.Hour}, writer.write)
+
+	for i := 0; i < 5; i++ {
+		if err := buffer.Add("events", testPoint()); err != nil {
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+	if err := buffer.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+
+	if got := writer.batchSizes("events"); len(got) != 1 || got[0] != 5 {
+		t.Errorf("expected remaining 5 points flushed on close, got %v", got)
+	}
+	if err := buffer.Add("events", testPoint()); !errors.Is(err, ErrBufferClosed) {
+		t.Errorf("expected ErrBufferClosed after close, got %v", err)
+	}
+	if err := buffer.Close(); err != nil {
+		t.Errorf("expected second close to be no-op, got %v", err)
+	}
+}
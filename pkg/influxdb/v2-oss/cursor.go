@@ -0,0 +1,223 @@
+package v2oss
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PageCursor is composite pagination cursor encoded as opaque token.
+// When sorting by _time, ID (tiebreaker column value) orders records sharing same timestamp.
+// When sorting by other column, Value decides page boundary and _time breaks ties.
+type PageCursor struct {
+	Value interface{} `json:"v,omitempty"`  // Sort column value of boundary record (string or number)
+	Time  string      `json:"t"`            // RFC3339Nano _time of boundary record
+	ID    string      `json:"id,omitempty"` // Tiebreaker column value of boundary record
+}
+
+// EncodeCursor encodes composite cursor as opaque URL safe string
+func EncodeCursor(cursor PageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes opaque composite cursor string
+func DecodeCursor(encoded string) (PageCursor, error) {
+	var cursor PageCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, cursor.Time); err != nil {
+		return cursor, fmt.Errorf("invalid cursor time, expected RFC3339 timestamp: %w", err)
+	}
+
+	return cursor, nil
+}
+
+// parseTimeCursor parses cursor of time sorted pages: plain RFC3339 timestamp (legacy) or composite (_time, id) token
+func parseTimeCursor(cursor string) (string, string, error) {
+	if _, err := time.Parse(time.RFC3339, cursor); err == nil {
+		return cursor, "", nil
+	}
+
+	decoded, err := DecodeCursor(cursor)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid cursor format, expected RFC3339 timestamp or cursor token: %w", err)
+	}
+	if decoded.ID == "" || decoded.Value != nil {
+		return "", "", fmt.Errorf("invalid cursor format, expected (_time, id) cursor token")
+	}
+
+	return decoded.Time, decoded.ID, nil
+}
+
+// tiebreakColumn returns unique column used to order records sharing same timestamp (CountField)
+func (qb *QueryBuilder) tiebreakColumn() string {
+	return qb.config.CountField
+}
+
+// buildTimeCursorTiebreak constructs exact (_time, id) cursor filter applied after pivot.
+// Records with equal time pass only when their id sorts past cursor id, so ties are never skipped or repeated.
+func (qb *QueryBuilder) buildTimeCursorTiebreak(req *PaginationRequest) string {
+	if req.Cursor == nil || *req.Cursor == "" || qb.sortColumn(req) != "" {
+		return ""
+	}
+
+	cursorTime, cursorID, err := parseTimeCursor(*req.Cursor)
+	if err != nil || cursorID == "" || qb.tiebreakColumn() == "" {
+		return "" // Legacy timestamp cursor handled before pivot
+	}
+
+	operator := ">"
+	if qb.sortDescending(req) {
+		operator = "<"
+	}
+
+	return fmt.Sprintf("\n  |> filter(fn: (r) => r._time %s time(v: \"%s\") or (r._time == time(v: \"%s\") and r[\"%s\"] %s \"%s\"))",
+		operator, cursorTime, cursorTime, qb.tiebreakColumn(), operator, escapeFluxString(cursorID))
+}
+
+// timeCursorFromRecord builds composite (_time, id) cursor from record
+func (qb *QueryBuilder) timeCursorFromRecord(record map[string]interface{}) *string {
+	column := qb.tiebreakColumn()
+	id, ok := record[column].(string)
+	if column == "" || !ok || id == "" {
+		return nil
+	}
+
+	var recordTime string
+	switch v := record["_time"].(type) {
+	case time.Time:
+		recordTime = v.Format(time.RFC3339Nano)
+	case string:
+		recordTime = v
+	default:
+		return nil
+	}
+
+	encoded := EncodeCursor(PageCursor{Time: recordTime, ID: id})
+	return &encoded
+}
+
+// sortColumn returns normalized sort column (empty when sorting by _time)
+func (qb *QueryBuilder) sortColumn(req *PaginationRequest) string {
+	column := strings.ToLower(strings.TrimSpace(req.SortBy))
+	if column == "_time" {
+		return ""
+	}
+	return column
+}
+
+// sortDescending returns effective sort order of query.
+// Default (no SortBy) is newest first, prev direction walks backward so order is reversed.
+func (qb *QueryBuilder) sortDescending(req *PaginationRequest) bool {
+	desc := true
+	if req.SortBy != "" {
+		desc = req.SortDesc
+	}
+	if req.Direction == "prev" {
+		return !desc
+	}
+	return desc
+}
+
+// buildSortCursorFilter constructs composite cursor filter (sort value + _time tiebreaker) applied after pivot
+func (qb *QueryBuilder) buildSortCursorFilter(req *PaginationRequest) string {
+	column := qb.sortColumn(req)
+	if column == "" || req.Cursor == nil || *req.Cursor == "" {
+		return ""
+	}
+
+	cursor, err := DecodeCursor(*req.Cursor)
+	if err != nil {
+		return "" // Rejected by ValidateRequest
+	}
+
+	operator := ">"
+	if qb.sortDescending(req) {
+		operator = "<"
+	}
+
+	// Format literal according to value type
+	var literal string
+	switch v := cursor.Value.(type) {
+	case float64:
+		literal = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		literal = fmt.Sprintf(`"%s"`, escapeFluxString(v))
+	default:
+		return "" // Rejected by ValidateRequest
+	}
+
+	return fmt.Sprintf("\n  |> filter(fn: (r) => r[\"%s\"] %s %s or (r[\"%s\"] == %s and r._time %s time(v: \"%s\")))",
+		column, operator, literal, column, literal, operator, cursor.Time)
+}
+
+// validateSort validates sort column and composite cursor format
+func (qb *QueryBuilder) validateSort(req *PaginationRequest) error {
+	column := qb.sortColumn(req)
+	if column == "" {
+		return nil
+	}
+
+	if !qb.config.ValidTags[column] && !qb.config.ValidFields[column] {
+		return fmt.Errorf("invalid sort column '%s'", req.SortBy)
+	}
+
+	if req.Cursor != nil && *req.Cursor != "" {
+		cursor, err := DecodeCursor(*req.Cursor)
+		if err != nil {
+			return fmt.Errorf("invalid cursor for sort column '%s': %w", column, err)
+		}
+		switch cursor.Value.(type) {
+		case string, float64:
+		default:
+			return fmt.Errorf("invalid cursor value for sort column '%s', expected string or number", column)
+		}
+		if _, isNumber := cursor.Value.(float64); isNumber != qb.config.NumericFields[column] {
+			return fmt.Errorf("invalid cursor value type for sort column '%s'", column)
+		}
+	}
+
+	return nil
+}
+
+// sortCursorFromRecord builds composite cursor from record sort value and _time
+func (qb *QueryBuilder) sortCursorFromRecord(column string, record map[string]interface{}) *string {
+	var recordTime string
+	switch v := record["_time"].(type) {
+	case time.Time:
+		recordTime = v.Format(time.RFC3339Nano)
+	case string:
+		recordTime = v
+	default:
+		return nil
+	}
+
+	var value interface{}
+	switch v := record[column].(type) {
+	case string:
+		value = v
+	case float64:
+		value = v
+	case int64:
+		value = float64(v)
+	case uint64:
+		value = float64(v)
+	case int:
+		value = float64(v)
+	default:
+		return nil
+	}
+
+	encoded := EncodeCursor(PageCursor{Value: value, Time: recordTime})
+	return &encoded
+}
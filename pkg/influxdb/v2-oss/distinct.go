@@ -0,0 +1,144 @@
+package v2oss
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BuildDistinctCountQuery constructs Flux query counting distinct values of column (tag or field).
+// Uses same time range and filters as list query so numbers are consistent with filtered lists.
+func (qb *QueryBuilder) BuildDistinctCountQuery(req *PaginationRequest, column, bucket string) (string, error) {
+	if err := qb.validateDistinctRequest(req, column); err != nil {
+		return "", err
+	}
+
+	// Validate bucket parameter
+	if bucket == "" {
+		return "", fmt.Errorf("bucket parameter is required")
+	}
+
+	// Build time range filter
+	timeRange, err := qb.buildTimeRange(req.Range)
+	if err != nil {
+		return "", fmt.Errorf("invalid time range: %w", err)
+	}
+
+	// Build dynamic filters (no cursor for distinct count)
+	tagFilters, fieldFilters := qb.buildFilters(req.Filters)
+
+	// Select one row per record holding column value, pivot only when field filters are present
+	var source, distinctColumn string
+	if fieldFilters != "" {
+		source = fmt.Sprintf(`%s
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")%s
+  |> keep(columns: ["_time", "%s"])`,
+			tagFilters,
+			fieldFilters,
+			column,
+		)
+		distinctColumn = column
+	} else if qb.config.ValidTags[column] {
+		// Tag value exists on every field row, use CountField rows only
+		source = fmt.Sprintf(`
+  |> filter(fn: (r) => r["_field"] == "%s")%s`,
+			qb.config.CountField,
+			tagFilters,
+		)
+		distinctColumn = column
+	} else {
+		source = fmt.Sprintf(`
+  |> filter(fn: (r) => r["_field"] == "%s")%s`,
+			column,
+			tagFilters,
+		)
+		distinctColumn = "_value"
+	}
+
+	query := fmt.Sprintf(`from(bucket: "%s")
+  |> range(%s)
+  |> filter(fn: (r) => r["_measurement"] == "%s")%s
+  |> group()
+  |> distinct(column: "%s")
+  |> count()`,
+		bucket, // Use provided bucket parameter
+		timeRange,
+		qb.config.Measurement,
+		source,
+		distinctColumn,
+	)
+
+	return qb.fluxImports(req.Filters) + strings.TrimSpace(query), nil
+}
+
+// ExecuteDistinctCount builds and executes distinct count query, returns number of distinct column values
+func (qb *QueryBuilder) ExecuteDistinctCount(req *PaginationRequest, column string, client *Client) (int, error) {
+	// Build query
+	bucket := qb.bucket(client)
+	query, err := qb.BuildDistinctCountQuery(req, column, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	// Execute query
+	result, err := client.Query(query)
+	if err != nil {
+		return 0, err
+	}
+
+	iterator, ok := result.(*QueryIterator)
+	if !ok || iterator == nil {
+		return 0, nil
+	}
+
+	defer func() { _ = iterator.Close() }()
+
+	// Parse count result (single row after group())
+	count := 0
+	for iterator.Next() {
+		record := iterator.Record()
+		if record == nil || record["_value"] == nil {
+			continue
+		}
+		switch v := record["_value"].(type) {
+		case int64:
+			count = int(v)
+		case float64:
+			count = int(v)
+		case string:
+			if parsed, parseErr := strconv.Atoi(v); parseErr == nil {
+				count = parsed
+			}
+		}
+	}
+
+	// Check for iterator errors
+	if err := iterator.Err(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// validateDistinctRequest validates distinct column, filters and date range
+func (qb *QueryBuilder) validateDistinctRequest(req *PaginationRequest, column string) error {
+	// Column must be known tag or field (prevents injection)
+	if column == "" {
+		return fmt.Errorf("distinct column is required")
+	}
+	if !qb.config.ValidTags[column] && !qb.config.ValidFields[column] {
+		return fmt.Errorf("invalid distinct column '%s'", column)
+	}
+
+	// Validate filter operators
+	if err := qb.validateFilters(req.Filters); err != nil {
+		return err
+	}
+
+	// Validate date range format if provided
+	if _, err := qb.buildTimeRange(req.Range); err != nil {
+		return fmt.Errorf("invalid time range: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package v2oss
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultRangeDuration is applied when request has no date range (matches buildTimeRange default)
+const defaultRangeDuration = 7 * 24 * time.Hour
+
+// ToPaginationRequest converts export request into pagination request usable by StreamQuery
+func (r *ExportRequest) ToPaginationRequest() *PaginationRequest {
+	return &PaginationRequest{
+		Length:    1, // Not applied when streaming
+		Direction: "next",
+		Filters:   r.Filters,
+		Range:     r.Range,
+		SortBy:    r.SortBy,
+		SortDesc:  r.SortDesc,
+	}
+}
+
+// RangeDuration returns time span covered by date range (start/end dates are inclusive whole days)
+func RangeDuration(dateRange *DateRangeFilter) (time.Duration, error) {
+	if dateRange == nil {
+		return defaultRangeDuration, nil
+	}
+
+	// Relative preset takes precedence over start/end
+	if preset := strings.TrimSpace(dateRange.Preset); preset != "" {
+		if !timeRangePresets[preset] {
+			return 0, fmt.Errorf("invalid range preset '%s'", preset)
+		}
+		return presetDuration(preset), nil
+	}
+
+	// Single day when only one bound is provided
+	if dateRange.Start == "" || dateRange.End == "" {
+		if dateRange.Start == "" && dateRange.End == "" {
+			return defaultRangeDuration, nil
+		}
+		return 24 * time.Hour, nil
+	}
+
+	startTime, err := time.Parse("2006-01-02", dateRange.Start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start date format, expected YYYY-MM-DD: %w", err)
+	}
+	endTime, err := time.Parse("2006-01-02", dateRange.End)
+	if err != nil {
+		return 0, fmt.Errorf("invalid end date format, expected YYYY-MM-DD: %w", err)
+	}
+	if startTime.After(endTime) {
+		return 0, fmt.Errorf("start date cannot be after end date")
+	}
+
+	return endTime.Sub(startTime) + 24*time.Hour, nil
+}
+
+// RangeLabel returns filename friendly label of date range (e.g. last_24h, 2025-01-01_2025-01-31)
+func RangeLabel(dateRange *DateRangeFilter) string {
+	if dateRange == nil {
+		return "last_7d"
+	}
+
+	if preset := strings.TrimSpace(dateRange.Preset); preset != "" {
+		return "last_" + preset
+	}
+
+	switch {
+	case dateRange.Start != "" && dateRange.End != "":
+		return dateRange.Start + "_" + dateRange.End
+	case dateRange.Start != "":
+		return dateRange.Start
+	case dateRange.End != "":
+		return dateRange.End
+	}
+
+	return "last_7d"
+}
@@ -3,11 +3,13 @@ package v2oss
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/influxdata/influxdb-client-go/v2/domain"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
 )
 
@@ -25,6 +27,9 @@ type Client struct {
 	writeAPI api.WriteAPIBlocking
 	queryAPI api.QueryAPI
 	config   *Config
+	buffer   *WriteBuffer // nil unless StartWriteBuffer was called
+	bufferMu sync.RWMutex
+	retry    RetryOptions // Transient write failure retry (zero = defaults)
 }
 
 // Point wraps write.Point
@@ -129,6 +134,11 @@ func (c *Client) SetConfig(url, token, org, bucket string) {
 	}
 }
 
+// SetWriteRetry sets retry of transient write failures (MaxAttempts 1 disables retry)
+func (c *Client) SetWriteRetry(opts RetryOptions) {
+	c.retry = opts
+}
+
 // Init initializes the InfluxDB v2 OSS client
 func (c *Client) Init() error {
 	cfg := c.config
@@ -176,11 +186,8 @@ func (c *Client) WritePoint(point interface{}) error {
 		return fmt.Errorf("invalid point type for v2-oss")
 	}
 
-	// Write single point
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := c.writeAPI.WritePoint(ctx, v2Point); err != nil {
+	// Write single point (transient failures retried)
+	if err := c.writeWithRetry(c.writeAPI, 30*time.Second, v2Point); err != nil {
 		logger.Error().Err(err).Str("measurement", v2Point.Name()).Msg("Failed to write point to InfluxDB v2-oss")
 		return fmt.Errorf("failed to write point: %w", err)
 	}
@@ -205,10 +212,7 @@ func (c *Client) WritePoints(points []interface{}) error {
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := c.writeAPI.WritePoint(ctx, v2Points...); err != nil {
+	if err := c.writeWithRetry(c.writeAPI, 30*time.Second, v2Points...); err != nil {
 		logger.Error().Err(err).Msg("Failed to write points to InfluxDB v2-oss")
 		return fmt.Errorf("failed to write points: %w", err)
 	}
@@ -217,6 +221,210 @@ func (c *Client) WritePoints(points []interface{}) error {
 	return nil
 }
 
+// WritePointToBucket writes single point to bucket other than configured one (e.g. short-retention probe bucket)
+func (c *Client) WritePointToBucket(bucket string, point interface{}) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+
+	p, ok := point.(*Point)
+	if !ok {
+		return fmt.Errorf("invalid point type for v2-oss")
+	}
+
+	if err := c.writeWithRetry(c.client.WriteAPIBlocking(c.config.Org, bucket), 10*time.Second, p.Point); err != nil {
+		return fmt.Errorf("failed to write point to bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// WritePointsToBucket writes points in batch to bucket other than configured one (e.g. per-measurement retention bucket)
+func (c *Client) WritePointsToBucket(bucket string, points []interface{}) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+
+	v2Points := make([]*write.Point, len(points))
+	for i, point := range points {
+		p, ok := point.(*Point)
+		if !ok {
+			return fmt.Errorf("invalid point type for v2-oss")
+		}
+		v2Points[i] = p.Point
+	}
+
+	if err := c.writeWithRetry(c.client.WriteAPIBlocking(c.config.Org, bucket), 30*time.Second, v2Points...); err != nil {
+		return fmt.Errorf("failed to write points to bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// StartWriteBuffer enables buffered writes used by EnqueuePoint (no-op when already started)
+func (c *Client) StartWriteBuffer(opts BufferOptions) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+
+	c.bufferMu.Lock()
+	defer c.bufferMu.Unlock()
+	if c.buffer != nil {
+		return nil
+	}
+
+	c.buffer = NewWriteBuffer(opts, c.writeBatch)
+	logger.Info().
+		Int("batch_size", c.buffer.opts.BatchSize).
+		Dur("flush_interval", c.buffer.opts.FlushInterval).
+		Int("max_buffered", c.buffer.opts.MaxBuffered).
+		Msg("InfluxDB v2-oss write buffer started")
+	return nil
+}
+
+// StopWriteBuffer flushes remaining points and disables buffered writes
+func (c *Client) StopWriteBuffer() error {
+	c.bufferMu.Lock()
+	buffer := c.buffer
+	c.buffer = nil
+	c.bufferMu.Unlock()
+	if buffer == nil {
+		return nil
+	}
+
+	err := buffer.Close()
+	stats := buffer.Stats()
+	logger.Info().
+		Int64("flushes", stats.Flushes).
+		Int64("flushed_points", stats.FlushedPoints).
+		Int64("failed_flushes", stats.FailedFlushes).
+		Int64("dropped_points", stats.DroppedPoints).
+		Int64("rejected", stats.Rejected).
+		Msg("InfluxDB v2-oss write buffer stopped")
+	return err
+}
+
+// EnqueuePoint buffers point for bucket (empty = configured bucket), writes immediately when buffer not started
+func (c *Client) EnqueuePoint(bucket string, point interface{}) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+
+	p, ok := point.(*Point)
+	if !ok {
+		return fmt.Errorf("invalid point type for v2-oss")
+	}
+
+	c.bufferMu.RLock()
+	buffer := c.buffer
+	c.bufferMu.RUnlock()
+	if buffer == nil {
+		if bucket == "" || bucket == c.config.Bucket {
+			return c.WritePoint(point)
+		}
+		return c.WritePointToBucket(bucket, point)
+	}
+
+	if bucket == "" {
+		bucket = c.config.Bucket
+	}
+	return buffer.Add(bucket, p.Point)
+}
+
+// WriteBufferStats returns write buffer activity, false when buffer not started
+func (c *Client) WriteBufferStats() (BufferStats, bool) {
+	c.bufferMu.RLock()
+	buffer := c.buffer
+	c.bufferMu.RUnlock()
+	if buffer == nil {
+		return BufferStats{}, false
+	}
+	return buffer.Stats(), true
+}
+
+// writeWithRetry writes points with fresh timeout per attempt, retrying transient failures with backoff
+func (c *Client) writeWithRetry(writeAPI api.WriteAPIBlocking, timeout time.Duration, points ...*write.Point) error {
+	measurement := ""
+	if len(points) > 0 {
+		measurement = points[0].Name()
+	}
+
+	return retryWrite(c.retry, measurement, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return writeAPI.WritePoint(ctx, points...)
+	})
+}
+
+// writeBatch writes buffered points of single bucket
+func (c *Client) writeBatch(bucket string, points []*write.Point) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+
+	if err := c.writeWithRetry(c.client.WriteAPIBlocking(c.config.Org, bucket), 30*time.Second, points...); err != nil {
+		return fmt.Errorf("failed to write %d buffered points to bucket %s: %w", len(points), bucket, err)
+	}
+
+	logger.Debug().Int("count", len(points)).Str("bucket", bucket).Msg("Buffered points flushed to InfluxDB v2-oss")
+	return nil
+}
+
+// DeleteByPredicate deletes points of measurement within [start, stop] matching optional predicate
+// (delete predicate syntax, tags only: `event_type="login" AND channel="web"`) from bucket (empty = configured bucket)
+func (c *Client) DeleteByPredicate(bucket, measurement string, start, stop time.Time, predicate string) error {
+	if c.client == nil || c.config == nil {
+		return fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+	if measurement == "" {
+		return fmt.Errorf("measurement is required")
+	}
+	if stop.Before(start) {
+		return fmt.Errorf("stop must not be before start")
+	}
+
+	fullPredicate := fmt.Sprintf(`_measurement="%s"`, measurement)
+	if predicate != "" {
+		fullPredicate += " AND " + predicate
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if bucket == "" {
+		bucket = c.config.Bucket
+	}
+
+	if err := c.client.DeleteAPI().DeleteWithName(ctx, c.config.Org, bucket, start, stop, fullPredicate); err != nil {
+		logger.Error().Err(err).Str("measurement", measurement).Str("predicate", fullPredicate).Msg("Failed to delete from InfluxDB v2-oss")
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
+
+// BucketRetention returns expire retention of bucket (configured bucket when empty), 0 means data never expires
+func (c *Client) BucketRetention(bucket string) (time.Duration, error) {
+	if c.client == nil || c.config == nil {
+		return 0, fmt.Errorf("InfluxDB v2-oss client not initialized")
+	}
+	if bucket == "" {
+		bucket = c.config.Bucket
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	found, err := c.client.BucketsAPI().FindBucketByName(ctx, bucket)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find bucket %s: %w", bucket, err)
+	}
+
+	for _, rule := range found.RetentionRules {
+		if rule.Type == nil || *rule.Type == domain.RetentionRuleTypeExpire {
+			return time.Duration(rule.EverySeconds) * time.Second, nil
+		}
+	}
+	return 0, nil
+}
+
 func (c *Client) Query(query string) (interface{}, error) {
 	if c.client == nil || c.queryAPI == nil {
 		logger.Error().Msg("InfluxDB v2-oss client not initialized")
@@ -271,6 +479,9 @@ func (c *Client) HealthCheck() error {
 }
 
 func (c *Client) Close() {
+	// Flush buffered points while client can still write
+	_ = c.StopWriteBuffer()
+
 	if c.client != nil {
 		c.client.Close()
 		logger.Info().Msg("InfluxDB v2-oss client closed")
@@ -2,6 +2,8 @@ package v2oss
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,12 +21,39 @@ func NewQueryBuilder(config QueryBuilderConfig) *QueryBuilder {
 	}
 }
 
+// bucket returns entity bucket override, falls back to client configured bucket
+func (qb *QueryBuilder) bucket(client *Client) string {
+	if qb.config.Bucket != "" {
+		return qb.config.Bucket
+	}
+	return client.config.Bucket
+}
+
 // BuildQuery constructs cursor-based Flux query for true server-side pagination
 func (qb *QueryBuilder) BuildQuery(req *PaginationRequest, bucket string) (string, error) {
 	if err := qb.ValidateRequest(req); err != nil {
 		return "", err
 	}
 
+	// Calculate server-side safety limit
+	limit := 0
+	if req.Cursor == nil || *req.Cursor == "" {
+		// Page 1: Apply safety limit to prevent catastrophic data transfer
+		limit = req.Length * 10 // 10x safety margin for first page
+		if limit < 50 {
+			limit = 50 // Minimum safety buffer
+		}
+		if limit > 1000 {
+			limit = 1000 // Maximum safety cap
+		}
+	}
+	// Page 2+: No safety limit needed (cursor filtering is efficient)
+
+	return qb.buildDataQuery(req, bucket, limit)
+}
+
+// buildDataQuery constructs data query for validated request, limit <= 0 means no server-side limit
+func (qb *QueryBuilder) buildDataQuery(req *PaginationRequest, bucket string, limit int) (string, error) {
 	// Validate bucket parameter
 	if bucket == "" {
 		return "", fmt.Errorf("bucket parameter is required")
@@ -36,53 +65,60 @@ func (qb *QueryBuilder) BuildQuery(req *PaginationRequest, bucket string) (strin
 		return "", fmt.Errorf("invalid time range: %w", err)
 	}
 
-	// Build cursor filter
-	cursorFilter := qb.buildCursorFilter(req.Cursor, req.Direction)
+	// Build cursor filter (time cursor before pivot, tiebreak & sort column cursor after pivot)
+	cursorFilter := qb.buildCursorFilter(req)
+	sortCursorFilter := qb.buildTimeCursorTiebreak(req) + qb.buildSortCursorFilter(req)
 
-	// Build dynamic filters
-	filters := qb.buildFilters(req.Filters)
+	// Build sort columns (_time as tiebreaker for custom sort column, id as tiebreaker for _time)
+	sortColumns := []string{"_time"}
+	if column := qb.sortColumn(req); column != "" {
+		sortColumns = []string{column, "_time"}
+	} else if tiebreak := qb.tiebreakColumn(); tiebreak != "" {
+		sortColumns = []string{"_time", tiebreak}
+	}
 
-	// Build columns selection
-	columns := qb.buildColumns()
+	// Build dynamic filters (tags before pivot, fields after pivot)
+	tagFilters, fieldFilters := qb.buildFilters(req.Filters)
 
-	// Calculate server-side safety limit
+	// Build columns selection (sort column must be kept)
+	columns := qb.buildColumns(sortColumns...)
+
+	// Build server-side safety limit
 	var safetyLimit string
-	if req.Cursor == nil || *req.Cursor == "" {
-		// Page 1: Apply safety limit to prevent catastrophic data transfer
-		limit := req.Length * 10 // 10x safety margin for first page
-		if limit < 50 {
-			limit = 50 // Minimum safety buffer
-		}
-		if limit > 1000 {
-			limit = 1000 // Maximum safety cap
-		}
+	if limit > 0 {
 		safetyLimit = fmt.Sprintf("\n  |> limit(n: %d)", limit)
-	} else {
-		// Page 2+: No safety limit needed (cursor filtering is efficient)
-		safetyLimit = ""
 	}
 
 	// Build complete query with safety limit for Page 1
 	query := fmt.Sprintf(`from(bucket: "%s")
   |> range(%s)
   |> filter(fn: (r) => r["_measurement"] == "%s")%s%s
-  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")%s
-  |> sort(columns: ["_time"], desc: %t)%s`,
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")%s%s%s
+  |> group()
+  |> sort(columns: [%s], desc: %t)%s`,
 		bucket, // Use provided bucket parameter
 		timeRange,
 		qb.config.Measurement,
-		filters,
-		cursorFilter,            // Cursor filtering for Page 2+
-		columns,                 // Keep columns after pivot
-		req.Direction == "next", // Sort direction
-		safetyLimit,             // Safety limit for Page 1 only
+		tagFilters,
+		cursorFilter,              // Cursor filtering for Page 2+
+		fieldFilters,              // Field filters need pivoted columns
+		sortCursorFilter,          // Sort column cursor for Page 2+
+		columns,                   // Keep columns after pivot
+		quoteColumns(sortColumns), // Sort columns
+		qb.sortDescending(req),    // Sort direction
+		safetyLimit,               // Safety limit (Page 1 only)
 	)
 
-	return strings.TrimSpace(query), nil
+	return qb.fluxImports(req.Filters) + strings.TrimSpace(query), nil
 }
 
 // BuildCountQuery constructs query to get total count of records (ignores cursor for total count)
 func (qb *QueryBuilder) BuildCountQuery(req *PaginationRequest, bucket string) (string, error) {
+	// Filters are interpolated into Flux, count query must reject same requests as data query
+	if err := qb.ValidateRequest(req); err != nil {
+		return "", err
+	}
+
 	// Validate bucket parameter
 	if bucket == "" {
 		return "", fmt.Errorf("bucket parameter is required")
@@ -95,7 +131,29 @@ func (qb *QueryBuilder) BuildCountQuery(req *PaginationRequest, bucket string) (
 	}
 
 	// Build dynamic filters (no cursor for total count)
-	filters := qb.buildFilters(req.Filters)
+	tagFilters, fieldFilters := qb.buildFilters(req.Filters)
+
+	// Field filters need pivoted rows, count CountField column after filtering
+	if fieldFilters != "" {
+		query := fmt.Sprintf(`from(bucket: "%s")
+  |> range(%s)
+  |> filter(fn: (r) => r["_measurement"] == "%s")%s
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")%s
+  |> keep(columns: ["_time", "%s"])
+  |> rename(columns: {"%s": "_value"})
+  |> group()
+  |> count()`,
+			bucket, // Use provided bucket parameter
+			timeRange,
+			qb.config.Measurement,
+			tagFilters,
+			fieldFilters,
+			qb.config.CountField,
+			qb.config.CountField,
+		)
+
+		return qb.fluxImports(req.Filters) + strings.TrimSpace(query), nil
+	}
 
 	// Simple count query using CountField
 	query := fmt.Sprintf(`from(bucket: "%s")
@@ -109,7 +167,7 @@ func (qb *QueryBuilder) BuildCountQuery(req *PaginationRequest, bucket string) (
 		timeRange,
 		qb.config.Measurement,
 		qb.config.CountField,
-		filters,
+		tagFilters,
 	)
 
 	return strings.TrimSpace(query), nil
@@ -122,6 +180,14 @@ func (qb *QueryBuilder) buildTimeRange(dateRange *DateRangeFilter) (string, erro
 		return "start: -7d", nil
 	}
 
+	// Relative preset takes precedence over start/end
+	if preset := strings.TrimSpace(dateRange.Preset); preset != "" {
+		if !timeRangePresets[preset] {
+			return "", fmt.Errorf("invalid range preset '%s'", preset)
+		}
+		return fmt.Sprintf("start: -%s", preset), nil
+	}
+
 	// Parse dates
 	var startTime, endTime time.Time
 	var err error
@@ -169,75 +235,236 @@ func (qb *QueryBuilder) buildTimeRange(dateRange *DateRangeFilter) (string, erro
 	return "start: -7d", nil
 }
 
-// buildFilters constructs dynamic filter conditions based on provided filters
-func (qb *QueryBuilder) buildFilters(filters []FilterItem) string {
+// buildFilters constructs dynamic filter conditions based on provided filters.
+// Values of single filter are ORed, separate filters are ANDed.
+// Tag filters are returned separately from field filters, fields only exist as columns after pivot.
+func (qb *QueryBuilder) buildFilters(filters []FilterItem) (string, string) {
 	if len(filters) == 0 {
-		return ""
+		return "", ""
 	}
 
-	var filterConditions []string
+	var tagConditions, fieldConditions []string
 
 	for _, filter := range filters {
 		key := strings.ToLower(strings.TrimSpace(filter.Key))
-		value := strings.TrimSpace(filter.Value)
+		values := filterValues(filter)
 
-		if value == "" {
+		if len(values) == 0 {
 			continue // Skip empty values
 		}
 
-		// Escape quotes in filter values
-		escapedValue := strings.ReplaceAll(value, `"`, `\"`)
+		operator := strings.ToLower(strings.TrimSpace(filter.Operator))
+
+		var comparisons []string
+		for _, value := range values {
+			if matchOperators[operator] {
+				// Partial match for string fields only (rejected by ValidateRequest for tags, numeric & boolean fields)
+				if !qb.isStringColumn(key) {
+					continue
+				}
+				if operator == OperatorRegex {
+					comparisons = append(comparisons,
+						fmt.Sprintf(`r["%s"] =~ /%s/`, key, escapeFluxRegex(value)))
+				} else {
+					comparisons = append(comparisons,
+						fmt.Sprintf(`strings.containsStr(v: r["%s"], substr: "%s")`, key, escapeFluxString(value)))
+				}
+			} else if qb.config.ValidFields[key] && qb.config.BoolFields[key] {
+				// Boolean field comparison (unquoted true/false)
+				flag, err := strconv.ParseBool(value)
+				if err != nil {
+					continue // Rejected by ValidateRequest
+				}
+				comparisons = append(comparisons,
+					fmt.Sprintf(`r["%s"] == %s`, key, strconv.FormatBool(flag)))
+			} else if qb.config.ValidFields[key] && qb.config.NumericFields[key] {
+				// Numeric field comparison (comparison operator, unquoted value)
+				number, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					continue // Rejected by ValidateRequest
+				}
+				comparisons = append(comparisons,
+					fmt.Sprintf(`r["%s"] %s %s`, key, fluxOperator(filter.Operator), strconv.FormatFloat(number, 'f', -1, 64)))
+			} else {
+				// Exact match for tags and string fields (escape backslashes & quotes in filter values)
+				comparisons = append(comparisons,
+					fmt.Sprintf(`r["%s"] == "%s"`, key, escapeFluxString(value)))
+			}
+		}
+
+		if len(comparisons) == 0 {
+			continue
+		}
+		condition := fmt.Sprintf(`filter(fn: (r) => %s)`, strings.Join(comparisons, " or "))
 
 		if qb.config.ValidTags[key] {
-			// Tag-based filter (exact match)
-			filterConditions = append(filterConditions,
-				fmt.Sprintf(`filter(fn: (r) => r["%s"] == "%s")`, key, escapedValue))
-		} else if qb.config.ValidFields[key] {
-			// Field-based filter (exact match for strings)
-			filterConditions = append(filterConditions,
-				fmt.Sprintf(`filter(fn: (r) => r["%s"] == "%s")`, key, escapedValue))
+			// Tag-based filter
+			tagConditions = append(tagConditions, condition)
+		} else if qb.config.ValidFields[key] || qb.isDynamicKey(key) {
+			// Field-based filter (dynamic keys too, tags are kept as columns after pivot)
+			fieldConditions = append(fieldConditions, condition)
 		}
 		// Invalid keys are silently ignored for security
 	}
 
-	if len(filterConditions) == 0 {
+	return joinFilters(tagConditions), joinFilters(fieldConditions)
+}
+
+// fluxImports returns Flux import header required by filters (strings package for contains operator)
+func (qb *QueryBuilder) fluxImports(filters []FilterItem) string {
+	for _, filter := range filters {
+		key := strings.ToLower(strings.TrimSpace(filter.Key))
+		if strings.ToLower(strings.TrimSpace(filter.Operator)) == OperatorContains &&
+			qb.isStringColumn(key) && len(filterValues(filter)) > 0 {
+			return "import \"strings\"\n\n"
+		}
+	}
+	return ""
+}
+
+// dynamicKeyPattern is accepted shape of dynamic filter key: lowercase letter followed by letters, digits or
+// underscores (no quotes, whitespace, operators or leading underscore system columns like _time/_measurement)
+var dynamicKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// isDynamicKey reports whether key is filterable only because dynamic fields are allowed
+func (qb *QueryBuilder) isDynamicKey(key string) bool {
+	return qb.config.AllowDynamicFields && !qb.config.ValidTags[key] && !qb.config.ValidFields[key] &&
+		dynamicKeyPattern.MatchString(key)
+}
+
+// isStringColumn reports whether key supports partial match operators (string fields and dynamic keys)
+func (qb *QueryBuilder) isStringColumn(key string) bool {
+	if qb.isDynamicKey(key) {
+		return true
+	}
+	return qb.config.ValidFields[key] && !qb.config.NumericFields[key] && !qb.config.BoolFields[key]
+}
+
+// DynamicFilterKeys returns filter keys accepted only through AllowDynamicFields (for audit logging)
+func (qb *QueryBuilder) DynamicFilterKeys(filters []FilterItem) []string {
+	var keys []string
+	for _, filter := range filters {
+		key := strings.ToLower(strings.TrimSpace(filter.Key))
+		if qb.isDynamicKey(key) && len(filterValues(filter)) > 0 && !containsColumn(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// escapeFluxString escapes backslash and double quote for Flux string literal
+func escapeFluxString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
+
+// escapeFluxRegex escapes unescaped slashes and line breaks for Flux regex literal (/pattern/).
+// Dangling trailing backslash is escaped too, otherwise it would escape closing slash of literal.
+func escapeFluxRegex(pattern string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			b.WriteRune(r)
+			escaped = true
+		case r == '/':
+			b.WriteString(`\/`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if escaped {
+		b.WriteRune('\\')
+	}
+	return b.String()
+}
+
+// filterValues returns trimmed non-empty values of filter (Value followed by Values)
+func filterValues(filter FilterItem) []string {
+	values := make([]string, 0, len(filter.Values)+1)
+	for _, value := range append([]string{filter.Value}, filter.Values...) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// joinFilters joins filter conditions with pipe operators
+func joinFilters(conditions []string) string {
+	if len(conditions) == 0 {
 		return ""
 	}
+	return "\n  |> " + strings.Join(conditions, "\n  |> ")
+}
 
-	// Join all filters with pipe operators
-	return "\n  |> " + strings.Join(filterConditions, "\n  |> ")
+// fluxOperator returns Flux comparison operator for filter operator (default: equality)
+func fluxOperator(operator string) string {
+	if op, exists := filterOperators[strings.ToLower(strings.TrimSpace(operator))]; exists {
+		return op
+	}
+	return filterOperators[OperatorEq]
 }
 
-// buildColumns constructs column selection based on configuration
-func (qb *QueryBuilder) buildColumns() string {
+// buildColumns constructs column selection based on configuration (extra columns are kept too)
+func (qb *QueryBuilder) buildColumns(extra ...string) string {
 	if len(qb.config.Columns) == 0 {
 		return "" // No column filtering, return all
 	}
 
-	// Build columns list with proper quoting
-	var quotedColumns []string
-	for _, col := range qb.config.Columns {
-		quotedColumns = append(quotedColumns, fmt.Sprintf(`"%s"`, col))
+	columns := qb.config.Columns
+	for _, col := range extra {
+		if !containsColumn(columns, col) {
+			columns = append(append([]string(nil), columns...), col)
+		}
 	}
 
-	return fmt.Sprintf("\n  |> keep(columns: [%s])", strings.Join(quotedColumns, ", "))
+	// Build columns list with proper quoting
+	return fmt.Sprintf("\n  |> keep(columns: [%s])", quoteColumns(columns))
+}
+
+// containsColumn checks if column exists in list
+func containsColumn(columns []string, column string) bool {
+	for _, col := range columns {
+		if col == column {
+			return true
+		}
+	}
+	return false
 }
 
-// buildCursorFilter constructs cursor-based time filtering for pagination
-func (qb *QueryBuilder) buildCursorFilter(cursor *string, direction string) string {
-	if cursor == nil || *cursor == "" {
-		return "" // No cursor filter for first page
+// buildCursorFilter constructs cursor-based time filtering for pagination (sorting by _time only).
+// Composite (_time, id) cursor includes boundary timestamp here, exact tiebreak is applied after pivot.
+func (qb *QueryBuilder) buildCursorFilter(req *PaginationRequest) string {
+	if req.Cursor == nil || *req.Cursor == "" || qb.sortColumn(req) != "" {
+		return "" // No cursor filter for first page, sort column cursor handled after pivot
 	}
 
 	// Parse cursor timestamp
-	cursorTime := *cursor
+	cursorTime, cursorID, err := parseTimeCursor(*req.Cursor)
+	if err != nil {
+		return "" // Rejected by ValidateRequest
+	}
 
-	// Build cursor filter based on direction
+	// Build cursor filter based on sort order
 	var operator string
-	if direction == "next" {
-		operator = "<" // Get records older than cursor (next page)
+	if qb.sortDescending(req) {
+		operator = "<" // Get records older than cursor
 	} else {
-		operator = ">" // Get records newer than cursor (prev page)
+		operator = ">" // Get records newer than cursor
+	}
+
+	// Keep records sharing cursor timestamp for tiebreak
+	if cursorID != "" && qb.tiebreakColumn() != "" {
+		operator += "="
 	}
 
 	return fmt.Sprintf("\n  |> filter(fn: (r) => r._time %s time(v: \"%s\"))", operator, cursorTime)
@@ -258,15 +485,31 @@ func (qb *QueryBuilder) ValidateRequest(req *PaginationRequest) error {
 		return fmt.Errorf("direction must be 'next' or 'prev'")
 	}
 
-	// Validate cursor format if provided
-	if req.Cursor != nil && *req.Cursor != "" {
-		if _, err := time.Parse(time.RFC3339, *req.Cursor); err != nil {
-			return fmt.Errorf("invalid cursor format, expected RFC3339 timestamp: %w", err)
+	// Validate sort column & composite cursor
+	if err := qb.validateSort(req); err != nil {
+		return err
+	}
+
+	// Validate cursor format if provided (time sorted pages use RFC3339 or (_time, id) cursor)
+	if req.Cursor != nil && *req.Cursor != "" && qb.sortColumn(req) == "" {
+		if _, _, err := parseTimeCursor(*req.Cursor); err != nil {
+			return err
 		}
 	}
 
+	// Validate filter operators
+	if err := qb.validateFilters(req.Filters); err != nil {
+		return err
+	}
+
 	// Validate date range format if provided
 	if req.Range != nil {
+		if preset := strings.TrimSpace(req.Range.Preset); preset != "" {
+			if !timeRangePresets[preset] {
+				return fmt.Errorf("invalid range preset '%s', allowed values: %s", preset, strings.Join(rangePresetValues(), ", "))
+			}
+			return nil
+		}
 		if req.Range.Start != "" {
 			if _, err := time.Parse("2006-01-02", req.Range.Start); err != nil {
 				return fmt.Errorf("invalid start date format, expected YYYY-MM-DD")
@@ -282,6 +525,107 @@ func (qb *QueryBuilder) ValidateRequest(req *PaginationRequest) error {
 	return nil
 }
 
+// rangePresetValues returns accepted range presets ordered by duration
+func rangePresetValues() []string {
+	values := make([]string, 0, len(timeRangePresets))
+	for preset := range timeRangePresets {
+		values = append(values, preset)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return presetDuration(values[i]) < presetDuration(values[j])
+	})
+	return values
+}
+
+// presetDuration converts range preset into time.Duration (supports m, h, d units)
+func presetDuration(preset string) time.Duration {
+	if strings.HasSuffix(preset, "d") {
+		days, _ := strconv.Atoi(strings.TrimSuffix(preset, "d"))
+		return time.Duration(days) * 24 * time.Hour
+	}
+	d, _ := time.ParseDuration(preset)
+	return d
+}
+
+// validateFilters validates filter operators against key type (tags support exact match only)
+func (qb *QueryBuilder) validateFilters(filters []FilterItem) error {
+	for _, filter := range filters {
+		key := strings.ToLower(strings.TrimSpace(filter.Key))
+		operator := strings.ToLower(strings.TrimSpace(filter.Operator))
+
+		if operator == "" {
+			operator = OperatorEq
+		}
+		if _, exists := filterOperators[operator]; !exists && !matchOperators[operator] {
+			return fmt.Errorf("invalid operator '%s' for filter '%s', expected one of: eq, gt, gte, lt, lte, contains, regex", filter.Operator, key)
+		}
+
+		// Keys outside config are only accepted when dynamic fields are allowed and key is sanitized
+		if qb.config.AllowDynamicFields && !qb.config.ValidTags[key] && !qb.config.ValidFields[key] {
+			if !dynamicKeyPattern.MatchString(key) {
+				return fmt.Errorf("invalid filter key '%s', dynamic keys must start with a letter and contain only letters, digits or underscores (max 64)", strings.TrimSpace(filter.Key))
+			}
+			if _, isComparison := filterOperators[operator]; isComparison && operator != OperatorEq {
+				return fmt.Errorf("operator '%s' is not supported for dynamic key '%s', dynamic keys support eq, contains and regex", operator, key)
+			}
+		}
+
+		// Boolean fields support exact match of true/false only
+		if qb.config.ValidFields[key] && qb.config.BoolFields[key] {
+			if operator != OperatorEq {
+				return fmt.Errorf("operator '%s' is not supported for boolean field '%s'", operator, key)
+			}
+			for _, value := range filterValues(filter) {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return fmt.Errorf("filter '%s' requires boolean value, got '%s'", key, value)
+				}
+			}
+			continue
+		}
+
+		// Partial match operators are field-only (tags & numeric fields use exact/range match)
+		if matchOperators[operator] {
+			if qb.config.ValidTags[key] {
+				return fmt.Errorf("operator '%s' is not supported for tag '%s', tags support exact match only", operator, key)
+			}
+			if qb.config.ValidFields[key] && qb.config.NumericFields[key] {
+				return fmt.Errorf("operator '%s' is not supported for numeric field '%s'", operator, key)
+			}
+			if operator == OperatorRegex {
+				for _, value := range filterValues(filter) {
+					if _, err := regexp.Compile(value); err != nil {
+						return fmt.Errorf("invalid regex for filter '%s': %v", key, err)
+					}
+				}
+			}
+			continue
+		}
+
+		// Numeric fields require numeric value for any operator
+		if qb.config.ValidFields[key] && qb.config.NumericFields[key] {
+			for _, value := range filterValues(filter) {
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return fmt.Errorf("filter '%s' requires numeric value, got '%s'", key, value)
+				}
+			}
+			continue
+		}
+
+		if operator == OperatorEq {
+			continue
+		}
+
+		if qb.config.ValidTags[key] {
+			return fmt.Errorf("operator '%s' is not supported for tag '%s', tags support exact match only", operator, key)
+		}
+		if qb.config.ValidFields[key] {
+			return fmt.Errorf("operator '%s' is not supported for non-numeric field '%s'", operator, key)
+		}
+	}
+
+	return nil
+}
+
 // GetPaginationInfo calculates cursor-based pagination metadata
 func (qb *QueryBuilder) GetPaginationInfo(req *PaginationRequest, results []map[string]interface{}, totalRecords int) PaginationInfo {
 	var nextCursor, prevCursor *string
@@ -307,11 +651,23 @@ func (qb *QueryBuilder) GetPaginationInfo(req *PaginationRequest, results []map[
 			return nil
 		}
 
-		// Next cursor (last record timestamp)
-		nextCursor = extractTimestamp(results[len(results)-1])
+		if column := qb.sortColumn(req); column != "" {
+			// Composite cursors (sort value + _time) for custom sort column
+			nextCursor = qb.sortCursorFromRecord(column, results[len(results)-1])
+			prevCursor = qb.sortCursorFromRecord(column, results[0])
+		} else if qb.tiebreakColumn() != "" {
+			// Composite (_time, id) cursors, stable for records sharing same timestamp
+			nextCursor = qb.timeCursorFromRecord(results[len(results)-1])
+			prevCursor = qb.timeCursorFromRecord(results[0])
+		}
 
-		// Previous cursor (first record timestamp)
-		prevCursor = extractTimestamp(results[0])
+		// Fallback to plain timestamp cursors when tiebreaker value is unavailable
+		if nextCursor == nil {
+			nextCursor = extractTimestamp(results[len(results)-1])
+		}
+		if prevCursor == nil {
+			prevCursor = extractTimestamp(results[0])
+		}
 	}
 
 	// Determine if there are more pages
@@ -331,7 +687,7 @@ func (qb *QueryBuilder) GetPaginationInfo(req *PaginationRequest, results []map[
 
 // GetTotalCount executes count query and returns total records using provided client
 func (qb *QueryBuilder) GetTotalCount(req *PaginationRequest, client *Client) int {
-	bucket := client.config.Bucket
+	bucket := qb.bucket(client)
 	countQuery, err := qb.BuildCountQuery(req, bucket)
 	if err != nil {
 		return 0
@@ -368,10 +724,30 @@ func (qb *QueryBuilder) GetTotalCount(req *PaginationRequest, client *Client) in
 	return 0
 }
 
+// BuildQueryString returns generated data and count Flux queries for request without executing them
+func (qb *QueryBuilder) BuildQueryString(req *PaginationRequest, client *Client) (*QueryDebug, error) {
+	bucket := qb.bucket(client)
+
+	dataQuery, err := qb.BuildQuery(req, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build data query: %w", err)
+	}
+
+	countQuery, err := qb.BuildCountQuery(req, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	return &QueryDebug{
+		DataQuery:  dataQuery,
+		CountQuery: countQuery,
+	}, nil
+}
+
 // ExecuteDataQuery builds and executes the main data query with client-side limiting (reusable)
 func (qb *QueryBuilder) ExecuteDataQuery(req *PaginationRequest, client *Client) ([]map[string]interface{}, error) {
 	// Build query
-	bucket := client.config.Bucket
+	bucket := qb.bucket(client)
 	query, err := qb.BuildQuery(req, bucket)
 	if err != nil {
 		return nil, err
@@ -395,14 +771,7 @@ func (qb *QueryBuilder) ExecuteDataQuery(req *PaginationRequest, client *Client)
 	for iterator.Next() {
 		record := iterator.Record()
 		if record != nil {
-			// Filter out internal InfluxDB fields
-			cleanRecord := make(map[string]interface{})
-			for key, value := range record {
-				if key != "result" && key != "table" && key != "_start" && key != "_stop" {
-					cleanRecord[key] = value
-				}
-			}
-			results = append(results, cleanRecord)
+			results = append(results, cleanRecord(record))
 		}
 	}
 
@@ -419,9 +788,68 @@ func (qb *QueryBuilder) ExecuteDataQuery(req *PaginationRequest, client *Client)
 	return results, nil
 }
 
+// StreamQuery executes data query without page limit and invokes fn per record as rows arrive (no buffering).
+// Iteration stops at first callback error, which is returned to caller.
+func (qb *QueryBuilder) StreamQuery(req *PaginationRequest, client *Client, fn func(record map[string]interface{}) error) error {
+	// Page length does not apply to streaming, validate remaining request with valid placeholder
+	streamReq := *req
+	streamReq.Length = 1
+	if streamReq.Direction == "" {
+		streamReq.Direction = "next"
+	}
+	if err := qb.ValidateRequest(&streamReq); err != nil {
+		return err
+	}
+
+	// Build query without safety limit
+	bucket := qb.bucket(client)
+	query, err := qb.buildDataQuery(&streamReq, bucket, 0)
+	if err != nil {
+		return err
+	}
+
+	// Execute query
+	result, err := client.Query(query)
+	if err != nil {
+		return err
+	}
+
+	iterator, ok := result.(*QueryIterator)
+	if !ok || iterator == nil {
+		return nil
+	}
+
+	defer func() { _ = iterator.Close() }()
+
+	// Pass records to callback one by one
+	for iterator.Next() {
+		record := iterator.Record()
+		if record == nil {
+			continue
+		}
+		if err := fn(cleanRecord(record)); err != nil {
+			return err
+		}
+	}
+
+	// Check for iterator errors
+	return iterator.Err()
+}
+
+// cleanRecord filters out internal InfluxDB fields from query record
+func cleanRecord(record map[string]interface{}) map[string]interface{} {
+	cleaned := make(map[string]interface{}, len(record))
+	for key, value := range record {
+		if key != "result" && key != "table" && key != "_start" && key != "_stop" {
+			cleaned[key] = value
+		}
+	}
+	return cleaned
+}
+
 // GetByTimestampAndUniqueID retrieves a single record by timestamp and unique column (reusable method)
 func (qb *QueryBuilder) GetByTimestampAndUniqueID(timestamp, columnKey string, columnValue string, client *Client) (map[string]interface{}, error) {
-	bucket := client.config.Bucket
+	bucket := qb.bucket(client)
 	if timestamp == "" {
 		return nil, fmt.Errorf("timestamp cannot be empty")
 	}
@@ -460,7 +888,7 @@ func (qb *QueryBuilder) GetByTimestampAndUniqueID(timestamp, columnKey string, c
 		endTime.Format(time.RFC3339),
 		qb.config.Measurement,
 		columnKey,
-		strings.ReplaceAll(columnValue, `"`, `\"`), // Escape quotes
+		escapeFluxString(columnValue),
 	)
 
 	// Execute query
@@ -481,14 +909,7 @@ func (qb *QueryBuilder) GetByTimestampAndUniqueID(timestamp, columnKey string, c
 	for iterator.Next() {
 		record := iterator.Record()
 		if record != nil {
-			// Filter out internal InfluxDB fields
-			cleanRecord := make(map[string]interface{})
-			for key, value := range record {
-				if key != "result" && key != "table" && key != "_start" && key != "_stop" {
-					cleanRecord[key] = value
-				}
-			}
-			return cleanRecord, nil
+			return cleanRecord(record), nil
 		}
 	}
 
@@ -0,0 +1,1130 @@
+package v2oss
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testQueryBuilder() *QueryBuilder {
+	return NewQueryBuilder(QueryBuilderConfig{
+		Measurement: "transaction_events",
+		ValidTags: map[string]bool{
+			"status": true,
+		},
+		ValidFields: map[string]bool{
+			"amount":   true,
+			"currency": true,
+			"is_bot":   true,
+		},
+		NumericFields: map[string]bool{
+			"amount": true,
+		},
+		BoolFields: map[string]bool{
+			"is_bot": true,
+		},
+		CountField: "request_id",
+	})
+}
+
+func TestNumericRangeFilters(t *testing.T) {
+	qb := testQueryBuilder()
+
+	tests := []struct {
+		operator string
+		expected string
+	}{
+		{"", `r["amount"] == 1000000`},
+		{"eq", `r["amount"] == 1000000`},
+		{"gt", `r["amount"] > 1000000`},
+		{"gte", `r["amount"] >= 1000000`},
+		{"lt", `r["amount"] < 1000000`},
+		{"lte", `r["amount"] <= 1000000`},
+	}
+
+	for _, tt := range tests {
+		req := &PaginationRequest{
+			Length:    10,
+			Direction: "next",
+			Filters:   []FilterItem{{Key: "amount", Value: "1000000", Operator: tt.operator}},
+		}
+
+		query, err := qb.BuildQuery(req, "bucket")
+		if err != nil {
+			t.Fatalf("operator %q: unexpected error: %v", tt.operator, err)
+		}
+		if !strings.Contains(query, tt.expected) {
+			t.Errorf("operator %q: query missing %s\n%s", tt.operator, tt.expected, query)
+		}
+
+		// Field filter must come after pivot
+		if strings.Index(query, tt.expected) < strings.Index(query, "pivot(") {
+			t.Errorf("operator %q: field filter applied before pivot\n%s", tt.operator, query)
+		}
+	}
+}
+
+func TestFilterPlacement(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters: []FilterItem{
+			{Key: "status", Value: "completed"},
+			{Key: "currency", Value: "IDR"},
+			{Key: "amount", Value: "50.5", Operator: "gte"},
+		},
+	}
+
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pivot := strings.Index(query, "pivot(")
+	if idx := strings.Index(query, `r["status"] == "completed"`); idx < 0 || idx > pivot {
+		t.Errorf("tag filter should be applied before pivot\n%s", query)
+	}
+	if idx := strings.Index(query, `r["currency"] == "IDR"`); idx < pivot {
+		t.Errorf("string field filter should be applied after pivot\n%s", query)
+	}
+	if !strings.Contains(query, `r["amount"] >= 50.5`) {
+		t.Errorf("numeric filter missing\n%s", query)
+	}
+
+	// Count query pivots when field filters are present
+	countQuery, err := qb.BuildCountQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected count error: %v", err)
+	}
+	if !strings.Contains(countQuery, "pivot(") || !strings.Contains(countQuery, `r["amount"] >= 50.5`) {
+		t.Errorf("count query should filter fields after pivot\n%s", countQuery)
+	}
+
+	// Tag-only count query keeps simple form
+	req.Filters = []FilterItem{{Key: "status", Value: "completed"}}
+	countQuery, _ = qb.BuildCountQuery(req, "bucket")
+	if strings.Contains(countQuery, "pivot(") {
+		t.Errorf("tag-only count query should not pivot\n%s", countQuery)
+	}
+}
+
+func TestFilterOperatorValidation(t *testing.T) {
+	qb := testQueryBuilder()
+
+	tests := []struct {
+		name    string
+		filter  FilterItem
+		wantErr string
+	}{
+		{"range on tag", FilterItem{Key: "status", Value: "completed", Operator: "gt"}, "not supported for tag 'status'"},
+		{"range on string field", FilterItem{Key: "currency", Value: "IDR", Operator: "lt"}, "non-numeric field 'currency'"},
+		{"unknown operator", FilterItem{Key: "amount", Value: "1", Operator: "between"}, "invalid operator"},
+		{"non numeric value", FilterItem{Key: "amount", Value: "abc", Operator: "gt"}, "requires numeric value"},
+		{"eq on tag", FilterItem{Key: "status", Value: "completed", Operator: "eq"}, ""},
+		{"range on numeric field", FilterItem{Key: "amount", Value: "10", Operator: "lte"}, ""},
+		{"range on boolean field", FilterItem{Key: "is_bot", Value: "true", Operator: "gt"}, "boolean field 'is_bot'"},
+		{"contains on boolean field", FilterItem{Key: "is_bot", Value: "tr", Operator: "contains"}, "boolean field 'is_bot'"},
+		{"non boolean value", FilterItem{Key: "is_bot", Value: "yes"}, "requires boolean value"},
+		{"eq on boolean field", FilterItem{Key: "is_bot", Value: "TRUE"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := qb.ValidateRequest(&PaginationRequest{
+				Length:    10,
+				Direction: "next",
+				Filters:   []FilterItem{tt.filter},
+			})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBoolFieldFilter(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "is_bot", Value: "true"}},
+	}
+
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `r["is_bot"] == true`) || strings.Contains(query, `r["is_bot"] == "true"`) {
+		t.Errorf("expected unquoted boolean comparison\n%s", query)
+	}
+}
+
+func TestMultiValueFilters(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters: []FilterItem{
+			{Key: "status", Values: []string{"completed", " ", "failed"}},
+			{Key: "currency", Value: "IDR", Values: []string{`US"D`}},
+			{Key: "amount", Values: []string{"100", "200"}},
+		},
+	}
+
+	if err := qb.ValidateRequest(req); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`filter(fn: (r) => r["status"] == "completed" or r["status"] == "failed")`,
+		`filter(fn: (r) => r["currency"] == "IDR" or r["currency"] == "US\"D")`,
+		`filter(fn: (r) => r["amount"] == 100 or r["amount"] == 200)`,
+	}
+	for _, condition := range expected {
+		if !strings.Contains(query, condition) {
+			t.Errorf("query missing %s\n%s", condition, query)
+		}
+	}
+
+	// Groups for different keys are separate (ANDed) filter() calls, excluding measurement filter
+	if got := strings.Count(query, "filter(fn: (r) => r[\"") - 1; got != 3 {
+		t.Errorf("expected 3 filter groups, got %d\n%s", got, query)
+	}
+
+	// All values empty: filter skipped
+	req.Filters = []FilterItem{{Key: "status", Values: []string{"", "  "}}}
+	query, _ = qb.BuildQuery(req, "bucket")
+	if strings.Contains(query, `r["status"]`) {
+		t.Errorf("empty values should be skipped\n%s", query)
+	}
+
+	// Every value of numeric filter is validated
+	req.Filters = []FilterItem{{Key: "amount", Values: []string{"100", "abc"}}}
+	if err := qb.ValidateRequest(req); err == nil {
+		t.Error("expected error for non-numeric value in values list")
+	}
+}
+
+func TestBuildAggregateQuery(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Plain group count
+	req := &PaginationRequest{
+		Filters: []FilterItem{{Key: "status", Values: []string{"completed", "failed"}}},
+	}
+	query, err := qb.BuildAggregateQuery(req, []string{"status"}, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`r["_field"] == "request_id"`,
+		`r["status"] == "completed" or r["status"] == "failed"`,
+		`group(columns: ["status"])`,
+		`count()`,
+	} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("aggregate query missing %s\n%s", expected, query)
+		}
+	}
+	if strings.Contains(query, "aggregateWindow") || strings.Contains(query, "pivot(") {
+		t.Errorf("unexpected window/pivot in plain aggregate\n%s", query)
+	}
+
+	// Windowed with field filter
+	req = &PaginationRequest{
+		Window:  "1h",
+		Filters: []FilterItem{{Key: "amount", Value: "1000", Operator: "gte"}},
+	}
+	query, err = qb.BuildAggregateQuery(req, []string{"status"}, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`pivot(`,
+		`r["amount"] >= 1000`,
+		`keep(columns: ["_time", "request_id", "status"])`,
+		`aggregateWindow(every: 1h, fn: count, createEmpty: false)`,
+	} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("windowed query missing %s\n%s", expected, query)
+		}
+	}
+
+	// Validation
+	invalid := []struct {
+		name    string
+		req     *PaginationRequest
+		groupBy []string
+	}{
+		{"field group by", &PaginationRequest{}, []string{"amount"}},
+		{"injection group by", &PaginationRequest{}, []string{`status"]) |> drop(`}},
+		{"duplicate group by", &PaginationRequest{}, []string{"status", "status"}},
+		{"invalid window", &PaginationRequest{Window: "1 hour"}, []string{"status"}},
+		{"zero window", &PaginationRequest{Window: "0h"}, []string{"status"}},
+	}
+	for _, tt := range invalid {
+		if _, err := qb.BuildAggregateQuery(tt.req, tt.groupBy, "bucket"); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestIsValidFluxDuration(t *testing.T) {
+	for _, value := range []string{"30s", "5m", "1h", "1h30m", "7d", "2w", "1mo"} {
+		if !IsValidFluxDuration(value) {
+			t.Errorf("IsValidFluxDuration(%q) = false, want true", value)
+		}
+	}
+	for _, value := range []string{"", "1", "h", "0s", "-1h", "1 h", "1x", "1h)"} {
+		if IsValidFluxDuration(value) {
+			t.Errorf("IsValidFluxDuration(%q) = true, want false", value)
+		}
+	}
+}
+
+func TestSortByColumn(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Default sort stays on _time, newest first
+	req := &PaginationRequest{Length: 10, Direction: "next"}
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `sort(columns: ["_time", "request_id"], desc: true)`) {
+		t.Errorf("default sort should be _time with request_id tiebreaker\n%s", query)
+	}
+
+	// Custom sort column with _time tiebreaker
+	req = &PaginationRequest{Length: 10, Direction: "next", SortBy: "amount", SortDesc: true}
+	query, err = qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `sort(columns: ["amount", "_time"], desc: true)`) {
+		t.Errorf("sort column missing\n%s", query)
+	}
+
+	// Page 2 uses composite cursor after pivot
+	cursor := EncodeCursor(PageCursor{Value: float64(1500), Time: "2025-08-06T12:30:00.123456789Z"})
+	req.Cursor = &cursor
+	query, err = qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `r["amount"] < 1500 or (r["amount"] == 1500 and r._time < time(v: "2025-08-06T12:30:00.123456789Z"))`
+	if !strings.Contains(query, expected) {
+		t.Errorf("composite cursor filter missing %s\n%s", expected, query)
+	}
+	if strings.Index(query, expected) < strings.Index(query, "pivot(") {
+		t.Errorf("composite cursor filter applied before pivot\n%s", query)
+	}
+
+	// Prev direction flips comparison & order
+	req.Direction = "prev"
+	query, _ = qb.BuildQuery(req, "bucket")
+	if !strings.Contains(query, `r["amount"] > 1500`) || !strings.Contains(query, `desc: false`) {
+		t.Errorf("prev direction should reverse order\n%s", query)
+	}
+
+	// Validation
+	invalid := []*PaginationRequest{
+		{Length: 10, Direction: "next", SortBy: "unknown_column"},
+		{Length: 10, Direction: "next", SortBy: "amount", Cursor: stringPtr("2025-08-06T12:30:00Z")},
+		{Length: 10, Direction: "next", SortBy: "amount", Cursor: stringPtr(EncodeCursor(PageCursor{Value: "abc", Time: "2025-08-06T12:30:00Z"}))},
+	}
+	for i, r := range invalid {
+		if err := qb.ValidateRequest(r); err == nil {
+			t.Errorf("case %d: expected validation error", i)
+		}
+	}
+
+	// Pagination info emits composite cursors
+	results := []map[string]interface{}{
+		{"_time": "2025-08-06T12:30:00Z", "amount": float64(2000)},
+		{"_time": "2025-08-06T12:29:00Z", "amount": int64(1500)},
+	}
+	info := qb.GetPaginationInfo(&PaginationRequest{Length: 2, Direction: "next", SortBy: "amount"}, results, 10)
+	if info.NextCursor == nil {
+		t.Fatal("next cursor missing")
+	}
+	decoded, err := DecodeCursor(*info.NextCursor)
+	if err != nil {
+		t.Fatalf("decode next cursor: %v", err)
+	}
+	if decoded.Value != float64(1500) || decoded.Time != "2025-08-06T12:29:00Z" {
+		t.Errorf("next cursor = %+v", decoded)
+	}
+}
+
+func TestSortCursorEscaping(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// String sort cursor value with backslash + quote stays inside literal
+	cursor := EncodeCursor(PageCursor{Value: `IDR\" or true or "`, Time: "2025-08-06T12:30:00Z"})
+	req := &PaginationRequest{Length: 10, Direction: "next", SortBy: "currency", SortDesc: true, Cursor: &cursor}
+	if err := qb.ValidateRequest(req); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `r["currency"] < "IDR\\\" or true or \""`
+	if !strings.Contains(query, expected) {
+		t.Errorf("query missing escaped cursor literal %s\n%s", expected, query)
+	}
+	if strings.Contains(query, `"IDR\\" or true`) {
+		t.Errorf("cursor value broke out of string literal\n%s", query)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestStableCursorIdenticalTimestamps(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Seed records, several sharing same timestamp
+	base := time.Date(2025, 8, 6, 12, 0, 0, 0, time.UTC)
+	var records []map[string]interface{}
+	for i := 0; i < 11; i++ {
+		ts := base.Add(time.Duration(i/4) * time.Second) // groups of 4 identical timestamps
+		records = append(records, map[string]interface{}{
+			"_time":      ts,
+			"request_id": fmt.Sprintf("req-%02d", i),
+		})
+	}
+
+	// Composite cursor filter & tiebreak sort are part of query
+	cursor := EncodeCursor(PageCursor{Time: base.Format(time.RFC3339Nano), ID: "req-02"})
+	query, err := qb.BuildQuery(&PaginationRequest{Length: 3, Direction: "next", Cursor: &cursor}, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`r._time <= time(v: "2025-08-06T12:00:00Z")`,
+		`r._time < time(v: "2025-08-06T12:00:00Z") or (r._time == time(v: "2025-08-06T12:00:00Z") and r["request_id"] < "req-02")`,
+		`sort(columns: ["_time", "request_id"], desc: true)`,
+	} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("query missing %s\n%s", expected, query)
+		}
+	}
+
+	// Walk pages applying same semantics as generated Flux
+	seen := make(map[string]bool)
+	var walked []string
+	var next *string
+	for page := 0; page < 10; page++ {
+		req := &PaginationRequest{Length: 3, Direction: "next", Cursor: next}
+		if err := qb.ValidateRequest(req); err != nil {
+			t.Fatalf("page %d: invalid request: %v", page, err)
+		}
+
+		results := simulateTimeCursorPage(t, records, req)
+		for _, r := range results {
+			id := r["request_id"].(string)
+			if seen[id] {
+				t.Fatalf("record %s repeated on page %d", id, page)
+			}
+			seen[id] = true
+			walked = append(walked, id)
+		}
+
+		info := qb.GetPaginationInfo(req, results, len(records))
+		if !info.HasNext {
+			break
+		}
+		next = info.NextCursor
+	}
+
+	if len(walked) != len(records) {
+		t.Fatalf("walked %d records, want %d: %v", len(walked), len(records), walked)
+	}
+	if walked[0] != "req-10" || walked[len(walked)-1] != "req-00" {
+		t.Errorf("unexpected order: %v", walked)
+	}
+}
+
+func TestTimeCursorTiebreakEscaping(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Cursor id with backslash + quote stays inside literal
+	cursor := EncodeCursor(PageCursor{Time: "2025-08-06T12:00:00Z", ID: `req\" or true or "`})
+	query, err := qb.BuildQuery(&PaginationRequest{Length: 3, Direction: "next", Cursor: &cursor}, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `r["request_id"] < "req\\\" or true or \""`
+	if !strings.Contains(query, expected) {
+		t.Errorf("query missing escaped cursor id %s\n%s", expected, query)
+	}
+	if strings.Contains(query, `"req\\" or true`) {
+		t.Errorf("cursor id broke out of string literal\n%s", query)
+	}
+}
+
+// simulateTimeCursorPage applies descending (_time, request_id) ordering and cursor filter like generated Flux
+func simulateTimeCursorPage(t *testing.T, records []map[string]interface{}, req *PaginationRequest) []map[string]interface{} {
+	t.Helper()
+
+	var cursorTime time.Time
+	var cursorID string
+	if req.Cursor != nil && *req.Cursor != "" {
+		timeStr, id, err := parseTimeCursor(*req.Cursor)
+		if err != nil {
+			t.Fatalf("parse cursor: %v", err)
+		}
+		cursorTime, _ = time.Parse(time.RFC3339Nano, timeStr)
+		cursorID = id
+	}
+
+	var filtered []map[string]interface{}
+	for _, r := range records {
+		ts := r["_time"].(time.Time)
+		id := r["request_id"].(string)
+		if req.Cursor != nil && !(ts.Before(cursorTime) || (ts.Equal(cursorTime) && id < cursorID)) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		ti, tj := filtered[i]["_time"].(time.Time), filtered[j]["_time"].(time.Time)
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return filtered[i]["request_id"].(string) > filtered[j]["request_id"].(string)
+	})
+
+	if len(filtered) > req.Length {
+		filtered = filtered[:req.Length]
+	}
+	return filtered
+}
+
+func TestBuildQueryString(t *testing.T) {
+	qb := testQueryBuilder()
+	client := &Client{config: &Config{Bucket: "insight"}}
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Debug:     true,
+		Filters:   []FilterItem{{Key: "status", Value: "completed"}},
+	}
+
+	queryDebug, err := qb.BuildQueryString(req, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dataQuery, _ := qb.BuildQuery(req, "insight")
+	countQuery, _ := qb.BuildCountQuery(req, "insight")
+	if queryDebug.DataQuery != dataQuery {
+		t.Errorf("DataQuery mismatch\n%s\n%s", queryDebug.DataQuery, dataQuery)
+	}
+	if queryDebug.CountQuery != countQuery {
+		t.Errorf("CountQuery mismatch\n%s\n%s", queryDebug.CountQuery, countQuery)
+	}
+
+	// Invalid request is reported instead of returning partial queries
+	req.Direction = "sideways"
+	if _, err := qb.BuildQueryString(req, client); err == nil {
+		t.Error("expected error for invalid request")
+	}
+}
+
+func TestBucketOverride(t *testing.T) {
+	client := &Client{config: &Config{Bucket: "insight"}}
+	req := &PaginationRequest{Length: 10, Direction: "next"}
+
+	// Unset override falls back to client bucket
+	qb := testQueryBuilder()
+	if bucket := qb.bucket(client); bucket != "insight" {
+		t.Errorf("expected default bucket, got %q", bucket)
+	}
+
+	// Entity bucket is used by data & count queries
+	config := qb.config
+	config.Bucket = "insight-cold"
+	qb = NewQueryBuilder(config)
+	queryDebug, err := qb.BuildQueryString(req, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, query := range map[string]string{"data": queryDebug.DataQuery, "count": queryDebug.CountQuery} {
+		if !strings.Contains(query, `from(bucket: "insight-cold")`) {
+			t.Errorf("%s query does not target entity bucket:\n%s", name, query)
+		}
+		if strings.Contains(query, `from(bucket: "insight")`) {
+			t.Errorf("%s query targets default bucket:\n%s", name, query)
+		}
+	}
+}
+
+func TestRangePreset(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Range:     &DateRangeFilter{Preset: "24h", Start: "2024-01-15", End: "2024-01-16"},
+	}
+
+	if err := qb.ValidateRequest(req); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	// Preset takes precedence over start/end
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, "range(start: -24h)") {
+		t.Errorf("expected relative range in query\n%s", query)
+	}
+	if strings.Contains(query, "2024-01-15") {
+		t.Errorf("start/end should be ignored when preset set\n%s", query)
+	}
+
+	countQuery, _ := qb.BuildCountQuery(req, "bucket")
+	if !strings.Contains(countQuery, "range(start: -24h)") {
+		t.Errorf("expected relative range in count query\n%s", countQuery)
+	}
+
+	// Unknown preset is rejected
+	req.Range.Preset = "3w"
+	err = qb.ValidateRequest(req)
+	if err == nil || !strings.Contains(err.Error(), "invalid range preset") {
+		t.Errorf("expected invalid preset error, got %v", err)
+	}
+	if _, err := qb.buildTimeRange(req.Range); err == nil {
+		t.Error("expected buildTimeRange error for unknown preset")
+	}
+
+	if got := rangePresetValues(); got[0] != "5m" || got[len(got)-1] != "90d" {
+		t.Errorf("rangePresetValues not ordered by duration: %v", got)
+	}
+}
+
+func TestBuildDistinctCountQuery(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Range: &DateRangeFilter{Preset: "7d"},
+	}
+
+	tests := []struct {
+		name     string
+		column   string
+		filters  []FilterItem
+		expected []string
+	}{
+		{
+			name:   "tag column",
+			column: "status",
+			expected: []string{
+				`range(start: -7d)`,
+				`filter(fn: (r) => r["_field"] == "request_id")`,
+				`group()
+  |> distinct(column: "status")
+  |> count()`,
+			},
+		},
+		{
+			name:    "field column with tag filter",
+			column:  "currency",
+			filters: []FilterItem{{Key: "status", Value: "completed"}},
+			expected: []string{
+				`filter(fn: (r) => r["_field"] == "currency")
+  |> filter(fn: (r) => r["status"] == "completed")`,
+				`distinct(column: "_value")`,
+			},
+		},
+		{
+			name:    "field filter pivots before distinct",
+			column:  "currency",
+			filters: []FilterItem{{Key: "amount", Value: "100", Operator: "gte"}},
+			expected: []string{
+				`pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")
+  |> filter(fn: (r) => r["amount"] >= 100)
+  |> keep(columns: ["_time", "currency"])`,
+				`distinct(column: "currency")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req.Filters = tt.filters
+			query, err := qb.BuildDistinctCountQuery(req, tt.column, "bucket")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, part := range tt.expected {
+				if !strings.Contains(query, part) {
+					t.Errorf("query missing %s\n%s", part, query)
+				}
+			}
+		})
+	}
+
+	// Unknown column is rejected
+	req.Filters = nil
+	if _, err := qb.BuildDistinctCountQuery(req, "user_id", "bucket"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if _, err := qb.BuildDistinctCountQuery(req, "", "bucket"); err == nil {
+		t.Error("expected error for empty column")
+	}
+}
+
+func TestStreamQueryBuild(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "status", Value: "completed"}},
+	}
+
+	// Page query keeps safety limit, stream query has none
+	pageQuery, _ := qb.BuildQuery(req, "bucket")
+	if !strings.Contains(pageQuery, "limit(n: 100)") {
+		t.Errorf("page query missing safety limit\n%s", pageQuery)
+	}
+	streamQuery, err := qb.buildDataQuery(req, "bucket", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(streamQuery, "limit(") {
+		t.Errorf("stream query should not be limited\n%s", streamQuery)
+	}
+	if strings.TrimSuffix(pageQuery, "\n  |> limit(n: 100)") != streamQuery {
+		t.Errorf("stream query should match page query without limit\n%s\n%s", streamQuery, pageQuery)
+	}
+
+	// Invalid request fails before executing, callback never invoked
+	client := &Client{config: &Config{Bucket: "bucket"}}
+	req.Filters = []FilterItem{{Key: "status", Value: "x", Operator: "gt"}}
+	req.Length = 0
+	called := false
+	err = qb.StreamQuery(req, client, func(map[string]interface{}) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Errorf("expected validation error without callback, got err=%v called=%v", err, called)
+	}
+}
+
+func TestExportRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateRange *DateRangeFilter
+		duration  time.Duration
+		label     string
+		wantErr   bool
+	}{
+		{"default", nil, 7 * 24 * time.Hour, "last_7d", false},
+		{"empty", &DateRangeFilter{}, 7 * 24 * time.Hour, "last_7d", false},
+		{"preset", &DateRangeFilter{Preset: "24h", Start: "2024-01-01"}, 24 * time.Hour, "last_24h", false},
+		{"start only", &DateRangeFilter{Start: "2024-01-15"}, 24 * time.Hour, "2024-01-15", false},
+		{"start end inclusive", &DateRangeFilter{Start: "2024-01-01", End: "2024-01-31"}, 31 * 24 * time.Hour, "2024-01-01_2024-01-31", false},
+		{"start after end", &DateRangeFilter{Start: "2024-02-01", End: "2024-01-01"}, 0, "", true},
+		{"invalid preset", &DateRangeFilter{Preset: "3w"}, 0, "", true},
+		{"invalid date", &DateRangeFilter{Start: "2024/01/01", End: "2024-01-31"}, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := RangeDuration(tt.dateRange)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.duration {
+			t.Errorf("%s: RangeDuration = %v, %v, want %v", tt.name, got, err, tt.duration)
+		}
+		if label := RangeLabel(tt.dateRange); label != tt.label {
+			t.Errorf("%s: RangeLabel = %q, want %q", tt.name, label, tt.label)
+		}
+	}
+
+	// Export request converts to valid stream request
+	req := (&ExportRequest{Filters: []FilterItem{{Key: "status", Value: "completed"}}}).ToPaginationRequest()
+	if err := testQueryBuilder().ValidateRequest(req); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
+
+func TestBuildTimeSeriesQuery(t *testing.T) {
+	qb := testQueryBuilder()
+
+	req := (&TimeSeriesRequest{
+		Range:   &DateRangeFilter{Preset: "24h"},
+		Filters: []FilterItem{{Key: "status", Value: "completed"}},
+	}).ToPaginationRequest()
+	query, err := qb.BuildTimeSeriesQuery(req, "1h", []string{"status"}, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`range(start: -24h)`,
+		`r["status"] == "completed"`,
+		`group(columns: ["status"])`,
+		`aggregateWindow(every: 1h, fn: count, createEmpty: false)`,
+	} {
+		if !strings.Contains(query, expected) {
+			t.Errorf("time-series query missing %s\n%s", expected, query)
+		}
+	}
+	if req.Window != "" {
+		t.Errorf("request window should not be modified, got %q", req.Window)
+	}
+
+	// Single series without group by
+	query, err = qb.BuildTimeSeriesQuery(&PaginationRequest{}, "15m", nil, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, `group(columns: [])`) || !strings.Contains(query, `every: 15m`) {
+		t.Errorf("unexpected single series query\n%s", query)
+	}
+
+	// Validation
+	invalid := []struct {
+		name    string
+		window  string
+		groupBy []string
+	}{
+		{"missing window", "", []string{"status"}},
+		{"invalid window", "1 hour", []string{"status"}},
+		{"injection window", "1h, fn: mean", []string{"status"}},
+		{"zero window", "0m", nil},
+		{"sub-minute window", "30s", nil},
+		{"too many buckets over default 7d range", "5m", nil},
+		{"field group by", "1h", []string{"amount"}},
+		{"unknown group by", "1h", []string{"country"}},
+	}
+	for _, tt := range invalid {
+		if _, err := qb.BuildTimeSeriesQuery(&PaginationRequest{}, tt.window, tt.groupBy, "bucket"); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+
+	// Bucket limit depends on range: 1m over 24h = 1440 windows, 5m = 288
+	day := &PaginationRequest{Range: &DateRangeFilter{Preset: "24h"}}
+	if _, err := qb.BuildTimeSeriesQuery(day, "1m", nil, "bucket"); err == nil {
+		t.Error("expected error for 1m window over 24h")
+	}
+	if _, err := qb.BuildTimeSeriesQuery(day, "5m", nil, "bucket"); err != nil {
+		t.Errorf("unexpected error for 5m window over 24h: %v", err)
+	}
+}
+
+func TestFluxDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30s":   30 * time.Second,
+		"5m":    5 * time.Minute,
+		"1h30m": 90 * time.Minute,
+		"1d":    24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"1mo":   30 * 24 * time.Hour,
+	}
+	for value, want := range tests {
+		if got := fluxDuration(value); got != want {
+			t.Errorf("fluxDuration(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestMatchOperatorFilters(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Contains on string field (strings package imported)
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "currency", Value: `I"D\`, Operator: "contains"}},
+	}
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(query, "import \"strings\"\n\nfrom(bucket:") {
+		t.Errorf("contains query missing strings import\n%s", query)
+	}
+	expected := `strings.containsStr(v: r["currency"], substr: "I\"D\\")`
+	if !strings.Contains(query, expected) {
+		t.Errorf("contains query missing %s\n%s", expected, query)
+	}
+	if pivot, filter := strings.Index(query, "pivot("), strings.Index(query, "containsStr"); filter < pivot {
+		t.Errorf("contains filter must be applied after pivot\n%s", query)
+	}
+
+	// Regex on string field (slashes escaped, no import)
+	req.Filters = []FilterItem{{Key: "currency", Values: []string{`^I/D`, `a\/b`}, Operator: "regex"}}
+	query, err = qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = `r["currency"] =~ /^I\/D/ or r["currency"] =~ /a\/b/`
+	if !strings.Contains(query, expected) {
+		t.Errorf("regex query missing %s\n%s", expected, query)
+	}
+	if strings.Contains(query, "import") {
+		t.Errorf("regex query should not import strings\n%s", query)
+	}
+
+	// Count query uses same filters
+	req.Filters = []FilterItem{{Key: "currency", Value: "ID", Operator: "contains"}}
+	countQuery, err := qb.BuildCountQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(countQuery, `import "strings"`) || !strings.Contains(countQuery, "containsStr") {
+		t.Errorf("count query missing contains filter\n%s", countQuery)
+	}
+
+	// Validation
+	tests := []struct {
+		name    string
+		filter  FilterItem
+		wantErr string
+	}{
+		{"contains on tag", FilterItem{Key: "status", Value: "comp", Operator: "contains"}, "not supported for tag 'status'"},
+		{"regex on numeric field", FilterItem{Key: "amount", Value: "^1", Operator: "regex"}, "numeric field 'amount'"},
+		{"invalid regex", FilterItem{Key: "currency", Value: "(ID", Operator: "regex"}, "invalid regex"},
+		{"valid regex", FilterItem{Key: "currency", Value: "^(IDR|USD)$", Operator: "regex"}, ""},
+		{"contains on string field", FilterItem{Key: "currency", Value: "ID", Operator: "contains"}, ""},
+	}
+	for _, tt := range tests {
+		err := qb.ValidateRequest(&PaginationRequest{Length: 10, Direction: "next", Filters: []FilterItem{tt.filter}})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRegexFilterTrailingBackslash(t *testing.T) {
+	tests := map[string]string{
+		`abc\`: `abc\\`,
+		`a\\`:  `a\\`,
+		`a\/`:  `a\/`,
+		`a/b\`: `a\/b\\`,
+		`\\\`:  `\\\\`,
+	}
+	for pattern, want := range tests {
+		if got := escapeFluxRegex(pattern); got != want {
+			t.Errorf("escapeFluxRegex(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+
+	// Dangling backslash would escape closing slash and turn next OR value into raw Flux
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "currency", Values: []string{`x\`, `/ or true) |> drop(columns: ["_value"]) //`}, Operator: "regex"}},
+	}
+	if _, err := qb.BuildCountQuery(req, "bucket"); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Errorf("count query error = %v, want invalid regex", err)
+	}
+	if _, err := qb.BuildQuery(req, "bucket"); err == nil {
+		t.Error("data query accepted dangling backslash regex")
+	}
+}
+
+func TestBuildCountQueryValidatesRequest(t *testing.T) {
+	qb := testQueryBuilder()
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "status", Value: "comp", Operator: "contains"}},
+	}
+	if _, err := qb.BuildCountQuery(req, "bucket"); err == nil || !strings.Contains(err.Error(), "not supported for tag") {
+		t.Errorf("count query error = %v, want tag operator error", err)
+	}
+}
+
+func TestExactMatchFilterEscaping(t *testing.T) {
+	qb := testQueryBuilder()
+
+	// Trailing backslash must not escape closing quote added by quote escaping
+	payload := `done\" or r["status"] != "`
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters:   []FilterItem{{Key: "status", Value: payload}},
+	}
+	query, err := qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `r["status"] == "done\\\" or r[\"status\"] != \""`
+	if !strings.Contains(query, expected) {
+		t.Errorf("query missing escaped literal %s\n%s", expected, query)
+	}
+	if strings.Contains(query, `r["status"] != "`) {
+		t.Errorf("filter value broke out of string literal\n%s", query)
+	}
+}
+
+func TestBackupRecordRoundTrip(t *testing.T) {
+	timestamp := time.Date(2025, 3, 1, 10, 15, 30, 123456789, time.UTC)
+	record := map[string]interface{}{
+		"_measurement": "transaction_events",
+		"_time":        timestamp,
+		"status":       "completed",
+		"currency":     "IDR",
+		"amount":       float64(150000), // Integral float must stay float
+		"retry_count":  int64(2),
+		"is_bot":       false,
+		"user_id":      "user-1",
+		"detail_score": nil, // Missing field in pivot
+	}
+
+	backup, err := NewBackupRecord(record, map[string]bool{"status": true, "currency": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backup.Tags) != 2 || backup.Tags["status"] != "completed" || backup.Tags["currency"] != "IDR" {
+		t.Errorf("unexpected tags: %v", backup.Tags)
+	}
+	if _, exists := backup.Fields["detail_score"]; exists {
+		t.Error("null field should be dropped")
+	}
+
+	line, err := json.Marshal(backup)
+	if err != nil {
+		t.Fatalf("failed to marshal backup: %v", err)
+	}
+
+	restored, err := DecodeBackupRecord(line)
+	if err != nil {
+		t.Fatalf("failed to decode backup: %v", err)
+	}
+	if !restored.Time.Equal(timestamp) {
+		t.Errorf("time = %v, want %v", restored.Time, timestamp)
+	}
+	if restored.Measurement != "transaction_events" {
+		t.Errorf("measurement = %q", restored.Measurement)
+	}
+	if v, ok := restored.Fields["amount"].(float64); !ok || v != 150000 {
+		t.Errorf("amount = %#v, want float64 150000", restored.Fields["amount"])
+	}
+	if v, ok := restored.Fields["retry_count"].(int64); !ok || v != 2 {
+		t.Errorf("retry_count = %#v, want int64 2", restored.Fields["retry_count"])
+	}
+	if v, ok := restored.Fields["is_bot"].(bool); !ok || v {
+		t.Errorf("is_bot = %#v, want false", restored.Fields["is_bot"])
+	}
+	if restored.Tags["status"] != "completed" {
+		t.Errorf("tags not restored: %v", restored.Tags)
+	}
+
+	// Records without measurement or time are rejected
+	if _, err := NewBackupRecord(map[string]interface{}{"_time": timestamp}, nil); err == nil {
+		t.Error("expected error for record without measurement")
+	}
+	if _, err := DecodeBackupRecord([]byte(`{"measurement":"transaction_events","fields":{"amount":1}}`)); err == nil {
+		t.Error("expected error for line without time")
+	}
+}
+
+func TestDynamicFieldFilters(t *testing.T) {
+	req := &PaginationRequest{
+		Length:    10,
+		Direction: "next",
+		Filters: []FilterItem{
+			{Key: "Tenant_ID", Value: "acme"},
+			{Key: "referrer", Value: "google", Operator: "contains"},
+			{Key: "status", Value: "completed"},
+		},
+	}
+
+	// Disabled by default: unknown keys are ignored
+	query, err := testQueryBuilder().BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(query, "tenant_id") || strings.Contains(query, "referrer") {
+		t.Errorf("dynamic keys must be ignored when not allowed\n%s", query)
+	}
+
+	qb := testQueryBuilder()
+	qb.config.AllowDynamicFields = true
+
+	query, err = qb.BuildQuery(req, "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pivot := strings.Index(query, "pivot(")
+	for _, expected := range []string{`r["tenant_id"] == "acme"`, `strings.containsStr(v: r["referrer"], substr: "google")`} {
+		if idx := strings.Index(query, expected); idx < pivot {
+			t.Errorf("expected %s after pivot\n%s", expected, query)
+		}
+	}
+	if !strings.HasPrefix(query, `import "strings"`) {
+		t.Errorf("contains on dynamic key requires strings import\n%s", query)
+	}
+	if idx := strings.Index(query, `r["status"] == "completed"`); idx > pivot {
+		t.Errorf("configured tag must stay before pivot\n%s", query)
+	}
+
+	keys := qb.DynamicFilterKeys(append(req.Filters, FilterItem{Key: "tenant_id", Value: "other"}, FilterItem{Key: "empty"}))
+	if len(keys) != 2 || keys[0] != "tenant_id" || keys[1] != "referrer" {
+		t.Errorf("unexpected dynamic keys %v", keys)
+	}
+
+	// Sanitization & operators
+	tests := []struct {
+		name    string
+		filter  FilterItem
+		wantErr string
+	}{
+		{"quote", FilterItem{Key: `tenant"]`, Value: "x"}, "invalid filter key"},
+		{"whitespace", FilterItem{Key: "tenant id", Value: "x"}, "invalid filter key"},
+		{"flux operator", FilterItem{Key: "a==b", Value: "x"}, "invalid filter key"},
+		{"system column", FilterItem{Key: "_measurement", Value: "x"}, "invalid filter key"},
+		{"too long", FilterItem{Key: "k" + strings.Repeat("a", 64), Value: "x"}, "invalid filter key"},
+		{"range operator", FilterItem{Key: "tenant_id", Value: "1", Operator: "gt"}, "not supported for dynamic key"},
+		{"regex", FilterItem{Key: "tenant_id", Value: "^ac", Operator: "regex"}, ""},
+		{"configured field keeps rules", FilterItem{Key: "amount", Value: "abc"}, "requires numeric value"},
+	}
+	for _, tt := range tests {
+		err := qb.ValidateRequest(&PaginationRequest{Length: 10, Direction: "next", Filters: []FilterItem{tt.filter}})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want containing %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
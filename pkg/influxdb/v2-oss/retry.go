@@ -0,0 +1,97 @@
+package v2oss
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	influxhttp "github.com/influxdata/influxdb-client-go/v2/api/http"
+)
+
+// Write retry defaults (influxdb.write_retry)
+const (
+	DefaultWriteMaxAttempts = 3
+	DefaultWriteBaseDelay   = 200 * time.Millisecond
+	maxWriteRetryDelay      = 5 * time.Second
+)
+
+// RetryOptions configures in-client retry of transient write failures
+type RetryOptions struct {
+	MaxAttempts int           // Total attempts including first one (1 disables retry)
+	BaseDelay   time.Duration // Delay before second attempt, doubled per attempt (capped at 5s)
+}
+
+// withDefaults fills non-positive options with defaults
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultWriteMaxAttempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultWriteBaseDelay
+	}
+	return o
+}
+
+// delay returns backoff before given retry (1 = first retry)
+func (o RetryOptions) delay(retry int) time.Duration {
+	delay := o.BaseDelay
+	for i := 1; i < retry && delay < maxWriteRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWriteRetryDelay {
+		delay = maxWriteRetryDelay
+	}
+	return delay
+}
+
+// sleep waits between attempts (replaced in tests)
+var sleep = time.Sleep
+
+// retryWrite runs write until it succeeds, fails with non-retryable error or attempts are exhausted.
+// Retryable: 5xx, 429, timeouts and connection errors. Non-retryable (4xx, malformed point) return immediately.
+func retryWrite(opts RetryOptions, measurement string, write func() error) error {
+	opts = opts.withDefaults()
+
+	var err error
+	for attempt := 1; attempt <= opts.MaxAttempts; attempt++ {
+		if err = write(); err == nil || !IsRetryableWriteError(err) {
+			return err
+		}
+		if attempt == opts.MaxAttempts {
+			break
+		}
+
+		delay := opts.delay(attempt)
+		logger.WithScope("InfluxWriteRetry").Warn().
+			Err(err).
+			Str("measurement", measurement).
+			Int("attempt", attempt).
+			Int("max_attempts", opts.MaxAttempts).
+			Dur("retry_in", delay).
+			Msg("Transient InfluxDB write failure, retrying")
+		sleep(delay)
+	}
+	return err
+}
+
+// IsRetryableWriteError reports whether write error is transient (server error, rate limit, timeout, connection)
+func IsRetryableWriteError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr *influxhttp.Error
+	if errors.As(err, &httpErr) && httpErr.StatusCode != 0 {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// Transport failures (connection refused/reset, DNS, timeout) before any response
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,87 @@
+package v2oss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	influxhttp "github.com/influxdata/influxdb-client-go/v2/api/http"
+)
+
+func TestIsRetryableWriteError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"server error", &influxhttp.Error{StatusCode: 503}, true},
+		{"internal error wrapped", fmt.Errorf("failed to write point: %w", &influxhttp.Error{StatusCode: 500}), true},
+		{"rate limited", &influxhttp.Error{StatusCode: 429}, true},
+		{"bad request (malformed point)", &influxhttp.Error{StatusCode: 400, Code: "invalid", Message: "unable to parse"}, false},
+		{"unauthorized", &influxhttp.Error{StatusCode: 401}, false},
+		{"bucket not found", &influxhttp.Error{StatusCode: 404}, false},
+		{"timeout", fmt.Errorf("write: %w", context.DeadlineExceeded), true},
+		{"connection refused", influxhttp.NewError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), true},
+		{"plain error", errors.New("invalid point type for v2-oss"), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryableWriteError(tt.err); got != tt.expected {
+			t.Errorf("%s: IsRetryableWriteError = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+// stubSleep records backoff delays instead of sleeping
+func stubSleep(t *testing.T) *[]time.Duration {
+	var delays []time.Duration
+	orig := sleep
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	t.Cleanup(func() { sleep = orig })
+	return &delays
+}
+
+func TestRetryWrite(t *testing.T) {
+	transient := &influxhttp.Error{StatusCode: 503}
+	permanent := &influxhttp.Error{StatusCode: 400}
+
+	tests := []struct {
+		name         string
+		opts         RetryOptions
+		results      []error // Result per attempt, last one repeats
+		wantErr      error
+		wantAttempts int
+		wantDelays   []time.Duration
+	}{
+		{"first attempt succeeds", RetryOptions{}, []error{nil}, nil, 1, nil},
+		{"recovers after transient failures", RetryOptions{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond}, []error{transient, transient, nil}, nil, 3, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{"non-retryable returned immediately", RetryOptions{MaxAttempts: 5}, []error{permanent}, permanent, 1, nil},
+		{"attempts exhausted", RetryOptions{MaxAttempts: 2, BaseDelay: time.Second}, []error{transient}, transient, 2, []time.Duration{time.Second}},
+		{"retry disabled", RetryOptions{MaxAttempts: 1}, []error{transient}, transient, 1, nil},
+		{"backoff capped", RetryOptions{MaxAttempts: 4, BaseDelay: 3 * time.Second}, []error{transient}, transient, 4, []time.Duration{3 * time.Second, 5 * time.Second, 5 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		delays := stubSleep(t)
+		attempts := 0
+		err := retryWrite(tt.opts, "transaction_events", func() error {
+			result := tt.results[min(attempts, len(tt.results)-1)]
+			attempts++
+			return result
+		})
+
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+		if attempts != tt.wantAttempts {
+			t.Errorf("%s: expected %d attempts, got %d", tt.name, tt.wantAttempts, attempts)
+		}
+		if fmt.Sprint(*delays) != fmt.Sprint(tt.wantDelays) && !(len(*delays) == 0 && len(tt.wantDelays) == 0) {
+			t.Errorf("%s: expected delays %v, got %v", tt.name, tt.wantDelays, *delays)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package v2oss
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	// minTimeSeriesWindow rejects sub-minute windows (points are not that dense, only adds query cost)
+	minTimeSeriesWindow = time.Minute
+
+	// maxTimeSeriesBuckets caps windows per series (range / window), keeps query and response bounded
+	maxTimeSeriesBuckets = 1000
+)
+
+// fluxDurationPartRegex matches single magnitude + unit of Flux duration literal (mo before m, leftmost alternative wins)
+var fluxDurationPartRegex = regexp.MustCompile(`([0-9]+)(ns|us|µs|ms|mo|s|m|h|d|w|y)`)
+
+// fluxUnitDurations approximates calendar units (mo = 30d, y = 365d), enough for bucket count limits
+var fluxUnitDurations = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"mo": 30 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// ToPaginationRequest converts time-series request into pagination request carrying filters and range
+func (r *TimeSeriesRequest) ToPaginationRequest() *PaginationRequest {
+	return &PaginationRequest{
+		Length:    1, // Not applied to aggregation
+		Direction: "next",
+		Filters:   r.Filters,
+		Range:     r.Range,
+	}
+}
+
+// BuildTimeSeriesQuery constructs Flux query counting records per time window, one series per group.
+// Same filters and range as list query; groupBy must be known tags (empty = single series).
+func (qb *QueryBuilder) BuildTimeSeriesQuery(req *PaginationRequest, window string, groupBy []string, bucket string) (string, error) {
+	if err := qb.ValidateTimeSeries(req, window, groupBy); err != nil {
+		return "", err
+	}
+
+	// Reuse windowed aggregate query (aggregateWindow per group table)
+	windowed := *req
+	windowed.Window = window
+	return qb.BuildAggregateQuery(&windowed, groupBy, bucket)
+}
+
+// ExecuteTimeSeries builds and executes time-series query, returns buckets ordered by time
+func (qb *QueryBuilder) ExecuteTimeSeries(req *PaginationRequest, window string, groupBy []string, client *Client) ([]TimeBucket, error) {
+	// Build query
+	bucket := qb.bucket(client)
+	query, err := qb.BuildTimeSeriesQuery(req, window, groupBy, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute query
+	result, err := client.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, ok := result.(*QueryIterator)
+	if !ok || iterator == nil {
+		return []TimeBucket{}, nil
+	}
+
+	defer func() { _ = iterator.Close() }()
+
+	// Parse results (window time + group keys + count)
+	buckets := []TimeBucket{}
+	for iterator.Next() {
+		record := iterator.Record()
+		if record == nil {
+			continue
+		}
+
+		bucketTime, ok := record["_time"].(time.Time)
+		if !ok {
+			continue
+		}
+
+		item := TimeBucket{Time: bucketTime, Value: countValue(record["_value"])}
+		if len(groupBy) > 0 {
+			item.Group = make(map[string]string, len(groupBy))
+			for _, key := range groupBy {
+				if value, ok := record[key].(string); ok {
+					item.Group[key] = value
+				}
+			}
+		}
+		buckets = append(buckets, item)
+	}
+
+	// Check for iterator errors
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+// ValidateTimeSeries validates window duration, group by tags, filters and date range
+func (qb *QueryBuilder) ValidateTimeSeries(req *PaginationRequest, window string, groupBy []string) error {
+	if window == "" {
+		return fmt.Errorf("window is required")
+	}
+	if !IsValidFluxDuration(window) {
+		return fmt.Errorf("invalid window '%s', expected duration like 5m, 1h, 1d", window)
+	}
+
+	windowDuration := fluxDuration(window)
+	if windowDuration < minTimeSeriesWindow {
+		return fmt.Errorf("window '%s' is too small, minimum is %s", window, minTimeSeriesWindow)
+	}
+
+	rangeDuration, err := RangeDuration(req.Range)
+	if err != nil {
+		return err
+	}
+	if buckets := rangeDuration / windowDuration; buckets > maxTimeSeriesBuckets {
+		return fmt.Errorf("window '%s' produces %d buckets over range, maximum is %d, use larger window or shorter range", window, buckets, maxTimeSeriesBuckets)
+	}
+
+	windowed := *req
+	windowed.Window = window
+	return qb.validateAggregateRequest(&windowed, groupBy)
+}
+
+// fluxDuration converts valid Flux duration literal into time.Duration
+func fluxDuration(value string) time.Duration {
+	var total time.Duration
+	for _, part := range fluxDurationPartRegex.FindAllStringSubmatch(value, -1) {
+		magnitude, err := strconv.ParseInt(part[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		total += time.Duration(magnitude) * fluxUnitDurations[part[2]]
+	}
+	return total
+}
+
+// countValue converts count result value into int64
+func countValue(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return 0
+}
@@ -1,30 +1,114 @@
 package v2oss
 
+import "time"
+
 // PaginationRequest represents cursor-based pagination request  
 type PaginationRequest struct {
 	Length    int              `json:"length" validate:"required,min=1,max=100"`
-	Cursor    *string          `json:"cursor,omitempty"`    // RFC3339 timestamp string for cursor-based pagination
+	Cursor    *string          `json:"cursor,omitempty"`    // Cursor token (or legacy RFC3339 timestamp) from previous page
 	Direction string           `json:"direction" validate:"required,oneof=next prev"`
 	Filters   []FilterItem     `json:"filters"`
 	Range     *DateRangeFilter `json:"range,omitempty"`
+	Window    string           `json:"window,omitempty"`    // Aggregate queries only: time bucket duration (e.g. 1h)
+	SortBy    string           `json:"sort_by,omitempty"`   // Sort column (tag or field), default _time
+	SortDesc  bool             `json:"sort_desc,omitempty"` // Sort descending when SortBy is set
+	Debug     bool             `json:"debug,omitempty"`     // Include generated Flux in response (requires debug:query permission)
+}
+
+// ExportRequest represents export request (same filters/range/sort as list, without paging)
+type ExportRequest struct {
+	Filters  []FilterItem     `json:"filters"`
+	Range    *DateRangeFilter `json:"range,omitempty"`
+	SortBy   string           `json:"sort_by,omitempty"`   // Sort column (tag or field), default _time
+	SortDesc bool             `json:"sort_desc,omitempty"` // Sort descending when SortBy is set
+}
+
+// TimeSeriesRequest represents time-series request (same filters/range as list, counted per window)
+type TimeSeriesRequest struct {
+	Filters []FilterItem     `json:"filters"`
+	Range   *DateRangeFilter `json:"range,omitempty"`
+	Window  string           `json:"window" validate:"required"` // Bucket duration (e.g. 5m, 1h, 1d)
+	GroupBy []string         `json:"group_by,omitempty"`         // Tag columns, one series per group
+}
+
+// TimeBucket represents count of records in single time window for one group
+type TimeBucket struct {
+	Time  time.Time         `json:"time"`
+	Group map[string]string `json:"group,omitempty"`
+	Value int64             `json:"value"`
 }
 
 // FilterItem represents individual filter criteria
 type FilterItem struct {
-	Key   string `json:"key" validate:"required"`
-	Value string `json:"value" validate:"required"`
+	Key      string   `json:"key" validate:"required"`
+	Value    string   `json:"value" validate:"required_without=Values"`
+	Values   []string `json:"values,omitempty"`                                                              // Multiple values combined with OR (together with Value)
+	Operator string   `json:"operator,omitempty" validate:"omitempty,oneof=eq gt gte lt lte contains regex"` // Default: eq (range operators only for numeric fields, contains/regex only for string fields)
+}
+
+// Filter operators
+const (
+	OperatorEq  = "eq"
+	OperatorGt  = "gt"
+	OperatorGte = "gte"
+	OperatorLt  = "lt"
+	OperatorLte = "lte"
+
+	// String match operators (non-numeric fields only, evaluated after pivot)
+	OperatorContains = "contains"
+	OperatorRegex    = "regex"
+)
+
+// filterOperators maps filter operators to Flux comparison operators
+var filterOperators = map[string]string{
+	OperatorEq:  "==",
+	OperatorGt:  ">",
+	OperatorGte: ">=",
+	OperatorLt:  "<",
+	OperatorLte: "<=",
+}
+
+// matchOperators lists string match operators (substring & regular expression)
+var matchOperators = map[string]bool{
+	OperatorContains: true,
+	OperatorRegex:    true,
 }
 
 // DateRangeFilter represents date range filtering
 type DateRangeFilter struct {
-	Start string `json:"start,omitempty"` // YYYY-MM-DD format
-	End   string `json:"end,omitempty"`   // YYYY-MM-DD format
+	Start  string `json:"start,omitempty"`  // YYYY-MM-DD format
+	End    string `json:"end,omitempty"`    // YYYY-MM-DD format
+	Preset string `json:"preset,omitempty"` // Relative range (e.g. 1h, 24h, 7d), takes precedence over start/end
+}
+
+// timeRangePresets lists accepted relative range presets
+var timeRangePresets = map[string]bool{
+	"5m":  true,
+	"15m": true,
+	"30m": true,
+	"1h":  true,
+	"3h":  true,
+	"6h":  true,
+	"12h": true,
+	"24h": true,
+	"2d":  true,
+	"7d":  true,
+	"14d": true,
+	"30d": true,
+	"90d": true,
 }
 
 // PaginationResponse represents the response structure with pagination info
 type PaginationResponse struct {
 	Data       interface{}    `json:"data"`
 	Pagination PaginationInfo `json:"pagination"`
+	Debug      *QueryDebug    `json:"debug,omitempty"`
+}
+
+// QueryDebug contains generated Flux queries for debugging list requests
+type QueryDebug struct {
+	DataQuery  string `json:"data_query"`
+	CountQuery string `json:"count_query"`
 }
 
 // PaginationInfo contains cursor-based pagination metadata
@@ -32,17 +116,24 @@ type PaginationInfo struct {
 	Length     int     `json:"length"`
 	HasNext    bool    `json:"has_next"`
 	HasPrev    bool    `json:"has_prev"`
-	NextCursor *string `json:"next_cursor,omitempty"` // RFC3339 timestamp for next page
-	PrevCursor *string `json:"prev_cursor,omitempty"` // RFC3339 timestamp for prev page  
+	NextCursor *string `json:"next_cursor,omitempty"` // Cursor token for next page
+	PrevCursor *string `json:"prev_cursor,omitempty"` // Cursor token for prev page  
 	Direction  string  `json:"direction"`
 	Total      int     `json:"total,omitempty"`       // Optional total count
 }
 
 // QueryBuilderConfig configuration for query builder
 type QueryBuilderConfig struct {
-	Measurement string          `json:"measurement"`
-	ValidTags   map[string]bool `json:"valid_tags"`   // Tag fields that can be filtered
-	ValidFields map[string]bool `json:"valid_fields"` // Field columns that can be filtered
-	Columns     []string        `json:"columns"`      // Columns to select in result
-	CountField  string          `json:"count_field"`  // Field to use for counting unique records (optional)
+	Measurement   string          `json:"measurement"`
+	ValidTags     map[string]bool `json:"valid_tags"`     // Tag fields that can be filtered
+	ValidFields   map[string]bool `json:"valid_fields"`   // Field columns that can be filtered
+	NumericFields map[string]bool `json:"numeric_fields"` // Subset of ValidFields holding numeric values (range operators allowed)
+	BoolFields    map[string]bool `json:"bool_fields"`    // Subset of ValidFields holding booleans (eq only, value true/false)
+	Columns       []string        `json:"columns"`        // Columns to select in result
+	CountField    string          `json:"count_field"`    // Field to use for counting unique records (optional)
+	Bucket        string          `json:"bucket"`         // Bucket override for measurement (optional, empty = client bucket)
+
+	// AllowDynamicFields permits filters on keys outside ValidTags/ValidFields (sanitized, string match only).
+	// Off by default, handlers enable it per request for admin clients.
+	AllowDynamicFields bool `json:"allow_dynamic_fields"`
 }
\ No newline at end of file
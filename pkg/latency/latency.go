@@ -0,0 +1,248 @@
+// Package latency records ingest end-to-end latency (task enqueue → InfluxDB write) as Prometheus-style histograms.
+// Workers observe locally and periodically flush counter deltas into shared Redis hash, so metrics endpoint served
+// by HTTP process sees totals of all worker processes.
+package latency
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+	"github.com/benedict-erwin/insight-collector/pkg/utils"
+)
+
+// EnqueuedAtField is payload JSON field stamped on enqueue
+const EnqueuedAtField = "enqueued_at"
+
+// redisKey holds cumulative counters of all task types (fields "<task_type>|<counter>")
+const redisKey = "asynq:metrics:ingest_latency"
+
+// BucketsMs are histogram upper bounds in milliseconds (+Inf bucket is implicit)
+var BucketsMs = []int64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000}
+
+// counters holds non-cumulative bucket counts, sum & count of single task type
+type counters struct {
+	buckets []int64 // len(BucketsMs)+1, last is +Inf
+	sumUs   int64
+	count   int64
+}
+
+var (
+	pending      = make(map[string]*counters) // Observed since last flush
+	pendingMutex sync.Mutex
+)
+
+// Bucket is cumulative count of observations less than or equal to Le milliseconds ("+Inf" for all)
+type Bucket struct {
+	Le    string `json:"le"`
+	Count int64  `json:"count"`
+}
+
+// Histogram is cumulative latency histogram of single task type
+type Histogram struct {
+	Buckets []Bucket `json:"buckets"`
+	SumMs   float64  `json:"sum_ms"`
+	Count   int64    `json:"count"`
+}
+
+// Stamp adds enqueued_at (RFC3339Nano) to JSON object payload, other payloads are returned unchanged
+func Stamp(data []byte, at time.Time) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return data
+	}
+
+	field := fmt.Sprintf(`"%s":"%s"`, EnqueuedAtField, at.Format(time.RFC3339Nano))
+	rest := bytes.TrimSpace(trimmed[1:])
+	if rest[0] != '}' {
+		field += ","
+	}
+
+	stamped := make([]byte, 0, len(trimmed)+len(field))
+	stamped = append(stamped, '{')
+	stamped = append(stamped, field...)
+	return append(stamped, rest...)
+}
+
+// EnqueuedAt extracts enqueue time stamped on payload
+func EnqueuedAt(payload []byte) (time.Time, bool) {
+	var stamp struct {
+		EnqueuedAt time.Time `json:"enqueued_at"`
+	}
+	if err := json.Unmarshal(payload, &stamp); err != nil || stamp.EnqueuedAt.IsZero() {
+		return time.Time{}, false
+	}
+	return stamp.EnqueuedAt, true
+}
+
+// ObserveTask records enqueue → now latency of task payload (payloads without enqueued_at are skipped).
+// Job handlers call it right after point write, latency is also logged at debug.
+func ObserveTask(taskType string, payload []byte) {
+	enqueuedAt, ok := EnqueuedAt(payload)
+	if !ok {
+		return
+	}
+
+	elapsed := utils.Now().Sub(enqueuedAt)
+	if elapsed < 0 {
+		elapsed = 0 // Clock skew between API & worker hosts
+	}
+	Observe(taskType, elapsed)
+
+	logger.WithScope("IngestLatency").Debug().
+		Str("task_type", taskType).
+		Dur("latency", elapsed).
+		Msg("Ingest latency observed")
+}
+
+// Observe records latency of task type
+func Observe(taskType string, elapsed time.Duration) {
+	pendingMutex.Lock()
+	defer pendingMutex.Unlock()
+
+	c, found := pending[taskType]
+	if !found {
+		c = &counters{buckets: make([]int64, len(BucketsMs)+1)}
+		pending[taskType] = c
+	}
+
+	c.buckets[bucketIndex(elapsed)]++
+	c.sumUs += elapsed.Microseconds()
+	c.count++
+}
+
+// bucketIndex returns index of first bucket holding elapsed, len(BucketsMs) for +Inf
+func bucketIndex(elapsed time.Duration) int {
+	ms := elapsed.Milliseconds()
+	for i, le := range BucketsMs {
+		if ms <= le {
+			return i
+		}
+	}
+	return len(BucketsMs)
+}
+
+// flushScript increments hash fields by ARGV pairs (field, delta)
+const flushScript = `
+for i = 1, #ARGV, 2 do
+  redis.call('HINCRBY', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1`
+
+// Flush adds latencies observed since last flush to shared Redis counters, deltas are kept for next flush on error
+func Flush(client redis.Client) error {
+	pendingMutex.Lock()
+	observed := pending
+	pending = make(map[string]*counters)
+	pendingMutex.Unlock()
+
+	if len(observed) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(observed)*(len(BucketsMs)+3)*2)
+	for taskType, c := range observed {
+		for i, count := range c.buckets {
+			if count > 0 {
+				args = append(args, field(taskType, bucketLabel(i)), count)
+			}
+		}
+		args = append(args, field(taskType, "sum_us"), c.sumUs, field(taskType, "count"), c.count)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := client.Eval(ctx, flushScript, []string{redisKey}, args...); err != nil {
+		restore(observed)
+		return fmt.Errorf("failed to flush ingest latency: %w", err)
+	}
+	return nil
+}
+
+// restore merges unflushed counters back into pending
+func restore(observed map[string]*counters) {
+	pendingMutex.Lock()
+	defer pendingMutex.Unlock()
+
+	for taskType, c := range observed {
+		current, found := pending[taskType]
+		if !found {
+			pending[taskType] = c
+			continue
+		}
+		for i := range c.buckets {
+			current.buckets[i] += c.buckets[i]
+		}
+		current.sumUs += c.sumUs
+		current.count += c.count
+	}
+}
+
+// Load returns cumulative histograms per task type from shared Redis counters
+func Load(client redis.Client) (map[string]Histogram, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	result, err := client.Eval(ctx, `return redis.call('HGETALL', KEYS[1])`, []string{redisKey})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ingest latency: %w", err)
+	}
+
+	values, _ := result.([]interface{})
+	fields := make(map[string]int64, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		key, _ := values[i].(string)
+		value, _ := values[i+1].(string)
+		fields[key], _ = strconv.ParseInt(value, 10, 64)
+	}
+	return buildHistograms(fields), nil
+}
+
+// buildHistograms converts raw counter fields into cumulative histograms
+func buildHistograms(fields map[string]int64) map[string]Histogram {
+	taskTypes := make(map[string]bool)
+	for key := range fields {
+		if taskType, _, found := strings.Cut(key, "|"); found {
+			taskTypes[taskType] = true
+		}
+	}
+
+	histograms := make(map[string]Histogram, len(taskTypes))
+	for taskType := range taskTypes {
+		histogram := Histogram{
+			Buckets: make([]Bucket, 0, len(BucketsMs)+1),
+			SumMs:   float64(fields[field(taskType, "sum_us")]) / 1000,
+			Count:   fields[field(taskType, "count")],
+		}
+
+		var cumulative int64
+		for i := 0; i <= len(BucketsMs); i++ {
+			label := bucketLabel(i)
+			cumulative += fields[field(taskType, label)]
+			histogram.Buckets = append(histogram.Buckets, Bucket{Le: strings.TrimPrefix(label, "le_"), Count: cumulative})
+		}
+		histograms[taskType] = histogram
+	}
+	return histograms
+}
+
+// bucketLabel returns counter name of bucket index
+func bucketLabel(i int) string {
+	if i >= len(BucketsMs) {
+		return "le_+Inf"
+	}
+	return "le_" + strconv.FormatInt(BucketsMs[i], 10)
+}
+
+// field returns hash field of task type counter
+func field(taskType, counter string) string {
+	return taskType + "|" + counter
+}
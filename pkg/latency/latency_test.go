@@ -0,0 +1,133 @@
+package latency
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+)
+
+// fakeRedis stores hash fields touched by flush & load scripts
+type fakeRedis struct {
+	redis.Client
+	hash map[string]int64
+	fail bool
+}
+
+func (f *fakeRedis) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	if f.fail {
+		return nil, errors.New("redis unavailable")
+	}
+	if script == flushScript {
+		for i := 0; i+1 < len(args); i += 2 {
+			f.hash[args[i].(string)] += args[i+1].(int64)
+		}
+		return int64(1), nil
+	}
+
+	values := make([]interface{}, 0, len(f.hash)*2)
+	for key, value := range f.hash {
+		values = append(values, key, strconv.FormatInt(value, 10))
+	}
+	return values, nil
+}
+
+func resetPending(t *testing.T) {
+	t.Helper()
+	pendingMutex.Lock()
+	pending = make(map[string]*counters)
+	pendingMutex.Unlock()
+}
+
+func TestStampAndEnqueuedAt(t *testing.T) {
+	at := time.Date(2025, 8, 6, 12, 30, 0, 123456789, time.FixedZone("WIB", 7*3600))
+
+	stamped := Stamp([]byte(`{"user_id":"user-1"}`), at)
+	if got, ok := EnqueuedAt(stamped); !ok || !got.Equal(at) {
+		t.Errorf("expected enqueued_at %v, got %v (%v) from %s", at, got, ok, stamped)
+	}
+
+	if stamped := Stamp([]byte(` { } `), at); string(stamped) != `{"enqueued_at":"2025-08-06T12:30:00.123456789+07:00"}` {
+		t.Errorf("unexpected stamped empty object %s", stamped)
+	}
+
+	// Non-object payloads untouched
+	for _, payload := range []string{`["a"]`, `"text"`, ``} {
+		if got := Stamp([]byte(payload), at); string(got) != payload {
+			t.Errorf("expected %q unchanged, got %q", payload, got)
+		}
+	}
+
+	if _, ok := EnqueuedAt([]byte(`{"user_id":"user-1"}`)); ok {
+		t.Error("expected no enqueued_at on unstamped payload")
+	}
+}
+
+func TestFlushAndLoadCumulativeHistogram(t *testing.T) {
+	resetPending(t)
+	client := &fakeRedis{hash: map[string]int64{}}
+
+	Observe("user_activities:logging", 5*time.Millisecond)
+	Observe("user_activities:logging", 80*time.Millisecond)
+	Observe("user_activities:logging", 10*time.Minute)
+	Observe("security_events:logging", 300*time.Millisecond)
+
+	// Failed flush keeps deltas for next flush
+	client.fail = true
+	if err := Flush(client); err == nil {
+		t.Fatal("expected flush error")
+	}
+	client.fail = false
+	if err := Flush(client); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	// Second worker flush adds to shared counters
+	Observe("user_activities:logging", 5*time.Millisecond)
+	if err := Flush(client); err != nil {
+		t.Fatalf("unexpected flush error: %v", err)
+	}
+
+	histograms, err := Load(client)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+
+	ua := histograms["user_activities:logging"]
+	if ua.Count != 4 {
+		t.Errorf("expected count 4, got %d", ua.Count)
+	}
+	if want := float64(5 + 80 + 600000 + 5); ua.SumMs != want {
+		t.Errorf("expected sum %vms, got %v", want, ua.SumMs)
+	}
+	expected := map[string]int64{"10": 2, "50": 2, "100": 3, "300000": 3, "+Inf": 4}
+	for _, bucket := range ua.Buckets {
+		if want, found := expected[bucket.Le]; found && bucket.Count != want {
+			t.Errorf("bucket le=%s: expected %d, got %d", bucket.Le, want, bucket.Count)
+		}
+	}
+	if last := ua.Buckets[len(ua.Buckets)-1]; last.Le != "+Inf" || last.Count != ua.Count {
+		t.Errorf("expected +Inf bucket to equal count, got %+v", last)
+	}
+
+	if se := histograms["security_events:logging"]; se.Count != 1 || se.Buckets[4].Le != "500" || se.Buckets[4].Count != 1 {
+		t.Errorf("unexpected security_events histogram %+v", se)
+	}
+}
+
+func TestObserveTaskSkipsUnstampedAndClampsSkew(t *testing.T) {
+	resetPending(t)
+
+	ObserveTask("error_events:logging", []byte(`{"message":"boom"}`))
+	ObserveTask("error_events:logging", Stamp([]byte(`{}`), time.Now().Add(time.Hour)))
+
+	pendingMutex.Lock()
+	defer pendingMutex.Unlock()
+	c := pending["error_events:logging"]
+	if c == nil || c.count != 1 || c.buckets[0] != 1 || c.sumUs != 0 {
+		t.Errorf("expected single zero latency observation, got %+v", c)
+	}
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var log zerolog.Logger
+var (
+	log      zerolog.Logger
+	silenced bool
+)
 
 // orderedJSONWriter ensures consistent field ordering in JSON output
 type orderedJSONWriter struct {
@@ -69,7 +72,7 @@ func init() {
 	zerolog.TimestampFunc = func() time.Time {
 		return time.Now().In(time.UTC)
 	}
-	log.Info().Msg("Logger initialized with default settings in pkg/logger")
+	log.Debug().Msg("Logger initialized with default settings in pkg/logger")
 }
 
 // Init configures the logger with timezone settings
@@ -112,6 +115,16 @@ func Init(timezone, environment string) {
 		return time.Now().In(loc)
 	}
 	log.Info().Str("timezone", loc.String()).Str("environment", environment).Msg("Logger reconfigured")
+
+	if silenced {
+		zerolog.SetGlobalLevel(zerolog.Disabled)
+	}
+}
+
+// Silence disables all log output, kept across Init (stdout reserved for command output, e.g. shell completion)
+func Silence() {
+	silenced = true
+	zerolog.SetGlobalLevel(zerolog.Disabled)
 }
 
 // Log returns a log event
@@ -164,6 +177,17 @@ func WithScope(scope string) *ScopedLogger {
 	}
 }
 
+// WithTraceID returns copy of scoped logger adding trace_id to every event, same logger when trace ID is empty
+func (s *ScopedLogger) WithTraceID(traceID string) *ScopedLogger {
+	if traceID == "" {
+		return s
+	}
+	return &ScopedLogger{
+		logger: s.logger.With().Str("trace_id", traceID).Logger(),
+		scope:  s.scope,
+	}
+}
+
 // Log returns a log level log event with scope
 func (s *ScopedLogger) Log() *zerolog.Event {
 	return s.logger.Log()
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// maxSampledKeys bounds tracked sampling keys, expired windows are dropped when reached
+const maxSampledKeys = 10000
+
+// sampleWindow counts events of single key within current interval
+type sampleWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+var (
+	sampleWindows = make(map[string]*sampleWindow)
+	sampleMutex   sync.Mutex
+
+	// sampleNow returns current time (replaced in tests)
+	sampleNow = time.Now
+)
+
+// SampledLogger emits at most burst events per key within every interval, shared by all callers using same key
+type SampledLogger struct {
+	key   string
+	burst int
+	every time.Duration
+}
+
+// Sampled returns warn level event emitted at most once per key within every, no-op event when over budget.
+// Use it for repeated identical lines (e.g. same unknown pattern, same lookup failure) from hot paths.
+func Sampled(key string, every time.Duration) *zerolog.Event {
+	return SampledBurst(key, 1, every).Warn()
+}
+
+// SampledBurst returns sampled logger emitting at most burst events per key within every.
+// Non-positive burst or every disables sampling (every event is emitted).
+func SampledBurst(key string, burst int, every time.Duration) *SampledLogger {
+	return &SampledLogger{key: key, burst: burst, every: every}
+}
+
+// Debug returns debug level event, no-op when over budget
+func (s *SampledLogger) Debug() *zerolog.Event {
+	return s.event(zerolog.DebugLevel)
+}
+
+// Info returns info level event, no-op when over budget
+func (s *SampledLogger) Info() *zerolog.Event {
+	return s.event(zerolog.InfoLevel)
+}
+
+// Warn returns warning level event, no-op when over budget
+func (s *SampledLogger) Warn() *zerolog.Event {
+	return s.event(zerolog.WarnLevel)
+}
+
+// Error returns error level event, no-op when over budget
+func (s *SampledLogger) Error() *zerolog.Event {
+	return s.event(zerolog.ErrorLevel)
+}
+
+// event returns level event when enabled and within budget, nil (no-op) event otherwise.
+// Budget is only consumed by enabled levels so disabled debug lines do not starve later ones.
+func (s *SampledLogger) event(level zerolog.Level) *zerolog.Event {
+	if level < zerolog.GlobalLevel() || level < log.GetLevel() {
+		return nil
+	}
+	if !allowSample(s.key, s.burst, s.every) {
+		return nil
+	}
+	return log.WithLevel(level).Str("sample_key", s.key)
+}
+
+// allowSample consumes one event of key budget, false when burst is exhausted within current interval
+func allowSample(key string, burst int, every time.Duration) bool {
+	if burst <= 0 || every <= 0 {
+		return true
+	}
+
+	now := sampleNow()
+
+	sampleMutex.Lock()
+	defer sampleMutex.Unlock()
+
+	window, found := sampleWindows[key]
+	if !found || !now.Before(window.resetAt) {
+		if !found && len(sampleWindows) >= maxSampledKeys {
+			pruneSampleWindows(now)
+		}
+		window = &sampleWindow{resetAt: now.Add(every)}
+		sampleWindows[key] = window
+	}
+
+	if window.count >= burst {
+		return false
+	}
+	window.count++
+	return true
+}
+
+// pruneSampleWindows drops expired windows, all windows when every key is still active (caller holds sampleMutex)
+func pruneSampleWindows(now time.Time) {
+	for key, window := range sampleWindows {
+		if !now.Before(window.resetAt) {
+			delete(sampleWindows, key)
+		}
+	}
+	if len(sampleWindows) >= maxSampledKeys {
+		sampleWindows = make(map[string]*sampleWindow)
+	}
+}
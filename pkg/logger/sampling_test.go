@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// captureLogs redirects package logger into buffer with fixed sampling clock
+func captureLogs(t *testing.T, level zerolog.Level) (*bytes.Buffer, *time.Time) {
+	t.Helper()
+	originalLog, originalNow := log, sampleNow
+	originalGlobal := zerolog.GlobalLevel()
+
+	buf := &bytes.Buffer{}
+	now := time.Date(2025, 8, 6, 12, 0, 0, 0, time.UTC)
+	log = zerolog.New(buf).Level(level)
+	zerolog.SetGlobalLevel(level)
+	sampleNow = func() time.Time { return now }
+
+	sampleMutex.Lock()
+	sampleWindows = make(map[string]*sampleWindow)
+	sampleMutex.Unlock()
+
+	t.Cleanup(func() {
+		log, sampleNow = originalLog, originalNow
+		zerolog.SetGlobalLevel(originalGlobal)
+	})
+	return buf, &now
+}
+
+func TestSampledLimitsIdenticalMessagesPerInterval(t *testing.T) {
+	buf, now := captureLogs(t, zerolog.InfoLevel)
+
+	for i := 0; i < 5; i++ {
+		Sampled("ua:unknown", time.Minute).Msg("UnknownPattern detected!")
+		Sampled("asn:failed", time.Minute).Msg("ASN lookup failed")
+	}
+	if got := strings.Count(buf.String(), "UnknownPattern"); got != 1 {
+		t.Errorf("expected 1 sampled line per key, got %d", got)
+	}
+	if got := strings.Count(buf.String(), "ASN lookup failed"); got != 1 {
+		t.Errorf("expected separate budget per key, got %d", got)
+	}
+
+	// Budget resets after interval
+	*now = now.Add(time.Minute)
+	Sampled("ua:unknown", time.Minute).Msg("UnknownPattern detected!")
+	if got := strings.Count(buf.String(), "UnknownPattern"); got != 2 {
+		t.Errorf("expected new line after interval, got %d", got)
+	}
+}
+
+func TestSampledBurstAndLevels(t *testing.T) {
+	buf, _ := captureLogs(t, zerolog.InfoLevel)
+
+	// Disabled level neither logs nor consumes budget
+	for i := 0; i < 3; i++ {
+		SampledBurst("burst", 2, time.Minute).Debug().Msg("debug line")
+	}
+	for i := 0; i < 5; i++ {
+		SampledBurst("burst", 2, time.Minute).Warn().Msg("warn line")
+	}
+	if strings.Contains(buf.String(), "debug line") {
+		t.Error("disabled debug level must not be logged")
+	}
+	if got := strings.Count(buf.String(), "warn line"); got != 2 {
+		t.Errorf("expected burst of 2, got %d", got)
+	}
+
+	// Non-positive settings disable sampling
+	for i := 0; i < 3; i++ {
+		SampledBurst("unsampled", 0, time.Minute).Info().Msg("info line")
+	}
+	if got := strings.Count(buf.String(), "info line"); got != 3 {
+		t.Errorf("expected every line without sampling, got %d", got)
+	}
+}
+
+func TestSampleWindowsBounded(t *testing.T) {
+	_, now := captureLogs(t, zerolog.InfoLevel)
+
+	for i := 0; i < maxSampledKeys; i++ {
+		allowSample(string(rune('a'+i%26))+time.Duration(i).String(), 1, time.Second)
+	}
+	*now = now.Add(time.Second)
+	allowSample("fresh", 1, time.Second)
+
+	sampleMutex.Lock()
+	defer sampleMutex.Unlock()
+	if len(sampleWindows) != 1 {
+		t.Errorf("expected expired windows to be pruned, got %d", len(sampleWindows))
+	}
+}
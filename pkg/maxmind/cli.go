@@ -94,6 +94,12 @@ func CheckDatabaseFiles() map[string]interface{} {
 		databases["asn"] = checkSingleDatabaseFile(asnPath, maxmindConfig.Databases.ASN)
 	}
 
+	// Check Anonymous IP database (optional)
+	if maxmindConfig.Databases.Anon != "" {
+		anonPath := maxmindConfig.StoragePath + "/" + maxmindConfig.Databases.Anon + ".mmdb"
+		databases["anon"] = checkSingleDatabaseFile(anonPath, maxmindConfig.Databases.Anon)
+	}
+
 	return result
 }
 
@@ -0,0 +1,52 @@
+package maxmind
+
+import (
+	"fmt"
+	"math"
+	"net"
+)
+
+// earthRadiusKm is mean Earth radius used by haversine formula
+const earthRadiusKm = 6371.0
+
+// HasCoordinates checks if location has latitude/longitude populated (0,0 is treated as missing)
+func (g *GeoLocation) HasCoordinates() bool {
+	return g != nil && (g.Latitude != 0 || g.Longitude != 0)
+}
+
+// Distance returns great-circle (haversine) distance in kilometers between two locations.
+// Returns 0 when either location lacks coordinates, use HasCoordinates to tell apart from same location.
+func Distance(a, b *GeoLocation) float64 {
+	if !a.HasCoordinates() || !b.HasCoordinates() {
+		return 0
+	}
+
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * math.Pi / 180
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// LookupDistanceBetweenIPs looks up both IPs and returns distance in kilometers between their locations
+func LookupDistanceBetweenIPs(ip1, ip2 net.IP) (float64, error) {
+	if ip1 == nil || ip2 == nil {
+		return 0, fmt.Errorf("invalid IP address")
+	}
+
+	loc1 := LookupCity(ip1)
+	if !loc1.HasCoordinates() {
+		return 0, fmt.Errorf("no coordinates found for IP %s", ip1.String())
+	}
+
+	loc2 := LookupCity(ip2)
+	if !loc2.HasCoordinates() {
+		return 0, fmt.Errorf("no coordinates found for IP %s", ip2.String())
+	}
+
+	return Distance(loc1, loc2), nil
+}
@@ -0,0 +1,47 @@
+package maxmind
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	jakarta := &GeoLocation{Latitude: -6.2088, Longitude: 106.8456}
+	singapore := &GeoLocation{Latitude: 1.3521, Longitude: 103.8198}
+	london := &GeoLocation{Latitude: 51.5074, Longitude: -0.1278}
+	newYork := &GeoLocation{Latitude: 40.7128, Longitude: -74.0060}
+
+	tests := []struct {
+		name     string
+		a, b     *GeoLocation
+		expected float64
+	}{
+		{"jakarta to singapore", jakarta, singapore, 905},
+		{"london to new york", london, newYork, 5570},
+		{"same location", jakarta, jakarta, 0},
+	}
+
+	for _, tt := range tests {
+		got := Distance(tt.a, tt.b)
+		if math.Abs(got-tt.expected) > 10 {
+			t.Errorf("%s: Distance = %.1f km, want ~%.0f km", tt.name, got, tt.expected)
+		}
+		if reverse := Distance(tt.b, tt.a); math.Abs(reverse-got) > 1e-9 {
+			t.Errorf("%s: Distance not symmetric, %.6f vs %.6f", tt.name, got, reverse)
+		}
+	}
+}
+
+func TestDistanceMissingCoordinates(t *testing.T) {
+	jakarta := &GeoLocation{Latitude: -6.2088, Longitude: 106.8456}
+
+	if got := Distance(jakarta, &GeoLocation{}); got != 0 {
+		t.Errorf("Distance with missing coordinates = %.1f, want 0", got)
+	}
+	if got := Distance(nil, jakarta); got != 0 {
+		t.Errorf("Distance with nil location = %.1f, want 0", got)
+	}
+	if (&GeoLocation{}).HasCoordinates() {
+		t.Errorf("empty location should not have coordinates")
+	}
+}
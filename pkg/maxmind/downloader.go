@@ -1,6 +1,7 @@
 package maxmind
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,9 +9,13 @@ import (
 	"time"
 
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
 	"github.com/benedict-erwin/insight-collector/pkg/utils"
 )
 
+// downloadLockTTL bounds how long one instance holds database download lock
+const downloadLockTTL = 10 * time.Minute
+
 // DatabaseDownloader handles MaxMind database downloads
 type DatabaseDownloader struct {
 	client      *MaxMindClient
@@ -121,6 +126,17 @@ func (d *DatabaseDownloader) CheckForUpdates() error {
 		}
 	}
 
+	// Check Anonymous IP database (optional)
+	if d.config.Databases.Anon != "" {
+		if err := d.DownloadIfNeeded("anon", d.config.Databases.Anon); err != nil {
+			log.Error().
+				Err(err).
+				Str("database", "anon").
+				Str("name", d.config.Databases.Anon).
+				Msg("Failed to update Anonymous IP database")
+		}
+	}
+
 	return nil
 }
 
@@ -128,6 +144,21 @@ func (d *DatabaseDownloader) CheckForUpdates() error {
 func (d *DatabaseDownloader) DownloadIfNeeded(dbType, dbName string) error {
 	log := logger.WithScope("maxmind-downloader")
 
+	// Prevent multiple instances downloading same database (proceed unlocked if Redis unavailable)
+	release, acquired, err := redis.Lock(context.Background(), "maxmind:download:"+dbName, downloadLockTTL)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("database", dbName).
+			Msg("Failed to acquire download lock, proceeding without lock")
+	} else if !acquired {
+		log.Info().
+			Str("database", dbName).
+			Msg("Database download in progress by another instance, skipping")
+		return nil
+	}
+	defer release()
+
 	// 1. HEAD request to check Last-Modified
 	updateInfo, err := d.client.CheckLastModified(dbName)
 	if err != nil {
@@ -209,7 +240,8 @@ func (d *DatabaseDownloader) DownloadIfNeeded(dbType, dbName string) error {
 	// 9. Update metadata
 	updateMetadata(d.storagePath,
 		filepath.Join(d.storagePath, d.config.Databases.City+".mmdb"),
-		filepath.Join(d.storagePath, d.config.Databases.ASN+".mmdb"))
+		filepath.Join(d.storagePath, d.config.Databases.ASN+".mmdb"),
+		d.config.anonDBPath())
 
 	log.Info().
 		Str("database", dbName).
@@ -233,6 +265,8 @@ func (d *DatabaseDownloader) ForceDownload(dbType string) error {
 		dbName = d.config.Databases.City
 	case "asn":
 		dbName = d.config.Databases.ASN
+	case "anon":
+		dbName = d.config.Databases.Anon
 	default:
 		return fmt.Errorf("invalid database type: %s", dbType)
 	}
@@ -297,6 +331,15 @@ func (d *DatabaseDownloader) GetDownloadStatus() map[string]interface{} {
 		}
 	}
 
+	// Anonymous IP database status (optional)
+	if d.config.Databases.Anon != "" {
+		anonStatus := d.getDatabaseFileStatus(d.config.anonDBPath())
+		databases["anon"] = map[string]interface{}{
+			"name":   d.config.Databases.Anon,
+			"status": anonStatus,
+		}
+	}
+
 	status["databases"] = databases
 	return status
 }
@@ -0,0 +1,338 @@
+package maxmind
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/oschwald/geoip2-golang/v2"
+)
+
+// PrimaryProviderName identifies MaxMind databases in provider list
+const PrimaryProviderName = "maxmind"
+
+// FallbackProvider supplies city data for IPs primary (MaxMind) databases have no data for
+type FallbackProvider interface {
+	Name() string
+	LookupCity(ip net.IP) *GeoLocation // Nil or location without country when provider has no data
+}
+
+// ChainedService consults fallback providers in order when primary city lookup yields default (empty) result.
+// First provider with data wins, its values fill fields primary left empty. Merged results are cached in chain
+// LRU (primary results stay in primary cache), other lookups are delegated to primary unchanged.
+type ChainedService struct {
+	GeoIPService
+	fallbacks []FallbackProvider
+	cache     *lru.Cache[string, *cacheEntry[*GeoLocation]]
+	cacheTTL  time.Duration
+}
+
+// NewChainedService wraps primary with fallback providers, cache of merged results is used when cacheSize > 0
+func NewChainedService(primary GeoIPService, fallbacks []FallbackProvider, cacheSize int, cacheTTL time.Duration) *ChainedService {
+	s := &ChainedService{
+		GeoIPService: primary,
+		fallbacks:    fallbacks,
+		cacheTTL:     cacheTTL,
+	}
+	if cacheSize > 0 && cacheTTL > 0 {
+		if cache, err := lru.New[string, *cacheEntry[*GeoLocation]](cacheSize); err == nil {
+			s.cache = cache
+		}
+	}
+	return s
+}
+
+// Providers returns active provider names in lookup order
+func (s *ChainedService) Providers() []string {
+	names := []string{PrimaryProviderName}
+	for _, fallback := range s.fallbacks {
+		names = append(names, fallback.Name())
+	}
+	return names
+}
+
+// LookupCity performs primary city lookup, falling back to secondary providers when it has no data
+func (s *ChainedService) LookupCity(ip net.IP) *GeoLocation {
+	if ip == nil {
+		return s.GeoIPService.LookupCity(ip)
+	}
+
+	ipStr := ip.String()
+	if s.cache != nil {
+		if cached, found := s.cache.Get(ipStr); found && !cached.isExpired() {
+			return cached.Data
+		}
+	}
+
+	return s.fallback(ip, s.GeoIPService.LookupCity(ip))
+}
+
+// LookupCityBatch performs primary batch lookup, results without data are completed by fallback providers
+func (s *ChainedService) LookupCityBatch(ips []net.IP) []*GeoLocation {
+	results := s.GeoIPService.LookupCityBatch(ips)
+	for i, ip := range ips {
+		if ip == nil || i >= len(results) || hasCityData(results[i]) {
+			continue
+		}
+
+		if s.cache != nil {
+			if cached, found := s.cache.Get(ip.String()); found && !cached.isExpired() {
+				results[i] = cached.Data
+				continue
+			}
+		}
+		results[i] = s.fallback(ip, results[i])
+	}
+	return results
+}
+
+// fallback merges first fallback provider result into primary result without data and caches it
+func (s *ChainedService) fallback(ip net.IP, primary *GeoLocation) *GeoLocation {
+	if hasCityData(primary) {
+		return primary
+	}
+
+	for _, provider := range s.fallbacks {
+		secondary := provider.LookupCity(ip)
+		if !hasCityData(secondary) {
+			continue
+		}
+
+		merged := mergeLocation(primary, secondary, ip)
+		if s.cache != nil {
+			s.cache.Add(ip.String(), &cacheEntry[*GeoLocation]{
+				Data:      merged,
+				ExpiresAt: time.Now().Add(s.cacheTTL),
+			})
+		}
+		logger.Debug().Str("provider", provider.Name()).Msg("City resolved by fallback geo provider")
+		return merged
+	}
+	return primary
+}
+
+// GetDatabaseInfo returns primary database information with active providers
+func (s *ChainedService) GetDatabaseInfo() *DatabaseInfo {
+	info := s.GeoIPService.GetDatabaseInfo()
+	if info == nil {
+		info = &DatabaseInfo{}
+	}
+	info.Providers = s.Providers()
+	return info
+}
+
+// ReloadDatabases reloads primary databases and drops merged results (primary may now have data)
+func (s *ChainedService) ReloadDatabases() error {
+	err := s.GeoIPService.ReloadDatabases()
+	if s.cache != nil {
+		s.cache.Purge()
+	}
+	return err
+}
+
+// Close closes primary and fallback providers holding resources
+func (s *ChainedService) Close() error {
+	err := s.GeoIPService.Close()
+	for _, fallback := range s.fallbacks {
+		if closer, ok := fallback.(io.Closer); ok {
+			if closeErr := closer.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if s.cache != nil {
+		s.cache.Purge()
+	}
+	return err
+}
+
+// hasCityData reports whether location carries any geo data (default result has none)
+func hasCityData(location *GeoLocation) bool {
+	return location != nil && (location.CountryCode != "" || location.City != "")
+}
+
+// mergeLocation fills fields missing from primary with secondary values
+func mergeLocation(primary, secondary *GeoLocation, ip net.IP) *GeoLocation {
+	merged := DefaultGeoLocation(ip)
+	if primary != nil {
+		*merged = *primary
+	}
+
+	if merged.Country == "" {
+		merged.Country = secondary.Country
+	}
+	if merged.CountryCode == "" {
+		merged.CountryCode = secondary.CountryCode
+	}
+	if merged.City == "" {
+		merged.City = secondary.City
+	}
+	if merged.Region == "" {
+		merged.Region = secondary.Region
+	}
+	if merged.PostalCode == "" {
+		merged.PostalCode = secondary.PostalCode
+	}
+	if merged.Latitude == 0 && merged.Longitude == 0 {
+		merged.Latitude, merged.Longitude = secondary.Latitude, secondary.Longitude
+	}
+	if merged.Timezone == "" {
+		merged.Timezone = secondary.Timezone
+	}
+	merged.LookedUpAt = time.Now()
+	return merged
+}
+
+// MMDBCityProvider looks up city data in secondary City-compatible mmdb (e.g. IP2Location, DB-IP)
+type MMDBCityProvider struct {
+	name   string
+	reader *geoip2.Reader
+}
+
+// NewMMDBCityProvider opens City-compatible mmdb file as fallback provider
+func NewMMDBCityProvider(name, path string) (*MMDBCityProvider, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fallback city database %s: %w", path, err)
+	}
+	return &MMDBCityProvider{name: name, reader: reader}, nil
+}
+
+// Name returns provider name
+func (p *MMDBCityProvider) Name() string {
+	return p.name
+}
+
+// LookupCity looks up IP in secondary mmdb
+func (p *MMDBCityProvider) LookupCity(ip net.IP) *GeoLocation {
+	return cityDBLookup(p.reader, ip)
+}
+
+// Close closes mmdb reader
+func (p *MMDBCityProvider) Close() error {
+	return p.reader.Close()
+}
+
+// cidrCountry maps network to country
+type cidrCountry struct {
+	network     *net.IPNet
+	countryCode string
+	country     string
+}
+
+// CIDRCountryProvider resolves country from static CIDR table (most specific network wins)
+type CIDRCountryProvider struct {
+	entries []cidrCountry
+}
+
+// NewCIDRCountryProvider loads CIDR table from CSV file, one "cidr,country_code[,country]" per line ('#' comments)
+func NewCIDRCountryProvider(path string) (*CIDRCountryProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CIDR country table: %w", err)
+	}
+	defer file.Close()
+	return ParseCIDRCountryTable(file)
+}
+
+// ParseCIDRCountryTable parses CIDR country table from reader
+func ParseCIDRCountryTable(r io.Reader) (*CIDRCountryProvider, error) {
+	provider := &CIDRCountryProvider{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("line %d: expected cidr,country_code[,country]", lineNo)
+		}
+		_, network, err := net.ParseCIDR(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		entry := cidrCountry{network: network, countryCode: strings.ToUpper(strings.TrimSpace(parts[1]))}
+		if len(parts) > 2 {
+			entry.country = strings.TrimSpace(parts[2])
+		}
+		provider.entries = append(provider.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CIDR country table: %w", err)
+	}
+	return provider, nil
+}
+
+// Name returns provider name
+func (p *CIDRCountryProvider) Name() string {
+	return "cidr_country"
+}
+
+// LookupCity returns country of most specific network containing IP, nil when none matches
+func (p *CIDRCountryProvider) LookupCity(ip net.IP) *GeoLocation {
+	var best *cidrCountry
+	bestBits := -1
+	for i := range p.entries {
+		entry := &p.entries[i]
+		if !entry.network.Contains(ip) {
+			continue
+		}
+		if bits, _ := entry.network.Mask.Size(); bits > bestBits {
+			best, bestBits = entry, bits
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	result := DefaultGeoLocation(ip)
+	result.CountryCode = best.countryCode
+	result.Country = best.country
+	return result
+}
+
+// newFallbackService wraps primary with fallback providers from configuration, primary is returned as is without them
+func newFallbackService(primary GeoIPService, cfg *Config) GeoIPService {
+	var fallbacks []FallbackProvider
+
+	if path := cfg.Fallback.CityDB; path != "" {
+		provider, err := NewMMDBCityProvider("secondary_mmdb", path)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Fallback city database unavailable, skipping provider")
+		} else {
+			fallbacks = append(fallbacks, provider)
+		}
+	}
+
+	if path := cfg.Fallback.CountryCIDR; path != "" {
+		provider, err := NewCIDRCountryProvider(path)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Fallback CIDR country table unavailable, skipping provider")
+		} else {
+			fallbacks = append(fallbacks, provider)
+		}
+	}
+
+	if len(fallbacks) == 0 {
+		return primary
+	}
+
+	var ttl time.Duration
+	if cfg.Cache.Enabled {
+		ttl, _ = time.ParseDuration(cfg.Cache.TTL)
+	}
+	chained := NewChainedService(primary, fallbacks, cfg.Cache.MaxEntries, ttl)
+	logger.Info().Strs("providers", chained.Providers()).Msg("GeoIP fallback providers configured")
+	return chained
+}
@@ -0,0 +1,127 @@
+package maxmind
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// staticPrimary returns fixed city data for known IPs, defaults otherwise
+type staticPrimary struct {
+	DisabledService
+	cities map[string]*GeoLocation
+	calls  int
+}
+
+func (p *staticPrimary) LookupCity(ip net.IP) *GeoLocation {
+	p.calls++
+	if location, ok := p.cities[ip.String()]; ok {
+		return location
+	}
+	return DefaultGeoLocation(ip)
+}
+
+func (p *staticPrimary) LookupCityBatch(ips []net.IP) []*GeoLocation {
+	results := make([]*GeoLocation, len(ips))
+	for i, ip := range ips {
+		results[i] = p.LookupCity(ip)
+	}
+	return results
+}
+
+// countingProvider wraps fallback provider counting lookups
+type countingProvider struct {
+	FallbackProvider
+	calls int
+}
+
+func (p *countingProvider) LookupCity(ip net.IP) *GeoLocation {
+	p.calls++
+	return p.FallbackProvider.LookupCity(ip)
+}
+
+func newTestCIDRProvider(t *testing.T) *CIDRCountryProvider {
+	t.Helper()
+	provider, err := ParseCIDRCountryTable(strings.NewReader(`
+# cidr,country_code,country
+36.64.0.0/11,id,Indonesia
+36.86.0.0/16,ID,Indonesia (Telkom)
+2404:c0::/29,SG,Singapore
+`))
+	if err != nil {
+		t.Fatalf("failed to parse CIDR table: %v", err)
+	}
+	return provider
+}
+
+func TestChainedServiceFallsBackWhenPrimaryHasNoData(t *testing.T) {
+	primary := &staticPrimary{cities: map[string]*GeoLocation{
+		"8.8.8.8": {IP: "8.8.8.8", CountryCode: "US", City: "Mountain View"},
+	}}
+	fallback := &countingProvider{FallbackProvider: newTestCIDRProvider(t)}
+	chained := NewChainedService(primary, []FallbackProvider{fallback}, 100, time.Hour)
+
+	// Primary data wins, fallback not consulted
+	if got := chained.LookupCity(net.ParseIP("8.8.8.8")); got.City != "Mountain View" || fallback.calls != 0 {
+		t.Errorf("expected primary result without fallback, got %+v (fallback calls %d)", got, fallback.calls)
+	}
+
+	// Most specific fallback network fills missing country
+	got := chained.LookupCity(net.ParseIP("36.86.63.182"))
+	if got.CountryCode != "ID" || got.Country != "Indonesia (Telkom)" || got.IP != "36.86.63.182" {
+		t.Errorf("unexpected fallback result %+v", got)
+	}
+
+	// Merged result is cached, neither provider is asked again
+	primaryCalls, fallbackCalls := primary.calls, fallback.calls
+	if cached := chained.LookupCity(net.ParseIP("36.86.63.182")); cached != got {
+		t.Errorf("expected cached merged result, got %+v", cached)
+	}
+	if primary.calls != primaryCalls || fallback.calls != fallbackCalls {
+		t.Error("cached merged result must not hit providers")
+	}
+
+	// No provider has data: primary default returned
+	if got := chained.LookupCity(net.ParseIP("1.1.1.1")); hasCityData(got) {
+		t.Errorf("expected default result, got %+v", got)
+	}
+
+	// Reload drops merged results
+	if err := chained.ReloadDatabases(); err != nil {
+		t.Fatalf("unexpected reload error: %v", err)
+	}
+	chained.LookupCity(net.ParseIP("36.86.63.182"))
+	if fallback.calls != fallbackCalls+2 {
+		t.Errorf("expected fallback lookup after reload, calls %d -> %d", fallbackCalls, fallback.calls)
+	}
+}
+
+func TestChainedServiceBatchAndProviders(t *testing.T) {
+	primary := &staticPrimary{cities: map[string]*GeoLocation{
+		"8.8.8.8": {IP: "8.8.8.8", CountryCode: "US"},
+	}}
+	chained := NewChainedService(primary, []FallbackProvider{newTestCIDRProvider(t)}, 0, 0)
+
+	results := chained.LookupCityBatch([]net.IP{net.ParseIP("8.8.8.8"), net.ParseIP("2404:c0::1"), nil})
+	if results[0].CountryCode != "US" || results[1].CountryCode != "SG" || hasCityData(results[2]) {
+		t.Errorf("unexpected batch results %+v %+v %+v", results[0], results[1], results[2])
+	}
+
+	want := []string{PrimaryProviderName, "cidr_country"}
+	if got := chained.Providers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Providers() = %v, want %v", got, want)
+	}
+	if got := chained.GetDatabaseInfo().Providers; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDatabaseInfo().Providers = %v, want %v", got, want)
+	}
+}
+
+func TestParseCIDRCountryTableRejectsMalformedLines(t *testing.T) {
+	for _, table := range []string{"36.64.0.0/11", "not-a-cidr,ID"} {
+		if _, err := ParseCIDRCountryTable(strings.NewReader(table)); err == nil {
+			t.Errorf("expected error for table %q", table)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package maxmind
+
+import (
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// Database types used for max age rules (maxmind.max_age keys)
+const (
+	DatabaseCity = "city"
+	DatabaseASN  = "asn"
+	DatabaseAnon = "anon"
+)
+
+// defaultMaxAge is staleness threshold when maxmind.max_age has no rule (weekly GeoLite2 releases + margin)
+const defaultMaxAge = 14 * 24 * time.Hour
+
+// DatabaseAge describes freshness of single database against its max age rule
+type DatabaseAge struct {
+	Database  string    `json:"database"` // city, asn or anon
+	Path      string    `json:"path"`
+	Loaded    bool      `json:"loaded"`
+	BuildTime time.Time `json:"build_time"` // mmdb metadata build epoch
+	Age       string    `json:"age"`
+	MaxAge    string    `json:"max_age"`
+	Stale     bool      `json:"stale"` // Older than max age, or required database not loaded
+}
+
+// MaxAge returns staleness threshold of database type from maxmind.max_age (Go duration, default 14 days)
+func MaxAge(database string) time.Duration {
+	cfg := config.Get()
+	if cfg == nil {
+		return defaultMaxAge
+	}
+
+	value := cfg.MaxMind.MaxAge[database]
+	if value == "" {
+		return defaultMaxAge
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil || maxAge <= 0 {
+		logger.WithScope("maxmind").Warn().Str("database", database).Str("max_age", value).Msg("Invalid max age, using default")
+		return defaultMaxAge
+	}
+	return maxAge
+}
+
+// CheckDatabaseAges compares build time of each database against max age rule.
+// City and ASN are required (not loaded = stale), anonymous IP database is checked only when configured.
+func CheckDatabaseAges(info *DatabaseInfo, maxAge func(database string) time.Duration, now time.Time) []DatabaseAge {
+	if info == nil || !info.Enabled {
+		return nil
+	}
+
+	check := func(database, path string, buildTime time.Time) DatabaseAge {
+		limit := maxAge(database)
+		result := DatabaseAge{
+			Database:  database,
+			Path:      path,
+			Loaded:    !buildTime.IsZero(),
+			BuildTime: buildTime,
+			MaxAge:    limit.String(),
+			Stale:     true,
+		}
+		if result.Loaded {
+			age := now.Sub(buildTime)
+			result.Age = age.Round(time.Hour).String()
+			result.Stale = age > limit
+		}
+		return result
+	}
+
+	ages := []DatabaseAge{
+		check(DatabaseCity, info.CityDBPath, info.CityDBBuildTime),
+		check(DatabaseASN, info.ASNDBPath, info.ASNDBBuildTime),
+	}
+	if info.AnonDBPath != "" {
+		ages = append(ages, check(DatabaseAnon, info.AnonDBPath, info.AnonDBBuildTime))
+	}
+	return ages
+}
@@ -0,0 +1,57 @@
+package maxmind
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckDatabaseAges(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	maxAge := func(database string) time.Duration {
+		if database == DatabaseASN {
+			return 30 * 24 * time.Hour
+		}
+		return 14 * 24 * time.Hour
+	}
+
+	info := &DatabaseInfo{
+		Enabled:         true,
+		CityDBPath:      "GeoLite2-City.mmdb",
+		CityDBBuildTime: now.Add(-20 * 24 * time.Hour),
+		ASNDBPath:       "GeoLite2-ASN.mmdb",
+		ASNDBBuildTime:  now.Add(-20 * 24 * time.Hour),
+		AnonDBPath:      "GeoIP2-Anonymous-IP.mmdb",
+	}
+
+	ages := CheckDatabaseAges(info, maxAge, now)
+	if len(ages) != 3 {
+		t.Fatalf("expected 3 databases, got %d", len(ages))
+	}
+
+	expected := map[string]bool{DatabaseCity: true, DatabaseASN: false, DatabaseAnon: true}
+	for _, age := range ages {
+		if age.Stale != expected[age.Database] {
+			t.Errorf("%s: expected stale=%v, got %v", age.Database, expected[age.Database], age.Stale)
+		}
+	}
+	if ages[2].Loaded {
+		t.Errorf("anon: expected not loaded")
+	}
+}
+
+func TestCheckDatabaseAgesSkipsOptionalAnon(t *testing.T) {
+	info := &DatabaseInfo{Enabled: true, CityDBBuildTime: time.Now(), ASNDBBuildTime: time.Now()}
+	ages := CheckDatabaseAges(info, func(string) time.Duration { return time.Hour }, time.Now())
+	if len(ages) != 2 {
+		t.Fatalf("expected 2 databases without anon configured, got %d", len(ages))
+	}
+	for _, age := range ages {
+		if age.Stale {
+			t.Errorf("%s: expected fresh database", age.Database)
+		}
+	}
+
+	if ages := CheckDatabaseAges(&DatabaseInfo{Enabled: false}, nil, time.Now()); ages != nil {
+		t.Errorf("expected nil for disabled service, got %v", ages)
+	}
+}
@@ -0,0 +1,104 @@
+package maxmind
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/cache"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// ipInfoCacheName namespaces shared IP info entries in Redis cache store
+const ipInfoCacheName = "maxmind_ip"
+
+var (
+	ipInfoCache    *cache.TwoTier[*IPInfo] // nil when shared cache disabled
+	ipInfoCacheTTL time.Duration
+	ipInfoCacheMu  sync.RWMutex
+)
+
+// IPInfo combines city and ASN lookup results of single IP (cached as one entry)
+type IPInfo struct {
+	City *GeoLocation `json:"city"`
+	ASN  *ASNInfo     `json:"asn"`
+}
+
+// hasData checks if lookup resolved anything (defaults are never shared between workers)
+func (i *IPInfo) hasData() bool {
+	return (i.City != nil && i.City.CountryCode != "") || (i.ASN != nil && i.ASN.ASN != 0)
+}
+
+// initIPInfoCache creates two-tier IP info cache when shared cache is enabled
+func initIPInfoCache(cfg *Config) {
+	if !cfg.Enabled || !cfg.Cache.Enabled || !cfg.Cache.Shared {
+		return
+	}
+
+	ttl, err := time.ParseDuration(cfg.Cache.TTL)
+	if err != nil {
+		logger.Warn().Err(err).Str("ttl", cfg.Cache.TTL).Msg("Invalid cache TTL, shared IP info cache disabled")
+		return
+	}
+
+	c, err := cache.NewWithCacheStore[*IPInfo](ipInfoCacheName, cfg.Cache.MaxEntries, ttl)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to initialize shared IP info cache")
+		return
+	}
+
+	ipInfoCacheMu.Lock()
+	ipInfoCache = c
+	ipInfoCacheTTL = ttl
+	ipInfoCacheMu.Unlock()
+
+	logger.Info().
+		Bool("redis", c.HasRemote()).
+		Int("max_entries", cfg.Cache.MaxEntries).
+		Dur("ttl", ttl).
+		Msg("MaxMind shared IP info cache initialized")
+}
+
+// purgeIPInfoCache clears local tier of IP info cache (called on database reload)
+func purgeIPInfoCache() {
+	ipInfoCacheMu.RLock()
+	defer ipInfoCacheMu.RUnlock()
+	if ipInfoCache != nil {
+		ipInfoCache.Purge()
+	}
+}
+
+// LookupIPInfo performs city & ASN lookup of IP string, shared between workers through two-tier cache when enabled
+func LookupIPInfo(ctx context.Context, ipStr string) *IPInfo {
+	ipInfoCacheMu.RLock()
+	c, ttl := ipInfoCache, ipInfoCacheTTL
+	ipInfoCacheMu.RUnlock()
+
+	ip := net.ParseIP(ipStr)
+	if c == nil || ip == nil {
+		return &IPInfo{City: LookupCityFromString(ipStr), ASN: LookupASNFromString(ipStr)}
+	}
+
+	key := ipInfoCacheKey(ip)
+	if info, found := c.Get(ctx, key); found && info != nil {
+		return info
+	}
+
+	info := &IPInfo{City: LookupCity(ip), ASN: LookupASN(ip)}
+	if info.hasData() {
+		if err := c.Set(ctx, key, info, ttl); err != nil {
+			logger.Debug().Err(err).Str("key", key).Msg("Failed to share IP info in cache")
+		}
+	}
+	return info
+}
+
+// ipInfoCacheKey hashes normalized IP (same address in different notation shares entry),
+// so raw client IPs are never stored as Redis keys (lookup still needs full IP, masked form would merge addresses)
+func ipInfoCacheKey(ip net.IP) string {
+	sum := sha256.Sum256([]byte(ip.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,30 @@
+package maxmind
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestIPInfoCacheKey(t *testing.T) {
+	key := ipInfoCacheKey(net.ParseIP("203.0.113.10"))
+
+	if strings.Contains(key, "203.0.113") {
+		t.Errorf("cache key must not contain raw IP, got %s", key)
+	}
+	if len(key) != 64 {
+		t.Errorf("expected hex SHA256 key, got %q", key)
+	}
+
+	// Same address in different notation shares entry
+	if ipInfoCacheKey(net.ParseIP("::ffff:203.0.113.10")) != key {
+		t.Error("expected IPv4-mapped IPv6 notation to share cache key")
+	}
+	if ipInfoCacheKey(net.ParseIP("2001:db8::1")) != ipInfoCacheKey(net.ParseIP("2001:0db8:0000::0001")) {
+		t.Error("expected IPv6 notations to share cache key")
+	}
+
+	if ipInfoCacheKey(net.ParseIP("203.0.113.11")) == key {
+		t.Error("expected different addresses to have different cache keys")
+	}
+}
@@ -63,10 +63,13 @@ func initWithOptions(enablePeriodicDownloader bool) error {
 			return
 		}
 		
-		// Store service reference
+		// Store service reference (wrapped with fallback providers when configured)
 		mu.Lock()
-		service = reader
+		service = newFallbackService(reader, maxmindConfig)
 		mu.Unlock()
+
+		// Shared IP info cache for job lookups (optional)
+		initIPInfoCache(maxmindConfig)
 		
 		// Initialize downloader if enabled and not CLI mode
 		if maxmindConfig.Downloader.Enabled && enablePeriodicDownloader {
@@ -126,9 +129,11 @@ func getMaxMindConfig(cfg *config.Config) *Config {
 		Databases: struct {
 			City string `json:"city"`
 			ASN  string `json:"asn"`
+			Anon string `json:"anon"` // Optional, empty = anonymous IP lookup disabled
 		}{
 			City: cfg.MaxMind.Databases.City,
 			ASN:  cfg.MaxMind.Databases.ASN,
+			Anon: cfg.MaxMind.Databases.Anon,
 		},
 		Downloader: DownloaderConfig{
 			Enabled:       cfg.MaxMind.Downloader.Enabled,
@@ -143,6 +148,11 @@ func getMaxMindConfig(cfg *config.Config) *Config {
 			Enabled:    cfg.MaxMind.Cache.Enabled,
 			MaxEntries: cfg.MaxMind.Cache.MaxEntries,
 			TTL:        cfg.MaxMind.Cache.TTL,
+			Shared:     cfg.MaxMind.Cache.Shared,
+		},
+		Fallback: FallbackConfig{
+			CityDB:      cfg.MaxMind.Fallback.CityDB,
+			CountryCIDR: cfg.MaxMind.Fallback.CountryCIDR,
 		},
 	}
 	
@@ -231,6 +241,50 @@ func LookupASN(ip net.IP) *ASNInfo {
 	return service.LookupASN(ip)
 }
 
+// LookupCityBatch performs city lookups for multiple IPs using the service (order preserved)
+func LookupCityBatch(ips []net.IP) []*GeoLocation {
+	service := GetService()
+	if service == nil {
+		results := make([]*GeoLocation, len(ips))
+		for i, ip := range ips {
+			results[i] = DefaultGeoLocation(ip)
+		}
+		return results
+	}
+	return service.LookupCityBatch(ips)
+}
+
+// LookupASNBatch performs ASN lookups for multiple IPs using the service (order preserved)
+func LookupASNBatch(ips []net.IP) []*ASNInfo {
+	service := GetService()
+	if service == nil {
+		results := make([]*ASNInfo, len(ips))
+		for i, ip := range ips {
+			results[i] = DefaultASNInfo(ip)
+		}
+		return results
+	}
+	return service.LookupASNBatch(ips)
+}
+
+// LookupAnonymous performs anonymous IP lookup using the service
+func LookupAnonymous(ip net.IP) *AnonymousInfo {
+	service := GetService()
+	if service == nil {
+		return DefaultAnonymousInfo(ip)
+	}
+	return service.LookupAnonymous(ip)
+}
+
+// HasAnonymousDB checks if Anonymous IP database is loaded (optional database)
+func HasAnonymousDB() bool {
+	service := GetService()
+	if service == nil {
+		return false
+	}
+	return service.HasAnonymousDB()
+}
+
 // GetDatabaseInfo returns database information
 func GetDatabaseInfo() *DatabaseInfo {
 	service := GetService()
@@ -291,6 +345,30 @@ func (d *DisabledService) LookupASN(ip net.IP) *ASNInfo {
 	return DefaultASNInfo(ip)
 }
 
+func (d *DisabledService) LookupCityBatch(ips []net.IP) []*GeoLocation {
+	results := make([]*GeoLocation, len(ips))
+	for i, ip := range ips {
+		results[i] = DefaultGeoLocation(ip)
+	}
+	return results
+}
+
+func (d *DisabledService) LookupASNBatch(ips []net.IP) []*ASNInfo {
+	results := make([]*ASNInfo, len(ips))
+	for i, ip := range ips {
+		results[i] = DefaultASNInfo(ip)
+	}
+	return results
+}
+
+func (d *DisabledService) LookupAnonymous(ip net.IP) *AnonymousInfo {
+	return DefaultAnonymousInfo(ip)
+}
+
+func (d *DisabledService) HasAnonymousDB() bool {
+	return false
+}
+
 func (d *DisabledService) GetDatabaseInfo() *DatabaseInfo {
 	return &DatabaseInfo{Enabled: false}
 }
@@ -331,6 +409,17 @@ func LookupASNFromString(ipStr string) *ASNInfo {
 	return LookupASN(ip)
 }
 
+// LookupAnonymousFromString parses IP string and performs anonymous IP lookup
+func LookupAnonymousFromString(ipStr string) *AnonymousInfo {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		result := DefaultAnonymousInfo(nil)
+		result.IP = ipStr
+		return result
+	}
+	return LookupAnonymous(ip)
+}
+
 // Downloader access functions
 
 // CheckForUpdates manually triggers database update check
@@ -15,6 +15,7 @@ const metadataFileName = ".metadata.json"
 type Metadata struct {
 	CityDBVersion string    `json:"city_db_version"`
 	ASNDBVersion  string    `json:"asn_db_version"`
+	AnonDBVersion string    `json:"anon_db_version,omitempty"`
 	LastUpdated   time.Time `json:"last_updated"`
 	UpdateCount   int       `json:"update_count"`
 }
@@ -57,7 +58,7 @@ func saveMetadata(storagePath string, metadata *Metadata) error {
 }
 
 // updateMetadata updates metadata with current database information
-func updateMetadata(storagePath string, cityDBPath, asnDBPath string) {
+func updateMetadata(storagePath string, cityDBPath, asnDBPath, anonDBPath string) {
 	metadata := loadMetadata(storagePath)
 	
 	// Update versions based on file modification times
@@ -68,6 +69,12 @@ func updateMetadata(storagePath string, cityDBPath, asnDBPath string) {
 	if asnInfo, err := os.Stat(asnDBPath); err == nil {
 		metadata.ASNDBVersion = asnInfo.ModTime().Format("2006-01-02")
 	}
+
+	if anonDBPath != "" {
+		if anonInfo, err := os.Stat(anonDBPath); err == nil {
+			metadata.AnonDBVersion = anonInfo.ModTime().Format("2006-01-02")
+		}
+	}
 	
 	metadata.LastUpdated = time.Now()
 	metadata.UpdateCount++
@@ -15,6 +15,9 @@ import (
 	"github.com/oschwald/geoip2-golang/v2"
 )
 
+// lookupFailureLogEvery limits repeated lookup failure debug lines (one per database within interval)
+const lookupFailureLogEvery = time.Minute
+
 // cacheEntry wraps cached data with expiration time
 type cacheEntry[T any] struct {
 	Data      T
@@ -31,9 +34,11 @@ type SafeGeoIPReader struct {
 	mu              sync.RWMutex
 	cityReader      *geoip2.Reader
 	asnReader       *geoip2.Reader
+	anonReader      *geoip2.Reader
 	config          *Config
 	lastCityModTime time.Time
 	lastASNModTime  time.Time
+	lastAnonModTime time.Time
 	dbInfo          *DatabaseInfo
 	stopCh          chan struct{}
 	wg              sync.WaitGroup
@@ -41,6 +46,7 @@ type SafeGeoIPReader struct {
 	// LRU caches for performance optimization
 	cityCache *lru.Cache[string, *cacheEntry[*GeoLocation]]
 	asnCache  *lru.Cache[string, *cacheEntry[*ASNInfo]]
+	anonCache *lru.Cache[string, *cacheEntry[*AnonymousInfo]]
 	cacheTTL  time.Duration
 }
 
@@ -104,6 +110,13 @@ func (r *SafeGeoIPReader) initCaches() error {
 		return fmt.Errorf("failed to create ASN cache: %w", err)
 	}
 	r.asnCache = asnCache
+
+	// Initialize Anonymous IP cache
+	anonCache, err := lru.New[string, *cacheEntry[*AnonymousInfo]](r.config.Cache.MaxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to create anonymous IP cache: %w", err)
+	}
+	r.anonCache = anonCache
 	
 	logger.Info().
 		Bool("enabled", true).
@@ -124,14 +137,28 @@ func (r *SafeGeoIPReader) clearCaches() {
 		r.asnCache.Purge()
 		logger.Debug().Msg("MaxMind ASN cache cleared")
 	}
+	if r.anonCache != nil {
+		r.anonCache.Purge()
+		logger.Debug().Msg("MaxMind anonymous IP cache cleared")
+	}
+	purgeIPInfoCache()
+}
+
+// anonDBPath returns Anonymous IP database path (empty when not configured)
+func (c *Config) anonDBPath() string {
+	if c.Databases.Anon == "" {
+		return ""
+	}
+	return filepath.Join(c.StoragePath, c.Databases.Anon+".mmdb")
 }
 
 // loadDatabases loads or reloads GeoIP databases
 func (r *SafeGeoIPReader) loadDatabases() error {
 	cityDBPath := filepath.Join(r.config.StoragePath, r.config.Databases.City+".mmdb")
 	asnDBPath := filepath.Join(r.config.StoragePath, r.config.Databases.ASN+".mmdb")
+	anonDBPath := r.config.anonDBPath()
 
-	var newCityReader, newASNReader *geoip2.Reader
+	var newCityReader, newASNReader, newAnonReader *geoip2.Reader
 	var err error
 
 	// Load City database
@@ -158,19 +185,35 @@ func (r *SafeGeoIPReader) loadDatabases() error {
 		logger.Warn().Err(statErr).Str("path", asnDBPath).Msg("ASN database file not found")
 	}
 
+	// Load Anonymous IP database (optional)
+	if anonDBPath != "" {
+		if anonInfo, statErr := os.Stat(anonDBPath); statErr == nil {
+			if newAnonReader, err = geoip2.Open(anonDBPath); err != nil {
+				logger.Error().Err(err).Str("path", anonDBPath).Msg("Failed to load Anonymous IP database")
+			} else {
+				logger.Debug().Str("path", anonDBPath).Msg("Anonymous IP database loaded successfully")
+			}
+			r.lastAnonModTime = anonInfo.ModTime()
+		} else {
+			logger.Warn().Err(statErr).Str("path", anonDBPath).Msg("Anonymous IP database file not found")
+		}
+	}
+
 	// Atomic swap with write lock
 	r.mu.Lock()
 	oldCityReader := r.cityReader
 	oldASNReader := r.asnReader
+	oldAnonReader := r.anonReader
 
 	r.cityReader = newCityReader
 	r.asnReader = newASNReader
+	r.anonReader = newAnonReader
 
 	// Clear caches when databases are reloaded
 	r.clearCaches()
 
 	// Update database info
-	r.updateDatabaseInfo(cityDBPath, asnDBPath)
+	r.updateDatabaseInfo(cityDBPath, asnDBPath, anonDBPath)
 
 	r.mu.Unlock()
 
@@ -185,22 +228,29 @@ func (r *SafeGeoIPReader) loadDatabases() error {
 			oldASNReader.Close()
 		})
 	}
+	if oldAnonReader != nil {
+		time.AfterFunc(5*time.Second, func() {
+			oldAnonReader.Close()
+		})
+	}
 
 	// Update metadata
-	updateMetadata(r.config.StoragePath, cityDBPath, asnDBPath)
+	updateMetadata(r.config.StoragePath, cityDBPath, asnDBPath, anonDBPath)
 
 	logger.Debug().
 		Bool("city_loaded", newCityReader != nil).
 		Bool("asn_loaded", newASNReader != nil).
+		Bool("anon_loaded", newAnonReader != nil).
 		Msg("GeoIP databases loaded")
 
 	return nil
 }
 
 // updateDatabaseInfo updates internal database information
-func (r *SafeGeoIPReader) updateDatabaseInfo(cityDBPath, asnDBPath string) {
+func (r *SafeGeoIPReader) updateDatabaseInfo(cityDBPath, asnDBPath, anonDBPath string) {
 	r.dbInfo.CityDBPath = cityDBPath
 	r.dbInfo.ASNDBPath = asnDBPath
+	r.dbInfo.AnonDBPath = anonDBPath
 	r.dbInfo.LoadedAt = utils.Now()
 	r.dbInfo.ReloadCount++
 
@@ -213,6 +263,30 @@ func (r *SafeGeoIPReader) updateDatabaseInfo(cityDBPath, asnDBPath string) {
 		r.dbInfo.ASNDBSize = asnInfo.Size()
 		r.dbInfo.ASNDBModTime = asnInfo.ModTime()
 	}
+
+	if anonDBPath != "" {
+		if anonInfo, err := os.Stat(anonDBPath); err == nil {
+			r.dbInfo.AnonDBSize = anonInfo.Size()
+			r.dbInfo.AnonDBModTime = anonInfo.ModTime()
+		}
+	}
+
+	// Build time from mmdb metadata (file mtime changes on copy/extract)
+	r.dbInfo.CityDBBuildTime = readerBuildTime(r.cityReader)
+	r.dbInfo.ASNDBBuildTime = readerBuildTime(r.asnReader)
+	r.dbInfo.AnonDBBuildTime = readerBuildTime(r.anonReader)
+}
+
+// readerBuildTime returns database build time of reader, zero when reader is not loaded
+func readerBuildTime(reader *geoip2.Reader) time.Time {
+	if reader == nil {
+		return time.Time{}
+	}
+	epoch := reader.Metadata().BuildEpoch
+	if epoch == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(epoch), 0).UTC()
 }
 
 // needsReload checks if databases need to be reloaded
@@ -234,6 +308,15 @@ func (r *SafeGeoIPReader) needsReload() bool {
 		}
 	}
 
+	// Check Anonymous IP database (optional)
+	if anonDBPath := r.config.anonDBPath(); anonDBPath != "" {
+		if anonInfo, err := os.Stat(anonDBPath); err == nil {
+			if anonInfo.ModTime().After(r.lastAnonModTime) {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
@@ -302,12 +385,17 @@ func (r *SafeGeoIPReader) LookupCity(ip net.IP) *GeoLocation {
 
 // performCityDBLookup performs actual database lookup (extracted from original LookupCity)
 func (r *SafeGeoIPReader) performCityDBLookup(ip net.IP) *GeoLocation {
-	result := DefaultGeoLocation(ip)
-
 	r.mu.RLock()
 	reader := r.cityReader
 	r.mu.RUnlock()
 
+	return cityDBLookup(reader, ip)
+}
+
+// cityDBLookup looks up IP in given City database reader with safe fallback
+func cityDBLookup(reader *geoip2.Reader, ip net.IP) *GeoLocation {
+	result := DefaultGeoLocation(ip)
+
 	if reader == nil {
 		logger.Debug().Str("ip", ip.String()).Msg("City database unavailable, using defaults")
 		return result
@@ -322,7 +410,7 @@ func (r *SafeGeoIPReader) performCityDBLookup(ip net.IP) *GeoLocation {
 
 	record, err := reader.City(addr)
 	if err != nil {
-		logger.Debug().Err(err).Str("ip", ip.String()).Msg("City lookup failed, using defaults")
+		logger.SampledBurst("maxmind:city_lookup_failed", 1, lookupFailureLogEvery).Debug().Err(err).Str("ip", ip.String()).Msg("City lookup failed, using defaults")
 		return result
 	}
 
@@ -384,12 +472,17 @@ func (r *SafeGeoIPReader) LookupASN(ip net.IP) *ASNInfo {
 
 // performASNDBLookup performs actual database lookup (extracted from original LookupASN)
 func (r *SafeGeoIPReader) performASNDBLookup(ip net.IP) *ASNInfo {
-	result := DefaultASNInfo(ip)
-
 	r.mu.RLock()
 	reader := r.asnReader
 	r.mu.RUnlock()
 
+	return asnDBLookup(reader, ip)
+}
+
+// asnDBLookup looks up IP in given ASN database reader with safe fallback
+func asnDBLookup(reader *geoip2.Reader, ip net.IP) *ASNInfo {
+	result := DefaultASNInfo(ip)
+
 	if reader == nil {
 		logger.Debug().Str("ip", ip.String()).Msg("ASN database unavailable, using defaults")
 		return result
@@ -404,7 +497,7 @@ func (r *SafeGeoIPReader) performASNDBLookup(ip net.IP) *ASNInfo {
 
 	record, err := reader.ASN(addr)
 	if err != nil {
-		logger.Debug().Err(err).Str("ip", ip.String()).Msg("ASN lookup failed, using defaults")
+		logger.SampledBurst("maxmind:asn_lookup_failed", 1, lookupFailureLogEvery).Debug().Err(err).Str("ip", ip.String()).Msg("ASN lookup failed, using defaults")
 		return result
 	}
 
@@ -416,6 +509,171 @@ func (r *SafeGeoIPReader) performASNDBLookup(ip net.IP) *ASNInfo {
 	return result
 }
 
+// LookupCityBatch performs city lookups for multiple IPs acquiring reader lock once, results keep input order
+func (r *SafeGeoIPReader) LookupCityBatch(ips []net.IP) []*GeoLocation {
+	results := make([]*GeoLocation, len(ips))
+	if !r.config.Enabled {
+		for i, ip := range ips {
+			results[i] = DefaultGeoLocation(ip)
+		}
+		return results
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i, ip := range ips {
+		// Invalid IP, fallback without cache
+		if ip == nil {
+			results[i] = DefaultGeoLocation(ip)
+			continue
+		}
+
+		ipStr := ip.String()
+
+		// Check cache first if enabled
+		if r.cityCache != nil {
+			if cached, found := r.cityCache.Get(ipStr); found && !cached.isExpired() {
+				results[i] = cached.Data
+				continue
+			}
+		}
+
+		// Cache miss or expired - perform database lookup with held reader
+		results[i] = cityDBLookup(r.cityReader, ip)
+
+		// Cache the result if cache is enabled
+		if r.cityCache != nil {
+			r.cityCache.Add(ipStr, &cacheEntry[*GeoLocation]{
+				Data:      results[i],
+				ExpiresAt: time.Now().Add(r.cacheTTL),
+			})
+		}
+	}
+
+	return results
+}
+
+// LookupASNBatch performs ASN lookups for multiple IPs acquiring reader lock once, results keep input order
+func (r *SafeGeoIPReader) LookupASNBatch(ips []net.IP) []*ASNInfo {
+	results := make([]*ASNInfo, len(ips))
+	if !r.config.Enabled {
+		for i, ip := range ips {
+			results[i] = DefaultASNInfo(ip)
+		}
+		return results
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i, ip := range ips {
+		// Invalid IP, fallback without cache
+		if ip == nil {
+			results[i] = DefaultASNInfo(ip)
+			continue
+		}
+
+		ipStr := ip.String()
+
+		// Check cache first if enabled
+		if r.asnCache != nil {
+			if cached, found := r.asnCache.Get(ipStr); found && !cached.isExpired() {
+				results[i] = cached.Data
+				continue
+			}
+		}
+
+		// Cache miss or expired - perform database lookup with held reader
+		results[i] = asnDBLookup(r.asnReader, ip)
+
+		// Cache the result if cache is enabled
+		if r.asnCache != nil {
+			r.asnCache.Add(ipStr, &cacheEntry[*ASNInfo]{
+				Data:      results[i],
+				ExpiresAt: time.Now().Add(r.cacheTTL),
+			})
+		}
+	}
+
+	return results
+}
+
+// LookupAnonymous performs anonymous IP (VPN, hosting, proxy, Tor) lookup with LRU cache and safe fallback
+func (r *SafeGeoIPReader) LookupAnonymous(ip net.IP) *AnonymousInfo {
+	if !r.config.Enabled {
+		return DefaultAnonymousInfo(ip)
+	}
+
+	ipStr := ip.String()
+
+	// Check cache first if enabled
+	if r.anonCache != nil {
+		if cached, found := r.anonCache.Get(ipStr); found && !cached.isExpired() {
+			return cached.Data
+		}
+	}
+
+	// Cache miss or expired - perform database lookup
+	result := r.performAnonDBLookup(ip)
+
+	// Cache the result if cache is enabled
+	if r.anonCache != nil && result != nil {
+		entry := &cacheEntry[*AnonymousInfo]{
+			Data:      result,
+			ExpiresAt: time.Now().Add(r.cacheTTL),
+		}
+		r.anonCache.Add(ipStr, entry)
+	}
+
+	return result
+}
+
+// HasAnonymousDB checks if Anonymous IP database reader is loaded
+func (r *SafeGeoIPReader) HasAnonymousDB() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.anonReader != nil
+}
+
+// performAnonDBLookup performs actual Anonymous IP database lookup
+func (r *SafeGeoIPReader) performAnonDBLookup(ip net.IP) *AnonymousInfo {
+	result := DefaultAnonymousInfo(ip)
+
+	r.mu.RLock()
+	reader := r.anonReader
+	r.mu.RUnlock()
+
+	if reader == nil {
+		logger.Debug().Str("ip", ip.String()).Msg("Anonymous IP database unavailable, using defaults")
+		return result
+	}
+
+	// Convert net.IP to netip.Addr for v2 API
+	addr, err := netip.ParseAddr(ip.String())
+	if err != nil {
+		logger.Debug().Err(err).Str("ip", ip.String()).Msg("Invalid IP address format")
+		return result
+	}
+
+	record, err := reader.AnonymousIP(addr)
+	if err != nil {
+		logger.SampledBurst("maxmind:anonymous_ip_lookup_failed", 1, lookupFailureLogEvery).Debug().Err(err).Str("ip", ip.String()).Msg("Anonymous IP lookup failed, using defaults")
+		return result
+	}
+
+	// Populate flags
+	result.IsAnonymous = record.IsAnonymous
+	result.IsAnonymousVPN = record.IsAnonymousVPN
+	result.IsHostingProvider = record.IsHostingProvider
+	result.IsPublicProxy = record.IsPublicProxy
+	result.IsResidentialProxy = record.IsResidentialProxy
+	result.IsTorExitNode = record.IsTorExitNode
+	result.LookedUpAt = utils.Now()
+
+	return result
+}
+
 // GetDatabaseInfo returns current database information
 func (r *SafeGeoIPReader) GetDatabaseInfo() *DatabaseInfo {
 	r.mu.RLock()
@@ -471,6 +729,11 @@ func (r *SafeGeoIPReader) Close() error {
 		r.asnReader.Close()
 		r.asnReader = nil
 	}
+
+	if r.anonReader != nil {
+		r.anonReader.Close()
+		r.anonReader = nil
+	}
 	
 	// Clear caches on close
 	if r.cityCache != nil {
@@ -481,6 +744,10 @@ func (r *SafeGeoIPReader) Close() error {
 		r.asnCache.Purge()
 		r.asnCache = nil
 	}
+	if r.anonCache != nil {
+		r.anonCache.Purge()
+		r.anonCache = nil
+	}
 
 	logger.Debug().Msg("MaxMind GeoIP reader closed")
 	return nil
@@ -0,0 +1,102 @@
+package maxmind
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+)
+
+// newBenchReader creates reader without periodic checker and cache (every lookup hits reader lock).
+// Set MAXMIND_BENCH_STORAGE to directory containing GeoLite2-City.mmdb & GeoLite2-ASN.mmdb to benchmark
+// real lookups, otherwise fallbacks are used.
+func newBenchReader(b *testing.B) *SafeGeoIPReader {
+	b.Helper()
+
+	storagePath := os.Getenv("MAXMIND_BENCH_STORAGE")
+	if storagePath == "" {
+		storagePath = b.TempDir()
+	}
+
+	cfg := &Config{
+		Enabled:       true,
+		StoragePath:   storagePath,
+		CheckInterval: "1h",
+		Cache:         CacheConfig{Enabled: false},
+	}
+	cfg.Databases.City = "GeoLite2-City"
+	cfg.Databases.ASN = "GeoLite2-ASN"
+
+	reader := &SafeGeoIPReader{
+		config: cfg,
+		stopCh: make(chan struct{}),
+		dbInfo: &DatabaseInfo{Enabled: true},
+	}
+	if err := reader.initCaches(); err != nil {
+		b.Fatalf("failed to init caches: %v", err)
+	}
+	if err := reader.loadDatabases(); err != nil {
+		b.Fatalf("failed to load databases: %v", err)
+	}
+	b.Cleanup(func() { _ = reader.Close() })
+
+	return reader
+}
+
+// benchIPs returns n distinct public IPv4 addresses
+func benchIPs(n int) []net.IP {
+	ips := make([]net.IP, n)
+	for i := range ips {
+		ips[i] = net.ParseIP(fmt.Sprintf("%d.%d.%d.%d", 1+i%200, (i/200)%256, i%256, 1+i%250))
+	}
+	return ips
+}
+
+func TestLookupCityBatchOrder(t *testing.T) {
+	cfg := &Config{Enabled: true}
+	reader := &SafeGeoIPReader{config: cfg, dbInfo: &DatabaseInfo{Enabled: true}}
+
+	ips := []net.IP{net.ParseIP("8.8.8.8"), nil, net.ParseIP("1.1.1.1")}
+	cities := reader.LookupCityBatch(ips)
+	asns := reader.LookupASNBatch(ips)
+
+	if len(cities) != len(ips) || len(asns) != len(ips) {
+		t.Fatalf("expected %d results, got %d cities and %d asns", len(ips), len(cities), len(asns))
+	}
+	for i, ip := range ips {
+		if cities[i] == nil || asns[i] == nil {
+			t.Fatalf("result %d is nil", i)
+		}
+		if cities[i].IP != ip.String() || asns[i].IP != ip.String() {
+			t.Errorf("result %d: got IP %q/%q, want %q", i, cities[i].IP, asns[i].IP, ip.String())
+		}
+	}
+}
+
+func BenchmarkLookupSingle(b *testing.B) {
+	reader := newBenchReader(b)
+	ips := benchIPs(100)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, ip := range ips {
+				reader.LookupCity(ip)
+				reader.LookupASN(ip)
+			}
+		}
+	})
+}
+
+func BenchmarkLookupBatch(b *testing.B) {
+	reader := newBenchReader(b)
+	ips := benchIPs(100)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			reader.LookupCityBatch(ips)
+			reader.LookupASNBatch(ips)
+		}
+	})
+}
@@ -27,17 +27,36 @@ type ASNInfo struct {
 	LookedUpAt   time.Time `json:"looked_up_at"`
 }
 
+// AnonymousInfo holds anonymous network flags (VPN, hosting, proxy, Tor) for an IP address
+type AnonymousInfo struct {
+	IP                 string    `json:"ip"`
+	IsAnonymous        bool      `json:"is_anonymous"`
+	IsAnonymousVPN     bool      `json:"is_anonymous_vpn"`
+	IsHostingProvider  bool      `json:"is_hosting_provider"`
+	IsPublicProxy      bool      `json:"is_public_proxy"`
+	IsResidentialProxy bool      `json:"is_residential_proxy"`
+	IsTorExitNode      bool      `json:"is_tor_exit_node"`
+	LookedUpAt         time.Time `json:"looked_up_at"`
+}
+
 // DatabaseInfo holds information about loaded databases
 type DatabaseInfo struct {
-	CityDBPath     string    `json:"city_db_path"`
-	CityDBSize     int64     `json:"city_db_size"`
-	CityDBModTime  time.Time `json:"city_db_modified"`
-	ASNDBPath      string    `json:"asn_db_path"`
-	ASNDBSize      int64     `json:"asn_db_size"`
-	ASNDBModTime   time.Time `json:"asn_db_modified"`
-	LoadedAt       time.Time `json:"loaded_at"`
-	ReloadCount    int       `json:"reload_count"`
-	Enabled        bool      `json:"enabled"`
+	CityDBPath      string    `json:"city_db_path"`
+	CityDBSize      int64     `json:"city_db_size"`
+	CityDBModTime   time.Time `json:"city_db_modified"`
+	CityDBBuildTime time.Time `json:"city_db_build_time"` // mmdb metadata build epoch, zero when not loaded
+	ASNDBPath       string    `json:"asn_db_path"`
+	ASNDBSize       int64     `json:"asn_db_size"`
+	ASNDBModTime    time.Time `json:"asn_db_modified"`
+	ASNDBBuildTime  time.Time `json:"asn_db_build_time"`
+	AnonDBPath      string    `json:"anon_db_path,omitempty"`
+	AnonDBSize      int64     `json:"anon_db_size,omitempty"`
+	AnonDBModTime   time.Time `json:"anon_db_modified,omitempty"`
+	AnonDBBuildTime time.Time `json:"anon_db_build_time,omitempty"`
+	LoadedAt        time.Time `json:"loaded_at"`
+	ReloadCount     int       `json:"reload_count"`
+	Enabled         bool      `json:"enabled"`
+	Providers       []string  `json:"providers,omitempty"` // Active city providers in lookup order when fallbacks configured
 }
 
 // Config holds MaxMind service configuration
@@ -48,9 +67,17 @@ type Config struct {
 	Databases     struct {
 		City string `json:"city"`
 		ASN  string `json:"asn"`
+		Anon string `json:"anon"` // Optional, empty = anonymous IP lookup disabled
 	} `json:"databases"`
 	Downloader DownloaderConfig `json:"downloader"`
 	Cache      CacheConfig      `json:"cache"`
+	Fallback   FallbackConfig   `json:"fallback"`
+}
+
+// FallbackConfig holds secondary geo providers consulted when MaxMind has no city data for IP
+type FallbackConfig struct {
+	CityDB      string `json:"city_db"`      // Optional City-compatible mmdb path (e.g. IP2Location, DB-IP)
+	CountryCIDR string `json:"country_cidr"` // Optional CSV path of "cidr,country_code[,country]" lines
 }
 
 // CacheConfig holds cache-specific configuration
@@ -58,6 +85,7 @@ type CacheConfig struct {
 	Enabled    bool   `json:"enabled"`
 	MaxEntries int    `json:"max_entries"`
 	TTL        string `json:"ttl"`
+	Shared     bool   `json:"shared"` // Share IP lookups between workers via Redis cache store
 }
 
 // DownloaderConfig holds downloader-specific configuration
@@ -90,6 +118,10 @@ type RetryConfig struct {
 type GeoIPService interface {
 	LookupCity(ip net.IP) *GeoLocation
 	LookupASN(ip net.IP) *ASNInfo
+	LookupCityBatch(ips []net.IP) []*GeoLocation
+	LookupASNBatch(ips []net.IP) []*ASNInfo
+	LookupAnonymous(ip net.IP) *AnonymousInfo
+	HasAnonymousDB() bool
 	GetDatabaseInfo() *DatabaseInfo
 	ReloadDatabases() error
 	Health() error
@@ -119,4 +151,12 @@ func DefaultASNInfo(ip net.IP) *ASNInfo {
 		Organization: "",
 		LookedUpAt:   time.Now(),
 	}
-}
\ No newline at end of file
+}
+
+// DefaultAnonymousInfo returns an AnonymousInfo with safe defaults (all flags false)
+func DefaultAnonymousInfo(ip net.IP) *AnonymousInfo {
+	return &AnonymousInfo{
+		IP:         ip.String(),
+		LookedUpAt: time.Now(),
+	}
+}
@@ -0,0 +1,121 @@
+package netutil
+
+import (
+	"net"
+	"net/netip"
+)
+
+// IPClass describes routability of IP address
+type IPClass int
+
+const (
+	Public    IPClass = iota // Globally routable address
+	Private                  // RFC 1918 (IPv4), unique local fc00::/7 (IPv6)
+	Loopback                 // 127.0.0.0/8, ::1
+	LinkLocal                // 169.254.0.0/16, fe80::/10
+	CGNAT                    // Carrier-grade NAT shared space 100.64.0.0/10
+	Bogon                    // Reserved, documentation, multicast, unspecified or unallocated space
+	Invalid                  // Nil or malformed address
+)
+
+// String returns string representation of IPClass
+func (c IPClass) String() string {
+	switch c {
+	case Public:
+		return "public"
+	case Private:
+		return "private"
+	case Loopback:
+		return "loopback"
+	case LinkLocal:
+		return "link_local"
+	case CGNAT:
+		return "cgnat"
+	case Bogon:
+		return "bogon"
+	default:
+		return "invalid"
+	}
+}
+
+// IsPublic reports whether address is globally routable (worth geo lookup)
+func (c IPClass) IsPublic() bool {
+	return c == Public
+}
+
+// classRange maps prefix to its class, checked in order (first match wins)
+type classRange struct {
+	prefix netip.Prefix
+	class  IPClass
+}
+
+var ipv4Ranges = []classRange{
+	{netip.MustParsePrefix("127.0.0.0/8"), Loopback},
+	{netip.MustParsePrefix("10.0.0.0/8"), Private},
+	{netip.MustParsePrefix("172.16.0.0/12"), Private},
+	{netip.MustParsePrefix("192.168.0.0/16"), Private},
+	{netip.MustParsePrefix("169.254.0.0/16"), LinkLocal},
+	{netip.MustParsePrefix("100.64.0.0/10"), CGNAT},
+	{netip.MustParsePrefix("0.0.0.0/8"), Bogon},       // "This" network
+	{netip.MustParsePrefix("192.0.0.0/24"), Bogon},    // IETF protocol assignments
+	{netip.MustParsePrefix("192.0.2.0/24"), Bogon},    // TEST-NET-1
+	{netip.MustParsePrefix("198.18.0.0/15"), Bogon},   // Benchmarking
+	{netip.MustParsePrefix("198.51.100.0/24"), Bogon}, // TEST-NET-2
+	{netip.MustParsePrefix("203.0.113.0/24"), Bogon},  // TEST-NET-3
+	{netip.MustParsePrefix("224.0.0.0/4"), Bogon},     // Multicast
+	{netip.MustParsePrefix("240.0.0.0/4"), Bogon},     // Reserved & broadcast
+}
+
+var ipv6Ranges = []classRange{
+	{netip.MustParsePrefix("::1/128"), Loopback},
+	{netip.MustParsePrefix("fc00::/7"), Private},
+	{netip.MustParsePrefix("fe80::/10"), LinkLocal},
+	{netip.MustParsePrefix("::/128"), Bogon},        // Unspecified
+	{netip.MustParsePrefix("100::/64"), Bogon},      // Discard-only
+	{netip.MustParsePrefix("2001:db8::/32"), Bogon}, // Documentation
+	{netip.MustParsePrefix("2001:10::/28"), Bogon},  // ORCHID
+	{netip.MustParsePrefix("fec0::/10"), Bogon},     // Deprecated site-local
+	{netip.MustParsePrefix("ff00::/8"), Bogon},      // Multicast
+}
+
+// globalUnicastV6 is only IPv6 space allocated for global unicast, anything else is bogon
+var globalUnicastV6 = netip.MustParsePrefix("2000::/3")
+
+// ClassifyIP returns routability class of IP (IPv4-mapped IPv6 is classified as IPv4)
+func ClassifyIP(ip net.IP) IPClass {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return Invalid
+	}
+	addr = addr.Unmap()
+
+	if addr.Is4() {
+		if class, found := matchRange(ipv4Ranges, addr); found {
+			return class
+		}
+		return Public
+	}
+
+	if class, found := matchRange(ipv6Ranges, addr); found {
+		return class
+	}
+	if !globalUnicastV6.Contains(addr) {
+		return Bogon
+	}
+	return Public
+}
+
+// ClassifyIPString parses and classifies IP string (Invalid when malformed)
+func ClassifyIPString(s string) IPClass {
+	return ClassifyIP(net.ParseIP(s))
+}
+
+// matchRange returns class of first range containing addr
+func matchRange(ranges []classRange, addr netip.Addr) (IPClass, bool) {
+	for _, r := range ranges {
+		if r.prefix.Contains(addr) {
+			return r.class, true
+		}
+	}
+	return Public, false
+}
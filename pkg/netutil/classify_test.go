@@ -0,0 +1,69 @@
+package netutil
+
+import (
+	"net"
+	"testing"
+)
+
+func TestClassifyIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want IPClass
+	}{
+		// IPv4
+		{"8.8.8.8", Public},
+		{"36.86.63.182", Public},
+		{"10.1.2.3", Private},
+		{"172.16.0.1", Private},
+		{"172.31.255.255", Private},
+		{"172.32.0.1", Public},
+		{"192.168.1.10", Private},
+		{"127.0.0.1", Loopback},
+		{"169.254.169.254", LinkLocal},
+		{"100.64.0.1", CGNAT},
+		{"100.127.255.254", CGNAT},
+		{"100.128.0.1", Public},
+		{"0.0.0.0", Bogon},
+		{"192.0.2.1", Bogon},
+		{"198.18.0.1", Bogon},
+		{"198.51.100.7", Bogon},
+		{"203.0.113.10", Bogon},
+		{"224.0.0.251", Bogon},
+		{"255.255.255.255", Bogon},
+
+		// IPv4-mapped IPv6
+		{"::ffff:10.0.0.1", Private},
+		{"::ffff:8.8.4.4", Public},
+
+		// IPv6
+		{"2001:4860:4860::8888", Public},
+		{"2404:6800:4003:c00::64", Public},
+		{"::1", Loopback},
+		{"fd12:3456:789a::1", Private},
+		{"fe80::1", LinkLocal},
+		{"::", Bogon},
+		{"2001:db8::1", Bogon},
+		{"100::1", Bogon},
+		{"ff02::1", Bogon},
+		{"fec0::1", Bogon},
+		{"4000::1", Bogon}, // Outside 2000::/3 global unicast
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("ClassifyIP(%s) = %s, want %s", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestClassifyIPInvalid(t *testing.T) {
+	if got := ClassifyIP(nil); got != Invalid {
+		t.Errorf("ClassifyIP(nil) = %s, want invalid", got)
+	}
+	if got := ClassifyIPString("not-an-ip"); got != Invalid {
+		t.Errorf("ClassifyIPString(not-an-ip) = %s, want invalid", got)
+	}
+	if Private.IsPublic() || !Public.IsPublic() {
+		t.Error("only public class should be public")
+	}
+}
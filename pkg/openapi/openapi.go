@@ -0,0 +1,331 @@
+package openapi
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Auth requirement of documented operation
+const (
+	AuthNone     = ""         // Public endpoint
+	AuthOptional = "optional" // Credentials accepted but not required
+	AuthRequired = "required" // JWT, API key or signature required
+)
+
+// Doc holds documentation metadata for handler (request/response types are introspected via struct tags)
+type Doc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Auth        string
+	Permission  string            // Required permission (e.g. export:security_events)
+	Query       map[string]string // Query parameters: name => description
+	Request     interface{}       // Request body type (nil = no body)
+	Response    interface{}       // Response envelope data type (nil = free-form)
+	Items       interface{}       // Element type of Response "data" array (paginated lists)
+	ContentType string            // Raw response content type instead of JSON envelope (e.g. text/csv)
+}
+
+// Route is registered HTTP route (Handler is fully qualified handler function name)
+type Route struct {
+	Method  string
+	Path    string
+	Handler string
+}
+
+var (
+	docs      = make(map[string]Doc)
+	docsMutex sync.RWMutex
+)
+
+// Register attaches documentation to handler function
+func Register(handler interface{}, doc Doc) {
+	docsMutex.Lock()
+	defer docsMutex.Unlock()
+	docs[HandlerName(handler)] = doc
+}
+
+// HandlerName returns fully qualified function name (same as echo route name)
+func HandlerName(handler interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+}
+
+// getDoc returns documentation registered for handler name
+func getDoc(name string) (Doc, bool) {
+	docsMutex.RLock()
+	defer docsMutex.RUnlock()
+	doc, exists := docs[name]
+	return doc, exists
+}
+
+// Generator builds OpenAPI document from routes and registered docs
+type Generator struct {
+	Title        string
+	Version      string
+	Description  string
+	Envelope     interface{}                // Response envelope type wrapping handler data
+	EnvelopeData string                     // Envelope JSON field replaced by operation response (default "data")
+	EnumValues   func(name string) []string // Resolves custom `enum=<name>` validate tag
+}
+
+// supportedMethods lists HTTP methods emitted as operations
+var supportedMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+// Generate builds OpenAPI document for routes (undocumented handlers get generic operation)
+func (g Generator) Generate(routes []Route) *Document {
+	schemas := newSchemaRegistry(g.EnumValues)
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       g.Title,
+			Version:     g.Version,
+			Description: g.Description,
+		},
+		Paths: make(map[string]map[string]*Operation),
+		Components: Components{
+			SecuritySchemes: securitySchemes(),
+		},
+	}
+
+	// Deterministic order keeps operation IDs stable between runs
+	sorted := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		if supportedMethods[route.Method] {
+			sorted = append(sorted, route)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	errorSchema := g.envelope(schemas, nil)
+	operationIDs := make(map[string]int)
+
+	for _, route := range sorted {
+		path, params := convertPath(route.Path)
+		handlerDoc, _ := getDoc(route.Handler)
+
+		op := &Operation{
+			OperationID: operationID(route.Handler, operationIDs),
+			Summary:     handlerDoc.Summary,
+			Description: handlerDoc.Description,
+			Tags:        handlerDoc.Tags,
+			Parameters:  params,
+			Responses:   make(map[string]*Response),
+		}
+		if len(op.Tags) == 0 {
+			op.Tags = []string{defaultTag(path)}
+		}
+
+		// Query parameters (sorted for stable output)
+		queryNames := make([]string, 0, len(handlerDoc.Query))
+		for name := range handlerDoc.Query {
+			queryNames = append(queryNames, name)
+		}
+		sort.Strings(queryNames)
+		for _, name := range queryNames {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:        name,
+				In:          "query",
+				Description: handlerDoc.Query[name],
+				Schema:      &Schema{Type: "string"},
+			})
+		}
+
+		// Request body
+		if handlerDoc.Request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{"application/json": {Schema: schemas.SchemaOf(handlerDoc.Request)}},
+			}
+		}
+
+		// Success response
+		if handlerDoc.ContentType != "" {
+			op.Responses["200"] = &Response{
+				Description: "Successful response",
+				Content:     map[string]MediaType{handlerDoc.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}},
+			}
+		} else {
+			op.Responses["200"] = &Response{
+				Description: "Successful response",
+				Content:     map[string]MediaType{"application/json": {Schema: g.envelope(schemas, &handlerDoc)}},
+			}
+		}
+		op.Responses["default"] = &Response{
+			Description: "Error response (see error codes)",
+			Content:     map[string]MediaType{"application/json": {Schema: errorSchema}},
+		}
+
+		// Security requirements
+		switch handlerDoc.Auth {
+		case AuthRequired, AuthOptional:
+			op.Security = []map[string][]string{
+				{"bearerAuth": {}},
+				{"apiKeyAuth": {}},
+				{"signatureAuth": {}, "clientIdAuth": {}},
+			}
+			if handlerDoc.Auth == AuthOptional {
+				op.Security = append(op.Security, map[string][]string{})
+			}
+		}
+		if handlerDoc.Permission != "" {
+			note := "Requires permission `" + handlerDoc.Permission + "`."
+			if op.Description != "" {
+				note = op.Description + "\n\n" + note
+			}
+			op.Description = note
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	doc.Components.Schemas = schemas.schemas
+	return doc
+}
+
+// envelope returns response envelope schema with data field replaced by documented response.
+// Without envelope type, documented response is returned as is.
+func (g Generator) envelope(schemas *schemaRegistry, handlerDoc *Doc) *Schema {
+	var data *Schema
+	if handlerDoc != nil {
+		data = schemas.SchemaOf(handlerDoc.Response)
+		if handlerDoc.Items != nil {
+			data = withItems(schemas, handlerDoc.Response, handlerDoc.Items)
+		}
+	}
+
+	if g.Envelope == nil {
+		if data == nil {
+			return &Schema{}
+		}
+		return data
+	}
+	if handlerDoc == nil {
+		return schemas.SchemaOf(g.Envelope)
+	}
+
+	field := g.EnvelopeData
+	if field == "" {
+		field = "data"
+	}
+	return replaceProperty(schemas, g.Envelope, field, data)
+}
+
+// withItems returns response schema whose "data" field is array of items (list responses)
+func withItems(schemas *schemaRegistry, response interface{}, items interface{}) *Schema {
+	list := &Schema{Type: "array", Items: schemas.SchemaOf(items)}
+	if response == nil {
+		return list
+	}
+	return replaceProperty(schemas, response, "data", list)
+}
+
+// replaceProperty builds inline copy of struct schema with one property replaced
+func replaceProperty(schemas *schemaRegistry, v interface{}, field string, replacement *Schema) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return replacement
+	}
+
+	schema := schemas.structSchema(t)
+	if _, exists := schema.Properties[field]; exists {
+		schema.Properties[field] = replacement
+	}
+	return schema
+}
+
+// convertPath converts echo path params (:id, *) to OpenAPI templates
+func convertPath(path string) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		name := ""
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name = segment[1:]
+		case segment == "*":
+			name = "wildcard"
+		default:
+			continue
+		}
+		segments[i] = "{" + name + "}"
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operationID derives unique operation ID from handler function name
+func operationID(handler string, seen map[string]int) string {
+	name := handler
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	if name == "" {
+		name = "operation"
+	}
+
+	seen[name]++
+	if count := seen[name]; count > 1 {
+		return name + "_" + strconv.Itoa(count)
+	}
+	return name
+}
+
+// defaultTag uses first path segment after version prefix (e.g. /v1/security-events/list => security-events)
+func defaultTag(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+// securitySchemes lists supported authentication methods
+func securitySchemes() map[string]*SecurityScheme {
+	return map[string]*SecurityScheme{
+		"bearerAuth": {
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "JWT signed with client RSA/Ed25519 key",
+		},
+		"apiKeyAuth": {
+			Type: "apiKey",
+			In:   "header",
+			Name: "X-API-Key",
+		},
+		"signatureAuth": {
+			Type:        "apiKey",
+			In:          "header",
+			Name:        "X-Signature",
+			Description: "HMAC request signature, sent together with X-Client-ID, X-Timestamp and X-Nonce headers",
+		},
+		"clientIdAuth": {
+			Type: "apiKey",
+			In:   "header",
+			Name: "X-Client-ID",
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type testEnvelope struct {
+	Success bool        `json:"success"`
+	Code    int         `json:"code"`
+	Data    interface{} `json:"data"`
+}
+
+type testRequest struct {
+	Length    int               `json:"length" validate:"required,min=1,max=100"`
+	Direction string            `json:"direction" validate:"required,oneof=next prev"`
+	Channel   string            `json:"channel" validate:"required,enum=channel"`
+	Tags      []string          `json:"tags" validate:"omitempty,max=5,dive,max=20"`
+	Cursor    *string           `json:"cursor,omitempty"`
+	Since     time.Time         `json:"since"`
+	Details   map[string]string `json:"details"`
+	Nested    testNested        `json:"nested"`
+	Ignored   string            `json:"-"`
+}
+
+type testNested struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type testItem struct {
+	ID string `json:"id"`
+}
+
+type testList struct {
+	Data  interface{} `json:"data"`
+	Total int         `json:"total"`
+}
+
+func testHandler()     {}
+func testListHandler() {}
+
+func TestSchemaFromTags(t *testing.T) {
+	schemas := newSchemaRegistry(func(name string) []string {
+		if name == "channel" {
+			return []string{"api", "web"}
+		}
+		return nil
+	})
+
+	ref := schemas.SchemaOf(testRequest{})
+	if ref.Ref != "#/components/schemas/TestRequest" {
+		t.Fatalf("expected component ref, got %q", ref.Ref)
+	}
+
+	schema := schemas.schemas["TestRequest"]
+	if got := schema.Required; len(got) != 3 || got[0] != "length" || got[1] != "direction" || got[2] != "channel" {
+		t.Errorf("unexpected required fields: %v", got)
+	}
+	if _, exists := schema.Properties["Ignored"]; exists {
+		t.Error("json:\"-\" field must be skipped")
+	}
+
+	length := schema.Properties["length"]
+	if length.Type != "integer" || *length.Minimum != 1 || *length.Maximum != 100 {
+		t.Errorf("unexpected length schema: %+v", length)
+	}
+	if enum := schema.Properties["direction"].Enum; len(enum) != 2 || enum[0] != "next" {
+		t.Errorf("unexpected oneof enum: %v", enum)
+	}
+	if enum := schema.Properties["channel"].Enum; len(enum) != 2 || enum[1] != "web" {
+		t.Errorf("unexpected custom enum: %v", enum)
+	}
+
+	tags := schema.Properties["tags"]
+	if tags.Type != "array" || *tags.MaxItems != 5 || *tags.Items.MaxLength != 20 {
+		t.Errorf("unexpected tags schema: %+v", tags)
+	}
+	if cursor := schema.Properties["cursor"]; cursor.Type != "string" || !cursor.Nullable {
+		t.Errorf("unexpected cursor schema: %+v", cursor)
+	}
+	if since := schema.Properties["since"]; since.Format != "date-time" {
+		t.Errorf("unexpected time schema: %+v", since)
+	}
+	if nested := schemas.schemas["TestNested"]; nested == nil || len(nested.Required) != 1 {
+		t.Errorf("nested struct must be registered as component: %+v", nested)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	Register(testHandler, Doc{Summary: "Detail", Auth: AuthRequired, Permission: "read:test"})
+	Register(testListHandler, Doc{Summary: "List", Auth: AuthOptional, Request: testRequest{}, Response: testList{}, Items: testItem{}})
+
+	generator := Generator{Title: "Test", Version: "1.0.0", Envelope: testEnvelope{}}
+	doc := generator.Generate([]Route{
+		{Method: "GET", Path: "/v1/items/:id", Handler: HandlerName(testHandler)},
+		{Method: "POST", Path: "/v1/items/list", Handler: HandlerName(testListHandler)},
+		{Method: "echo_route_not_found", Path: "/v1/*", Handler: "notFound"},
+	})
+
+	if len(doc.Paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(doc.Paths))
+	}
+
+	detail := doc.Paths["/v1/items/{id}"]["get"]
+	if detail == nil {
+		t.Fatal("expected templated detail path")
+	}
+	if len(detail.Parameters) != 1 || detail.Parameters[0].Name != "id" || detail.Parameters[0].In != "path" {
+		t.Errorf("unexpected path parameters: %+v", detail.Parameters)
+	}
+	if detail.OperationID != "testHandler" || detail.Tags[0] != "items" {
+		t.Errorf("unexpected operation id/tags: %s %v", detail.OperationID, detail.Tags)
+	}
+	if len(detail.Security) != 3 {
+		t.Errorf("required auth must not allow anonymous access: %v", detail.Security)
+	}
+
+	list := doc.Paths["/v1/items/list"]["post"]
+	if list.RequestBody == nil || list.RequestBody.Content["application/json"].Schema.Ref == "" {
+		t.Fatal("expected request body ref")
+	}
+	if len(list.Security) != 4 {
+		t.Errorf("optional auth must allow anonymous access: %v", list.Security)
+	}
+
+	data := list.Responses["200"].Content["application/json"].Schema.Properties["data"]
+	items := data.Properties["data"]
+	if items.Type != "array" || items.Items.Ref != "#/components/schemas/TestItem" {
+		t.Errorf("expected list data items ref, got %+v", items)
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("failed to marshal document: %v", err)
+	}
+}
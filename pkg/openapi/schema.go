@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry builds schemas from Go types, named structs are stored as components and referenced
+type schemaRegistry struct {
+	schemas    map[string]*Schema
+	names      map[reflect.Type]string
+	enumValues func(name string) []string
+}
+
+// newSchemaRegistry creates empty schema registry
+func newSchemaRegistry(enumValues func(name string) []string) *schemaRegistry {
+	return &schemaRegistry{
+		schemas:    make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+		enumValues: enumValues,
+	}
+}
+
+// SchemaOf returns schema for value type (struct values are referenced from components)
+func (r *schemaRegistry) SchemaOf(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return r.schemaFor(reflect.TypeOf(v))
+}
+
+// schemaFor maps Go type to schema
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time", Nullable: nullable}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string", Nullable: nullable}
+	case reflect.Bool:
+		return &Schema{Type: "boolean", Nullable: nullable}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Nullable: nullable}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Nullable: nullable}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float", Nullable: nullable}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double", Nullable: nullable}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte", Nullable: nullable}
+		}
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem()), Nullable: nullable}
+	case reflect.Map:
+		var additional interface{} = true
+		if t.Elem().Kind() != reflect.Interface {
+			additional = r.schemaFor(t.Elem())
+		}
+		return &Schema{Type: "object", AdditionalProperties: additional, Nullable: nullable}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return r.ref(t)
+	default:
+		// interface{} and anything unsupported is free-form
+		return &Schema{}
+	}
+}
+
+// ref registers named struct as component (once) and returns reference to it
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, exists := r.names[t]
+	if !exists {
+		name = r.componentName(t)
+		r.names[t] = name
+
+		// Placeholder first so self-referencing types terminate
+		r.schemas[name] = &Schema{}
+		*r.schemas[name] = *r.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// componentName returns unique exported-style component name for type
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	runes := []rune(t.Name())
+	runes[0] = unicode.ToUpper(runes[0])
+	base := string(runes)
+
+	name := base
+	for i := 2; r.schemas[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+// structSchema builds object schema from exported fields using json & validate tags
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		// Embedded struct without json name is flattened into parent
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := r.structSchema(embedded)
+				for propName, prop := range inner.Properties {
+					schema.Properties[propName] = prop
+				}
+				schema.Required = append(schema.Required, inner.Required...)
+				continue
+			}
+		}
+
+		prop := r.schemaFor(field.Type)
+		if r.applyValidate(prop, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+
+	return schema
+}
+
+// applyValidate maps validator rules onto schema constraints, returns true when field is required.
+// Rules after "dive" apply to array items.
+func (r *schemaRegistry) applyValidate(schema *Schema, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	target := schema
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch key {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+		case "oneof":
+			target.Enum = strings.Fields(value)
+		case "enum":
+			if r.enumValues != nil {
+				target.Enum = r.enumValues(value)
+			}
+		case "min", "gte":
+			setBound(target, value, true)
+		case "max", "lte":
+			setBound(target, value, false)
+		}
+	}
+	return required
+}
+
+// setBound applies min/max rule according to schema type (value, length or item count)
+func setBound(schema *Schema, value string, lower bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || schema.Ref != "" {
+		return
+	}
+	count := int(number)
+
+	switch schema.Type {
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &number
+		} else {
+			schema.Maximum = &number
+		}
+	case "string":
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	}
+}
+
+// jsonName returns JSON property name for field, skip is true for `json:"-"`
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
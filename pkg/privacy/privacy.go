@@ -0,0 +1,71 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+
+	"github.com/benedict-erwin/insight-collector/config"
+)
+
+// Options controls which PII fields are masked before storage
+type Options struct {
+	MaskIP        bool
+	HashUserAgent bool
+}
+
+// GetOptions returns masking options from privacy configuration (all disabled when not configured)
+func GetOptions() Options {
+	cfg := config.Get()
+	if cfg == nil {
+		return Options{}
+	}
+
+	return Options{
+		MaskIP:        cfg.Privacy.MaskIP,
+		HashUserAgent: cfg.Privacy.HashUserAgent,
+	}
+}
+
+// Apply masks IP address and user agent according to privacy configuration.
+// Must be called after geo lookup and UA detection, both need original values.
+func Apply(ipAddress, userAgent string) (string, string) {
+	return ApplyWithOptions(GetOptions(), ipAddress, userAgent)
+}
+
+// ApplyWithOptions masks IP address and user agent according to given options
+func ApplyWithOptions(opts Options, ipAddress, userAgent string) (string, string) {
+	if opts.MaskIP {
+		ipAddress = MaskIP(ipAddress)
+	}
+	if opts.HashUserAgent {
+		userAgent = HashUserAgent(userAgent)
+	}
+	return ipAddress, userAgent
+}
+
+// MaskIP zeroes last octet of IPv4 and last 80 bits of IPv6 address (invalid input returned as is)
+func MaskIP(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ipAddress
+	}
+
+	// IPv4 (including IPv4-mapped IPv6): keep /24
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return ipv4.Mask(net.CIDRMask(24, 32)).String()
+	}
+
+	// IPv6: keep /48
+	return ip.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// HashUserAgent returns hex encoded SHA256 of user agent (empty input returned as is)
+func HashUserAgent(userAgent string) string {
+	if userAgent == "" {
+		return userAgent
+	}
+
+	sum := sha256.Sum256([]byte(userAgent))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,77 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestMaskIPv4(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"192.168.1.123", "192.168.1.0"},
+		{"8.8.8.8", "8.8.8.0"},
+		{"10.0.0.0", "10.0.0.0"},
+		{"::ffff:203.0.113.45", "203.0.113.0"},
+		{"", ""},
+		{"not-an-ip", "not-an-ip"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskIP(tt.input); got != tt.expected {
+			t.Errorf("MaskIP(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMaskIPv6(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2001:db8:85a3:8d3:1319:8a2e:370:7348", "2001:db8:85a3::"},
+		{"2001:0db8:0001:ffff:ffff:ffff:ffff:ffff", "2001:db8:1::"},
+		{"fe80::1", "fe80::"},
+		{"::1", "::"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskIP(tt.input); got != tt.expected {
+			t.Errorf("MaskIP(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestHashUserAgentToggle(t *testing.T) {
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	sum := sha256.Sum256([]byte(ua))
+	hashed := hex.EncodeToString(sum[:])
+
+	// Disabled: values untouched
+	ip, gotUA := ApplyWithOptions(Options{}, "192.168.1.123", ua)
+	if ip != "192.168.1.123" || gotUA != ua {
+		t.Errorf("disabled options modified values: %q, %q", ip, gotUA)
+	}
+
+	// Hash only
+	ip, gotUA = ApplyWithOptions(Options{HashUserAgent: true}, "192.168.1.123", ua)
+	if ip != "192.168.1.123" {
+		t.Errorf("IP masked while MaskIP disabled: %q", ip)
+	}
+	if gotUA != hashed {
+		t.Errorf("user agent hash = %q, want %q", gotUA, hashed)
+	}
+
+	// Both enabled
+	ip, gotUA = ApplyWithOptions(Options{MaskIP: true, HashUserAgent: true}, "192.168.1.123", ua)
+	if ip != "192.168.1.0" || gotUA != hashed {
+		t.Errorf("ApplyWithOptions(all) = %q, %q", ip, gotUA)
+	}
+
+	// Empty user agent stays empty
+	if got := HashUserAgent(""); got != "" {
+		t.Errorf("HashUserAgent(\"\") = %q, want empty", got)
+	}
+}
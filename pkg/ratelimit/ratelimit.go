@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+)
+
+// keyPrefix namespaces token bucket keys in cache store
+const keyPrefix = "ratelimit:"
+
+// tokenBucketScript refills bucket based on elapsed Redis server time and takes one token.
+// Returns {allowed (1/0), retry_after_ms}. Bucket state: hash {tokens, ts (ms)}.
+const tokenBucketScript = `local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, retry}`
+
+// storeRetryInterval throttles reconnect attempts while Redis is unavailable (requests stay unlimited meanwhile)
+const storeRetryInterval = 10 * time.Second
+
+var (
+	store            redis.Client // Shared bucket store, nil until Redis connects
+	storeMu          sync.Mutex
+	storeLastAttempt time.Time
+	newStoreClient   = redis.NewClientForCache // Replaced in tests
+)
+
+// Limit holds resolved token bucket parameters
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Resolve returns effective limit for client (per-client override takes precedence over global config)
+func Resolve(global config.RateLimitConfig, override *config.RateLimitConfig) Limit {
+	limit := Limit{RequestsPerSecond: global.RequestsPerSecond, Burst: global.Burst}
+	if override != nil && override.RequestsPerSecond > 0 {
+		limit = Limit{RequestsPerSecond: override.RequestsPerSecond, Burst: override.Burst}
+	}
+
+	// Default burst allows one second worth of requests
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Ceil(limit.RequestsPerSecond))
+	}
+	return limit
+}
+
+// getStore lazily connects to Redis cache client, failed connection is retried after storeRetryInterval
+func getStore() redis.Client {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if store != nil {
+		return store
+	}
+	retrying := !storeLastAttempt.IsZero()
+	if retrying && time.Since(storeLastAttempt) < storeRetryInterval {
+		return nil
+	}
+	storeLastAttempt = time.Now()
+
+	client, err := newStoreClient()
+	if err != nil {
+		// Warn on first failure only, reconnect attempts log at debug
+		event := logger.WithScope("ratelimit").Warn()
+		if retrying {
+			event = logger.WithScope("ratelimit").Debug()
+		}
+		event.Err(err).Dur("retry_in", storeRetryInterval).Msg("Redis rate limit store unavailable, requests will not be limited")
+		return nil
+	}
+	store = client
+	return store
+}
+
+// Allow takes one token from bucket identified by key, returns retry delay when limited.
+// Limit with non-positive rate is unlimited. Error reports Redis failure (caller decides fail open/closed).
+func Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.RequestsPerSecond <= 0 {
+		return true, 0, nil
+	}
+
+	client := getStore()
+	if client == nil {
+		return true, 0, fmt.Errorf("rate limit store not initialized")
+	}
+
+	result, err := client.Eval(ctx, tokenBucketScript, []string{keyPrefix + key}, limit.RequestsPerSecond, limit.Burst)
+	if err != nil {
+		return true, 0, fmt.Errorf("rate limit check failed: %w", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return true, 0, fmt.Errorf("unexpected rate limit script result: %v", result)
+	}
+
+	allowed, _ := values[0].(int64)
+	retryMs, _ := values[1].(int64)
+
+	return allowed == 1, time.Duration(retryMs) * time.Millisecond, nil
+}
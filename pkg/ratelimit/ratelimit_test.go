@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+)
+
+func TestResolve(t *testing.T) {
+	global := config.RateLimitConfig{RequestsPerSecond: 10, Burst: 20}
+
+	tests := []struct {
+		name     string
+		global   config.RateLimitConfig
+		override *config.RateLimitConfig
+		expected Limit
+	}{
+		{"global", global, nil, Limit{RequestsPerSecond: 10, Burst: 20}},
+		{"override", global, &config.RateLimitConfig{RequestsPerSecond: 100, Burst: 150}, Limit{RequestsPerSecond: 100, Burst: 150}},
+		{"override default burst", global, &config.RateLimitConfig{RequestsPerSecond: 2.5}, Limit{RequestsPerSecond: 2.5, Burst: 3}},
+		{"zero override uses global", global, &config.RateLimitConfig{}, Limit{RequestsPerSecond: 10, Burst: 20}},
+		{"global default burst", config.RateLimitConfig{RequestsPerSecond: 5}, nil, Limit{RequestsPerSecond: 5, Burst: 5}},
+	}
+
+	for _, tt := range tests {
+		if got := Resolve(tt.global, tt.override); got != tt.expected {
+			t.Errorf("%s: Resolve = %+v, want %+v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestAllowUnlimited(t *testing.T) {
+	allowed, retryAfter, err := Allow(context.Background(), "client:unlimited", Limit{})
+	if !allowed || retryAfter != 0 || err != nil {
+		t.Errorf("expected unlimited allow, got allowed=%v retry=%v err=%v", allowed, retryAfter, err)
+	}
+}
+
+// resetStore clears cached store and reconnect state
+func resetStore() {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	store = nil
+	storeLastAttempt = time.Time{}
+	newStoreClient = redis.NewClientForCache
+}
+
+func TestGetStoreRetriesAfterFailure(t *testing.T) {
+	resetStore()
+	defer resetStore()
+
+	attempts := 0
+	var connected redis.Client = &redis.RedisClient{}
+	newStoreClient = func() (redis.Client, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return connected, nil
+	}
+
+	// First failure is not cached forever, but retries are throttled
+	if getStore() != nil {
+		t.Fatal("expected nil store while Redis is down")
+	}
+	if getStore() != nil || attempts != 1 {
+		t.Fatalf("expected no reconnect within retry interval, got %d attempts", attempts)
+	}
+
+	// Retry once interval elapsed
+	storeMu.Lock()
+	storeLastAttempt = time.Now().Add(-storeRetryInterval)
+	storeMu.Unlock()
+	if got := getStore(); got != connected || attempts != 2 {
+		t.Fatalf("expected reconnected store after retry interval, got %v after %d attempts", got, attempts)
+	}
+
+	// Connected store is reused
+	if got := getStore(); got != connected || attempts != 2 {
+		t.Errorf("expected cached store, got %v after %d attempts", got, attempts)
+	}
+}
+
+// TestAllowBurst runs against Redis at REDIS_TEST_ADDR (host:port), skipped when not set
+func TestAllowBurst(t *testing.T) {
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set, skipping Redis rate limit test")
+	}
+
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("invalid REDIS_TEST_ADDR %q: %v", addr, err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	cfg := redis.DefaultRedisConfig()
+	cfg.Single.Host = host
+	cfg.Single.Port = port
+	client, err := redis.NewRedisClient(cfg, "test:", redis.DBTempData)
+	if err != nil {
+		t.Fatalf("failed to connect to Redis: %v", err)
+	}
+	defer client.Close()
+
+	// Use test client as bucket store
+	storeMu.Lock()
+	store = client
+	storeMu.Unlock()
+	defer resetStore()
+
+	ctx := context.Background()
+	key := "burst-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	limit := Limit{RequestsPerSecond: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := Allow(ctx, key, limit)
+		if err != nil || !allowed {
+			t.Fatalf("request %d: expected allowed, got allowed=%v err=%v", i+1, allowed, err)
+		}
+	}
+
+	allowed, retryAfter, err := Allow(ctx, key, limit)
+	if err != nil || allowed {
+		t.Fatalf("expected limited after burst, got allowed=%v err=%v", allowed, err)
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("retryAfter = %v, want (0, 1s]", retryAfter)
+	}
+}
@@ -9,13 +9,14 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// RedisClient implements the Client interface for both single-node and cluster modes
+// RedisClient implements the Client interface for single-node, sentinel and cluster modes
 type RedisClient struct {
 	mode          RedisMode
-	singleClient  *redis.Client        // For single-node Redis
+	singleClient  *redis.Client        // For single-node Redis (also sentinel failover client)
 	clusterClient *redis.ClusterClient // For Redis Cluster
 	keyPrefix     string               // Key prefix for logical separation in cluster mode
 	db            int                  // Database number for single-node mode
+	poolSize      int                  // Configured pool size (reported in PoolStats)
 }
 
 // NewRedisClient creates a new Redis client based on configuration
@@ -24,6 +25,7 @@ func NewRedisClient(cfg RedisConfig, keyPrefix string, db int) (*RedisClient, er
 		mode:      RedisMode(cfg.Mode),
 		keyPrefix: keyPrefix,
 		db:        db,
+		poolSize:  cfg.Pool.Size,
 	}
 
 	switch client.mode {
@@ -69,8 +71,28 @@ func NewRedisClient(cfg RedisConfig, keyPrefix string, db int) (*RedisClient, er
 		}
 
 	case ModeSentinel:
-		// todo: Implement Sentinel support in future
-		return nil, fmt.Errorf("redis Sentinel mode not yet implemented")
+		// Failover client resolves current master via sentinels, DB selection still applies
+		client.singleClient = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Sentinel.MasterName,
+			SentinelAddrs:    cfg.Sentinel.Nodes,
+			SentinelPassword: cfg.Sentinel.Password,
+			Password:         cfg.Single.Password,
+			DB:               db,
+			DialTimeout:      cfg.Pool.DialTimeout,
+			ReadTimeout:      cfg.Pool.ReadTimeout,
+			WriteTimeout:     cfg.Pool.WriteTimeout,
+			PoolSize:         cfg.Pool.Size,
+			PoolTimeout:      cfg.Pool.Timeout,
+			ConnMaxLifetime:  cfg.Pool.MaxLifetime,
+			ConnMaxIdleTime:  cfg.Pool.IdleTimeout,
+		})
+
+		// Test connection
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.singleClient.Ping(ctx).Err(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis via sentinel (master %s): %w", cfg.Sentinel.MasterName, err)
+		}
 
 	default:
 		return nil, fmt.Errorf("unsupported Redis mode: %s", cfg.Mode)
@@ -92,7 +114,7 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 	finalKey := r.buildKey(key)
 
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		return r.singleClient.Set(ctx, finalKey, value, expiration).Err()
 	case ModeCluster:
 		return r.clusterClient.Set(ctx, finalKey, value, expiration).Err()
@@ -101,12 +123,26 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 	}
 }
 
+// SetNX sets a key-value pair with expiration only if key does not exist (returns false when key exists)
+func (r *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	finalKey := r.buildKey(key)
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		return r.singleClient.SetNX(ctx, finalKey, value, expiration).Result()
+	case ModeCluster:
+		return r.clusterClient.SetNX(ctx, finalKey, value, expiration).Result()
+	default:
+		return false, fmt.Errorf("unsupported mode: %s", r.mode)
+	}
+}
+
 // Get retrieves a value by key
 func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	finalKey := r.buildKey(key)
 
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		return r.singleClient.Get(ctx, finalKey).Result()
 	case ModeCluster:
 		return r.clusterClient.Get(ctx, finalKey).Result()
@@ -115,6 +151,20 @@ func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	}
 }
 
+// GetDel retrieves a value and deletes key atomically (GETDEL, Redis >= 6.2)
+func (r *RedisClient) GetDel(ctx context.Context, key string) (string, error) {
+	finalKey := r.buildKey(key)
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		return r.singleClient.GetDel(ctx, finalKey).Result()
+	case ModeCluster:
+		return r.clusterClient.GetDel(ctx, finalKey).Result()
+	default:
+		return "", fmt.Errorf("unsupported mode: %s", r.mode)
+	}
+}
+
 // SetJSON stores JSON-serialized data with expiration
 func (r *RedisClient) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	data, err := json.Marshal(value)
@@ -152,7 +202,7 @@ func (r *RedisClient) Delete(ctx context.Context, keys ...string) error {
 	}
 
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		return r.singleClient.Del(ctx, finalKeys...).Err()
 	case ModeCluster:
 		return r.clusterClient.Del(ctx, finalKeys...).Err()
@@ -169,7 +219,7 @@ func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
 	var err error
 
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		count, err = r.singleClient.Exists(ctx, finalKey).Result()
 	case ModeCluster:
 		count, err = r.clusterClient.Exists(ctx, finalKey).Result()
@@ -184,13 +234,63 @@ func (r *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
 	return count > 0, nil
 }
 
+// Eval runs Lua script with prefixed keys
+func (r *RedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	// Build final keys with prefix
+	finalKeys := make([]string, len(keys))
+	for i, key := range keys {
+		finalKeys[i] = r.buildKey(key)
+	}
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		return r.singleClient.Eval(ctx, script, finalKeys, args...).Result()
+	case ModeCluster:
+		return r.clusterClient.Eval(ctx, script, finalKeys, args...).Result()
+	default:
+		return nil, fmt.Errorf("unsupported mode: %s", r.mode)
+	}
+}
+
+// Publish sends message to pub/sub channel (channel is prefixed like keys)
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	finalChannel := r.buildKey(channel)
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		return r.singleClient.Publish(ctx, finalChannel, message).Err()
+	case ModeCluster:
+		return r.clusterClient.Publish(ctx, finalChannel, message).Err()
+	default:
+		return fmt.Errorf("unsupported mode: %s", r.mode)
+	}
+}
+
+// Subscribe subscribes to pub/sub channels, caller must Close returned PubSub (nil on unsupported mode)
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	// Build final channels with prefix
+	finalChannels := make([]string, len(channels))
+	for i, channel := range channels {
+		finalChannels[i] = r.buildKey(channel)
+	}
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		return r.singleClient.Subscribe(ctx, finalChannels...)
+	case ModeCluster:
+		return r.clusterClient.Subscribe(ctx, finalChannels...)
+	default:
+		return nil
+	}
+}
+
 // Health checks the Redis connection
 func (r *RedisClient) Health() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		return r.singleClient.Ping(ctx).Err()
 	case ModeCluster:
 		return r.clusterClient.Ping(ctx).Err()
@@ -199,10 +299,37 @@ func (r *RedisClient) Health() error {
 	}
 }
 
+// PoolStats returns connection pool statistics (cluster stats are aggregated over all nodes)
+func (r *RedisClient) PoolStats() PoolStats {
+	var stats *redis.PoolStats
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		if r.singleClient != nil {
+			stats = r.singleClient.PoolStats()
+		}
+	case ModeCluster:
+		if r.clusterClient != nil {
+			stats = r.clusterClient.PoolStats()
+		}
+	}
+
+	result := PoolStats{PoolSize: r.poolSize}
+	if stats != nil {
+		result.Hits = stats.Hits
+		result.Misses = stats.Misses
+		result.Timeouts = stats.Timeouts
+		result.TotalConns = stats.TotalConns
+		result.IdleConns = stats.IdleConns
+		result.StaleConns = stats.StaleConns
+	}
+	return result
+}
+
 // Close closes the Redis connection
 func (r *RedisClient) Close() error {
 	switch r.mode {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		if r.singleClient != nil {
 			return r.singleClient.Close()
 		}
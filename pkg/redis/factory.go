@@ -76,10 +76,11 @@ func buildRedisConfig(dbOverride *int, clientType string) RedisConfig {
 	poolConfig := convertToRedisPoolConfig(poolConfigFromRedis)
 
 	return RedisConfig{
-		Mode:    mode,
-		Single:  SingleConfig{Host: cfg.Redis.Host, Port: cfg.Redis.Port, Password: cfg.Redis.Password, DB: db},
-		Cluster: ClusterConfig{Nodes: cfg.Redis.Cluster.Nodes, Password: cfg.Redis.Cluster.Password},
-		Pool:    poolConfig,
+		Mode:     mode,
+		Single:   SingleConfig{Host: cfg.Redis.Host, Port: cfg.Redis.Port, Password: cfg.Redis.Password, DB: db},
+		Cluster:  ClusterConfig{Nodes: cfg.Redis.Cluster.Nodes, Password: cfg.Redis.Cluster.Password},
+		Sentinel: SentinelConfig{MasterName: cfg.Redis.Sentinel.MasterName, Nodes: cfg.Redis.Sentinel.Nodes, Password: cfg.Redis.Sentinel.Password},
+		Pool:     poolConfig,
 	}
 }
 
@@ -92,7 +93,7 @@ func NewClientForMain() (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = DBMain
 		keyPrefix = ""
 	case ModeCluster:
@@ -125,7 +126,7 @@ func NewClientForWorkerConfig() (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = DBMain // Worker config in main DB for single-node
 		keyPrefix = ""
 	case ModeCluster:
@@ -158,7 +159,7 @@ func NewClientForSessions() (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = DBSessions // Dedicated DB for sessions
 		keyPrefix = ""
 	case ModeCluster:
@@ -191,7 +192,7 @@ func NewClientForCache() (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = DBCache // Dedicated DB for cache
 		keyPrefix = ""
 	case ModeCluster:
@@ -224,7 +225,7 @@ func NewClientForNonceStore() (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = DBNonceStore // Dedicated DB for nonce storage
 		keyPrefix = ""
 	case ModeCluster:
@@ -264,7 +265,7 @@ func NewClientForAsynq(heartbeat ...bool) (Client, error) {
 	var db int
 
 	switch RedisMode(redisConfig.Mode) {
-	case ModeSingle:
+	case ModeSingle, ModeSentinel:
 		db = cfg.Asynq.DB // Use Asynq-specific DB from config
 		keyPrefix = ""
 	case ModeCluster:
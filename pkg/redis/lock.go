@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+)
+
+// lockKeyPrefix namespaces distributed lock keys
+const lockKeyPrefix = "lock:"
+
+// unlockScript deletes lock key only if it still holds caller token (avoids releasing lock re-acquired by another process after TTL expiry)
+const unlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
+
+// Lock acquires distributed lock on main client (release is no-op when not acquired)
+func Lock(ctx context.Context, key string, ttl time.Duration) (release func(), acquired bool, err error) {
+	client := GetClient()
+	if client == nil {
+		return func() {}, false, fmt.Errorf("redis client not initialized")
+	}
+	return LockWithClient(ctx, client, key, ttl)
+}
+
+// LockWithClient acquires distributed lock using SET NX with TTL and random token
+func LockWithClient(ctx context.Context, client Client, key string, ttl time.Duration) (release func(), acquired bool, err error) {
+	if ttl <= 0 {
+		return func() {}, false, fmt.Errorf("lock TTL must be positive")
+	}
+
+	token, err := generateLockToken()
+	if err != nil {
+		return func() {}, false, err
+	}
+
+	lockKey := lockKeyPrefix + key
+	acquired, err = client.SetNX(ctx, lockKey, token, ttl)
+	if err != nil {
+		return func() {}, false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		return func() {}, false, nil
+	}
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			// Use fresh context, caller context may already be cancelled
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if _, err := client.Eval(releaseCtx, unlockScript, []string{lockKey}, token); err != nil {
+				logger.WithScope("RedisLock").Warn().
+					Err(err).
+					Str("key", key).
+					Msg("Failed to release lock, it will expire after TTL")
+			}
+		})
+	}
+
+	return release, true, nil
+}
+
+// generateLockToken returns random token identifying lock owner
+func generateLockToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
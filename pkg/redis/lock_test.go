@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClient connects to Redis at REDIS_TEST_ADDR (host:port), skips test when not set
+func newTestClient(t *testing.T) *RedisClient {
+	t.Helper()
+
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set, skipping Redis lock test")
+	}
+
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("invalid REDIS_TEST_ADDR %q: %v", addr, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("invalid REDIS_TEST_ADDR port %q: %v", portStr, err)
+	}
+
+	cfg := DefaultRedisConfig()
+	cfg.Single.Host = host
+	cfg.Single.Port = port
+
+	client, err := NewRedisClient(cfg, "test:", DBTempData)
+	if err != nil {
+		t.Fatalf("failed to connect to Redis: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestLockConcurrentAcquire(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "concurrent-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	const workers = 20
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		acquired int
+		releases []func()
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			release, ok, err := LockWithClient(ctx, client, key, 10*time.Second)
+			if err != nil {
+				t.Errorf("lock error: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				acquired++
+				releases = append(releases, release)
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if acquired != 1 {
+		t.Fatalf("expected exactly 1 acquire, got %d", acquired)
+	}
+
+	// Lock can be re-acquired after release
+	releases[0]()
+	release, ok, err := LockWithClient(ctx, client, key, 10*time.Second)
+	if err != nil || !ok {
+		t.Fatalf("expected re-acquire after release, got acquired=%v err=%v", ok, err)
+	}
+	release()
+}
+
+func TestLockReleaseKeepsForeignLock(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "foreign-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	// First owner lock expires, second owner acquires it
+	staleRelease, ok, err := LockWithClient(ctx, client, key, 100*time.Millisecond)
+	if err != nil || !ok {
+		t.Fatalf("expected first acquire, got acquired=%v err=%v", ok, err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	release, ok, err := LockWithClient(ctx, client, key, 10*time.Second)
+	if err != nil || !ok {
+		t.Fatalf("expected second acquire, got acquired=%v err=%v", ok, err)
+	}
+	defer release()
+
+	// Stale release must not delete second owner lock
+	staleRelease()
+	exists, err := client.Exists(ctx, lockKeyPrefix+key)
+	if err != nil {
+		t.Fatalf("exists error: %v", err)
+	}
+	if !exists {
+		t.Fatalf("stale release removed lock held by another owner")
+	}
+}
@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Pipe queues commands inside Client.Pipeline, results are available after Pipeline returns.
+// Keys are prefixed like on Client, missing Get key yields redis.Nil on its result only.
+type Pipe interface {
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Delete(ctx context.Context, keys ...string) *redis.IntCmd
+	Exists(ctx context.Context, key string) *redis.IntCmd
+	PTTL(ctx context.Context, key string) *redis.DurationCmd
+}
+
+// redisPipe wraps go-redis pipeliner with client key prefix
+type redisPipe struct {
+	pipe     redis.Pipeliner
+	buildKey func(key string) string
+}
+
+// Set queues SET with expiration
+func (p *redisPipe) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	return p.pipe.Set(ctx, p.buildKey(key), value, expiration)
+}
+
+// Get queues GET
+func (p *redisPipe) Get(ctx context.Context, key string) *redis.StringCmd {
+	return p.pipe.Get(ctx, p.buildKey(key))
+}
+
+// Delete queues DEL of one or more keys
+func (p *redisPipe) Delete(ctx context.Context, keys ...string) *redis.IntCmd {
+	finalKeys := make([]string, len(keys))
+	for i, key := range keys {
+		finalKeys[i] = p.buildKey(key)
+	}
+	return p.pipe.Del(ctx, finalKeys...)
+}
+
+// Exists queues EXISTS (result > 0 means key exists)
+func (p *redisPipe) Exists(ctx context.Context, key string) *redis.IntCmd {
+	return p.pipe.Exists(ctx, p.buildKey(key))
+}
+
+// PTTL queues PTTL, negative result means key has no expiration (-1ns) or doesn't exist (-2ns)
+func (p *redisPipe) PTTL(ctx context.Context, key string) *redis.DurationCmd {
+	return p.pipe.PTTL(ctx, p.buildKey(key))
+}
+
+// Pipeline queues commands from fn and sends them in one round trip (one per node in cluster mode).
+// Nothing is sent when fn returns error. Missing keys (redis.Nil) are not reported as pipeline error.
+func (r *RedisClient) Pipeline(ctx context.Context, fn func(Pipe) error) error {
+	var pipe redis.Pipeliner
+
+	switch r.mode {
+	case ModeSingle, ModeSentinel:
+		pipe = r.singleClient.Pipeline()
+	case ModeCluster:
+		pipe = r.clusterClient.Pipeline()
+	default:
+		return fmt.Errorf("unsupported mode: %s", r.mode)
+	}
+
+	if err := fn(&redisPipe{pipe: pipe, buildKey: r.buildKey}); err != nil {
+		pipe.Discard()
+		return err
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("pipeline exec failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// countingHook records single commands and pipeline batches sent to Redis
+type countingHook struct {
+	mu       sync.Mutex
+	commands int
+	batches  []int
+}
+
+func (h *countingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *countingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.mu.Lock()
+		h.commands++
+		h.mu.Unlock()
+		return next(ctx, cmd)
+	}
+}
+
+func (h *countingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h.mu.Lock()
+		h.batches = append(h.batches, len(cmds))
+		h.mu.Unlock()
+		return next(ctx, cmds)
+	}
+}
+
+func TestPipelineSingleRoundTrip(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	prefix := "pipeline-" + strconv.FormatInt(time.Now().UnixNano(), 10) + ":"
+
+	hook := &countingHook{}
+	client.singleClient.AddHook(hook)
+
+	const n = 10
+	gets := make([]*redis.StringCmd, n)
+	var missing *redis.StringCmd
+	var exists *redis.IntCmd
+	var ttl *redis.DurationCmd
+
+	err := client.Pipeline(ctx, func(pipe Pipe) error {
+		for i := 0; i < n; i++ {
+			pipe.Set(ctx, prefix+strconv.Itoa(i), i, time.Minute)
+		}
+		for i := 0; i < n; i++ {
+			gets[i] = pipe.Get(ctx, prefix+strconv.Itoa(i))
+		}
+		missing = pipe.Get(ctx, prefix+"missing")
+		exists = pipe.Exists(ctx, prefix+"0")
+		ttl = pipe.PTTL(ctx, prefix+"1")
+		pipe.Delete(ctx, prefix+"0")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("pipeline error: %v", err)
+	}
+
+	// All 2N+4 operations sent as one batch, no single command round trips
+	if hook.commands != 0 || len(hook.batches) != 1 || hook.batches[0] != 2*n+4 {
+		t.Fatalf("expected 1 batch of %d commands, got batches=%v commands=%d", 2*n+4, hook.batches, hook.commands)
+	}
+
+	for i, get := range gets {
+		if value, err := get.Result(); err != nil || value != strconv.Itoa(i) {
+			t.Errorf("get %d: expected %d, got %q err=%v", i, i, value, err)
+		}
+	}
+	if !errors.Is(missing.Err(), redis.Nil) {
+		t.Errorf("expected redis.Nil for missing key, got %v", missing.Err())
+	}
+	if count, _ := exists.Result(); count != 1 {
+		t.Errorf("expected key to exist before delete, got count=%d", count)
+	}
+	if remaining, _ := ttl.Result(); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("expected remaining ttl within 1m, got %v", remaining)
+	}
+	if found, _ := client.Exists(ctx, prefix+"0"); found {
+		t.Errorf("expected key deleted by pipeline")
+	}
+}
+
+func TestPipelineDiscardOnError(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "pipeline-discard-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	fnErr := errors.New("abort")
+	err := client.Pipeline(ctx, func(pipe Pipe) error {
+		pipe.Set(ctx, key, "value", time.Minute)
+		return fnErr
+	})
+	if !errors.Is(err, fnErr) {
+		t.Fatalf("expected fn error, got %v", err)
+	}
+
+	if found, _ := client.Exists(ctx, key); found {
+		t.Fatalf("expected queued commands discarded when fn fails")
+	}
+}
@@ -88,6 +88,16 @@ func Health() error {
 	return client.Health()
 }
 
+// GetPoolStats returns connection pool statistics of main Redis client
+func GetPoolStats() (PoolStats, error) {
+	client := GetClient()
+	if client == nil {
+		return PoolStats{}, fmt.Errorf("redis client not initialized")
+	}
+
+	return client.PoolStats(), nil
+}
+
 // validateConfig validates the Redis configuration
 func validateConfig(cfg config.RedisConfig) error {
 	// Default to single mode if not specified
@@ -115,7 +125,17 @@ func validateConfig(cfg config.RedisConfig) error {
 		}
 
 	case ModeSentinel:
-		return fmt.Errorf("redis Sentinel mode not yet implemented")
+		if cfg.Sentinel.MasterName == "" {
+			return fmt.Errorf("redis sentinel master name not specified")
+		}
+		if len(cfg.Sentinel.Nodes) == 0 {
+			return fmt.Errorf("redis sentinel nodes not specified")
+		}
+		for _, node := range cfg.Sentinel.Nodes {
+			if node == "" {
+				return fmt.Errorf("empty Redis sentinel node")
+			}
+		}
 
 	default:
 		return fmt.Errorf("unsupported Redis mode: %s", cfg.Mode)
@@ -162,6 +182,15 @@ func Get(ctx context.Context, key string) (string, error) {
 	return client.Get(ctx, key)
 }
 
+// GetDel retrieves and deletes value atomically with the main client
+func GetDel(ctx context.Context, key string) (string, error) {
+	client := GetClient()
+	if client == nil {
+		return "", fmt.Errorf("redis client not initialized")
+	}
+	return client.GetDel(ctx, key)
+}
+
 // Delete removes keys with the main client
 func Delete(ctx context.Context, keys ...string) error {
 	client := GetClient()
@@ -179,3 +208,21 @@ func Exists(ctx context.Context, key string) (bool, error) {
 	}
 	return client.Exists(ctx, key)
 }
+
+// Pipeline batches commands in one round trip with the main client
+func Pipeline(ctx context.Context, fn func(Pipe) error) error {
+	client := GetClient()
+	if client == nil {
+		return fmt.Errorf("redis client not initialized")
+	}
+	return client.Pipeline(ctx, fn)
+}
+
+// Publish sends message to pub/sub channel with the main client
+func Publish(ctx context.Context, channel string, message interface{}) error {
+	client := GetClient()
+	if client == nil {
+		return fmt.Errorf("redis client not initialized")
+	}
+	return client.Publish(ctx, channel, message)
+}
@@ -3,6 +3,8 @@ package redis
 import (
 	"context"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // RedisMode defines the Redis deployment mode
@@ -38,15 +40,33 @@ const (
 // Client defines the unified Redis client interface
 type Client interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
 	SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	GetJSON(ctx context.Context, key string, dest interface{}) error
 	Delete(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, key string) (bool, error)
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+	Pipeline(ctx context.Context, fn func(Pipe) error) error
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 	Health() error
+	PoolStats() PoolStats
 	Close() error
 }
 
+// PoolStats holds connection pool statistics (summed over master & replica nodes in cluster mode)
+type PoolStats struct {
+	Hits       uint32 `json:"hits"`        // Free connection found in pool
+	Misses     uint32 `json:"misses"`      // Free connection not found, new one dialed
+	Timeouts   uint32 `json:"timeouts"`    // Waits for free connection that timed out (pool exhausted)
+	TotalConns uint32 `json:"total_conns"` // Connections currently in pool
+	IdleConns  uint32 `json:"idle_conns"`  // Idle connections in pool
+	StaleConns uint32 `json:"stale_conns"` // Stale connections removed from pool
+	PoolSize   int    `json:"pool_size"`   // Configured max connections (per node in cluster mode)
+}
+
 // RedisConfig holds Redis configuration for different modes
 type RedisConfig struct {
 	Mode     string         `json:"mode"`     // single, cluster, sentinel
@@ -3,13 +3,19 @@ package response
 import (
 	"bytes"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/goccy/go-json"
 	"github.com/labstack/echo/v4"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
+// MsgPackContentType is MIME type of MessagePack responses
+const MsgPackContentType = "application/msgpack"
+
 // Buffer pool for high-performance JSON encoding
 var bufferPool = sync.Pool{
 	New: func() interface{} {
@@ -50,6 +56,74 @@ func fastJSON(c echo.Context, code int, obj interface{}) error {
 	return err
 }
 
+// fastMsgPack serializes response as MessagePack with buffer pooling (json struct tags, same field names as JSON)
+func fastMsgPack(c echo.Context, code int, obj interface{}) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	enc := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(enc)
+
+	// Reset clears encoder options, set them per use
+	enc.Reset(buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	if err := enc.Encode(obj); err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, MsgPackContentType)
+	c.Response().WriteHeader(code)
+	_, err := c.Response().Write(buf.Bytes())
+	return err
+}
+
+// render writes response in encoding negotiated from Accept header, JSON by default
+func render(c echo.Context, code int, obj interface{}) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	if WantsMsgPack(c.Request().Header.Get(echo.HeaderAccept)) {
+		return fastMsgPack(c, code, obj)
+	}
+	return fastJSON(c, code, obj)
+}
+
+// WantsMsgPack reports whether Accept header prefers MessagePack over JSON.
+// application/msgpack (or application/x-msgpack) must have q > 0 and at least q of JSON, ties go to MessagePack.
+func WantsMsgPack(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	msgpackQ, jsonQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		q := acceptQuality(params)
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case MsgPackContentType, "application/x-msgpack":
+			msgpackQ = max(msgpackQ, q)
+		case echo.MIMEApplicationJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return msgpackQ > 0 && msgpackQ >= jsonQ
+}
+
+// acceptQuality returns q parameter of Accept media range, 1 when absent or invalid
+func acceptQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || !strings.EqualFold(name, "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 1
+		}
+		return q
+	}
+	return 1
+}
+
 // Standard Response struct
 type Response struct {
 	Success   bool   `json:"success"`
@@ -57,6 +131,7 @@ type Response struct {
 	Data      any    `json:"data"`
 	Message   string `json:"message"`
 	RequestID string `json:"request_id"`
+	TraceID   string `json:"trace_id,omitempty"` // Failed responses only, for support correlation
 }
 
 // getReqId extracts request ID from Echo context
@@ -64,9 +139,14 @@ func getReqId(c echo.Context) string {
 	return constants.GetRequestID(c)
 }
 
+// getTraceId extracts trace ID from Echo context
+func getTraceId(c echo.Context) string {
+	return constants.GetTraceID(c)
+}
+
 // Success returns a successful response with data
 func Success(c echo.Context, data any) error {
-	return fastJSON(c, http.StatusOK, Response{
+	return render(c, http.StatusOK, Response{
 		Success:   true,
 		Code:      0,
 		Data:      data,
@@ -77,47 +157,54 @@ func Success(c echo.Context, data any) error {
 
 // Fail returns an error response with message
 func Fail(c echo.Context, httpStatus int, code int, message string) error {
-	return fastJSON(c, httpStatus, Response{
+	return render(c, httpStatus, Response{
 		Success:   false,
 		Code:      code,
 		Data:      nil,
 		Message:   message,
 		RequestID: getReqId(c),
+		TraceID:   getTraceId(c),
 	})
 }
 
 // General returns a customizable response
 func General(c echo.Context, httpStatus int, code int, data any, message string) error {
-	return fastJSON(c, httpStatus, Response{
+	resp := Response{
 		Success:   httpStatus < 400,
 		Code:      code,
 		Data:      data,
 		Message:   message,
 		RequestID: getReqId(c),
-	})
+	}
+	if !resp.Success {
+		resp.TraceID = getTraceId(c)
+	}
+	return render(c, httpStatus, resp)
 }
 
 // FailWithCode returns an error response using standardized error code
 func FailWithCode(c echo.Context, code int) error {
 	httpStatus := constants.GetHTTPStatusFromCode(code)
 	message := constants.GetErrorMessage(code)
-	return fastJSON(c, httpStatus, Response{
+	return render(c, httpStatus, Response{
 		Success:   false,
 		Code:      code,
 		Data:      nil,
 		Message:   message,
 		RequestID: getReqId(c),
+		TraceID:   getTraceId(c),
 	})
 }
 
 // FailWithCodeAndMessage returns an error response with custom message
 func FailWithCodeAndMessage(c echo.Context, code int, customMessage string) error {
 	httpStatus := constants.GetHTTPStatusFromCode(code)
-	return fastJSON(c, httpStatus, Response{
+	return render(c, httpStatus, Response{
 		Success:   false,
 		Code:      code,
 		Data:      nil,
 		Message:   customMessage,
 		RequestID: getReqId(c),
+		TraceID:   getTraceId(c),
 	})
 }
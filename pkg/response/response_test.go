@@ -0,0 +1,154 @@
+package response_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/benedict-erwin/insight-collector/internal/constants"
+	seEntities "github.com/benedict-erwin/insight-collector/internal/entities/security_events"
+	v2oss "github.com/benedict-erwin/insight-collector/pkg/influxdb/v2-oss"
+	"github.com/benedict-erwin/insight-collector/pkg/response"
+)
+
+func TestWantsMsgPack(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/msgpack", true},
+		{"application/x-msgpack", true},
+		{"Application/MsgPack", true},
+		{"application/json, application/msgpack", true},
+		{"application/msgpack;q=0.5, application/json", false},
+		{"application/msgpack, application/json;q=0.9", true},
+		{"application/msgpack;q=0", false},
+	}
+
+	for _, tt := range tests {
+		if got := response.WantsMsgPack(tt.accept); got != tt.want {
+			t.Errorf("WantsMsgPack(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+// securityEventsPage returns list response as returned by security events list endpoint
+func securityEventsPage() v2oss.PaginationResponse {
+	next := "cursor-2"
+	return v2oss.PaginationResponse{
+		Data: []seEntities.SecurityEventsResponse{{
+			ID:                  "se-1",
+			Time:                "2025-01-02T03:04:05Z",
+			UserID:              "user-1",
+			EventType:           "login_failed",
+			Severity:            "high",
+			AttemptCount:        3,
+			RiskScore:           0.85,
+			IsBot:               true,
+			IPAddress:           "192.168.1.*",
+			PreviousSuccessTime: 1735787045,
+			Details:             map[string]interface{}{"reason": "bad_password", "lockout": false},
+		}},
+		Pagination: v2oss.PaginationInfo{Length: 1, HasNext: true, NextCursor: &next, Direction: "next"},
+	}
+}
+
+// serve renders page with response.Success for Accept header
+func serve(t *testing.T, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/v1/security-events/list", nil)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	if err := response.Success(c, securityEventsPage()); err != nil {
+		t.Fatalf("Success: %v", err)
+	}
+	return rec
+}
+
+// decodeMsgPack decodes MessagePack response using json struct tags like encoder
+func decodeMsgPack(data []byte, v interface{}) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
+
+// listResponse is typed decode target of both encodings
+type listResponse struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Data       []seEntities.SecurityEventsResponse `json:"data"`
+		Pagination v2oss.PaginationInfo                `json:"pagination"`
+	} `json:"data"`
+}
+
+func TestSuccessNegotiatesEncoding(t *testing.T) {
+	want := securityEventsPage()
+
+	jsonRec := serve(t, "")
+	if ct := jsonRec.Header().Get(echo.HeaderContentType); ct != echo.MIMEApplicationJSONCharsetUTF8 {
+		t.Fatalf("default Content-Type = %q, want JSON", ct)
+	}
+	var fromJSON listResponse
+	if err := json.Unmarshal(jsonRec.Body.Bytes(), &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	msgpackRec := serve(t, response.MsgPackContentType)
+	if ct := msgpackRec.Header().Get(echo.HeaderContentType); ct != response.MsgPackContentType {
+		t.Fatalf("Content-Type = %q, want %q", ct, response.MsgPackContentType)
+	}
+	if vary := msgpackRec.Header().Get(echo.HeaderVary); vary != echo.HeaderAccept {
+		t.Errorf("Vary = %q, want Accept", vary)
+	}
+	var fromMsgPack listResponse
+	if err := decodeMsgPack(msgpackRec.Body.Bytes(), &fromMsgPack); err != nil {
+		t.Fatalf("decode msgpack: %v", err)
+	}
+
+	events := want.Data.([]seEntities.SecurityEventsResponse)
+	for name, got := range map[string]listResponse{"json": fromJSON, "msgpack": fromMsgPack} {
+		if !got.Success {
+			t.Errorf("%s: success = false", name)
+		}
+		if !reflect.DeepEqual(got.Data.Data, events) {
+			t.Errorf("%s: events = %#v, want %#v", name, got.Data.Data, events)
+		}
+		if !reflect.DeepEqual(got.Data.Pagination, want.Pagination) {
+			t.Errorf("%s: pagination = %#v, want %#v", name, got.Data.Pagination, want.Pagination)
+		}
+	}
+}
+
+func TestFailWithCodeNegotiatesEncoding(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/security-events/detail/x", nil)
+	req.Header.Set(echo.HeaderAccept, "application/x-msgpack")
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	if err := response.FailWithCode(c, constants.CodeResourceNotFound); err != nil {
+		t.Fatalf("FailWithCode: %v", err)
+	}
+	if ct := rec.Header().Get(echo.HeaderContentType); ct != response.MsgPackContentType {
+		t.Fatalf("Content-Type = %q, want %q", ct, response.MsgPackContentType)
+	}
+
+	var got response.Response
+	if err := decodeMsgPack(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode msgpack: %v", err)
+	}
+	if got.Success || got.Code != constants.CodeResourceNotFound {
+		t.Errorf("response = %+v, want failed with CodeResourceNotFound", got)
+	}
+}
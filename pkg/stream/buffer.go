@@ -0,0 +1,53 @@
+package stream
+
+import "sync/atomic"
+
+// DefaultBufferSize is per-connection send buffer size
+const DefaultBufferSize = 256
+
+// Buffer is bounded per-connection send buffer, when full oldest message is dropped (slow clients never block publisher)
+type Buffer struct {
+	ch      chan []byte
+	dropped atomic.Int64
+}
+
+// NewBuffer creates send buffer with given capacity (DefaultBufferSize when <= 0)
+func NewBuffer(size int) *Buffer {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	return &Buffer{ch: make(chan []byte, size)}
+}
+
+// Push enqueues message, dropping oldest queued message when buffer is full (single producer)
+func (b *Buffer) Push(msg []byte) {
+	for {
+		select {
+		case b.ch <- msg:
+			return
+		default:
+		}
+
+		// Buffer full, drop oldest and retry
+		select {
+		case <-b.ch:
+			b.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// C returns channel to read queued messages from
+func (b *Buffer) C() <-chan []byte {
+	return b.ch
+}
+
+// Cap returns buffer capacity
+func (b *Buffer) Cap() int {
+	return cap(b.ch)
+}
+
+// Dropped returns number of messages dropped due to backpressure
+func (b *Buffer) Dropped() int64 {
+	return b.dropped.Load()
+}
@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+)
+
+// Channel is Redis pub/sub channel carrying ingested events
+const Channel = "stream:events"
+
+// publishTimeout bounds publish call so slow Redis never stalls job processing
+const publishTimeout = 2 * time.Second
+
+// Event represents ingested event published to live-tail subscribers
+type Event struct {
+	Type      string      `json:"type"`                 // Entity measurement name (e.g. transaction_events)
+	RiskLevel string      `json:"risk_level,omitempty"` // low/medium/high/critical when entity has risk level
+	Time      time.Time   `json:"time"`
+	Data      interface{} `json:"data"`
+}
+
+// Publish publishes ingested event to live-tail channel (best effort, errors are logged only)
+func Publish(eventType, riskLevel string, timestamp time.Time, data interface{}) {
+	payload, err := json.Marshal(Event{
+		Type:      eventType,
+		RiskLevel: strings.ToLower(riskLevel),
+		Time:      timestamp,
+		Data:      data,
+	})
+	if err != nil {
+		logger.WithScope("stream").Warn().Err(err).Str("type", eventType).Msg("Failed to marshal stream event")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := redis.Publish(ctx, Channel, payload); err != nil {
+		logger.WithScope("stream").Warn().Err(err).Str("type", eventType).Msg("Failed to publish stream event")
+	}
+}
+
+// Subscribe subscribes to live-tail channel on main Redis client, caller must Close returned PubSub
+func Subscribe(ctx context.Context) (*goredis.PubSub, error) {
+	client := redis.GetClient()
+	if client == nil {
+		return nil, fmt.Errorf("redis client not initialized")
+	}
+
+	pubsub := client.Subscribe(ctx, Channel)
+	if pubsub == nil {
+		return nil, fmt.Errorf("redis pub/sub not supported")
+	}
+
+	// Wait for subscription confirmation so events are not missed after handshake
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", Channel, err)
+	}
+
+	return pubsub, nil
+}
+
+// Filter holds optional live-tail filters (empty set matches all)
+type Filter struct {
+	Types      map[string]bool
+	RiskLevels map[string]bool
+}
+
+// ParseFilter builds filter from comma separated query values (e.g. "transaction_events,security_events")
+func ParseFilter(types, riskLevels string) Filter {
+	return Filter{
+		Types:      parseSet(types),
+		RiskLevels: parseSet(riskLevels),
+	}
+}
+
+// Matches checks if event passes filter
+func (f Filter) Matches(event Event) bool {
+	if len(f.Types) > 0 && !f.Types[event.Type] {
+		return false
+	}
+	if len(f.RiskLevels) > 0 && !f.RiskLevels[event.RiskLevel] {
+		return false
+	}
+	return true
+}
+
+// parseSet splits comma separated values into lowercase set
+func parseSet(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
+
+// RiskLevelFromScore maps 0.0 - 1.0 risk score to low/medium/high/critical (empty when no score)
+func RiskLevelFromScore(score float64) string {
+	switch {
+	case score <= 0:
+		return ""
+	case score < 0.3:
+		return "low"
+	case score < 0.6:
+		return "medium"
+	case score < 0.8:
+		return "high"
+	default:
+		return "critical"
+	}
+}
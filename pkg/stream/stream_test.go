@@ -0,0 +1,76 @@
+package stream
+
+import "testing"
+
+func TestBufferDropOldest(t *testing.T) {
+	buffer := NewBuffer(3)
+	for _, msg := range []string{"1", "2", "3", "4", "5"} {
+		buffer.Push([]byte(msg))
+	}
+
+	if got := buffer.Dropped(); got != 2 {
+		t.Errorf("Dropped = %d, want 2", got)
+	}
+
+	// Oldest messages dropped, newest kept in order
+	for _, want := range []string{"3", "4", "5"} {
+		select {
+		case got := <-buffer.C():
+			if string(got) != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		default:
+			t.Fatalf("buffer empty, want %q", want)
+		}
+	}
+
+	if NewBuffer(0).Cap() != DefaultBufferSize {
+		t.Errorf("expected default buffer size for non-positive size")
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		types    string
+		risk     string
+		event    Event
+		expected bool
+	}{
+		{"no filter", "", "", Event{Type: "user_activities"}, true},
+		{"type match", "transaction_events, security_events", "", Event{Type: "security_events"}, true},
+		{"type mismatch", "transaction_events", "", Event{Type: "security_events"}, false},
+		{"risk match case insensitive", "", "HIGH,critical", Event{Type: "transaction_events", RiskLevel: "high"}, true},
+		{"risk mismatch", "", "high", Event{Type: "transaction_events", RiskLevel: "low"}, false},
+		{"risk filter excludes events without risk level", "", "high", Event{Type: "security_events"}, false},
+		{"type and risk", "transaction_events", "critical", Event{Type: "transaction_events", RiskLevel: "critical"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := ParseFilter(tt.types, tt.risk).Matches(tt.event); got != tt.expected {
+			t.Errorf("%s: Matches = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestRiskLevelFromScore(t *testing.T) {
+	tests := []struct {
+		score    float64
+		expected string
+	}{
+		{0, ""},
+		{-0.5, ""},
+		{0.1, "low"},
+		{0.3, "medium"},
+		{0.59, "medium"},
+		{0.6, "high"},
+		{0.8, "critical"},
+		{1, "critical"},
+	}
+
+	for _, tt := range tests {
+		if got := RiskLevelFromScore(tt.score); got != tt.expected {
+			t.Errorf("RiskLevelFromScore(%v) = %q, want %q", tt.score, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package useragent
+
+import (
+	"strings"
+)
+
+// User-Agent Client Hints header names
+const (
+	HeaderUserAgent          = "User-Agent"
+	HeaderSecCHUA            = "Sec-CH-UA"
+	HeaderSecCHUAFullVersion = "Sec-CH-UA-Full-Version-List"
+	HeaderSecCHUAPlatform    = "Sec-CH-UA-Platform"
+	HeaderSecCHUAPlatformVer = "Sec-CH-UA-Platform-Version"
+	HeaderSecCHUAMobile      = "Sec-CH-UA-Mobile"
+	HeaderSecCHUAModel       = "Sec-CH-UA-Model"
+)
+
+// clientHintBrand represents single entry of Sec-CH-UA brand list
+type clientHintBrand struct {
+	brand   string
+	version string
+}
+
+// Client hint brand to browser name - Priority order (specific -> general)
+var clientHintBrands = []struct {
+	brand string
+	name  string
+}{
+	{"microsoft edge", "Edge"},
+	{"opera", "Opera"},
+	{"brave", "Brave"},
+	{"vivaldi", "Vivaldi"},
+	{"samsung internet", "Samsung Browser"},
+	{"yandex", "Yandex"},
+	{"duckduckgo", "DuckDuckGo"},
+	{"google chrome", "Chrome"},
+	{"chromium", "Chrome"},
+}
+
+// Client hint platform to OS name
+var clientHintPlatforms = map[string]string{
+	"windows":   "Windows",
+	"macos":     "macOS",
+	"android":   "Android",
+	"ios":       "iOS",
+	"chrome os": "Chrome OS",
+	"chromeos":  "Chrome OS",
+	"linux":     "Linux",
+}
+
+// DetectFromClientHints detects device info from Sec-CH-UA headers merged over User-Agent detection.
+// Hints win for browser, browser version, platform and platform version, falls back to Detect when incomplete.
+func (d *FastDeviceDetector) DetectFromClientHints(hints map[string]string) *FastDeviceInfo {
+	// Normalize header keys (case insensitive)
+	normalized := make(map[string]string, len(hints))
+	for key, value := range hints {
+		normalized[strings.ToLower(key)] = strings.TrimSpace(value)
+	}
+
+	// UA-derived baseline
+	userAgent := normalized[strings.ToLower(HeaderUserAgent)]
+	info := &FastDeviceInfo{Type: Unknown, OS: "Unknown", Browser: "Unknown"}
+	if userAgent != "" {
+		info = d.Detect(userAgent)
+	}
+
+	// Incomplete hints - UA result only
+	brandList := normalized[strings.ToLower(HeaderSecCHUA)]
+	platform := unquoteHint(normalized[strings.ToLower(HeaderSecCHUAPlatform)])
+	if brandList == "" || platform == "" {
+		return info
+	}
+
+	// Browser from brand list, full version list preferred for version
+	if name, version := resolveClientHintBrand(parseClientHintBrands(brandList)); name != "" {
+		info.Browser = name
+		info.BrowserVersion = version
+
+		if fullList := normalized[strings.ToLower(HeaderSecCHUAFullVersion)]; fullList != "" {
+			if fullName, fullVersion := resolveClientHintBrand(parseClientHintBrands(fullList)); fullName == name && fullVersion != "" {
+				info.BrowserVersion = fullVersion
+			}
+		}
+	}
+
+	// Platform
+	if osName, exists := clientHintPlatforms[strings.ToLower(platform)]; exists {
+		info.OS = osName
+	} else {
+		info.OS = platform
+	}
+	if platformVersion := unquoteHint(normalized[strings.ToLower(HeaderSecCHUAPlatformVer)]); platformVersion != "" {
+		info.OSVersion = platformVersion
+	}
+
+	// Mobile flag - Android without mobile flag is tablet
+	switch normalized[strings.ToLower(HeaderSecCHUAMobile)] {
+	case "?1":
+		info.Type = Mobile
+	case "?0":
+		if info.OS == "Android" {
+			info.Type = Tablet
+		} else if info.Type != Tablet {
+			info.Type = Desktop
+		}
+	}
+
+	// Model (only sent when explicitly requested by server)
+	if model := unquoteHint(normalized[strings.ToLower(HeaderSecCHUAModel)]); model != "" {
+		info.Brand, info.Model = lookupDeviceModel(model)
+	}
+
+	// Rescore with hint-provided values
+	info.Confidence = detectionConfidence(info)
+	return info
+}
+
+// parseClientHintBrands parses structured header brand list (e.g. `"Chromium";v="124", "Google Chrome";v="124"`)
+func parseClientHintBrands(header string) []clientHintBrand {
+	var brands []clientHintBrand
+
+	for _, item := range splitOutsideQuotes(header, ',') {
+		params := splitOutsideQuotes(item, ';')
+		if len(params) == 0 {
+			continue
+		}
+
+		entry := clientHintBrand{brand: unquoteHint(params[0])}
+		for _, param := range params[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(key) == "v" {
+				entry.version = unquoteHint(value)
+			}
+		}
+
+		if entry.brand != "" {
+			brands = append(brands, entry)
+		}
+	}
+
+	return brands
+}
+
+// resolveClientHintBrand picks most specific known browser from brand list, GREASE brands are ignored
+func resolveClientHintBrand(brands []clientHintBrand) (name, version string) {
+	for _, known := range clientHintBrands {
+		for _, b := range brands {
+			if strings.Contains(strings.ToLower(b.brand), known.brand) {
+				return known.name, b.version
+			}
+		}
+	}
+	return "", ""
+}
+
+// splitOutsideQuotes splits string by separator ignoring separators inside double quotes
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case sep:
+			if !inQuotes {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, strings.TrimSpace(s[start:]))
+
+	return parts
+}
+
+// unquoteHint trims whitespace and surrounding quotes from structured header value
+func unquoteHint(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"`)
+}
@@ -0,0 +1,74 @@
+package useragent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// Fingerprint returns stable SHA256 (hex) device fingerprint for session binding.
+//
+// Included from info: device type, OS, OS version, browser, browser version, engine,
+// arch, brand, model and webview app. Versions are reduced to major.minor so patch/build
+// updates (Chrome 120.0.6099.129 -> 120.0.6099.130) keep fingerprint unchanged.
+// Bot flags, engine version and confidence are excluded (derived or volatile).
+//
+// extra holds additional signals such as screen resolution, timezone or Accept-Language /
+// Accept-Encoding headers. Keys are case-insensitive, values are trimmed with collapsed
+// whitespace, empty values are ignored and keys are sorted so map order never matters.
+func Fingerprint(info *FastDeviceInfo, extra map[string]string) string {
+	var b strings.Builder
+
+	if info != nil {
+		writeFingerprintPart(&b, "type", info.Type.String())
+		writeFingerprintPart(&b, "os", info.OS)
+		writeFingerprintPart(&b, "os_version", stableVersion(info.OSVersion))
+		writeFingerprintPart(&b, "browser", info.Browser)
+		writeFingerprintPart(&b, "browser_version", stableVersion(info.BrowserVersion))
+		writeFingerprintPart(&b, "engine", info.Engine)
+		writeFingerprintPart(&b, "arch", info.Arch)
+		writeFingerprintPart(&b, "brand", info.Brand)
+		writeFingerprintPart(&b, "model", info.Model)
+		writeFingerprintPart(&b, "webview_app", info.WebViewApp)
+	}
+
+	// Extra signals, namespaced so they never collide with device fields
+	keys := make([]string, 0, len(extra))
+	normalized := make(map[string]string, len(extra))
+	for key, value := range extra {
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Join(strings.Fields(value), " ")
+		if key == "" || value == "" {
+			continue
+		}
+		if _, exists := normalized[key]; !exists {
+			keys = append(keys, key)
+		}
+		normalized[key] = value
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeFingerprintPart(&b, "x."+key, normalized[key])
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeFingerprintPart appends lowercase key=value line (unambiguous canonical form)
+func writeFingerprintPart(b *strings.Builder, key, value string) {
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(strings.ToLower(strings.TrimSpace(value)))
+	b.WriteByte('\n')
+}
+
+// stableVersion keeps major.minor of dotted version ("17.2.1" -> "17.2", "121" -> "121")
+func stableVersion(version string) string {
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,177 @@
+package useragent
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// PatternsFile represents external JSON document with additional detection patterns
+type PatternsFile struct {
+	BotPatterns     []string           `json:"bot_patterns"`
+	BrowserPatterns []PatternFileEntry `json:"browser_patterns"`
+	OSPatterns      []PatternFileEntry `json:"os_patterns"`
+}
+
+// PatternFileEntry represents named browser/OS pattern with optional version regex
+type PatternFileEntry struct {
+	Name         string   `json:"name"`
+	Patterns     []string `json:"patterns"`
+	VersionRegex string   `json:"version_regex,omitempty"`
+}
+
+// compiledPatternEntry holds validated entry ready to be merged
+type compiledPatternEntry struct {
+	name     string
+	patterns []string
+	regex    *regexp.Regexp
+}
+
+// LoadPatternsFromFile reads bot/browser/OS patterns from JSON file and merges them into pattern sets.
+// All entries are validated before anything is applied, existing patterns stay untouched on error.
+func LoadPatternsFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read patterns file: %w", err)
+	}
+
+	var file PatternsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse patterns file %s: %w", path, err)
+	}
+
+	// Validate everything first
+	bots, err := normalizePatterns("bot_patterns", file.BotPatterns)
+	if err != nil {
+		return err
+	}
+
+	browsers, err := compilePatternEntries("browser_patterns", file.BrowserPatterns)
+	if err != nil {
+		return err
+	}
+
+	oses, err := compilePatternEntries("os_patterns", file.OSPatterns)
+	if err != nil {
+		return err
+	}
+
+	// Apply under single write lock
+	patternsMutex.Lock()
+	defer patternsMutex.Unlock()
+
+	if len(bots) > 0 {
+		botPatterns = mergePatternEntry(botPatterns, compiledPatternEntry{name: BotCategoryGeneric, patterns: bots})
+	}
+
+	for _, entry := range browsers {
+		browserPatterns = mergePatternEntry(browserPatterns, entry)
+		if entry.regex != nil {
+			browserVersionRegexes[entry.name] = entry.regex
+		}
+	}
+
+	for _, entry := range oses {
+		osPatterns = mergePatternEntry(osPatterns, entry)
+		if entry.regex != nil {
+			osVersionRegexes[entry.name] = entry.regex
+		}
+	}
+
+	patternsVersion.Add(1)
+
+	log.Printf("✅ PATTERNS_LOADED: %d bot, %d browser, %d OS patterns merged from '%s'", len(bots), len(browsers), len(oses), path)
+	return nil
+}
+
+// compilePatternEntries validates named entries and compiles their version regexes
+func compilePatternEntries(section string, entries []PatternFileEntry) ([]compiledPatternEntry, error) {
+	compiled := make([]compiledPatternEntry, 0, len(entries))
+
+	for i, entry := range entries {
+		name := strings.TrimSpace(entry.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%s[%d]: name is required", section, i)
+		}
+
+		patterns, err := normalizePatterns(fmt.Sprintf("%s[%d] (%s)", section, i, name), entry.Patterns)
+		if err != nil {
+			return nil, err
+		}
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf("%s[%d] (%s): at least one pattern is required", section, i, name)
+		}
+
+		item := compiledPatternEntry{name: name, patterns: patterns}
+		if entry.VersionRegex != "" {
+			regex, err := regexp.Compile(entry.VersionRegex)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d] (%s): invalid version_regex: %w", section, i, name, err)
+			}
+			if regex.NumSubexp() < 1 {
+				return nil, fmt.Errorf("%s[%d] (%s): version_regex must contain a capture group for the version", section, i, name)
+			}
+			item.regex = regex
+		}
+
+		compiled = append(compiled, item)
+	}
+
+	return compiled, nil
+}
+
+// normalizePatterns lowercases patterns (detection runs on lowercase UA) and rejects empty ones
+func normalizePatterns(section string, patterns []string) ([]string, error) {
+	normalized := make([]string, 0, len(patterns))
+	for i, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			return nil, fmt.Errorf("%s: pattern %d is empty", section, i)
+		}
+		normalized = append(normalized, pattern)
+	}
+	return normalized, nil
+}
+
+// patternList aliases the anonymous slice type used by botPatterns, browserPatterns and osPatterns
+type patternList = []struct {
+	name     string
+	patterns []string
+}
+
+// mergePatternEntry appends new patterns to existing named entry or adds entry at the end
+func mergePatternEntry(list patternList, entry compiledPatternEntry) patternList {
+	for i := range list {
+		if list[i].name != entry.name {
+			continue
+		}
+
+		// Copy before append so detector caches holding old slice stay consistent
+		merged := append([]string(nil), list[i].patterns...)
+		for _, pattern := range entry.patterns {
+			if !containsString(merged, pattern) {
+				merged = append(merged, pattern)
+			}
+		}
+		list[i].patterns = merged
+		return list
+	}
+
+	return append(list, struct {
+		name     string
+		patterns []string
+	}{entry.name, entry.patterns})
+}
+
+// containsString checks if slice contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,20 @@
 package useragent
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	lru "github.com/hashicorp/golang-lru/v2"
 )
 
 // DeviceType enum untuk tipe device
@@ -35,6 +41,45 @@ func (d DeviceType) String() string {
 	}
 }
 
+// ParseDeviceType converts string representation back to DeviceType (case insensitive)
+func ParseDeviceType(s string) DeviceType {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "desktop":
+		return Desktop
+	case "mobile":
+		return Mobile
+	case "tablet":
+		return Tablet
+	default:
+		return Unknown
+	}
+}
+
+// MarshalJSON encodes DeviceType as its string representation
+func (d DeviceType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes DeviceType from string, numeric value accepted for backward compatibility
+func (d *DeviceType) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*d = ParseDeviceType(s)
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid device type %s: %w", string(data), err)
+	}
+	if n < int(Desktop) || n > int(Unknown) {
+		*d = Unknown
+		return nil
+	}
+	*d = DeviceType(n)
+	return nil
+}
+
 // FastDeviceInfo hasil deteksi device
 type FastDeviceInfo struct {
 	Type           DeviceType `json:"type"`
@@ -42,35 +87,95 @@ type FastDeviceInfo struct {
 	OSVersion      string     `json:"os_version,omitempty"`
 	Browser        string     `json:"browser"`
 	BrowserVersion string     `json:"browser_version,omitempty"`
+	Engine         string     `json:"engine"`
+	EngineVersion  string     `json:"engine_version,omitempty"`
+	Arch           string     `json:"arch,omitempty"` // x64/arm64/x86/arm, empty when UA has no hint
+	Brand          string     `json:"brand,omitempty"`
+	Model          string     `json:"model,omitempty"`
 	IsBot          bool       `json:"is_bot"`
+	BotCategory    string     `json:"bot_category,omitempty"` // search/ai/social/seo/tool/generic
+	IsWebView      bool       `json:"is_webview"`
+	WebViewApp     string     `json:"webview_app,omitempty"`
+	Confidence     float64    `json:"confidence"` // 0-1, see detectionConfidence
 }
 
 // DetectionLogger untuk logging pattern failures
 type DetectionLogger struct {
-	enabled         bool
+	enabled         atomic.Bool    // Toggled at runtime on shared detectors, read on every detection
 	unknownUAs      map[string]int // Cache untuk avoid spam
 	unknownBrowsers map[string]int
 	unknownOSs      map[string]int
 	mutex           sync.RWMutex
 	lastCleanup     time.Time
 	cleanupInterval time.Duration
+	maxLogs         int // Max logs per pattern within cleanup interval (see logger.SampledBurst)
+}
+
+// Detection logger defaults
+const (
+	defaultCleanupInterval   = 5 * time.Minute
+	defaultMaxLogsPerPattern = 3
+)
+
+// DetectionLoggerOption configures DetectionLogger on creation
+type DetectionLoggerOption func(*DetectionLogger)
+
+// WithCleanupInterval sets how often seen-pattern counters are reset
+func WithCleanupInterval(interval time.Duration) DetectionLoggerOption {
+	return func(dl *DetectionLogger) {
+		dl.SetCleanupInterval(interval)
+	}
+}
+
+// WithMaxLogsPerPattern sets how many times same pattern is logged per cleanup interval
+func WithMaxLogsPerPattern(n int) DetectionLoggerOption {
+	return func(dl *DetectionLogger) {
+		dl.SetMaxLogsPerPattern(n)
+	}
 }
 
 // NewDetectionLogger creates new logger instance for unknown pattern detection
-func NewDetectionLogger(enabled bool) *DetectionLogger {
-	return &DetectionLogger{
-		enabled:         enabled,
+func NewDetectionLogger(enabled bool, opts ...DetectionLoggerOption) *DetectionLogger {
+	dl := &DetectionLogger{
 		unknownUAs:      make(map[string]int),
 		unknownBrowsers: make(map[string]int),
 		unknownOSs:      make(map[string]int),
 		lastCleanup:     time.Now(),
-		cleanupInterval: 5 * time.Minute, // Cleanup every 5 minutes
+		cleanupInterval: defaultCleanupInterval,
+		maxLogs:         defaultMaxLogsPerPattern,
+	}
+	dl.enabled.Store(enabled)
+	for _, opt := range opts {
+		opt(dl)
 	}
+	return dl
+}
+
+// SetCleanupInterval changes counter reset interval, non-positive value is ignored
+func (dl *DetectionLogger) SetCleanupInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	dl.mutex.Lock()
+	dl.cleanupInterval = interval
+	dl.mutex.Unlock()
+}
+
+// SetMaxLogsPerPattern changes per pattern log cap within cleanup interval, non-positive value is ignored
+func (dl *DetectionLogger) SetMaxLogsPerPattern(n int) {
+	if n <= 0 {
+		return
+	}
+
+	dl.mutex.Lock()
+	dl.maxLogs = n
+	dl.mutex.Unlock()
 }
 
 // logUnknownPattern logs unknown patterns with maintenance instructions
 func (dl *DetectionLogger) logUnknownPattern(category, userAgent, recommendation string) {
-	if !dl.enabled {
+	if !dl.enabled.Load() {
 		return
 	}
 
@@ -79,10 +184,7 @@ func (dl *DetectionLogger) logUnknownPattern(category, userAgent, recommendation
 
 	// Cleanup old entries periodically
 	if time.Since(dl.lastCleanup) > dl.cleanupInterval {
-		dl.unknownUAs = make(map[string]int)
-		dl.unknownBrowsers = make(map[string]int)
-		dl.unknownOSs = make(map[string]int)
-		dl.lastCleanup = time.Now()
+		dl.resetLocked()
 	}
 
 	var cache map[string]int
@@ -102,11 +204,12 @@ func (dl *DetectionLogger) logUnknownPattern(category, userAgent, recommendation
 		shortUA = string(runes[:100]) + "..."
 	}
 
-	// Only log if we haven't seen this pattern recently
-	if cache[shortUA] < 3 { // Max 3 times per cleanup interval
+	// Max N times per pattern within cleanup interval, rate-limited by shared log sampler
+	sampled := logger.SampledBurst("useragent:"+category+":"+shortUA, dl.maxLogs, dl.cleanupInterval)
+	if event := sampled.Warn(); event != nil {
 		cache[shortUA]++
 
-		logger.Warn().
+		event.
 			Str(fmt.Sprintf("UNKNOWN_%s_DETECTED", strings.ToUpper(category)), shortUA).
 			Str("USERAGENT", userAgent).
 			Str("INSTRUCTION", recommendation).
@@ -122,6 +225,13 @@ var detectionLogger = NewDetectionLogger(true) // Enable by default
 // PATTERNS CONFIGURATION - For easy maintain
 // =============================================================================
 
+// patternsMutex guards runtime mutation of botPatterns, browserPatterns, osPatterns and version regexes.
+// patternsVersion is bumped on every mutation so detectors can refresh their caches lazily.
+var (
+	patternsMutex   sync.RWMutex
+	patternsVersion atomic.Uint64
+)
+
 // Device Type Patterns - Priority order (specifiv -> general)
 var (
 	tabletPatterns = []string{
@@ -130,18 +240,35 @@ var (
 	}
 
 	mobilePatterns = []string{
-		"iphone", "ipod", "android.*mobile", "blackberry", "windows phone",
+		"iphone", "ipod", "blackberry", "windows phone",
 		"palm", "symbian", "opera mini", "opera mobi", "fennec", "minimo",
 	}
+)
 
-	// Bot patterns - case insensitive - UPDATED 2025
-	botPatterns = []string{
-		// Traditional crawlers
-		"bot", "crawler", "spider", "scraper", "curl", "wget",
+// Bot categories reported in FastDeviceInfo.BotCategory
+const (
+	BotCategorySearch  = "search"  // Search engine crawlers
+	BotCategoryAI      = "ai"      // AI/LLM crawlers and fetchers
+	BotCategorySocial  = "social"  // Social/messaging link preview bots
+	BotCategorySEO     = "seo"     // SEO tools
+	BotCategoryTool    = "tool"    // HTTP clients and scrapers
+	BotCategoryGeneric = "generic" // Generic crawler keywords (runtime/file patterns land here)
+)
+
+// Bot patterns grouped by category - case insensitive - UPDATED 2025.
+// Priority order (specific -> generic): first matching category is reported, IsBot matches any pattern.
+var botPatterns = []struct {
+	name     string
+	patterns []string
+}{
+	{BotCategorySearch, []string{
 		"googlebot", "bingbot", "msnbot", "yahoo", "duckduckbot",
-		"yandexbot", "baiduspider", "ia_archiver", "archive.org",
+		"yandexbot", "baiduspider",
+		"applebot", "amazonbot", // Apple, Amazon
+	}},
 
-		// AI Crawlers (2024-2025) - CRITICAL ADDITIONS
+	// AI Crawlers (2024-2025) - CRITICAL ADDITIONS
+	{BotCategoryAI, []string{
 		"gptbot", "chatgpt-user", // OpenAI
 		"claudebot", "anthropic-ai", // Anthropic
 		"meta-externalagent", "meta-externalfetcher", // Meta
@@ -149,21 +276,34 @@ var (
 		"bytespider",      // TikTok/ByteDance
 		"google-extended", // Google AI training
 		"mistralai-user",  // Mistral AI
+	}},
 
-		// Social Media Bots
+	// Social Media Bots
+	{BotCategorySocial, []string{
 		"facebookexternalhit", "facebot", // Facebook
 		"twitterbot",                // X/Twitter
 		"linkedinbot",               // LinkedIn
 		"pinterestbot", "pinterest", // Pinterest
 		"whatsapp", "telegram", // Messaging
 		"slackbot", "discordbot", // Communication
-
-		// Other Major Crawlers
-		"applebot", "amazonbot", // Apple, Amazon
-		"semrushbot", "ahrefsbot", // SEO tools
-		"screaming frog", "sitebulb", // SEO crawlers
-	}
-)
+	}},
+
+	// SEO Crawlers
+	{BotCategorySEO, []string{
+		"semrushbot", "ahrefsbot",
+		"screaming frog", "sitebulb",
+	}},
+
+	// HTTP clients & scrapers
+	{BotCategoryTool, []string{
+		"curl", "wget", "scraper",
+	}},
+
+	// Traditional crawlers
+	{BotCategoryGeneric, []string{
+		"bot", "crawler", "spider", "ia_archiver", "archive.org",
+	}},
+}
 
 // OS Detection Patterns - Priority order (specific -> general) - UPDATED 2025
 var osPatterns = []struct {
@@ -202,6 +342,85 @@ var browserPatterns = []struct {
 	{"Yandex", []string{"yabrowser/", "yandex"}},    // Popular in Russia
 }
 
+// In-app browser / WebView Patterns - Priority order (specific app -> generic WebView)
+var webViewPatterns = []struct {
+	name     string
+	patterns []string
+}{
+	{"Facebook", []string{"fban/", "fbav/", "fb_iab"}},
+	{"Instagram", []string{"instagram"}},
+	{"LINE", []string{" line/"}},
+	{"Google App", []string{"gsa/"}},
+	{"Twitter", []string{"twitter"}},
+	{"Android WebView", []string{"; wv)"}}, // Generic Android System WebView
+}
+
+// =============================================================================
+// DEVICE MODEL PATTERNS - Hardware model code to human readable name
+// =============================================================================
+
+// deviceModel holds brand and marketing name for a hardware model code
+type deviceModel struct {
+	brand string
+	name  string
+}
+
+// Device model map - keys are lowercase model codes, extend via AddDeviceModel
+var (
+	deviceModels = map[string]deviceModel{
+		// Samsung
+		"sm-g973f": {"Samsung", "Galaxy S10"},
+		"sm-g991b": {"Samsung", "Galaxy S21"},
+		"sm-g996b": {"Samsung", "Galaxy S21+"},
+		"sm-g998b": {"Samsung", "Galaxy S21 Ultra"},
+		"sm-s901b": {"Samsung", "Galaxy S22"},
+		"sm-s908b": {"Samsung", "Galaxy S22 Ultra"},
+		"sm-s911b": {"Samsung", "Galaxy S23"},
+		"sm-s918b": {"Samsung", "Galaxy S23 Ultra"},
+		"sm-s921b": {"Samsung", "Galaxy S24"},
+		"sm-a525f": {"Samsung", "Galaxy A52"},
+		"sm-a536b": {"Samsung", "Galaxy A53 5G"},
+		"sm-t870":  {"Samsung", "Galaxy Tab S7"},
+
+		// Xiaomi
+		"m2101k6g":   {"Xiaomi", "Redmi Note 10 Pro"},
+		"2201117tg":  {"Xiaomi", "Redmi Note 11"},
+		"23021raa2y": {"Xiaomi", "Redmi Note 12"},
+
+		// Apple (iOS hardware identifiers)
+		"iphone14,2": {"Apple", "iPhone 13 Pro"},
+		"iphone14,3": {"Apple", "iPhone 13 Pro Max"},
+		"iphone14,5": {"Apple", "iPhone 13"},
+		"iphone14,7": {"Apple", "iPhone 14"},
+		"iphone15,2": {"Apple", "iPhone 14 Pro"},
+		"iphone15,3": {"Apple", "iPhone 14 Pro Max"},
+		"iphone15,4": {"Apple", "iPhone 15"},
+		"iphone16,1": {"Apple", "iPhone 15 Pro"},
+		"iphone16,2": {"Apple", "iPhone 15 Pro Max"},
+		"iphone17,1": {"Apple", "iPhone 16 Pro"},
+		"iphone17,3": {"Apple", "iPhone 16"},
+		"ipad13,18":  {"Apple", "iPad (10th generation)"},
+	}
+	deviceModelsMutex sync.RWMutex
+
+	// Brand prefixes for model codes not present in deviceModels - Priority order (specific -> general)
+	deviceBrandPrefixes = []struct {
+		prefix string
+		brand  string
+	}{
+		{"sm-", "Samsung"}, {"gt-", "Samsung"}, {"samsung", "Samsung"},
+		{"pixel", "Google"}, {"nexus", "Google"},
+		{"redmi", "Xiaomi"}, {"poco", "Xiaomi"}, {"mi ", "Xiaomi"},
+		{"cph", "OPPO"}, {"rmx", "realme"}, {"vivo", "vivo"},
+		{"oneplus", "OnePlus"}, {"huawei", "Huawei"}, {"honor", "Honor"},
+		{"moto", "Motorola"}, {"nokia", "Nokia"}, {"lm-", "LG"},
+		{"infinix", "Infinix"}, {"tecno", "Tecno"},
+	}
+
+	// Android tokens that are not device models (reduced UA, WebView, security flag)
+	androidNonModelTokens = map[string]bool{"k": true, "u": true, "wv": true, "mobile": true}
+)
+
 // =============================================================================
 // VERSION DETECTION PATTERNS - Pre-compiled regex for performance
 // =============================================================================
@@ -237,6 +456,19 @@ var (
 		// BlackBerry no reliable pattern
 	}
 
+	// Rendering engine version regexes
+	engineVersionRegexes = map[string]*regexp.Regexp{
+		"EdgeHTML": regexp.MustCompile(`(?i)edge[\/\s]+([0-9]+(?:\.[0-9]+)*)`),
+		"Trident":  regexp.MustCompile(`(?i)trident[\/\s]+([0-9]+(?:\.[0-9]+)*)`),
+		"Presto":   regexp.MustCompile(`(?i)presto[\/\s]+([0-9]+(?:\.[0-9]+)*)`),
+		"Blink":    regexp.MustCompile(`(?i)(?:chrome|chromium)[\/\s]+([0-9]+(?:\.[0-9]+)*)`), // Blink version follows Chromium
+		"WebKit":   regexp.MustCompile(`(?i)applewebkit[\/\s]+([0-9]+(?:\.[0-9]+)*)`),
+		"Gecko":    regexp.MustCompile(`(?i)rv:([0-9]+(?:\.[0-9]+)*)`),
+	}
+
+	// iOS hardware identifier regex (e.g. iPhone15,2 in app UAs)
+	iosModelRegex = regexp.MustCompile(`(?i)((?:iphone|ipad|ipod)[0-9]+,[0-9]+)`)
+
 	// Compile once for thread safety
 	compileOnce sync.Once
 )
@@ -245,14 +477,21 @@ var (
 // FAST DEVICE DETECTOR - Optimized for high traffic
 // =============================================================================
 
+// batchParallelThreshold is the batch size above which DetectBatch uses worker pool
+const batchParallelThreshold = 1000
+
 type FastDeviceDetector struct {
 	logger *DetectionLogger
 
 	// Pre-computed caches for performance optimization
-	mobileOS   []osPattern
-	desktopOS  []osPattern
-	cacheBuilt bool
-	cacheMutex sync.RWMutex
+	mobileOS     []osPattern
+	desktopOS    []osPattern
+	cacheBuilt   bool
+	cacheMutex   sync.RWMutex
+	builtVersion atomic.Uint64 // patternsVersion used to build caches
+
+	// Optional LRU result cache keyed on raw user agent (nil = disabled)
+	resultCache *lru.Cache[string, *FastDeviceInfo]
 }
 
 // osPattern represents a pre-computed OS pattern for optimization
@@ -261,8 +500,9 @@ type osPattern struct {
 	patterns []string
 }
 
-// NewFastDetector creates optimized device detector with pre-compiled patterns
-func NewFastDetector() *FastDeviceDetector {
+// NewFastDetector creates optimized device detector with pre-compiled patterns.
+// Logger options give detector its own detection logger instead of shared global one.
+func NewFastDetector(opts ...DetectionLoggerOption) *FastDeviceDetector {
 	// Ensure regexes are compiled
 	compileOnce.Do(func() {
 		// Regexes already pre-compiled on global variabel
@@ -271,6 +511,9 @@ func NewFastDetector() *FastDeviceDetector {
 	detector := &FastDeviceDetector{
 		logger: detectionLogger,
 	}
+	if len(opts) > 0 {
+		detector.logger = NewDetectionLogger(true, opts...)
+	}
 
 	// Build optimization caches
 	detector.buildOptimizationCaches()
@@ -278,16 +521,44 @@ func NewFastDetector() *FastDeviceDetector {
 	return detector
 }
 
-// buildOptimizationCaches pre-computes OS categorizations for performance
-func (d *FastDeviceDetector) buildOptimizationCaches() {
-	d.cacheMutex.Lock()
-	defer d.cacheMutex.Unlock()
+// Shared detector returned by Default
+var (
+	defaultDetector     *FastDeviceDetector
+	defaultDetectorOnce sync.Once
+)
 
-	if d.cacheBuilt {
-		return
+// Default returns process-wide detector shared by handlers and job workers, caches are built on first call only.
+// Safe for concurrent use; runtime pattern changes (Add*/Remove* functions, patterns file) rebuild its caches lazily.
+// Use NewFastDetector for isolated detectors (tests, custom logger options).
+func Default() *FastDeviceDetector {
+	defaultDetectorOnce.Do(func() {
+		defaultDetector = NewFastDetector()
+	})
+	return defaultDetector
+}
+
+// NewFastDetectorWithCache creates device detector with LRU result cache for repeated user agents
+func NewFastDetectorWithCache(maxEntries int, opts ...DetectionLoggerOption) *FastDeviceDetector {
+	detector := NewFastDetector(opts...)
+
+	cache, err := lru.New[string, *FastDeviceInfo](maxEntries)
+	if err != nil {
+		logger.Warn().Err(err).Int("max_entries", maxEntries).Msg("Failed to create user agent result cache, continuing without cache")
+		return detector
 	}
+	detector.resultCache = cache
 
-	// Pre-categorize OS patterns by type for faster detection
+	return detector
+}
+
+// buildOptimizationCaches pre-computes OS categorizations for performance
+func (d *FastDeviceDetector) buildOptimizationCaches() {
+	var mobileOS, desktopOS []osPattern
+
+	// Pre-categorize OS patterns by type for faster detection.
+	// Snapshot taken before cacheMutex so patternsMutex is never acquired while holding cacheMutex.
+	patternsMutex.RLock()
+	version := patternsVersion.Load()
 	for _, os := range osPatterns {
 		osEntry := osPattern{
 			name:     os.name,
@@ -297,58 +568,212 @@ func (d *FastDeviceDetector) buildOptimizationCaches() {
 		// Categorize based on OS nature
 		switch os.name {
 		case "iOS", "Android", "Windows Phone", "KaiOS":
-			d.mobileOS = append(d.mobileOS, osEntry)
+			mobileOS = append(mobileOS, osEntry)
 		default:
-			d.desktopOS = append(d.desktopOS, osEntry)
+			desktopOS = append(desktopOS, osEntry)
 		}
 	}
+	patternsMutex.RUnlock()
 
+	// Swap in one step so readers never see partially built caches
+	d.cacheMutex.Lock()
+	d.mobileOS = mobileOS
+	d.desktopOS = desktopOS
 	d.cacheBuilt = true
+	d.builtVersion.Store(version)
+	d.cacheMutex.Unlock()
+}
+
+// refreshIfStale rebuilds caches when global patterns changed since last build
+func (d *FastDeviceDetector) refreshIfStale() {
+	if d.builtVersion.Load() != patternsVersion.Load() {
+		d.RebuildCaches()
+	}
 }
 
 // EnableLogging toggles detection logging for unknown patterns
 func (d *FastDeviceDetector) EnableLogging(enabled bool) {
-	d.logger.enabled = enabled
+	d.logger.enabled.Store(enabled)
 }
 
 // RebuildCaches rebuilds optimization caches when patterns are updated
 func (d *FastDeviceDetector) RebuildCaches() {
-	d.cacheMutex.Lock()
-	d.cacheBuilt = false
-	d.mobileOS = nil
-	d.desktopOS = nil
-	d.cacheMutex.Unlock()
+	// Cached results may be stale after pattern changes
+	if d.resultCache != nil {
+		d.resultCache.Purge()
+	}
 
 	d.buildOptimizationCaches()
 }
 
 // Detect performs fast user agent analysis and returns device information
 func (d *FastDeviceDetector) Detect(userAgent string) *FastDeviceInfo {
+	info := &FastDeviceInfo{}
+	d.detectInto(userAgent, info)
+	return info
+}
+
+// DetectBatch performs detection for multiple user agents, result order matches input order.
+// Large batches are split across a worker pool, every entry (empty included) matches Detect result.
+func (d *FastDeviceDetector) DetectBatch(userAgents []string) []*FastDeviceInfo {
+	results := make([]*FastDeviceInfo, len(userAgents))
+	if len(userAgents) == 0 {
+		return results
+	}
+
+	// Single backing array to avoid per-item allocation
+	infos := make([]FastDeviceInfo, len(userAgents))
+
+	detectRange := func(start, end int) {
+		for i := start; i < end; i++ {
+			d.detectInto(userAgents[i], &infos[i]) // Empty UA included, results match Detect
+			results[i] = &infos[i]
+		}
+	}
+
+	// Small batch - sequential is cheaper than goroutine overhead
+	workers := runtime.NumCPU()
+	if len(userAgents) <= batchParallelThreshold || workers < 2 {
+		detectRange(0, len(userAgents))
+		return results
+	}
+
+	// Large batch - split into contiguous chunks per worker
+	chunkSize := (len(userAgents) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(userAgents); start += chunkSize {
+		end := start + chunkSize
+		if end > len(userAgents) {
+			end = len(userAgents)
+		}
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			detectRange(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// detectInto fills info using result cache when enabled
+func (d *FastDeviceDetector) detectInto(userAgent string, info *FastDeviceInfo) {
+	d.refreshIfStale()
+
+	if d.resultCache == nil {
+		d.detect(userAgent, info)
+		return
+	}
+
+	// Cache hit bypasses detection and unknown pattern logging
+	if cached, ok := d.resultCache.Get(userAgent); ok {
+		*info = *cached // Copy so callers can't mutate the cached entry
+		return
+	}
+
+	d.detect(userAgent, info)
+	cached := *info
+	d.resultCache.Add(userAgent, &cached)
+}
+
+// detect runs full pattern matching on user agent without result cache
+func (d *FastDeviceDetector) detect(userAgent string, info *FastDeviceInfo) {
 	ua := strings.ToLower(userAgent)
 
 	deviceType := d.detectDeviceType(ua)
+	brand, model := d.detectDeviceModel(userAgent)
+	engine, engineVersion := d.detectEngine(userAgent)
+	arch := d.detectArch(ua)
+
+	// Single read lock for all global pattern slices and version regexes
+	patternsMutex.RLock()
 	osName := d.detectOS(ua)
 	osVersion := d.detectOSVersion(userAgent)
 	browserName := d.detectBrowser(ua)
 	browserVersion := d.detectBrowserVersion(userAgent)
-	isBot := d.isBot(ua)
+	isBot, botCategory := d.detectBot(ua)
+	patternsMutex.RUnlock()
+
+	// WebView detection is independent from bot detection (link preview fetchers stay bots)
+	webViewApp := ""
+	if !isBot {
+		webViewApp = d.detectWebView(ua)
+	}
 
 	// Log if unknown
 	d.logUnknownDetections(userAgent, deviceType, osName, browserName, isBot)
+	if brand == "Unknown" && !isBot {
+		recommendation := "Append deviceModels map (AddDeviceModel) or deviceBrandPrefixes slice"
+		d.logger.logUnknownPattern("model", userAgent, recommendation)
+	}
 
-	return &FastDeviceInfo{
+	*info = FastDeviceInfo{
 		Type:           deviceType,
 		OS:             osName,
 		OSVersion:      osVersion,
 		Browser:        browserName,
 		BrowserVersion: browserVersion,
+		Engine:         engine,
+		EngineVersion:  engineVersion,
+		Arch:           arch,
+		Brand:          brand,
+		Model:          model,
 		IsBot:          isBot,
+		BotCategory:    botCategory,
+		IsWebView:      webViewApp != "",
+		WebViewApp:     webViewApp,
+	}
+	info.Confidence = detectionConfidence(info)
+}
+
+// detectionConfidence scores how specific detection was, from fields already detected (no extra matching).
+//
+//	browser name        0.30   browser version  0.10
+//	OS name             0.25   OS version       0.05
+//	device type         0.15   rendering engine 0.10
+//	brand/model         0.05
+//
+// Full browser+version+OS+device desktop UA scores ~0.95, OS-only match 0.25-0.45, fallback-only 0.
+// Bots are scored by matched pattern instead: named bot 0.9, generic "bot"/"crawler" token 0.6.
+func detectionConfidence(info *FastDeviceInfo) float64 {
+	if info.IsBot {
+		if info.BotCategory == BotCategoryGeneric {
+			return 0.6
+		}
+		return 0.9
+	}
+
+	score := 0.0
+	if info.Browser != "" && info.Browser != "Unknown" {
+		score += 0.30
+		if info.BrowserVersion != "" {
+			score += 0.10
+		}
 	}
+	if info.OS != "" && info.OS != "Unknown" {
+		score += 0.25
+		if info.OSVersion != "" {
+			score += 0.05
+		}
+	}
+	if info.Type != Unknown {
+		score += 0.15
+	}
+	if info.Engine != "" && info.Engine != "Unknown" {
+		score += 0.10
+	}
+	if info.Brand != "" && info.Brand != "Unknown" {
+		score += 0.05
+	}
+
+	return math.Round(score*100) / 100
 }
 
 // logUnknownDetections logs patterns that might need to be added to detection rules
 func (d *FastDeviceDetector) logUnknownDetections(userAgent string, deviceType DeviceType, os, browser string, isBot bool) {
-	if !d.logger.enabled {
+	if !d.logger.enabled.Load() {
 		return
 	}
 
@@ -403,6 +828,11 @@ func (d *FastDeviceDetector) detectDeviceType(ua string) DeviceType {
 		}
 	}
 
+	// Android phones carry "mobile" keyword
+	if strings.Contains(ua, "android") && strings.Contains(ua, "mobile") {
+		return Mobile
+	}
+
 	// Special handling for Android without "mobile" keyword = tablet
 	if strings.Contains(ua, "android") && !strings.Contains(ua, "mobile") {
 		return Tablet
@@ -424,7 +854,7 @@ func (d *FastDeviceDetector) detectDeviceType(ua string) DeviceType {
 	return Unknown
 }
 
-// detectOS identifies operating system from user agent string
+// detectOS identifies operating system from user agent string (caller must hold patternsMutex)
 func (d *FastDeviceDetector) detectOS(ua string) string {
 	for _, os := range osPatterns {
 		for _, pattern := range os.patterns {
@@ -436,7 +866,7 @@ func (d *FastDeviceDetector) detectOS(ua string) string {
 	return "Unknown"
 }
 
-// detectBrowser identifies browser from user agent string
+// detectBrowser identifies browser from user agent string (caller must hold patternsMutex)
 func (d *FastDeviceDetector) detectBrowser(ua string) string {
 	for _, browser := range browserPatterns {
 		for _, pattern := range browser.patterns {
@@ -448,17 +878,31 @@ func (d *FastDeviceDetector) detectBrowser(ua string) string {
 	return "Unknown"
 }
 
-// isBot checks if user agent indicates automated bot or crawler
-func (d *FastDeviceDetector) isBot(ua string) bool {
-	for _, pattern := range botPatterns {
-		if strings.Contains(ua, pattern) {
-			return true
+// detectWebView identifies in-app browser or WebView host app, empty when regular browser
+func (d *FastDeviceDetector) detectWebView(ua string) string {
+	for _, webView := range webViewPatterns {
+		for _, pattern := range webView.patterns {
+			if strings.Contains(ua, pattern) {
+				return webView.name
+			}
+		}
+	}
+	return ""
+}
+
+// detectBot checks if user agent indicates automated bot or crawler and returns matched category (caller must hold patternsMutex)
+func (d *FastDeviceDetector) detectBot(ua string) (bool, string) {
+	for _, category := range botPatterns {
+		for _, pattern := range category.patterns {
+			if strings.Contains(ua, pattern) {
+				return true, category.name
+			}
 		}
 	}
-	return false
+	return false, ""
 }
 
-// detectOSVersion extracts operating system version using regex patterns
+// detectOSVersion extracts operating system version using regex patterns (caller must hold patternsMutex)
 func (d *FastDeviceDetector) detectOSVersion(ua string) string {
 	// iOS fast path
 	if strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod") {
@@ -510,7 +954,7 @@ func (d *FastDeviceDetector) detectOSVersion(ua string) string {
 	return ""
 }
 
-// detectBrowserVersion extracts browser version using pre-compiled regex
+// detectBrowserVersion extracts browser version using pre-compiled regex (caller must hold patternsMutex)
 func (d *FastDeviceDetector) detectBrowserVersion(ua string) string {
 	// Dynamic order from browserPatterns - ALWAYS IN SYNC ✅
 	for _, browser := range browserPatterns {
@@ -531,12 +975,148 @@ func (d *FastDeviceDetector) detectBrowserVersion(ua string) string {
 	return ""
 }
 
+// detectEngine identifies rendering engine and its version.
+// Blink is AppleWebKit with Chrome/Chromium token, pure WebKit (Safari, iOS browsers) has no such token.
+func (d *FastDeviceDetector) detectEngine(ua string) (engine, version string) {
+	uaLower := strings.ToLower(ua)
+
+	switch {
+	case strings.Contains(uaLower, "edge/"): // Legacy Edge, Chromium Edge uses "edg/"
+		engine = "EdgeHTML"
+	case strings.Contains(uaLower, "trident/"):
+		engine = "Trident"
+	case strings.Contains(uaLower, "presto/"):
+		engine = "Presto"
+	case strings.Contains(uaLower, "applewebkit/"):
+		if strings.Contains(uaLower, "chrome/") || strings.Contains(uaLower, "chromium/") {
+			engine = "Blink"
+		} else {
+			engine = "WebKit"
+		}
+	case strings.Contains(uaLower, "gecko/"):
+		engine = "Gecko"
+	default:
+		return "Unknown", ""
+	}
+
+	if matches := engineVersionRegexes[engine].FindStringSubmatch(ua); len(matches) > 1 {
+		version = matches[1]
+	}
+
+	return engine, version
+}
+
+// detectArch identifies CPU architecture from platform tokens (ua must be lowercase).
+// 64-bit tokens are checked first since "x86_64" and "armv8" also contain 32-bit prefixes.
+// WOW64 is 32-bit browser on 64-bit Windows, reported as x64.
+func (d *FastDeviceDetector) detectArch(ua string) string {
+	switch {
+	case strings.Contains(ua, "aarch64"), strings.Contains(ua, "arm64"):
+		return "arm64"
+	case strings.Contains(ua, "x86_64"), strings.Contains(ua, "x64"), strings.Contains(ua, "win64"),
+		strings.Contains(ua, "wow64"), strings.Contains(ua, "amd64"):
+		return "x64"
+	case strings.Contains(ua, "armv"), strings.Contains(ua, "arm;"), strings.Contains(ua, "arm)"):
+		return "arm"
+	case strings.Contains(ua, "i686"), strings.Contains(ua, "i586"), strings.Contains(ua, "i386"), strings.Contains(ua, "x86"):
+		return "x86"
+	}
+	return ""
+}
+
+// detectDeviceModel extracts hardware brand and model from Android and iOS user agents.
+// Unknown model codes are returned as raw token, empty result means no model token present.
+func (d *FastDeviceDetector) detectDeviceModel(ua string) (brand, model string) {
+	uaLower := strings.ToLower(ua)
+
+	// iOS fast path - hardware identifier first, then generic device family
+	if strings.Contains(uaLower, "iphone") || strings.Contains(uaLower, "ipad") || strings.Contains(uaLower, "ipod") {
+		if matches := iosModelRegex.FindStringSubmatch(ua); len(matches) > 1 {
+			return lookupDeviceModel(matches[1])
+		}
+
+		switch {
+		case strings.Contains(uaLower, "ipad"):
+			return "Apple", "iPad"
+		case strings.Contains(uaLower, "ipod"):
+			return "Apple", "iPod"
+		default:
+			return "Apple", "iPhone"
+		}
+	}
+
+	// Android - model token follows version inside "(Linux; Android X; MODEL)"
+	idx := strings.Index(uaLower, "android")
+	if idx == -1 {
+		return "", ""
+	}
+
+	segment := ua[idx:]
+	if end := strings.IndexByte(segment, ')'); end != -1 {
+		segment = segment[:end]
+	}
+
+	parts := strings.Split(segment, ";")
+	for _, part := range parts[1:] {
+		token := strings.TrimSpace(part)
+
+		// Strip build identifier (e.g. "GT-I9100 Build/IML74K")
+		if buildIdx := strings.Index(strings.ToLower(token), "build/"); buildIdx != -1 {
+			token = strings.TrimSpace(token[:buildIdx])
+		}
+
+		if token == "" || androidNonModelTokens[strings.ToLower(token)] || isLocaleToken(token) {
+			continue
+		}
+
+		return lookupDeviceModel(token)
+	}
+
+	return "", ""
+}
+
+// lookupDeviceModel maps model code to brand and human name, falls back to brand prefix and raw token
+func lookupDeviceModel(code string) (brand, model string) {
+	key := strings.ToLower(code)
+
+	deviceModelsMutex.RLock()
+	entry, exists := deviceModels[key]
+	deviceModelsMutex.RUnlock()
+
+	if exists {
+		return entry.brand, entry.name
+	}
+
+	for _, bp := range deviceBrandPrefixes {
+		if strings.HasPrefix(key, bp.prefix) {
+			return bp.brand, code
+		}
+	}
+
+	if strings.HasPrefix(key, "iphone") || strings.HasPrefix(key, "ipad") || strings.HasPrefix(key, "ipod") {
+		return "Apple", code
+	}
+
+	return "Unknown", code
+}
+
+// isLocaleToken checks if token looks like a locale (e.g. "en-us", "id_ID")
+func isLocaleToken(token string) bool {
+	if len(token) != 5 || (token[2] != '-' && token[2] != '_') {
+		return false
+	}
+	return strings.IndexFunc(token[:2], func(r rune) bool { return r < 'a' || r > 'z' }) == -1
+}
+
 // =============================================================================
 // UTILITY FUNCTIONS - For maintenance and testing
 // =============================================================================
 
 // GetSupportedBrowsers returns list of browsers supported by detector
 func (d *FastDeviceDetector) GetSupportedBrowsers() []string {
+	patternsMutex.RLock()
+	defer patternsMutex.RUnlock()
+
 	browsers := make([]string, len(browserPatterns))
 	for i, browser := range browserPatterns {
 		browsers[i] = browser.name
@@ -546,6 +1126,9 @@ func (d *FastDeviceDetector) GetSupportedBrowsers() []string {
 
 // GetSupportedOS returns list of operating systems supported by detector
 func (d *FastDeviceDetector) GetSupportedOS() []string {
+	patternsMutex.RLock()
+	defer patternsMutex.RUnlock()
+
 	oses := make([]string, len(osPatterns))
 	for i, os := range osPatterns {
 		oses[i] = os.name
@@ -553,27 +1136,92 @@ func (d *FastDeviceDetector) GetSupportedOS() []string {
 	return oses
 }
 
-// AddBotPattern adds new bot detection pattern at runtime
+// AddBotPattern adds new bot detection pattern at runtime (generic category)
 func AddBotPattern(pattern string) {
-	botPatterns = append(botPatterns, pattern)
-	log.Printf("✅ BOT_PATTERN_ADDED: '%s' has been added to botPatterns", pattern)
+	AddCategorizedBotPattern(BotCategoryGeneric, pattern)
+}
+
+// AddCategorizedBotPattern adds new bot detection pattern to category at runtime, unknown category is created
+func AddCategorizedBotPattern(category, pattern string) {
+	patternsMutex.Lock()
+	botPatterns = mergePatternEntry(botPatterns, compiledPatternEntry{name: category, patterns: []string{pattern}})
+	patternsVersion.Add(1)
+	patternsMutex.Unlock()
+	log.Printf("✅ BOT_PATTERN_ADDED: '%s' has been added to botPatterns (%s)", pattern, category)
 }
 
 // AddBrowserPattern adds new browser detection pattern at runtime
 func AddBrowserPattern(name string, patterns []string) {
+	patternsMutex.Lock()
 	browserPatterns = append(browserPatterns, struct {
 		name     string
 		patterns []string
 	}{name, patterns})
+	patternsVersion.Add(1)
+	patternsMutex.Unlock()
 	log.Printf("✅ BROWSER_PATTERN_ADDED: '%s' has been added to browserPatterns", name)
 }
 
+// RemoveBotPattern removes bot pattern (case insensitive) from all categories at runtime, returns whether anything was removed
+func RemoveBotPattern(pattern string) bool {
+	patternsMutex.Lock()
+	removed := false
+	for i := range botPatterns {
+		// New slice instead of in-place filtering so shared backing arrays stay intact
+		kept := make([]string, 0, len(botPatterns[i].patterns))
+		for _, existing := range botPatterns[i].patterns {
+			if !strings.EqualFold(existing, pattern) {
+				kept = append(kept, existing)
+			}
+		}
+		if len(kept) != len(botPatterns[i].patterns) {
+			botPatterns[i].patterns = kept
+			removed = true
+		}
+	}
+	if removed {
+		patternsVersion.Add(1) // Detectors purge cached results lazily
+	}
+	patternsMutex.Unlock()
+
+	if removed {
+		log.Printf("🗑️ BOT_PATTERN_REMOVED: '%s' has been removed from botPatterns", pattern)
+	}
+	return removed
+}
+
+// RemoveBrowserPattern removes browser entry by name (case insensitive) at runtime, returns whether anything was removed.
+// Version regex is kept so pattern can be re-added with AddBrowserPattern.
+func RemoveBrowserPattern(name string) bool {
+	patternsMutex.Lock()
+	kept := browserPatterns[:0:0]
+	for _, browser := range browserPatterns {
+		if !strings.EqualFold(browser.name, name) {
+			kept = append(kept, browser)
+		}
+	}
+	removed := len(kept) != len(browserPatterns)
+	if removed {
+		browserPatterns = kept
+		patternsVersion.Add(1)
+	}
+	patternsMutex.Unlock()
+
+	if removed {
+		log.Printf("🗑️ BROWSER_PATTERN_REMOVED: '%s' has been removed from browserPatterns", name)
+	}
+	return removed
+}
+
 // AddOSPattern adds new OS detection pattern and rebuilds caches
 func AddOSPattern(name string, patterns []string, detector *FastDeviceDetector) {
+	patternsMutex.Lock()
 	osPatterns = append(osPatterns, struct {
 		name     string
 		patterns []string
 	}{name, patterns})
+	patternsVersion.Add(1)
+	patternsMutex.Unlock()
 
 	// Rebuild caches since OS patterns changed
 	if detector != nil {
@@ -583,38 +1231,128 @@ func AddOSPattern(name string, patterns []string, detector *FastDeviceDetector)
 	log.Printf("✅ OS_PATTERN_ADDED: '%s' has been added to osPatterns and caches rebuilt", name)
 }
 
+// AddDeviceModel adds or overrides device model mapping at runtime
+func AddDeviceModel(code, brand, name string) {
+	deviceModelsMutex.Lock()
+	deviceModels[strings.ToLower(code)] = deviceModel{brand: brand, name: name}
+	patternsVersion.Add(1) // Cached results may hold previous mapping
+	deviceModelsMutex.Unlock()
+	log.Printf("✅ DEVICE_MODEL_ADDED: '%s' (%s %s) has been added to deviceModels", code, brand, name)
+}
+
+// GetResultCacheLen returns number of entries in result cache (0 if cache disabled)
+func (d *FastDeviceDetector) GetResultCacheLen() int {
+	if d.resultCache == nil {
+		return 0
+	}
+	return d.resultCache.Len()
+}
+
+// PatternCount is unknown user agent, browser or OS string with its logged detections
+type PatternCount struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+}
+
+// DetectionStats is detection counters plus most frequent unknown strings per category (ua, browser, os)
+type DetectionStats struct {
+	Counts map[string]int            `json:"counts"`
+	Top    map[string][]PatternCount `json:"top,omitempty"`
+}
+
 // GetDetectionStats returns statistics about unknown pattern detections
 func (d *FastDeviceDetector) GetDetectionStats() map[string]int {
 	d.logger.mutex.RLock()
 	defer d.logger.mutex.RUnlock()
 
+	return d.logger.countsLocked()
+}
+
+// GetDetectionStatsWithTop returns detection counters with top N unknown strings per category (most detections first).
+// Counters and top lists come from single snapshot, non-positive topN omits top lists.
+func (d *FastDeviceDetector) GetDetectionStatsWithTop(topN int) DetectionStats {
+	d.logger.mutex.RLock()
+	defer d.logger.mutex.RUnlock()
+
+	stats := DetectionStats{Counts: d.logger.countsLocked()}
+	if topN > 0 {
+		stats.Top = map[string][]PatternCount{
+			"ua":      topPatterns(d.logger.unknownUAs, topN),
+			"browser": topPatterns(d.logger.unknownBrowsers, topN),
+			"os":      topPatterns(d.logger.unknownOSs, topN),
+		}
+	}
+	return stats
+}
+
+// ResetDetectionStats clears unknown pattern counters and restarts cleanup interval, e.g. after patterns were reviewed.
+// Per pattern log budgets (logger.SampledBurst) are not reset and keep their current window.
+func (d *FastDeviceDetector) ResetDetectionStats() {
+	d.logger.mutex.Lock()
+	defer d.logger.mutex.Unlock()
+
+	d.logger.resetLocked()
+}
+
+// countsLocked returns aggregate detection counters (caller holds mutex)
+func (dl *DetectionLogger) countsLocked() map[string]int {
 	stats := make(map[string]int)
 
 	totalUnknown := 0
-	for _, count := range d.logger.unknownUAs {
+	for _, count := range dl.unknownUAs {
 		totalUnknown += count
 	}
 
 	totalUnknownBrowsers := 0
-	for _, count := range d.logger.unknownBrowsers {
+	for _, count := range dl.unknownBrowsers {
 		totalUnknownBrowsers += count
 	}
 
 	totalUnknownOS := 0
-	for _, count := range d.logger.unknownOSs {
+	for _, count := range dl.unknownOSs {
 		totalUnknownOS += count
 	}
 
-	stats["unknown_uas"] = len(d.logger.unknownUAs)
-	stats["unknown_browsers"] = len(d.logger.unknownBrowsers)
-	stats["unknown_oses"] = len(d.logger.unknownOSs)
+	stats["unknown_uas"] = len(dl.unknownUAs)
+	stats["unknown_browsers"] = len(dl.unknownBrowsers)
+	stats["unknown_oses"] = len(dl.unknownOSs)
 	stats["total_unknown_detections"] = totalUnknown
 	stats["total_unknown_browser_detections"] = totalUnknownBrowsers
 	stats["total_unknown_os_detections"] = totalUnknownOS
+	stats["cleanup_interval_seconds"] = int(dl.cleanupInterval.Seconds())
+	stats["max_logs_per_pattern"] = dl.maxLogs
 
 	return stats
 }
 
+// resetLocked replaces seen-pattern counters (caller holds mutex)
+func (dl *DetectionLogger) resetLocked() {
+	dl.unknownUAs = make(map[string]int)
+	dl.unknownBrowsers = make(map[string]int)
+	dl.unknownOSs = make(map[string]int)
+	dl.lastCleanup = time.Now()
+}
+
+// topPatterns returns up to n patterns ordered by count desc, then pattern asc for stable output
+func topPatterns(counts map[string]int, n int) []PatternCount {
+	patterns := make([]PatternCount, 0, len(counts))
+	for pattern, count := range counts {
+		patterns = append(patterns, PatternCount{Pattern: pattern, Count: count})
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Count != patterns[j].Count {
+			return patterns[i].Count > patterns[j].Count
+		}
+		return patterns[i].Pattern < patterns[j].Pattern
+	})
+
+	if len(patterns) > n {
+		patterns = patterns[:n]
+	}
+	return patterns
+}
+
 // // =============================================================================
 // // BENCHMARKING DAN TESTING
 // // =============================================================================
@@ -1,10 +1,15 @@
 package useragent
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -84,6 +89,87 @@ func BenchmarkDetectParallel(b *testing.B) {
 	})
 }
 
+// Benchmark parallel detection with LRU result cache
+func BenchmarkDetectParallelCached(b *testing.B) {
+	detector := NewFastDetectorWithCache(1000)
+	detector.EnableLogging(false)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ua := testUserAgents[rand.Intn(len(testUserAgents))]
+			_ = detector.Detect(ua)
+		}
+	})
+}
+
+// Benchmark repeated user agents with and without result cache
+func BenchmarkDetectCached(b *testing.B) {
+	uncached := NewFastDetector()
+	uncached.EnableLogging(false)
+
+	cached := NewFastDetectorWithCache(1000)
+	cached.EnableLogging(false)
+
+	b.Run("Uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = uncached.Detect(testUserAgents[i%len(testUserAgents)])
+		}
+	})
+
+	b.Run("Cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = cached.Detect(testUserAgents[i%len(testUserAgents)])
+		}
+	})
+}
+
+// Benchmark batch detection vs looped single calls
+func BenchmarkDetectBatch(b *testing.B) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	for _, size := range []int{100, 5000} {
+		batch := make([]string, size)
+		for i := range batch {
+			batch[i] = testUserAgents[i%len(testUserAgents)]
+		}
+
+		b.Run(fmt.Sprintf("Loop_%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, ua := range batch {
+					_ = detector.Detect(ua)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Batch_%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = detector.DetectBatch(batch)
+			}
+		})
+	}
+}
+
+// Benchmark enrichment lookup: detector built per call (previous behavior) vs shared Default detector
+func BenchmarkDetectorPerCallVsDefault(b *testing.B) {
+	Default().EnableLogging(false)
+	ua := testUserAgents[0]
+
+	b.Run("NewPerCall", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = NewFastDetector().Detect(ua)
+		}
+	})
+
+	b.Run("Default", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Default().Detect(ua)
+		}
+	})
+}
+
 // Benchmark memory allocations
 func BenchmarkDetectMemory(b *testing.B) {
 	detector := NewFastDetector()
@@ -113,46 +199,104 @@ func TestAccuracy(t *testing.T) {
 		expectedOS      string
 		expectedBrowser string
 		expectedBot     bool
+		expectedEngine  string
+		expectedWebView string // Empty = regular browser
+		expectedArch    string // Empty = no architecture hint in UA
 	}{
 		{
 			"Chrome Windows Desktop",
 			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
-			Desktop, "Windows", "Chrome", false,
+			Desktop, "Windows", "Chrome", false, "Blink", "", "x64",
 		},
 		{
 			"Safari iPhone",
 			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
-			Mobile, "iOS", "Safari", false,
+			Mobile, "iOS", "Safari", false, "WebKit", "", "",
 		},
 		{
 			"Safari iPad",
 			"Mozilla/5.0 (iPad; CPU OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
-			Tablet, "iOS", "Safari", false,
+			Tablet, "iOS", "Safari", false, "WebKit", "", "",
 		},
 		{
 			"Chrome Android Mobile",
 			"Mozilla/5.0 (Linux; Android 13; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
-			Mobile, "Android", "Chrome", false,
+			Mobile, "Android", "Chrome", false, "Blink", "", "",
 		},
 		{
 			"Android Tablet",
 			"Mozilla/5.0 (Linux; Android 13; SM-T870) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
-			Tablet, "Android", "Chrome", false,
+			Tablet, "Android", "Chrome", false, "Blink", "", "",
 		},
 		{
 			"Googlebot",
 			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
-			Unknown, "Unknown", "Unknown", true,
+			Unknown, "Unknown", "Unknown", true, "Unknown", "", "",
 		},
 		{
 			"GPTBot (AI Crawler)",
 			"GPTBot/1.0 (+https://openai.com/gptbot)",
-			Unknown, "Unknown", "Unknown", true,
+			Unknown, "Unknown", "Unknown", true, "Unknown", "", "",
 		},
 		{
 			"Edge Windows",
 			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
-			Desktop, "Windows", "Edge", false,
+			Desktop, "Windows", "Edge", false, "Blink", "", "x64",
+		},
+		{
+			"Firefox Windows",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/121.0",
+			Desktop, "Windows", "Firefox", false, "Gecko", "", "x64",
+		},
+		{
+			"Safari macOS",
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15",
+			Desktop, "macOS", "Safari", false, "WebKit", "", "",
+		},
+		{
+			"Internet Explorer 11",
+			"Mozilla/5.0 (Windows NT 10.0; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			Desktop, "Windows", "Internet Explorer", false, "Trident", "", "x64",
+		},
+		{
+			"Legacy Edge",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19582",
+			Desktop, "Windows", "Edge", false, "EdgeHTML", "", "x64",
+		},
+		{
+			"Facebook In-App iPhone",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 [FBAN/FBIOS;FBAV/443.0.0.23.229;FBBV/551238812;FBDV/iPhone15,2;FBMD/iPhone;FBSN/iOS;FBSV/17.1;FBSS/3;FBID/phone;FBLC/en_US;FBOP/5]",
+			Mobile, "iOS", "Unknown", false, "WebKit", "Facebook", "",
+		},
+		{
+			"Facebook In-App Android",
+			"Mozilla/5.0 (Linux; Android 13; SM-G991B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/119.0.6045.163 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/443.0.0.30.108;]",
+			Mobile, "Android", "Chrome", false, "Blink", "Facebook", "",
+		},
+		{
+			"Instagram In-App iPhone",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 307.0.0.34.111 (iPhone15,2; iOS 17_1; en_US; en; scale=3.00; 1179x2556; 531732958)",
+			Mobile, "iOS", "Unknown", false, "WebKit", "Instagram", "",
+		},
+		{
+			"LINE In-App Android",
+			"Mozilla/5.0 (Linux; Android 13; SM-S911B Build/TP1A.220624.014; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/119.0.6045.163 Mobile Safari/537.36 Line/13.19.1",
+			Mobile, "Android", "Chrome", false, "Blink", "LINE", "",
+		},
+		{
+			"Android System WebView",
+			"Mozilla/5.0 (Linux; Android 13; Pixel 7 Build/TQ3A.230805.001; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/119.0.6045.163 Mobile Safari/537.36",
+			Mobile, "Android", "Chrome", false, "Blink", "Android WebView", "",
+		},
+		{
+			"Firefox Windows x64",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:128.0) Gecko/20100101 Firefox/128.0",
+			Desktop, "Windows", "Firefox", false, "Gecko", "", "x64",
+		},
+		{
+			"Chrome Linux aarch64",
+			"Mozilla/5.0 (X11; Linux aarch64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			Desktop, "Linux", "Chrome", false, "Blink", "", "arm64",
 		},
 	}
 
@@ -172,6 +316,15 @@ func TestAccuracy(t *testing.T) {
 			if result.IsBot != tc.expectedBot {
 				t.Errorf("Expected Bot=%v, got %v", tc.expectedBot, result.IsBot)
 			}
+			if result.Engine != tc.expectedEngine {
+				t.Errorf("Expected Engine=%s, got %s", tc.expectedEngine, result.Engine)
+			}
+			if result.IsWebView != (tc.expectedWebView != "") || result.WebViewApp != tc.expectedWebView {
+				t.Errorf("Expected WebView=%q, got %q (IsWebView=%v)", tc.expectedWebView, result.WebViewApp, result.IsWebView)
+			}
+			if result.Arch != tc.expectedArch {
+				t.Errorf("Expected Arch=%q, got %q", tc.expectedArch, result.Arch)
+			}
 		})
 	}
 }
@@ -317,6 +470,76 @@ func TestCacheEfficiency(t *testing.T) {
 		len(detector.mobileOS), len(detector.desktopOS))
 }
 
+// Test LRU result cache behaviour
+func TestResultCache(t *testing.T) {
+	detector := NewFastDetectorWithCache(2)
+	detector.EnableLogging(false)
+
+	first := detector.Detect(testUserAgents[0])
+	second := detector.Detect(testUserAgents[0])
+
+	if *first != *second {
+		t.Errorf("Cached result differs from original: %+v vs %+v", first, second)
+	}
+	if detector.GetResultCacheLen() != 1 {
+		t.Errorf("Expected 1 cached entry, got %d", detector.GetResultCacheLen())
+	}
+
+	// Mutating returned result must not affect cache
+	second.Browser = "Mutated"
+	if third := detector.Detect(testUserAgents[0]); third.Browser == "Mutated" {
+		t.Error("Cached entry should not be affected by caller mutation")
+	}
+
+	// Eviction at capacity
+	detector.Detect(testUserAgents[1])
+	detector.Detect(testUserAgents[2])
+	if detector.GetResultCacheLen() != 2 {
+		t.Errorf("Expected cache capped at 2 entries, got %d", detector.GetResultCacheLen())
+	}
+
+	// Rebuild purges cached results
+	detector.RebuildCaches()
+	if detector.GetResultCacheLen() != 0 {
+		t.Errorf("Expected empty cache after RebuildCaches, got %d", detector.GetResultCacheLen())
+	}
+}
+
+// Test batch detection order and empty entries
+func TestDetectBatch(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	// Above parallel threshold to exercise worker pool
+	batch := make([]string, batchParallelThreshold+123)
+	for i := range batch {
+		if i%50 == 0 {
+			continue // Leave empty entries
+		}
+		batch[i] = testUserAgents[i%len(testUserAgents)]
+	}
+
+	results := detector.DetectBatch(batch)
+	if len(results) != len(batch) {
+		t.Fatalf("Expected %d results, got %d", len(batch), len(results))
+	}
+
+	for i, ua := range batch {
+		if results[i] == nil {
+			t.Fatalf("Result %d is nil", i)
+		}
+
+		// Empty entries go through same detection as Detect
+		if expected := detector.Detect(ua); *results[i] != *expected {
+			t.Errorf("Result %d (empty=%v) differs from Detect: expected %+v, got %+v", i, ua == "", expected, results[i])
+		}
+	}
+
+	if empty := detector.DetectBatch(nil); len(empty) != 0 {
+		t.Errorf("Expected empty result for nil batch, got %d", len(empty))
+	}
+}
+
 // Test concurrent access safety
 func TestConcurrentSafety(t *testing.T) {
 	detector := NewFastDetector()
@@ -369,6 +592,370 @@ func TestConcurrentSafety(t *testing.T) {
 		numRoutines, iterationsPerRoutine)
 }
 
+// Test shared detector is created once and picks up runtime pattern changes without explicit rebuild
+func TestDefaultDetectorSharedAndRefreshed(t *testing.T) {
+	var wg sync.WaitGroup
+	detectors := make([]*FastDeviceDetector, 20)
+	for i := range detectors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			detectors[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+	for i, detector := range detectors {
+		if detector == nil || detector != detectors[0] {
+			t.Fatalf("expected single shared detector, call %d returned %p (first %p)", i, detector, detectors[0])
+		}
+	}
+
+	// Desktop device type comes from OS cache built on first call, stale until patterns change
+	ua := "DefaultProbe/1.0 (defaultsingletonos)"
+	Default().Detect(ua)
+	AddOSPattern("DefaultSingletonOS", []string{"defaultsingletonos"}, nil)
+
+	info := Default().Detect(ua)
+	if info.OS != "DefaultSingletonOS" || info.Type != Desktop {
+		t.Errorf("expected runtime OS pattern on shared detector, got os=%s type=%s", info.OS, info.Type)
+	}
+}
+
+// Test runtime pattern mutation while detecting (run with -race)
+func TestPatternMutationConcurrentSafety(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	const numRoutines = 20
+	const iterationsPerRoutine = 200
+
+	var wg sync.WaitGroup
+
+	// Writer goroutine mutating patterns
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			AddBotPattern(fmt.Sprintf("racetestbot%d", i))
+			if i%10 == 0 {
+				AddBrowserPattern(fmt.Sprintf("RaceBrowser%d", i), []string{fmt.Sprintf("racebrowser%d/", i)})
+				AddOSPattern(fmt.Sprintf("RaceOS%d", i), []string{fmt.Sprintf("raceos%d", i)}, detector)
+			}
+		}
+	}()
+
+	// Reader goroutines
+	for i := 0; i < numRoutines; i++ {
+		wg.Add(1)
+		go func(routineID int) {
+			defer wg.Done()
+			for j := 0; j < iterationsPerRoutine; j++ {
+				ua := testUserAgents[(routineID+j)%len(testUserAgents)]
+				if result := detector.Detect(ua); result == nil {
+					t.Errorf("routine %d got nil result", routineID)
+					return
+				}
+			}
+			_ = detector.GetSupportedBrowsers()
+			_ = detector.GetSupportedOS()
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Newly added pattern must be visible
+	AddBotPattern("racefinalbot")
+	if !detector.Detect("RaceFinalBot/1.0").IsBot {
+		t.Error("Expected pattern added at runtime to be detected")
+	}
+}
+
+// Test detection logger cleanup interval and per pattern cap configuration
+func TestDetectionLoggerConfig(t *testing.T) {
+	// Defaults
+	logger := NewDetectionLogger(false)
+	if logger.cleanupInterval != 5*time.Minute || logger.maxLogs != 3 {
+		t.Fatalf("Expected defaults 5m/3, got %s/%d", logger.cleanupInterval, logger.maxLogs)
+	}
+
+	detector := NewFastDetector(WithCleanupInterval(time.Hour), WithMaxLogsPerPattern(1))
+	if detector.logger == detectionLogger {
+		t.Fatal("Expected detector with options to get its own detection logger")
+	}
+
+	stats := detector.GetDetectionStats()
+	if stats["cleanup_interval_seconds"] != 3600 {
+		t.Errorf("Expected cleanup_interval_seconds=3600, got %d", stats["cleanup_interval_seconds"])
+	}
+	if stats["max_logs_per_pattern"] != 1 {
+		t.Errorf("Expected max_logs_per_pattern=1, got %d", stats["max_logs_per_pattern"])
+	}
+
+	// Cap applies per pattern
+	for i := 0; i < 3; i++ {
+		detector.logger.logUnknownPattern("browser", "CapTestAgent/1.0", "test")
+	}
+	if count := detector.GetDetectionStats()["total_unknown_browser_detections"]; count != 1 {
+		t.Errorf("Expected 1 logged detection with cap 1, got %d", count)
+	}
+
+	// Invalid values are ignored
+	detector.logger.SetCleanupInterval(0)
+	detector.logger.SetMaxLogsPerPattern(-1)
+	stats = detector.GetDetectionStats()
+	if stats["cleanup_interval_seconds"] != 3600 || stats["max_logs_per_pattern"] != 1 {
+		t.Errorf("Expected invalid values to be ignored, got %d/%d", stats["cleanup_interval_seconds"], stats["max_logs_per_pattern"])
+	}
+
+	// Concurrent setters alongside logging (run with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			detector.logger.SetCleanupInterval(time.Duration(i+1) * time.Minute)
+			detector.logger.SetMaxLogsPerPattern(i + 1)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			detector.logger.logUnknownPattern("os", fmt.Sprintf("ConcurrentAgent/%d", i), "test")
+			_ = detector.GetDetectionStats()
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Test top unknown patterns snapshot and atomic reset
+func TestDetectionStatsTopAndReset(t *testing.T) {
+	detector := NewFastDetector(WithCleanupInterval(time.Hour), WithMaxLogsPerPattern(5))
+
+	for i := 0; i < 3; i++ {
+		detector.logger.logUnknownPattern("browser", "TopBrowserA/1.0", "test")
+	}
+	detector.logger.logUnknownPattern("browser", "TopBrowserB/1.0", "test")
+	detector.logger.logUnknownPattern("browser", "TopBrowserC/1.0", "test")
+	detector.logger.logUnknownPattern("os", "TopOS/1.0", "test")
+
+	stats := detector.GetDetectionStatsWithTop(2)
+	if stats.Counts["total_unknown_browser_detections"] != 5 {
+		t.Errorf("Expected 5 browser detections, got %d", stats.Counts["total_unknown_browser_detections"])
+	}
+	expected := []PatternCount{{"TopBrowserA/1.0", 3}, {"TopBrowserB/1.0", 1}}
+	if !reflect.DeepEqual(stats.Top["browser"], expected) {
+		t.Errorf("Expected top browsers %v, got %v", expected, stats.Top["browser"])
+	}
+	if len(stats.Top["os"]) != 1 || len(stats.Top["ua"]) != 0 {
+		t.Errorf("Unexpected top os/ua %v / %v", stats.Top["os"], stats.Top["ua"])
+	}
+	if detector.GetDetectionStatsWithTop(0).Top != nil {
+		t.Error("Expected no top lists when topN is not positive")
+	}
+
+	detector.ResetDetectionStats()
+	stats = detector.GetDetectionStatsWithTop(2)
+	if stats.Counts["unknown_browsers"] != 0 || stats.Counts["unknown_oses"] != 0 || len(stats.Top["browser"]) != 0 {
+		t.Errorf("Expected empty stats after reset, got %+v", stats)
+	}
+	if stats.Counts["cleanup_interval_seconds"] != 3600 || stats.Counts["max_logs_per_pattern"] != 5 {
+		t.Errorf("Expected settings to survive reset, got %v", stats.Counts)
+	}
+
+	// Reset alongside logging and snapshots (run with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			detector.logger.logUnknownPattern("ua", fmt.Sprintf("ResetAgent/%d", i), "test")
+			_ = detector.GetDetectionStatsWithTop(3)
+		}(i)
+		go func() {
+			defer wg.Done()
+			detector.ResetDetectionStats()
+		}()
+	}
+	wg.Wait()
+}
+
+// Test bot category reported for matched pattern group
+func TestBotCategory(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	testCases := []struct {
+		name             string
+		userAgent        string
+		expectedBot      bool
+		expectedCategory string
+	}{
+		{"GPTBot", "Mozilla/5.0 AppleWebKit/537.36 (KHTML, like Gecko; compatible; GPTBot/1.2; +https://openai.com/gptbot)", true, BotCategoryAI},
+		{"Googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true, BotCategorySearch},
+		{"Facebook Preview", "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", true, BotCategorySocial},
+		{"AhrefsBot", "Mozilla/5.0 (compatible; AhrefsBot/7.0; +http://ahrefs.com/robot/)", true, BotCategorySEO},
+		{"curl", "curl/8.4.0", true, BotCategoryTool},
+		{"Generic Crawler", "MyCustomCrawler/1.0", true, BotCategoryGeneric},
+		{"Regular Browser", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36", false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := detector.Detect(tc.userAgent)
+			if result.IsBot != tc.expectedBot {
+				t.Errorf("Expected Bot=%v, got %v", tc.expectedBot, result.IsBot)
+			}
+			if result.BotCategory != tc.expectedCategory {
+				t.Errorf("Expected BotCategory=%q, got %q", tc.expectedCategory, result.BotCategory)
+			}
+		})
+	}
+}
+
+// Test confidence ranks specific detections above fallback guesses
+func TestConfidence(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	full := detector.Detect("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	osOnly := detector.Detect("Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+	bare := detector.Detect("CustomBrowser/1.0")
+
+	if full.Confidence < 0.9 || full.Confidence > 1 {
+		t.Errorf("Expected high confidence for full Chrome/Windows UA, got %.2f", full.Confidence)
+	}
+	if bare.Confidence > 0.2 {
+		t.Errorf("Expected low confidence for bare UA, got %.2f", bare.Confidence)
+	}
+	if !(full.Confidence > osOnly.Confidence && osOnly.Confidence > bare.Confidence) {
+		t.Errorf("Expected full (%.2f) > OS only (%.2f) > bare (%.2f)", full.Confidence, osOnly.Confidence, bare.Confidence)
+	}
+
+	// Bots scored by matched pattern
+	if named := detector.Detect("Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"); named.Confidence != 0.9 {
+		t.Errorf("Expected 0.9 for named bot, got %.2f", named.Confidence)
+	}
+}
+
+// Test removing patterns at runtime flips detection back (including cached results)
+func TestRemovePatterns(t *testing.T) {
+	detector := NewFastDetectorWithCache(100)
+	detector.EnableLogging(false)
+
+	t.Run("Bot Pattern", func(t *testing.T) {
+		ua := "RemoveTestAgent/1.0 (X11; Linux x86_64)"
+		if detector.Detect(ua).IsBot {
+			t.Fatal("Expected user agent not to be bot before pattern added")
+		}
+
+		AddBotPattern("removetestagent")
+		if !detector.Detect(ua).IsBot {
+			t.Fatal("Expected user agent to be bot after pattern added")
+		}
+
+		if !RemoveBotPattern("RemoveTestAgent") {
+			t.Fatal("Expected RemoveBotPattern to report removal")
+		}
+		if detector.Detect(ua).IsBot {
+			t.Error("Expected user agent not to be bot after pattern removed")
+		}
+		if RemoveBotPattern("removetestagent") {
+			t.Error("Expected second RemoveBotPattern to report nothing removed")
+		}
+	})
+
+	t.Run("Browser Pattern", func(t *testing.T) {
+		ua := "RemoveTestBrowser/2.0 (X11; Linux x86_64)"
+		AddBrowserPattern("RemoveTest", []string{"removetestbrowser/"})
+		if browser := detector.Detect(ua).Browser; browser != "RemoveTest" {
+			t.Fatalf("Expected Browser=RemoveTest after pattern added, got %s", browser)
+		}
+
+		if !RemoveBrowserPattern("removetest") {
+			t.Fatal("Expected RemoveBrowserPattern to report removal")
+		}
+		if browser := detector.Detect(ua).Browser; browser != "Unknown" {
+			t.Errorf("Expected Browser=Unknown after pattern removed, got %s", browser)
+		}
+		if RemoveBrowserPattern("RemoveTest") {
+			t.Error("Expected second RemoveBrowserPattern to report nothing removed")
+		}
+	})
+}
+
+// Test loading patterns from external JSON file
+func TestLoadPatternsFromFile(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	t.Run("Invalid Regex Leaves Patterns Untouched", func(t *testing.T) {
+		browsersBefore := len(detector.GetSupportedBrowsers())
+		path := writeFile("invalid.json", `{
+			"bot_patterns": ["loadtestinvalidagent"],
+			"browser_patterns": [{"name": "Broken", "patterns": ["broken/"], "version_regex": "broken/(["}]
+		}`)
+
+		err := LoadPatternsFromFile(path)
+		if err == nil || !strings.Contains(err.Error(), "browser_patterns[0] (Broken)") {
+			t.Fatalf("Expected descriptive regex error, got %v", err)
+		}
+		if len(detector.GetSupportedBrowsers()) != browsersBefore {
+			t.Error("Browser patterns should not change on invalid file")
+		}
+		if detector.Detect("LoadTestInvalidAgent/1.0").IsBot {
+			t.Error("Bot pattern from invalid file should not be applied")
+		}
+	})
+
+	t.Run("Valid File Merged", func(t *testing.T) {
+		path := writeFile("valid.json", `{
+			"bot_patterns": ["LoadTestBot"],
+			"browser_patterns": [{"name": "LoadTestBrowser", "patterns": ["loadtestbrowser/"], "version_regex": "(?i)loadtestbrowser/([0-9.]+)"}],
+			"os_patterns": [{"name": "LoadTestOS", "patterns": ["loadtestos"]}]
+		}`)
+
+		if err := LoadPatternsFromFile(path); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !detector.Detect("LoadTestBot/2.0").IsBot {
+			t.Error("Expected loaded bot pattern to be detected")
+		}
+
+		result := detector.Detect("Mozilla/5.0 (LoadTestOS) LoadTestBrowser/3.1")
+		if result.Browser != "LoadTestBrowser" || result.BrowserVersion != "3.1" {
+			t.Errorf("Expected LoadTestBrowser 3.1, got %s %s", result.Browser, result.BrowserVersion)
+		}
+		if result.OS != "LoadTestOS" {
+			t.Errorf("Expected OS=LoadTestOS, got %s", result.OS)
+		}
+
+		// Detector caches refreshed without explicit RebuildCaches call
+		found := false
+		for _, os := range detector.desktopOS {
+			if os.name == "LoadTestOS" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("Expected detector OS cache to include loaded OS pattern")
+		}
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		if err := LoadPatternsFromFile(filepath.Join(dir, "missing.json")); err == nil {
+			t.Error("Expected error for missing file")
+		}
+	})
+}
+
 // Test version detection accuracy
 func TestVersionDetection(t *testing.T) {
 	detector := NewFastDetector()
@@ -406,6 +993,19 @@ func TestVersionDetection(t *testing.T) {
 		},
 	}
 
+	// Engine versions
+	engineVersionCases := map[string]string{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.4472.124 Safari/537.36":              "120.0.4472.124",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15":             "605.1.15",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:109.0) Gecko/20100101 Firefox/121.0":                                                  "109.0",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19582": "18.19582",
+	}
+	for ua, expected := range engineVersionCases {
+		if result := detector.Detect(ua); result.EngineVersion != expected {
+			t.Errorf("Expected engine version=%s, got %s (%s)", expected, result.EngineVersion, result.Engine)
+		}
+	}
+
 	for _, tc := range versionTestCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result := detector.Detect(tc.userAgent)
@@ -421,6 +1021,260 @@ func TestVersionDetection(t *testing.T) {
 	}
 }
 
+// Test device brand and model detection
+func TestDeviceModelDetection(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	modelTestCases := []struct {
+		name          string
+		userAgent     string
+		expectedBrand string
+		expectedModel string
+	}{
+		{
+			"Samsung Known Model",
+			"Mozilla/5.0 (Linux; Android 13; SM-G991B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			"Samsung", "Galaxy S21",
+		},
+		{
+			"Samsung Unknown Model Raw Token",
+			"Mozilla/5.0 (Linux; Android 14; SM-X999Z) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			"Samsung", "SM-X999Z",
+		},
+		{
+			"Legacy Android With Locale And Build",
+			"Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; GT-I9100 Build/IML74K) AppleWebKit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30",
+			"Samsung", "GT-I9100",
+		},
+		{
+			"Unknown Brand Raw Token",
+			"Mozilla/5.0 (Linux; Android 12; ABC-123) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			"Unknown", "ABC-123",
+		},
+		{
+			"Android Reduced UA",
+			"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			"", "",
+		},
+		{
+			"iPhone Hardware Identifier",
+			"MyApp/2.3.1 (iPhone15,2; iOS 17.1; Scale/3.00)",
+			"Apple", "iPhone 14 Pro",
+		},
+		{
+			"iPhone Safari Generic",
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			"Apple", "iPhone",
+		},
+		{
+			"Desktop No Model",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"", "",
+		},
+	}
+
+	for _, tc := range modelTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := detector.Detect(tc.userAgent)
+
+			if result.Brand != tc.expectedBrand {
+				t.Errorf("Expected Brand=%s, got %s", tc.expectedBrand, result.Brand)
+			}
+			if result.Model != tc.expectedModel {
+				t.Errorf("Expected Model=%s, got %s", tc.expectedModel, result.Model)
+			}
+		})
+	}
+
+	// Runtime extension
+	AddDeviceModel("ABC-123", "Acme", "Acme Phone 1")
+	result := detector.Detect(modelTestCases[3].userAgent)
+	if result.Brand != "Acme" || result.Model != "Acme Phone 1" {
+		t.Errorf("Expected Acme/Acme Phone 1 after AddDeviceModel, got %s/%s", result.Brand, result.Model)
+	}
+}
+
+// Test AddDeviceModel invalidates cached results
+func TestAddDeviceModelInvalidatesResultCache(t *testing.T) {
+	detector := NewFastDetectorWithCache(16)
+	detector.EnableLogging(false)
+
+	ua := "Mozilla/5.0 (Linux; Android 12; XYZ-789) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36"
+	if result := detector.Detect(ua); result.Model != "XYZ-789" {
+		t.Fatalf("Expected raw token model before AddDeviceModel, got %s", result.Model)
+	}
+
+	AddDeviceModel("XYZ-789", "Xyz", "Xyz Phone 9")
+	if result := detector.Detect(ua); result.Brand != "Xyz" || result.Model != "Xyz Phone 9" {
+		t.Errorf("Expected Xyz/Xyz Phone 9 from refreshed cache, got %s/%s", result.Brand, result.Model)
+	}
+}
+
+// Test DeviceType JSON round-trip
+func TestDeviceTypeJSON(t *testing.T) {
+	data, err := json.Marshal(Mobile)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	if string(data) != `"mobile"` {
+		t.Errorf("Expected \"mobile\", got %s", data)
+	}
+
+	var decoded DeviceType
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if decoded != Mobile {
+		t.Errorf("Expected Mobile, got %s", decoded)
+	}
+
+	// Struct round-trip
+	info := FastDeviceInfo{Type: Tablet, OS: "Android", Browser: "Chrome"}
+	data, _ = json.Marshal(info)
+	if !strings.Contains(string(data), `"type":"tablet"`) {
+		t.Errorf("Expected type serialized as string, got %s", data)
+	}
+
+	var decodedInfo FastDeviceInfo
+	if err := json.Unmarshal(data, &decodedInfo); err != nil || decodedInfo.Type != Tablet {
+		t.Errorf("Expected Tablet after round-trip, got %s (err=%v)", decodedInfo.Type, err)
+	}
+
+	// Legacy numeric value
+	if err := json.Unmarshal([]byte("0"), &decoded); err != nil || decoded != Desktop {
+		t.Errorf("Expected Desktop from legacy numeric value, got %s (err=%v)", decoded, err)
+	}
+}
+
+// Test client hints parsing and merge with UA detection
+func TestClientHints(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	frozenAndroidUA := "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36"
+
+	t.Run("Hints Override UA", func(t *testing.T) {
+		result := detector.DetectFromClientHints(map[string]string{
+			"User-Agent":                  frozenAndroidUA,
+			"Sec-CH-UA":                   `"Chromium";v="124", "Microsoft Edge";v="124", "Not-A.Brand";v="99"`,
+			"Sec-CH-UA-Full-Version-List": `"Chromium";v="124.0.6367.82", "Microsoft Edge";v="124.0.2478.51", "Not-A.Brand";v="99.0.0.0"`,
+			"Sec-CH-UA-Platform":          `"Android"`,
+			"Sec-CH-UA-Platform-Version":  `"14.0.0"`,
+			"Sec-CH-UA-Mobile":            "?1",
+			"Sec-CH-UA-Model":             `"SM-G991B"`,
+		})
+
+		if result.Browser != "Edge" || result.BrowserVersion != "124.0.2478.51" {
+			t.Errorf("Expected Edge 124.0.2478.51, got %s %s", result.Browser, result.BrowserVersion)
+		}
+		if result.OS != "Android" || result.OSVersion != "14.0.0" {
+			t.Errorf("Expected Android 14.0.0, got %s %s", result.OS, result.OSVersion)
+		}
+		if result.Type != Mobile {
+			t.Errorf("Expected Type=mobile, got %s", result.Type)
+		}
+		if result.Brand != "Samsung" || result.Model != "Galaxy S21" {
+			t.Errorf("Expected Samsung Galaxy S21, got %s %s", result.Brand, result.Model)
+		}
+	})
+
+	t.Run("GREASE Brand With Separators", func(t *testing.T) {
+		result := detector.DetectFromClientHints(map[string]string{
+			"sec-ch-ua":          `"Not;A=Brand";v="8", "Chromium";v="124", "Google Chrome";v="124"`,
+			"sec-ch-ua-platform": `"Windows"`,
+			"sec-ch-ua-mobile":   "?0",
+		})
+
+		if result.Browser != "Chrome" || result.BrowserVersion != "124" {
+			t.Errorf("Expected Chrome 124, got %s %s", result.Browser, result.BrowserVersion)
+		}
+		if result.OS != "Windows" || result.Type != Desktop {
+			t.Errorf("Expected Windows desktop, got %s %s", result.OS, result.Type)
+		}
+	})
+
+	t.Run("Incomplete Hints Fallback", func(t *testing.T) {
+		result := detector.DetectFromClientHints(map[string]string{
+			"User-Agent": testUserAgents[0],
+			"Sec-CH-UA":  `"Chromium";v="124"`,
+		})
+
+		if expected := detector.Detect(testUserAgents[0]); *result != *expected {
+			t.Errorf("Expected UA fallback %+v, got %+v", expected, result)
+		}
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	detector := NewFastDetector()
+	detector.EnableLogging(false)
+
+	extra := map[string]string{"screen": "1920x1080", "Accept-Language": "en-US,en;q=0.9"}
+
+	t.Run("Patch Version Ignored", func(t *testing.T) {
+		pairs := [][2]string{
+			{
+				"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.129 Safari/537.36",
+				"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.6099.216 Safari/537.36",
+			},
+			{
+				"Mozilla/5.0 (iPhone; CPU iPhone OS 17_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2 Mobile/15E148 Safari/604.1",
+				"Mozilla/5.0 (iPhone; CPU iPhone OS 17_2_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.2.1 Mobile/15E148 Safari/604.1",
+			},
+			{
+				"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0",
+				"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:121.0) Gecko/20100101 Firefox/121.0.1",
+			},
+		}
+
+		for _, pair := range pairs {
+			first := Fingerprint(detector.Detect(pair[0]), extra)
+			second := Fingerprint(detector.Detect(pair[1]), extra)
+			if first != second {
+				t.Errorf("Expected same fingerprint for patch update:\n  %s\n  %s", pair[0], pair[1])
+			}
+		}
+	})
+
+	t.Run("Major Version And Device Change", func(t *testing.T) {
+		base := Fingerprint(detector.Detect(testUserAgents[0]), extra)
+		if len(base) != 64 {
+			t.Fatalf("Expected 64 char SHA256 hex, got %q", base)
+		}
+
+		upgraded := Fingerprint(detector.Detect(strings.Replace(testUserAgents[0], "Chrome/120", "Chrome/121", 1)), extra)
+		if upgraded == base {
+			t.Error("Expected different fingerprint for major browser upgrade")
+		}
+		if other := Fingerprint(detector.Detect(testUserAgents[3]), extra); other == base {
+			t.Error("Expected different fingerprint for different device")
+		}
+	})
+
+	t.Run("Extra Canonicalization", func(t *testing.T) {
+		info := detector.Detect(testUserAgents[0])
+		base := Fingerprint(info, extra)
+
+		// Key case, surrounding whitespace and empty values must not matter
+		same := Fingerprint(info, map[string]string{
+			"accept-language ": "  en-US,en;q=0.9 ",
+			"SCREEN":           "1920x1080",
+			"timezone":         "",
+		})
+		if same != base {
+			t.Error("Expected canonicalized extra to yield same fingerprint")
+		}
+
+		if changed := Fingerprint(info, map[string]string{"screen": "1280x720", "accept-language": "en-US,en;q=0.9"}); changed == base {
+			t.Error("Expected different fingerprint for different screen")
+		}
+		if Fingerprint(nil, nil) == "" {
+			t.Error("Expected fingerprint for nil info")
+		}
+	})
+}
+
 // ============================================================================
 // DEMO & EXAMPLE OUTPUT
 // ============================================================================
@@ -14,13 +14,9 @@ import (
 	"github.com/benedict-erwin/insight-collector/http/middleware"
 	"github.com/benedict-erwin/insight-collector/http/registry"
 	"github.com/benedict-erwin/insight-collector/internal/constants"
-	asynqPkg "github.com/benedict-erwin/insight-collector/pkg/asynq"
-	"github.com/benedict-erwin/insight-collector/pkg/auth"
-	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
 	"github.com/benedict-erwin/insight-collector/pkg/logger"
-	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
-	"github.com/benedict-erwin/insight-collector/pkg/redis"
 	"github.com/benedict-erwin/insight-collector/pkg/response"
+	"github.com/jpillora/overseer"
 	"github.com/labstack/echo/v4"
 )
 
@@ -33,12 +29,24 @@ func Start(port int) error {
 	e := echo.New()
 	e.HideBanner = true
 
+	// Resolve c.RealIP() from TCP peer, forwarding headers only honored from app.trusted_proxies
+	e.IPExtractor = middleware.IPExtractor()
+
 	// Setup logger scope
 	log := logger.WithScope("startServer")
 
+	// Track in-flight requests for shutdown draining
+	e.Use(middleware.InFlight)
+
+	// Resolve trace ID (W3C traceparent or generated) before logging
+	e.Use(middleware.Trace)
+
 	// Add logger middleware
 	e.Use(middleware.Logger)
 
+	// CORS runs before routing so preflight for /v1 is answered without hitting group/route auth
+	e.Pre(middleware.CORSMiddleware("/v1"))
+
 	// Custom error handler
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
 		httpStatus := 500
@@ -178,26 +186,20 @@ func Start(port int) error {
 		}
 	}()
 
-	// Handle graceful shutdown
+	// Handle graceful shutdown (overseer restart signal included so restarts drain too)
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	log.Info().Msg("Shutting down server...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Error().Err(err).Msg("Server shutdown failed")
-		return err
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, overseer.SIGUSR2)
+	sig := <-quit
+	log.Info().Str("signal", sig.String()).Msg("Shutting down server...")
+
+	// Stop accepting connections, wait for in-flight requests, then release resources even when drain timed out
+	drainErr := drain(shutdownTimeout())
+	closeResources()
+	if drainErr != nil {
+		log.Error().Err(drainErr).Msg("Server shutdown incomplete")
+		return drainErr
 	}
 
-	// Close resources
-	influxdb.Close()
-	redis.Close()
-	maxmind.Close()
-	asynqPkg.CloseClient()
-	auth.StopAuth()
-
 	// Shutdown completed
 	log.Info().Msg("Server gracefully stopped")
 	return nil
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/benedict-erwin/insight-collector/config"
+	"github.com/benedict-erwin/insight-collector/http/middleware"
+	asynqPkg "github.com/benedict-erwin/insight-collector/pkg/asynq"
+	"github.com/benedict-erwin/insight-collector/pkg/auth"
+	"github.com/benedict-erwin/insight-collector/pkg/influxdb"
+	"github.com/benedict-erwin/insight-collector/pkg/logger"
+	"github.com/benedict-erwin/insight-collector/pkg/maxmind"
+	"github.com/benedict-erwin/insight-collector/pkg/redis"
+)
+
+const (
+	// defaultShutdownTimeout bounds in-flight draining when app.shutdown_timeout is not set
+	defaultShutdownTimeout = 15 * time.Second
+
+	// maxShutdownTimeout keeps draining below overseer TerminateTimeout (30s) so process exits before SIGKILL
+	maxShutdownTimeout = 25 * time.Second
+
+	// drainLogInterval is how often remaining in-flight requests are logged while draining
+	drainLogInterval = time.Second
+)
+
+// shutdownTimeout returns configured drain timeout, default when missing or invalid, capped at maxShutdownTimeout
+func shutdownTimeout() time.Duration {
+	cfg := config.Get()
+	if cfg == nil || cfg.App.ShutdownTimeout == "" {
+		return defaultShutdownTimeout
+	}
+
+	timeout, err := time.ParseDuration(cfg.App.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		logger.WithScope("shutdown").Warn().Str("shutdown_timeout", cfg.App.ShutdownTimeout).Msg("Invalid shutdown timeout, using default")
+		return defaultShutdownTimeout
+	}
+	return min(timeout, maxShutdownTimeout)
+}
+
+// drain stops accepting connections and waits for in-flight requests up to timeout.
+// Connections still active after timeout are closed forcibly.
+func drain(timeout time.Duration) error {
+	log := logger.WithScope("shutdown")
+	log.Info().
+		Dur("timeout", timeout).
+		Int64("in_flight", middleware.InFlightRequests()).
+		Msg("Draining in-flight requests")
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- httpServer.Shutdown(ctx)
+	}()
+
+	ticker := time.NewTicker(drainLogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Int64("in_flight", middleware.InFlightRequests()).
+					Msg("Drain timeout reached, closing remaining connections")
+				httpServer.Close()
+				return err
+			}
+			log.Info().Msg("All in-flight requests drained")
+			return nil
+		case <-ticker.C:
+			log.Info().Int64("in_flight", middleware.InFlightRequests()).Msg("Waiting for in-flight requests")
+		}
+	}
+}
+
+// closeResources flushes buffered InfluxDB writes and releases clients used by handlers
+func closeResources() {
+	log := logger.WithScope("shutdown")
+
+	if err := influxdb.StopWriteBuffer(); err != nil {
+		log.Error().Err(err).Msg("Failed to flush InfluxDB write buffer")
+	}
+	influxdb.Close()
+	asynqPkg.CloseClient()
+	redis.Close()
+	maxmind.Close()
+	auth.StopAuth()
+	log.Info().Msg("Resources closed")
+}